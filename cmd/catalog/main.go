@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"veg-store-backend/internal/application/dto"
+)
+
+/*
+Command catalog uploads and downloads catalog sheets through the running API, so imports go through
+the same validation, permissions and transaction as the admin endpoint.
+
+Usage:
+	catalog import -file products.xlsx [-dry-run] [-lang vi]
+	catalog export [-format csv|xlsx] [-out catalog.csv]
+
+The API base URL and the staff access token are read from -api / -token, or from the
+CATALOG_API_URL / CATALOG_TOKEN environment variables.
+*/
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "catalog:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: catalog import -file <sheet> [-dry-run] [-lang en|vi]")
+	fmt.Fprintln(os.Stderr, "       catalog export [-format csv|xlsx] [-out <file>]")
+	os.Exit(2)
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	api := flags.String("api", envOr("CATALOG_API_URL", "http://localhost:8080/api/v1"), "API base URL")
+	token := flags.String("token", os.Getenv("CATALOG_TOKEN"), "staff or admin access token")
+	file := flags.String("file", "", "CSV or XLSX sheet to import")
+	dryRun := flags.Bool("dry-run", false, "validate and preview without writing")
+	lang := flags.String("lang", "en", "language of error messages")
+	_ = flags.Parse(args)
+	if *file == "" {
+		usage()
+	}
+
+	content, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("file", filepath.Base(*file))
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/catalog/import?dry_run=%t", strings.TrimRight(*api, "/"), *dryRun), body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	request.Header.Set("Accept-Language", *lang)
+	response, err := send(request, *token)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var result dto.HttpResponse[dto.ImportReport]
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("unexpected response (%s)", response.Status)
+	}
+	if result.Code != "" {
+		return fmt.Errorf("%s: %s", result.Code, result.Message)
+	}
+
+	report := result.Data
+	for _, row := range report.Rows {
+		for _, rowError := range row.Errors {
+			column := rowError.Column
+			if column == "" {
+				column = "-"
+			}
+			fmt.Printf("row %d\t%s\t%s\t%s\n", row.Row, row.SKU, column, rowError.Message)
+		}
+	}
+	fmt.Printf("%d rows: %d to create, %d to update, %d failed\n", report.Total, report.Created, report.Updated, report.Failed)
+	switch {
+	case report.Applied:
+		fmt.Println("Import applied.")
+	case report.DryRun:
+		fmt.Println("Dry run: nothing was written.")
+	default:
+		return fmt.Errorf("nothing was written, fix the rows above and retry")
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	api := flags.String("api", envOr("CATALOG_API_URL", "http://localhost:8080/api/v1"), "API base URL")
+	token := flags.String("token", os.Getenv("CATALOG_TOKEN"), "staff or admin access token")
+	format := flags.String("format", "csv", "csv or xlsx")
+	out := flags.String("out", "", "output file (defaults to catalog.<format>)")
+	_ = flags.Parse(args)
+	if *out == "" {
+		*out = "catalog." + *format
+	}
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/catalog/export?format=%s", strings.TrimRight(*api, "/"), *format), nil)
+	if err != nil {
+		return err
+	}
+	response, err := send(request, *token)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		var result dto.HttpResponse[any]
+		_ = json.NewDecoder(response.Body).Decode(&result)
+		return fmt.Errorf("export failed (%s): %s", response.Status, result.Message)
+	}
+
+	target, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(target, response.Body)
+	// The file is only complete once it is closed, so a failed close fails the export
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d bytes to %s\n", written, *out)
+	return nil
+}
+
+func send(request *http.Request, token string) (*http.Response, error) {
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	return (&http.Client{Timeout: 5 * time.Minute}).Do(request)
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -6,11 +6,21 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"veg-store-backend/injection"
 	"veg-store-backend/injection/core"
-	"veg-store-backend/internal/application/exception"
 	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/infrastructure/data"
+	"veg-store-backend/internal/infrastructure/idempotency"
+	"veg-store-backend/internal/infrastructure/identity"
+	"veg-store-backend/internal/infrastructure/imaging"
+	"veg-store-backend/internal/infrastructure/notification"
+	"veg-store-backend/internal/infrastructure/payment"
 	"veg-store-backend/internal/infrastructure/repository"
 	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/infrastructure/scheduler"
+	"veg-store-backend/internal/infrastructure/spreadsheet"
+	"veg-store-backend/internal/infrastructure/storage"
+	"veg-store-backend/internal/infrastructure/worker"
 	"veg-store-backend/internal/restful/handler"
 	"veg-store-backend/internal/restful/route"
 
@@ -42,12 +52,93 @@ import (
 // @schemes http https
 
 func main() {
-	injectGlobalComponents(determineMode())
+	injection.Inject(determineMode())
 
 	app := fx.New(
+		data.DatasourceModule,
+		identity.JWTManagerModule,
+		storage.StorageModule,
+		imaging.ImageProcessorModule,
+		worker.BackgroundWorkerModule,
+		scheduler.SchedulerModule,
+		notification.NotifierModule,
+		payment.PaymentModule,
+		idempotency.IdempotencyStoreModule,
+		spreadsheet.SpreadsheetModule,
 		repository.UserRepositoryModule,
+		repository.ProductRepositoryModule,
+		repository.ProductImageRepositoryModule,
+		repository.CategoryRepositoryModule,
+		repository.StockLotRepositoryModule,
+		repository.ReviewRepositoryModule,
+		repository.InventoryRepositoryModule,
+		repository.LocationRepositoryModule,
+		repository.SupplierRepositoryModule,
+		repository.PurchaseOrderRepositoryModule,
+		repository.ReorderRepositoryModule,
+		repository.CartRepositoryModule,
+		repository.DeliverySlotRepositoryModule,
+		repository.OrderRepositoryModule,
+		repository.PaymentRepositoryModule,
+		repository.ClaimRepositoryModule,
+		repository.StoreCreditRepositoryModule,
+		repository.DeliveryZoneRepositoryModule,
+		repository.DeliveryRepositoryModule,
+		repository.SubscriptionRepositoryModule,
+		repository.PromotionRepositoryModule,
+		repository.LoyaltyRepositoryModule,
 		service.UserServiceModule,
+		service.ProductServiceModule,
+		service.ProductImageServiceModule,
+		service.CategoryServiceModule,
+		service.TranslationServiceModule,
+		service.CatalogServiceModule,
+		service.StockLotServiceModule,
+		service.ReviewServiceModule,
+		service.InventoryServiceModule,
+		service.LocationServiceModule,
+		service.StockLedgerServiceModule,
+		service.SupplierServiceModule,
+		service.PurchaseOrderServiceModule,
+		service.ReorderServiceModule,
+		service.CartServiceModule,
+		service.DeliverySlotServiceModule,
+		service.CheckoutServiceModule,
+		service.OrderServiceModule,
+		service.PaymentServiceModule,
+		service.ClaimServiceModule,
+		service.StoreCreditServiceModule,
+		service.DeliveryZoneServiceModule,
+		service.DeliveryServiceModule,
+		service.SubscriptionServiceModule,
+		service.PromotionServiceModule,
+		service.LoyaltyServiceModule,
 		handler.UserHandlerModule,
+		handler.ProductHandlerModule,
+		handler.ProductImageHandlerModule,
+		handler.CategoryHandlerModule,
+		handler.TranslationHandlerModule,
+		handler.CatalogHandlerModule,
+		handler.StockLotHandlerModule,
+		handler.ReviewHandlerModule,
+		handler.InventoryHandlerModule,
+		handler.LocationHandlerModule,
+		handler.StockLedgerHandlerModule,
+		handler.SupplierHandlerModule,
+		handler.PurchaseOrderHandlerModule,
+		handler.ReorderHandlerModule,
+		handler.CartHandlerModule,
+		handler.DeliverySlotHandlerModule,
+		handler.CheckoutHandlerModule,
+		handler.OrderHandlerModule,
+		handler.PaymentHandlerModule,
+		handler.ClaimHandlerModule,
+		handler.StoreCreditHandlerModule,
+		handler.DeliveryZoneHandlerModule,
+		handler.DeliveryHandlerModule,
+		handler.SubscriptionHandlerModule,
+		handler.PromotionHandlerModule,
+		handler.LoyaltyHandlerModule,
 		router.RouterModule,
 		route.RoutesModule,
 
@@ -90,14 +181,6 @@ func main() {
 	}
 }
 
-func injectGlobalComponents(mode string) {
-	core.Configs.Mode = mode
-	core.Logger = core.InitLogger()       // Initialize Logger
-	core.Configs = core.Load()            // Load configuration
-	core.Translator = core.InitI18n()     // Initialize i18n Translator
-	core.Error = exception.InitAppError() // Initialize App Error
-}
-
 func determineMode() string {
 	mode := os.Getenv("MODE")
 	switch mode {
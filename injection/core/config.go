@@ -51,6 +51,7 @@ type Config struct {
 		Port       string `mapstructure:"port"`
 		ApiPrefix  string `mapstructure:"api_prefix"`
 		ApiVersion string `mapstructure:"api_version"`
+		PublicURL  string `mapstructure:"public_url"` // Where clients reach the API; links to files served to signed-in users start with it
 	} `mapstructure:"server"`
 
 	JWT struct {
@@ -73,6 +74,97 @@ type Config struct {
 		Host string `mapstructure:"host"`
 	} `mapstructure:"swagger"`
 
+	Storage struct {
+		Driver    string `mapstructure:"driver"`
+		PublicURL string `mapstructure:"public_url"`
+		Local     struct {
+			Root string `mapstructure:"root"`
+		} `mapstructure:"local"`
+		S3 struct {
+			Endpoint     string `mapstructure:"endpoint"`
+			Region       string `mapstructure:"region"`
+			Bucket       string `mapstructure:"bucket"`
+			AccessKey    string `mapstructure:"access_key"`
+			SecretKey    string `mapstructure:"secret_key"`
+			UsePathStyle bool   `mapstructure:"use_path_style"`
+		} `mapstructure:"s3"`
+	} `mapstructure:"storage"`
+
+	Upload struct {
+		MaxImageSize      int64    `mapstructure:"max_image_size"`
+		AllowedImageTypes []string `mapstructure:"allowed_image_types"`
+		MaxImportSize     int64    `mapstructure:"max_import_size"`
+	} `mapstructure:"upload"`
+
+	Worker struct {
+		Size      int `mapstructure:"size"`
+		QueueSize int `mapstructure:"queue_size"`
+	} `mapstructure:"worker"`
+
+	Inventory struct {
+		ReservationTTL  string `mapstructure:"reservation_ttl"`
+		SweepInterval   string `mapstructure:"sweep_interval"`
+		ReorderInterval string `mapstructure:"reorder_interval"`
+	} `mapstructure:"inventory"`
+
+	Cart struct {
+		GuestTTL string `mapstructure:"guest_ttl"`
+	} `mapstructure:"cart"`
+
+	Checkout struct {
+		PaymentTTL string `mapstructure:"payment_ttl"`
+	} `mapstructure:"checkout"`
+
+	Payment struct {
+		Gateway struct {
+			URL          string `mapstructure:"url"`
+			MerchantCode string `mapstructure:"merchant_code"`
+			Secret       string `mapstructure:"secret"`
+			ReturnURL    string `mapstructure:"return_url"`
+			IPNURL       string `mapstructure:"ipn_url"`
+		} `mapstructure:"gateway"`
+		BankTransfer struct {
+			BankName      string `mapstructure:"bank_name"`
+			AccountNumber string `mapstructure:"account_number"`
+			AccountName   string `mapstructure:"account_name"`
+		} `mapstructure:"bank_transfer"`
+	} `mapstructure:"payment"`
+
+	Delivery struct {
+		SlotHorizon string `mapstructure:"slot_horizon"`
+	} `mapstructure:"delivery"`
+
+	Claim struct {
+		Window string `mapstructure:"window"`
+	} `mapstructure:"claim"`
+
+	Subscription struct {
+		LeadTime    string `mapstructure:"lead_time"`
+		RunInterval string `mapstructure:"run_interval"`
+	} `mapstructure:"subscription"`
+
+	Loyalty struct {
+		SpendPerPoint     int64  `mapstructure:"spend_per_point"`
+		PointValue        int64  `mapstructure:"point_value"`
+		MaxRedeemPercent  int64  `mapstructure:"max_redeem_percent"`
+		PointsTTL         string `mapstructure:"points_ttl"`
+		TierWindow        string `mapstructure:"tier_window"`
+		SilverPoints      int64  `mapstructure:"silver_points"`
+		SilverEarnPercent int64  `mapstructure:"silver_earn_percent"`
+		GoldPoints        int64  `mapstructure:"gold_points"`
+		GoldEarnPercent   int64  `mapstructure:"gold_earn_percent"`
+		RunInterval       string `mapstructure:"run_interval"`
+	} `mapstructure:"loyalty"`
+
+	Idempotency struct {
+		TTL string `mapstructure:"ttl"`
+	} `mapstructure:"idempotency"`
+
+	Notification struct {
+		Driver     string `mapstructure:"driver"`
+		WebhookURL string `mapstructure:"webhook_url"`
+	} `mapstructure:"notification"`
+
 	Database struct {
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
@@ -1,9 +1,11 @@
 package core
 
 import (
+	"veg-store-backend/internal/application/infra_interface"
 	"veg-store-backend/util"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 /*
@@ -11,11 +13,13 @@ This file defines the HttpContext struct, which holds shared resources for handl
 It includes:
 - Translator: For handling localization and translations.
 - Gin: The current Gin context for the request.
+- Logger: For request-scoped logs, tagged with the request's trace_id.
 */
 
 type HttpContext struct {
 	Translator *Localizer
 	Gin        *gin.Context
+	Logger     *zap.Logger
 }
 
 // GetHttpContext - Usage: httpContext := core.GetHttpContext(c) to get the HttpContext in a handler.
@@ -52,3 +56,13 @@ func (httpContext *HttpContext) Locale() string {
 	}
 	return "en"
 }
+
+// Claims - Usage: claims := httpContext.Claims() to get the JWT claims set by the Authentication middleware (nil for anonymous requests).
+func (httpContext *HttpContext) Claims() *infra_interface.JWTClaims {
+	if v, ok := httpContext.Gin.Get(util.ClaimsContextKey); ok {
+		if claims, ok := v.(*infra_interface.JWTClaims); ok {
+			return claims
+		}
+	}
+	return nil
+}
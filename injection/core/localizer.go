@@ -48,6 +48,16 @@ func InitI18n() *Localizer {
 	return &Localizer{Bundle: bundle}
 }
 
+// Locales - Usage: Translator.Locales() to list the locales that have a message file, e.g. ["en", "vi"].
+func (localizer *Localizer) Locales() []string {
+	locales := make([]string, 0)
+	for _, tag := range localizer.Bundle.LanguageTags() {
+		base, _ := tag.Base()
+		locales = append(locales, base.String())
+	}
+	return locales
+}
+
 // T - Usage: Translator.T("message_id", params) to get a localized message.
 func (localizer *Localizer) T(locale string, msgID string, params ...map[string]interface{}) string {
 	return localizer.Localize(locale, msgID, params...)
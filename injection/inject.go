@@ -0,0 +1,16 @@
+package injection
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/exception"
+)
+
+// Inject initializes the global components (logger, configs, translator, app errors) for the given mode.
+// It must run before the fx application or any test that relies on core.* globals.
+func Inject(mode string) {
+	core.Configs.Mode = mode
+	core.Logger = core.InitLogger()       // Initialize Logger
+	core.Configs = core.Load()            // Load configuration
+	core.Translator = core.InitI18n()     // Initialize i18n Translator
+	core.Error = exception.InitAppError() // Initialize App Error
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"slices"
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type CartLineRequest struct {
+	ProductID string      `json:"product_id" binding:"required"`
+	Quantity  QuantityDto `json:"quantity" binding:"required"` // added to what is already in the cart
+}
+
+type UpdateCartLineRequest struct {
+	Quantity QuantityDto `json:"quantity" binding:"required"` // replaces the quantity in the cart
+}
+
+type CartLineResponse struct {
+	ProductID     string      `json:"product_id"`
+	Name          string      `json:"name"`
+	Quantity      QuantityDto `json:"quantity"`
+	Price         int64       `json:"price" example:"45000"` // current price per price unit
+	PriceUnit     string      `json:"price_unit" example:"kg"`
+	PreviousPrice *int64      `json:"previous_price,omitempty" example:"42000"` // when the price changed since the customer last saw it
+	Total         int64       `json:"total" example:"22500"`
+	Discount      int64       `json:"discount" example:"2250"` // promotions' share of the total
+	Available     QuantityDto `json:"available"`
+	Issues        []string    `json:"issues"` // unavailable, out_of_stock, insufficient_stock, price_changed, quantity_rule
+}
+
+type CartResponse struct {
+	Token              string                      `json:"token,omitempty"` // guest carts only: send it back in the X-Cart-Token header
+	Lines              []CartLineResponse          `json:"lines"`
+	Subtotal           int64                       `json:"subtotal" example:"67500"`
+	Coupon             string                      `json:"coupon,omitempty" example:"WELCOME10"`
+	Discount           int64                       `json:"discount" example:"6750"`
+	Total              int64                       `json:"total" example:"60750"` // before delivery, which is priced at checkout
+	FreeShipping       bool                        `json:"free_shipping"`
+	Promotions         []AppliedPromotionResponse  `json:"promotions"`
+	RejectedPromotions []RejectedPromotionResponse `json:"rejected_promotions"` // checked against the cart and why they do not apply
+	Ready              bool                        `json:"ready"`               // no line blocks checkout
+	UpdatedAt          *time.Time                  `json:"updated_at,omitempty"`
+}
+
+func ToCartResponse(cart *model.PricedCart, locale string) CartResponse {
+	lines := make([]CartLineResponse, 0, len(cart.Lines))
+	for index, line := range cart.Lines {
+		response := CartLineResponse{
+			ProductID: line.ProductID,
+			Quantity:  ToQuantityDto(line.Quantity),
+			Price:     int64(line.UnitPrice),
+			Total:     int64(line.Total),
+			Issues:    make([]string, 0, len(line.Issues)),
+		}
+		if index < len(cart.Promotions.LineDiscounts) {
+			response.Discount = int64(cart.Promotions.LineDiscounts[index])
+		}
+		if line.Product != nil {
+			response.Name, _ = line.Product.Localized(locale)
+			response.PriceUnit = string(line.Product.PriceUnit)
+			response.Available = ToQuantityDto(model.Quantity{Base: line.Available, Unit: line.Product.PriceUnit})
+		}
+		if slices.Contains(line.Issues, model.CartIssuePriceChanged) {
+			previous := int64(line.PreviousPrice)
+			response.PreviousPrice = &previous
+		}
+		for _, issue := range line.Issues {
+			response.Issues = append(response.Issues, string(issue))
+		}
+		lines = append(lines, response)
+	}
+
+	response := CartResponse{
+		Token:              cart.Cart.Token,
+		Lines:              lines,
+		Subtotal:           int64(cart.Subtotal()),
+		Coupon:             cart.Cart.Coupon,
+		Discount:           int64(cart.Promotions.Discount),
+		Total:              int64(cart.Subtotal() - cart.Promotions.Discount),
+		FreeShipping:       cart.Promotions.FreeShipping,
+		Promotions:         ToAppliedPromotionResponses(cart.Promotions.Applied, locale),
+		RejectedPromotions: ToRejectedPromotionResponses(cart.Promotions.Rejected),
+		Ready:              cart.Ready(),
+	}
+	if !cart.Cart.UpdatedAt.IsZero() {
+		response.UpdatedAt = &cart.Cart.UpdatedAt
+	}
+	return response
+}
@@ -0,0 +1,33 @@
+package dto
+
+type CatalogImportQuery struct {
+	DryRun bool `form:"dry_run" example:"true"`
+}
+
+type CatalogExportQuery struct {
+	Format string `form:"format" example:"xlsx"`
+}
+
+type ImportRowError struct {
+	Column  string `json:"column,omitempty" example:"price"`
+	Code    string `json:"code" example:"invalid/price"`
+	Message string `json:"message"`
+}
+
+type ImportRowResult struct {
+	Row    int              `json:"row" example:"2"` // spreadsheet row number, the header being row 1
+	SKU    string           `json:"sku" example:"VEG-TOMATO-001"`
+	Action string           `json:"action" example:"create"` // create, update or "" when the row has errors
+	Errors []ImportRowError `json:"errors,omitempty"`
+}
+
+// ImportReport - nothing is applied unless every row is valid, so Applied is false on dry runs and on any row error.
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Applied bool              `json:"applied"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type TranslationDto struct {
+	Name        string `json:"name" example:"Cà chua bi"`
+	Description string `json:"description,omitempty" example:"Cà chua bi Đà Lạt"`
+}
+
+type CategoryRequest struct {
+	Slug         string                    `json:"slug" binding:"required" example:"leafy-greens"`
+	Name         string                    `json:"name" binding:"required" example:"Leafy greens"`
+	Description  string                    `json:"description" example:"Lettuce, spinach and more"`
+	Translations map[string]TranslationDto `json:"translations,omitempty"`
+}
+
+type CategoryResponse struct {
+	ID          string    `json:"id"`
+	Slug        string    `json:"slug"`
+	Locale      string    `json:"locale" example:"vi"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TranslationsResponse - every stored language of an entity, used by admins to edit content
+type TranslationsResponse struct {
+	DefaultLocale string                    `json:"default_locale" example:"en"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	Translations  map[string]TranslationDto `json:"translations"`
+}
+
+type MissingTranslationResponse struct {
+	EntityType string   `json:"entity_type" example:"product"`
+	EntityID   string   `json:"entity_id"`
+	Reference  string   `json:"reference" example:"VEG-TOMATO-001"` // SKU of products, slug of categories
+	Locale     string   `json:"locale" example:"vi"`
+	Fields     []string `json:"fields" example:"name,description"`
+}
+
+type MissingTranslationQuery struct {
+	PageRequest
+	Locale string `form:"locale" example:"vi"`
+}
+
+func ToCategoryResponse(category *model.Category, locale string) CategoryResponse {
+	name, description := category.Localized(locale)
+	return CategoryResponse{
+		ID:          category.ID,
+		Slug:        category.Slug,
+		Locale:      locale,
+		Name:        name,
+		Description: description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func ToTranslationsResponse(defaultLocale string, name string, description string, translations model.Translations) TranslationsResponse {
+	result := TranslationsResponse{
+		DefaultLocale: defaultLocale,
+		Name:          name,
+		Description:   description,
+		Translations:  map[string]TranslationDto{},
+	}
+	for locale, translation := range translations {
+		result.Translations[locale] = TranslationDto{Name: translation.Name, Description: translation.Description}
+	}
+	return result
+}
+
+func ToTranslations(request map[string]TranslationDto) model.Translations {
+	translations := model.Translations{}
+	for locale, translation := range request {
+		translations[locale] = model.Translation{Name: translation.Name, Description: translation.Description}
+	}
+	return translations
+}
@@ -0,0 +1,149 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type ClaimLineRequest struct {
+	ProductID string      `json:"product_id" binding:"required"`
+	Quantity  QuantityDto `json:"quantity" binding:"required"` // how much of the delivered quantity is affected
+	Reason    string      `json:"reason" binding:"required,oneof=bruised spoiled missing wrong_item other" example:"bruised"`
+}
+
+// ClaimRequest claims lines of a delivered order; photos are added afterwards
+type ClaimRequest struct {
+	Lines       []ClaimLineRequest `json:"lines" binding:"required,min=1,dive"`
+	Description string             `json:"description" example:"Half the tomatoes were squashed at the bottom of the bag"`
+}
+
+type ClaimQuery struct {
+	PageRequest
+	Status  string `form:"status" binding:"omitempty,oneof=pending refunding approved rejected" example:"pending"`
+	OrderID string `form:"order_id"`
+}
+
+// ApproveClaimRequest pays the claim back, in full unless an amount is given
+type ApproveClaimRequest struct {
+	Resolution  string `json:"resolution" binding:"required,oneof=refund store_credit" example:"refund"`
+	Amount      int64  `json:"amount" binding:"omitempty,min=1" example:"14000"`                            // defaults to the claimed amount
+	Disposition string `json:"disposition" binding:"omitempty,oneof=write_off restock" example:"write_off"` // defaults to write_off
+	Note        string `json:"note" example:"Sorry about that, refunded in full"`
+}
+
+type RejectClaimRequest struct {
+	Note string `json:"note" binding:"required" example:"The photos show the tomatoes in good condition"`
+}
+
+type ClaimLineResponse struct {
+	ProductID string      `json:"product_id"`
+	Name      string      `json:"name"`
+	Quantity  QuantityDto `json:"quantity"`
+	Reason    string      `json:"reason" example:"bruised"`
+	Amount    int64       `json:"amount" example:"14000"`
+}
+
+type ClaimPhotoDto struct {
+	ID  string `json:"id"`
+	URL string `json:"url" example:"http://localhost:8080/api/v1/claim/1/photo/2"`
+}
+
+type ClaimResponse struct {
+	ID            string              `json:"id"`
+	OrderID       string              `json:"order_id"`
+	OrderNumber   string              `json:"order_number" example:"ORD-000042"`
+	UserID        string              `json:"user_id"`
+	Lines         []ClaimLineResponse `json:"lines"`
+	Description   string              `json:"description,omitempty"`
+	Photos        []ClaimPhotoDto     `json:"photos"`
+	Status        string              `json:"status" example:"approved"`
+	Claimed       int64               `json:"claimed" example:"14000"`
+	Resolution    string              `json:"resolution,omitempty" example:"refund"`
+	Disposition   string              `json:"disposition,omitempty" example:"write_off"`
+	Amount        int64               `json:"amount,omitempty" example:"14000"` // paid back
+	TransactionID string              `json:"transaction_id,omitempty"`
+	DecisionNote  string              `json:"decision_note,omitempty"`
+	DecidedAt     *time.Time          `json:"decided_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type CreditEntryResponse struct {
+	ID         string    `json:"id"`
+	Amount     int64     `json:"amount" example:"14000"` // negative when spent
+	Note       string    `json:"note,omitempty"`
+	SourceType string    `json:"source_type,omitempty" example:"claim"`
+	SourceID   string    `json:"source_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type StoreCreditResponse struct {
+	Balance int64                 `json:"balance" example:"14000"`
+	Entries []CreditEntryResponse `json:"entries"` // the latest first
+}
+
+func ToClaimResponse(claim *model.Claim) ClaimResponse {
+	response := ClaimResponse{
+		ID:            claim.ID,
+		OrderID:       claim.OrderID,
+		OrderNumber:   claim.OrderNumber,
+		UserID:        claim.UserID,
+		Lines:         make([]ClaimLineResponse, 0, len(claim.Lines)),
+		Description:   claim.Description,
+		Photos:        make([]ClaimPhotoDto, 0, len(claim.Photos)),
+		Status:        string(claim.Status),
+		Claimed:       int64(claim.Claimed),
+		Resolution:    string(claim.Resolution),
+		Disposition:   string(claim.Disposition),
+		Amount:        int64(claim.Amount),
+		TransactionID: claim.TransactionID,
+		DecisionNote:  claim.DecisionNote,
+		CreatedAt:     claim.CreatedAt,
+		UpdatedAt:     claim.UpdatedAt,
+	}
+	for _, line := range claim.Lines {
+		response.Lines = append(response.Lines, ClaimLineResponse{
+			ProductID: line.ProductID,
+			Name:      line.Name,
+			Quantity:  ToQuantityDto(line.Quantity),
+			Reason:    string(line.Reason),
+			Amount:    int64(line.Amount),
+		})
+	}
+	for _, photo := range claim.Photos {
+		response.Photos = append(response.Photos, ClaimPhotoDto{ID: photo.ID, URL: photo.URL})
+	}
+	if !claim.DecidedAt.IsZero() {
+		response.DecidedAt = &claim.DecidedAt
+	}
+	return response
+}
+
+func ToClaimPage(page Page[model.Claim]) Page[ClaimResponse] {
+	items := make([]ClaimResponse, 0, len(page.Items))
+	for _, claim := range page.Items {
+		items = append(items, ToClaimResponse(&claim))
+	}
+	return Page[ClaimResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToStoreCreditResponse(balance model.Money, entries []model.CreditEntry) StoreCreditResponse {
+	response := StoreCreditResponse{Balance: int64(balance), Entries: make([]CreditEntryResponse, 0, len(entries))}
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+		response.Entries = append(response.Entries, CreditEntryResponse{
+			ID:         entry.ID,
+			Amount:     int64(entry.Amount),
+			Note:       entry.Note,
+			SourceType: entry.SourceType,
+			SourceID:   entry.SourceID,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+	return response
+}
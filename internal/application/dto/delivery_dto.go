@@ -0,0 +1,198 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type ShipperRequest struct {
+	UserID string `json:"user_id" example:"7f1c2e9a"` // required on create, ignored on update
+	Name   string `json:"name" binding:"required" example:"Trần Văn Bình"`
+	Phone  string `json:"phone" binding:"required" example:"0912 345 678"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+type ShipperResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Phone     string    `json:"phone"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShipperContactResponse is who brings the customer's order
+type ShipperContactResponse struct {
+	Name  string `json:"name" example:"Trần Văn Bình"`
+	Phone string `json:"phone" example:"0912 345 678"`
+}
+
+type AssignDeliveryRequest struct {
+	ShipperID string `json:"shipper_id" binding:"required"`
+}
+
+type RunSheetQuery struct {
+	Date string `form:"date" example:"2026-10-20"` // store date of the delivery slots, default today
+}
+
+// DeliverRequest hands the order over; cash-on-delivery orders report the cash collected, which must be the total
+type DeliverRequest struct {
+	CODCollected int64 `json:"cod_collected" binding:"min=0" example:"72000"`
+}
+
+type FailDeliveryRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Nobody home, phone switched off"`
+}
+
+type CashReportQuery struct {
+	Date string `form:"date" example:"2026-10-20"` // store date the deliveries finished, default today
+}
+
+// RemitCashRequest records the cash a shipper handed over for a day; it must be all they still hold for it
+type RemitCashRequest struct {
+	Date   string `json:"date" binding:"required" example:"2026-10-20"`
+	Amount int64  `json:"amount" binding:"min=0" example:"1250000"`
+}
+
+type DeliveryProofDto struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind" example:"photo"` // photo or signature
+	URL  string `json:"url" example:"http://localhost:8080/api/v1/delivery/1/proof/2"`
+}
+
+type DeliveryResponse struct {
+	OrderID       string             `json:"order_id"`
+	OrderNumber   string             `json:"order_number" example:"ORD-000042"`
+	ShipperID     string             `json:"shipper_id"`
+	Status        string             `json:"status" example:"picked_up"` // assigned, picked_up, delivered or failed
+	CODDue        int64              `json:"cod_due" example:"72000"`    // cash to collect; 0 for orders paid online
+	Collected     int64              `json:"collected" example:"0"`
+	Proofs        []DeliveryProofDto `json:"proofs"`
+	FailureReason string             `json:"failure_reason,omitempty"`
+	AssignedAt    time.Time          `json:"assigned_at"`
+	PickedUpAt    *time.Time         `json:"picked_up_at,omitempty"`
+	FinishedAt    *time.Time         `json:"finished_at,omitempty"`
+	RemittedAt    *time.Time         `json:"remitted_at,omitempty"`
+}
+
+// RunSheetStopResponse is a delivery with what the shipper needs to bring it to the door
+type RunSheetStopResponse struct {
+	DeliveryResponse
+	Address       DeliveryAddressDto `json:"address"`
+	SlotStart     time.Time          `json:"slot_start"`
+	SlotEnd       time.Time          `json:"slot_end"`
+	PaymentMethod string             `json:"payment_method" example:"cod"`
+	Weight        int64              `json:"weight" example:"2100"` // grams
+	Note          string             `json:"note,omitempty"`
+}
+
+type CashReportResponse struct {
+	ShipperID    string             `json:"shipper_id"`
+	ShipperName  string             `json:"shipper_name"`
+	ShipperPhone string             `json:"shipper_phone"`
+	Date         string             `json:"date" example:"2026-10-20"`
+	Delivered    int                `json:"delivered" example:"18"`
+	Failed       int                `json:"failed" example:"1"`
+	Collected    int64              `json:"collected" example:"1250000"`  // cash collected on delivery
+	Remitted     int64              `json:"remitted" example:"1000000"`   // of which handed over
+	Outstanding  int64              `json:"outstanding" example:"250000"` // still held by the shipper
+	Deliveries   []DeliveryResponse `json:"deliveries"`
+}
+
+func ToShipperResponse(shipper *model.Shipper) ShipperResponse {
+	return ShipperResponse{
+		ID:        shipper.ID,
+		Name:      shipper.Name,
+		Phone:     shipper.Phone,
+		Active:    shipper.Active,
+		CreatedAt: shipper.CreatedAt,
+		UpdatedAt: shipper.UpdatedAt,
+	}
+}
+
+func ToShipperResponses(shippers []model.Shipper) []ShipperResponse {
+	responses := make([]ShipperResponse, 0, len(shippers))
+	for _, shipper := range shippers {
+		responses = append(responses, ToShipperResponse(&shipper))
+	}
+	return responses
+}
+
+func ToDeliveryResponse(delivery *model.Delivery) DeliveryResponse {
+	response := DeliveryResponse{
+		OrderID:       delivery.OrderID,
+		OrderNumber:   delivery.OrderNumber,
+		ShipperID:     delivery.ShipperID,
+		Status:        string(delivery.Status),
+		CODDue:        int64(delivery.CODDue),
+		Collected:     int64(delivery.Collected),
+		Proofs:        make([]DeliveryProofDto, 0, len(delivery.Proofs)),
+		FailureReason: delivery.FailureReason,
+		AssignedAt:    delivery.AssignedAt,
+	}
+	for _, proof := range delivery.Proofs {
+		response.Proofs = append(response.Proofs, DeliveryProofDto{ID: proof.ID, Kind: string(proof.Kind), URL: proof.URL})
+	}
+	if !delivery.PickedUpAt.IsZero() {
+		response.PickedUpAt = &delivery.PickedUpAt
+	}
+	if !delivery.FinishedAt.IsZero() {
+		response.FinishedAt = &delivery.FinishedAt
+	}
+	if !delivery.RemittedAt.IsZero() {
+		response.RemittedAt = &delivery.RemittedAt
+	}
+	return response
+}
+
+func ToDeliveryResponses(deliveries []model.Delivery) []DeliveryResponse {
+	responses := make([]DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, ToDeliveryResponse(&delivery))
+	}
+	return responses
+}
+
+func ToRunSheetStopResponse(stop *model.RunSheetStop) RunSheetStopResponse {
+	return RunSheetStopResponse{
+		DeliveryResponse: ToDeliveryResponse(&stop.Delivery),
+		Address:          ToDeliveryAddressDto(stop.Order.Address),
+		SlotStart:        stop.Order.SlotStart,
+		SlotEnd:          stop.Order.SlotEnd,
+		PaymentMethod:    string(stop.Order.PaymentMethod),
+		Weight:           stop.Order.Weight,
+		Note:             stop.Order.Note,
+	}
+}
+
+func ToRunSheetStopResponses(stops []model.RunSheetStop) []RunSheetStopResponse {
+	responses := make([]RunSheetStopResponse, 0, len(stops))
+	for _, stop := range stops {
+		responses = append(responses, ToRunSheetStopResponse(&stop))
+	}
+	return responses
+}
+
+func ToCashReportResponse(report *model.CashReport) CashReportResponse {
+	return CashReportResponse{
+		ShipperID:    report.Shipper.ID,
+		ShipperName:  report.Shipper.Name,
+		ShipperPhone: report.Shipper.Phone,
+		Date:         report.Date.In(util.StoreLocation).Format(util.DateLayout),
+		Delivered:    report.Delivered,
+		Failed:       report.Failed,
+		Collected:    int64(report.Collected),
+		Remitted:     int64(report.Remitted),
+		Outstanding:  int64(report.Outstanding),
+		Deliveries:   ToDeliveryResponses(report.Deliveries),
+	}
+}
+
+func ToCashReportResponses(reports []model.CashReport) []CashReportResponse {
+	responses := make([]CashReportResponse, 0, len(reports))
+	for _, report := range reports {
+		responses = append(responses, ToCashReportResponse(&report))
+	}
+	return responses
+}
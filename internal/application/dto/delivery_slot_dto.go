@@ -0,0 +1,189 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type DeliverySlotRequest struct {
+	ZoneID         string     `json:"zone_id,omitempty" example:"inner-city"` // empty serves every zone
+	Start          time.Time  `json:"start" binding:"required" example:"2026-10-20T08:00:00+07:00"`
+	End            time.Time  `json:"end" binding:"required" example:"2026-10-20T10:00:00+07:00"`
+	CutoffAt       *time.Time `json:"cutoff_at,omitempty" example:"2026-10-19T22:00:00+07:00"` // bookings close; default the start
+	Capacity       int        `json:"capacity" binding:"min=0" example:"20"`                   // orders, 0 for no limit
+	WeightCapacity int64      `json:"weight_capacity" binding:"min=0" example:"150000"`        // grams, 0 for no limit
+	Active         *bool      `json:"active,omitempty"`
+}
+
+// DeliverySlotQuery - with an address, only the slots delivering to it are listed
+type DeliverySlotQuery struct {
+	From      string   `form:"from" example:"2026-10-20"` // store date, default today
+	To        string   `form:"to" example:"2026-10-26"`   // store date, inclusive, default a week after from
+	Province  string   `form:"province" example:"Hồ Chí Minh"`
+	District  string   `form:"district" example:"Quận 1"`
+	Ward      string   `form:"ward" example:"Bến Nghé"`
+	Latitude  *float64 `form:"latitude" binding:"omitempty,min=-90,max=90" example:"10.7743"` // where the address was geocoded
+	Longitude *float64 `form:"longitude" binding:"omitempty,min=-180,max=180" example:"106.7038"`
+	Weight    int64    `form:"weight" binding:"omitempty,min=0" example:"2500"` // grams of the order to fit, e.g. the cart's
+}
+
+func (query DeliverySlotQuery) Address() model.Address {
+	return model.Address{
+		Ward:     query.Ward,
+		District: query.District,
+		Province: query.Province,
+		Point:    toGeoPoint(query.Latitude, query.Longitude),
+	}
+}
+
+type DeliverySlotResponse struct {
+	ID              string    `json:"id"`
+	ZoneID          string    `json:"zone_id,omitempty"`
+	TemplateID      string    `json:"template_id,omitempty"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	CutoffAt        time.Time `json:"cutoff_at"`
+	Capacity        int       `json:"capacity" example:"20"`            // orders, 0 for no limit
+	WeightCapacity  int64     `json:"weight_capacity" example:"150000"` // grams, 0 for no limit
+	Booked          int       `json:"booked" example:"12"`              // orders
+	BookedWeight    int64     `json:"booked_weight" example:"84000"`    // grams
+	Remaining       *int      `json:"remaining,omitempty" example:"8"`  // orders; absent when not limited
+	RemainingWeight *int64    `json:"remaining_weight,omitempty"`       // grams; absent when not limited
+	HolidayID       string    `json:"holiday_id,omitempty"`             // set while a holiday closes the slot
+	Active          bool      `json:"active"`
+}
+
+func ToDeliverySlotResponse(slot *model.DeliverySlot) DeliverySlotResponse {
+	response := DeliverySlotResponse{
+		ID:             slot.ID,
+		ZoneID:         slot.ZoneID,
+		TemplateID:     slot.TemplateID,
+		Start:          slot.Start.In(util.StoreLocation),
+		End:            slot.End.In(util.StoreLocation),
+		CutoffAt:       slot.Cutoff().In(util.StoreLocation),
+		Capacity:       slot.Capacity,
+		WeightCapacity: slot.WeightCapacity,
+		Booked:         slot.Booked,
+		BookedWeight:   slot.BookedWeight,
+		HolidayID:      slot.HolidayID,
+		Active:         slot.Active,
+	}
+	if remaining, limited := slot.Remaining(); limited {
+		response.Remaining = &remaining
+	}
+	if remaining, limited := slot.RemainingWeight(); limited {
+		response.RemainingWeight = &remaining
+	}
+	return response
+}
+
+func ToDeliverySlotResponses(slots []model.DeliverySlot) []DeliverySlotResponse {
+	responses := make([]DeliverySlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		responses = append(responses, ToDeliverySlotResponse(&slot))
+	}
+	return responses
+}
+
+// SlotTemplateRequest opens a slot every week; times are "HH:MM" in the store time zone
+type SlotTemplateRequest struct {
+	ZoneID         string `json:"zone_id,omitempty" example:"inner-city"`    // empty serves every zone
+	Weekday        int    `json:"weekday" binding:"min=0,max=6" example:"1"` // 0 is Sunday
+	Starts         string `json:"starts" binding:"required" example:"08:00"`
+	Ends           string `json:"ends" binding:"required" example:"10:00"`
+	Cutoff         int    `json:"cutoff" binding:"min=0" example:"600"`             // minutes before the start when bookings close
+	Capacity       int    `json:"capacity" binding:"min=0" example:"20"`            // orders, 0 for no limit
+	WeightCapacity int64  `json:"weight_capacity" binding:"min=0" example:"150000"` // grams, 0 for no limit
+	Active         *bool  `json:"active,omitempty"`
+}
+
+type SlotTemplateResponse struct {
+	ID             string    `json:"id"`
+	ZoneID         string    `json:"zone_id,omitempty"`
+	Weekday        int       `json:"weekday" example:"1"`
+	Starts         string    `json:"starts" example:"08:00"`
+	Ends           string    `json:"ends" example:"10:00"`
+	Cutoff         int       `json:"cutoff" example:"600"`
+	Capacity       int       `json:"capacity" example:"20"`
+	WeightCapacity int64     `json:"weight_capacity" example:"150000"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ToSlotTemplate parses the request's "HH:MM" times into minutes after midnight
+func ToSlotTemplate(request SlotTemplateRequest) (model.SlotTemplate, error) {
+	starts, err := parseClock(request.Starts)
+	if err != nil {
+		return model.SlotTemplate{}, model.ErrInvalidSlotTemplate
+	}
+	ends, err := parseClock(request.Ends)
+	if err != nil {
+		return model.SlotTemplate{}, model.ErrInvalidSlotTemplate
+	}
+	return model.SlotTemplate{
+		ZoneID:         request.ZoneID,
+		Weekday:        time.Weekday(request.Weekday),
+		Starts:         starts,
+		Ends:           ends,
+		Cutoff:         request.Cutoff,
+		Capacity:       request.Capacity,
+		WeightCapacity: request.WeightCapacity,
+	}, nil
+}
+
+func ToSlotTemplateResponse(template *model.SlotTemplate) SlotTemplateResponse {
+	return SlotTemplateResponse{
+		ID:             template.ID,
+		ZoneID:         template.ZoneID,
+		Weekday:        int(template.Weekday),
+		Starts:         formatClock(template.Starts),
+		Ends:           formatClock(template.Ends),
+		Cutoff:         template.Cutoff,
+		Capacity:       template.Capacity,
+		WeightCapacity: template.WeightCapacity,
+		Active:         template.Active,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+}
+
+func ToSlotTemplateResponses(templates []model.SlotTemplate) []SlotTemplateResponse {
+	responses := make([]SlotTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		responses = append(responses, ToSlotTemplateResponse(&template))
+	}
+	return responses
+}
+
+// HolidayRequest closes a store date, in one zone or all of them
+type HolidayRequest struct {
+	Date   string `json:"date" binding:"required" example:"2026-04-30"`
+	ZoneID string `json:"zone_id,omitempty" example:"inner-city"` // empty closes every zone
+	Name   string `json:"name" binding:"required" example:"Reunification Day"`
+}
+
+type HolidayResponse struct {
+	ID     string `json:"id"`
+	Date   string `json:"date" example:"2026-04-30"`
+	ZoneID string `json:"zone_id,omitempty"`
+	Name   string `json:"name" example:"Reunification Day"`
+}
+
+func ToHolidayResponse(holiday *model.Holiday) HolidayResponse {
+	return HolidayResponse{
+		ID:     holiday.ID,
+		Date:   holiday.Date.In(util.StoreLocation).Format(util.DateLayout),
+		ZoneID: holiday.ZoneID,
+		Name:   holiday.Name,
+	}
+}
+
+func ToHolidayResponses(holidays []model.Holiday) []HolidayResponse {
+	responses := make([]HolidayResponse, 0, len(holidays))
+	for _, holiday := range holidays {
+		responses = append(responses, ToHolidayResponse(&holiday))
+	}
+	return responses
+}
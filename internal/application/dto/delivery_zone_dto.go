@@ -0,0 +1,132 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type ZoneAreaDto struct {
+	Province string   `json:"province" binding:"required" example:"Hồ Chí Minh"`
+	District string   `json:"district" binding:"required" example:"Quận 1"`
+	Wards    []string `json:"wards,omitempty" example:"Bến Nghé"` // empty for the whole district
+}
+
+// DeliveryFeeRuleDto prices delivery in a zone; every amount is in VND
+type DeliveryFeeRuleDto struct {
+	BaseFee          int64 `json:"base_fee" binding:"min=0" example:"15000"`
+	IncludedDistance int64 `json:"included_distance" binding:"min=0" example:"3000"` // meters the base fee covers
+	PerKm            int64 `json:"per_km" binding:"min=0" example:"3000"`            // per started km beyond the included distance
+	IncludedWeight   int64 `json:"included_weight" binding:"min=0" example:"5000"`   // grams the base fee covers
+	PerKg            int64 `json:"per_kg" binding:"min=0" example:"2000"`            // per started kg beyond the included weight
+	FreeFrom         int64 `json:"free_from" binding:"min=0" example:"300000"`       // subtotal from which delivery is free; 0 for never
+}
+
+type DeliveryZoneRequest struct {
+	ID      string             `json:"id" example:"inner-city"` // required on create, ignored on update
+	Name    string             `json:"name" binding:"required" example:"Inner city"`
+	Areas   []ZoneAreaDto      `json:"areas" binding:"dive"`
+	Polygon []GeoPointDto      `json:"polygon" binding:"dive"` // at least three points; areas, a polygon or both are required
+	Fees    DeliveryFeeRuleDto `json:"fees"`
+	Active  *bool              `json:"active,omitempty"`
+}
+
+type DeliveryZoneResponse struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Areas     []ZoneAreaDto      `json:"areas"`
+	Polygon   []GeoPointDto      `json:"polygon"`
+	Fees      DeliveryFeeRuleDto `json:"fees"`
+	Active    bool               `json:"active"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// DeliveryQuoteRequest asks what delivering an order of subtotal and weight to the address costs
+type DeliveryQuoteRequest struct {
+	Address  AddressDto `json:"address" binding:"required"`
+	Subtotal int64      `json:"subtotal" binding:"min=0" example:"120000"`
+	Weight   int64      `json:"weight" binding:"min=0" example:"2500"` // grams
+}
+
+type DeliveryQuoteResponse struct {
+	ZoneID            string `json:"zone_id" example:"inner-city"`
+	ZoneName          string `json:"zone_name" example:"Inner city"`
+	Distance          int64  `json:"distance" example:"4200"` // meters from the store; 0 when the address is not geocoded
+	Weight            int64  `json:"weight" example:"2500"`
+	BaseFee           int64  `json:"base_fee" example:"15000"`
+	DistanceSurcharge int64  `json:"distance_surcharge" example:"6000"`
+	WeightSurcharge   int64  `json:"weight_surcharge" example:"0"`
+	FreeShipping      bool   `json:"free_shipping" example:"false"`
+	FreeFrom          int64  `json:"free_from,omitempty" example:"300000"`
+	Fee               int64  `json:"fee" example:"21000"`
+}
+
+func ToZoneAreas(areas []ZoneAreaDto) []model.ZoneArea {
+	result := make([]model.ZoneArea, 0, len(areas))
+	for _, area := range areas {
+		result = append(result, model.ZoneArea{Province: area.Province, District: area.District, Wards: area.Wards})
+	}
+	return result
+}
+
+func ToDeliveryFeeRule(request DeliveryFeeRuleDto) model.DeliveryFeeRule {
+	return model.DeliveryFeeRule{
+		BaseFee:          model.Money(request.BaseFee),
+		IncludedDistance: request.IncludedDistance,
+		PerKm:            model.Money(request.PerKm),
+		IncludedWeight:   request.IncludedWeight,
+		PerKg:            model.Money(request.PerKg),
+		FreeFrom:         model.Money(request.FreeFrom),
+	}
+}
+
+func ToDeliveryFeeRuleDto(rule model.DeliveryFeeRule) DeliveryFeeRuleDto {
+	return DeliveryFeeRuleDto{
+		BaseFee:          int64(rule.BaseFee),
+		IncludedDistance: rule.IncludedDistance,
+		PerKm:            int64(rule.PerKm),
+		IncludedWeight:   rule.IncludedWeight,
+		PerKg:            int64(rule.PerKg),
+		FreeFrom:         int64(rule.FreeFrom),
+	}
+}
+
+func ToDeliveryZoneResponse(zone *model.DeliveryZone) DeliveryZoneResponse {
+	areas := make([]ZoneAreaDto, 0, len(zone.Areas))
+	for _, area := range zone.Areas {
+		areas = append(areas, ZoneAreaDto{Province: area.Province, District: area.District, Wards: area.Wards})
+	}
+	return DeliveryZoneResponse{
+		ID:        zone.ID,
+		Name:      zone.Name,
+		Areas:     areas,
+		Polygon:   ToGeoPointDtos(zone.Polygon),
+		Fees:      ToDeliveryFeeRuleDto(zone.Fees),
+		Active:    zone.Active,
+		CreatedAt: zone.CreatedAt,
+		UpdatedAt: zone.UpdatedAt,
+	}
+}
+
+func ToDeliveryZoneResponses(zones []model.DeliveryZone) []DeliveryZoneResponse {
+	responses := make([]DeliveryZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		responses = append(responses, ToDeliveryZoneResponse(&zone))
+	}
+	return responses
+}
+
+func ToDeliveryQuoteResponse(zone *model.DeliveryZone, quote model.DeliveryQuote) DeliveryQuoteResponse {
+	return DeliveryQuoteResponse{
+		ZoneID:            zone.ID,
+		ZoneName:          zone.Name,
+		Distance:          quote.Distance,
+		Weight:            quote.Weight,
+		BaseFee:           int64(quote.BaseFee),
+		DistanceSurcharge: int64(quote.DistanceSurcharge),
+		WeightSurcharge:   int64(quote.WeightSurcharge),
+		FreeShipping:      quote.FreeShipping,
+		FreeFrom:          int64(zone.Fees.FreeFrom),
+		Fee:               int64(quote.Fee),
+	}
+}
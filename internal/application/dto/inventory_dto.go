@@ -0,0 +1,196 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type StockLevelQuery struct {
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"`
+}
+
+type AdjustStockRequest struct {
+	ProductID  string      `json:"product_id" binding:"required"`
+	LocationID string      `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	Quantity   QuantityDto `json:"quantity" binding:"required"`
+	Direction  string      `json:"direction" binding:"required,oneof=in out" example:"in"` // "in" adds stock, "out" removes it
+	Reason     string      `json:"reason" binding:"required,oneof=recount damaged found correction" example:"recount"`
+	Note       string      `json:"note" example:"Two crates hidden behind the chiller"`
+}
+
+type ReservationItemDto struct {
+	ProductID string      `json:"product_id" binding:"required"`
+	Quantity  QuantityDto `json:"quantity" binding:"required"`
+}
+
+type ReserveRequest struct {
+	Reference  string               `json:"reference" binding:"required" example:"checkout-7f3a"` // what holds the stock
+	LocationID string               `json:"location_id" example:"central-warehouse"`              // defaults to the central warehouse
+	Items      []ReservationItemDto `json:"items" binding:"required,min=1,dive"`
+}
+
+type ReservationQuery struct {
+	Reference string `form:"reference"`
+	Status    string `form:"status" binding:"omitempty,oneof=active committed released expired" example:"active"`
+}
+
+type TransferRequest struct {
+	ProductID      string      `json:"product_id" binding:"required"`
+	FromLocationID string      `json:"from_location_id" binding:"required" example:"central-warehouse"`
+	ToLocationID   string      `json:"to_location_id" binding:"required" example:"store-district-1"`
+	Quantity       QuantityDto `json:"quantity" binding:"required"`
+	Note           string      `json:"note" example:"Morning restock"`
+}
+
+type TransferQuery struct {
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"` // sent from or to this location
+	Status     string `form:"status" binding:"omitempty,oneof=in_transit received cancelled" example:"in_transit"`
+}
+
+type StockLevelResponse struct {
+	ProductID  string      `json:"product_id"`
+	LocationID string      `json:"location_id"`
+	OnHand     QuantityDto `json:"on_hand"`
+	Reserved   QuantityDto `json:"reserved"`
+	Available  QuantityDto `json:"available"`
+	InTransit  QuantityDto `json:"in_transit"` // on its way here, not available yet
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type TransferResponse struct {
+	ID             string      `json:"id"`
+	ProductID      string      `json:"product_id"`
+	FromLocationID string      `json:"from_location_id"`
+	ToLocationID   string      `json:"to_location_id"`
+	Quantity       QuantityDto `json:"quantity"`
+	Status         string      `json:"status" example:"in_transit"`
+	Note           string      `json:"note,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	ReceivedAt     *time.Time  `json:"received_at,omitempty"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+type LocationAvailabilityDto struct {
+	LocationID string      `json:"location_id"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type" example:"store"`
+	Address    AddressDto  `json:"address"`
+	OpenNow    bool        `json:"open_now"`
+	InStock    bool        `json:"in_stock"`
+	Available  QuantityDto `json:"available"`
+	InTransit  QuantityDto `json:"in_transit"` // arriving with a transfer
+}
+
+type ProductAvailabilityResponse struct {
+	ProductID string                    `json:"product_id"`
+	Available QuantityDto               `json:"available"` // across all locations
+	Locations []LocationAvailabilityDto `json:"locations"`
+}
+
+type ReservationResponse struct {
+	ID         string      `json:"id"`
+	ProductID  string      `json:"product_id"`
+	LocationID string      `json:"location_id"`
+	Quantity   QuantityDto `json:"quantity"`
+	Reference  string      `json:"reference"`
+	Status     string      `json:"status" example:"active"`
+	ExpiresAt  *time.Time  `json:"expires_at,omitempty"` // absent when the stock is held until the order ships
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func ToStockLevelResponse(level model.StockLevel) StockLevelResponse {
+	return StockLevelResponse{
+		ProductID:  level.ProductID,
+		LocationID: level.LocationID,
+		OnHand:     ToQuantityDto(model.Quantity{Base: level.OnHand, Unit: level.Unit}),
+		Reserved:   ToQuantityDto(model.Quantity{Base: level.Reserved, Unit: level.Unit}),
+		Available:  ToQuantityDto(model.Quantity{Base: level.Available(), Unit: level.Unit}),
+		InTransit:  ToQuantityDto(model.Quantity{Base: level.InTransit, Unit: level.Unit}),
+		UpdatedAt:  level.UpdatedAt,
+	}
+}
+
+func ToTransferResponse(transfer model.Transfer) TransferResponse {
+	response := TransferResponse{
+		ID:             transfer.ID,
+		ProductID:      transfer.ProductID,
+		FromLocationID: transfer.FromLocationID,
+		ToLocationID:   transfer.ToLocationID,
+		Quantity:       ToQuantityDto(model.Quantity{Base: transfer.Quantity, Unit: transfer.Unit}),
+		Status:         string(transfer.Status),
+		Note:           transfer.Note,
+		CreatedAt:      transfer.CreatedAt,
+		UpdatedAt:      transfer.UpdatedAt,
+	}
+	if !transfer.ReceivedAt.IsZero() {
+		response.ReceivedAt = &transfer.ReceivedAt
+	}
+	return response
+}
+
+func ToTransferResponses(transfers []model.Transfer) []TransferResponse {
+	responses := make([]TransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		responses = append(responses, ToTransferResponse(transfer))
+	}
+	return responses
+}
+
+// ToProductAvailabilityResponse lists every location with its stock of the product; now must be in the store time zone
+func ToProductAvailabilityResponse(product *model.Product, locations []model.Location, levels []model.StockLevel, now time.Time) ProductAvailabilityResponse {
+	byLocation := make(map[string]model.StockLevel, len(levels))
+	for _, level := range levels {
+		byLocation[level.LocationID] = level
+	}
+
+	total := int64(0)
+	result := make([]LocationAvailabilityDto, 0, len(locations))
+	for _, location := range locations {
+		level := byLocation[location.ID]
+		available := level.Available()
+		total += available
+		result = append(result, LocationAvailabilityDto{
+			LocationID: location.ID,
+			Name:       location.Name,
+			Type:       string(location.Type),
+			Address:    ToAddressDto(location.Address),
+			OpenNow:    location.OpenAt(now),
+			InStock:    available > 0,
+			Available:  ToQuantityDto(model.Quantity{Base: available, Unit: product.PriceUnit}),
+			InTransit:  ToQuantityDto(model.Quantity{Base: level.InTransit, Unit: product.PriceUnit}),
+		})
+	}
+	return ProductAvailabilityResponse{
+		ProductID: product.ID,
+		Available: ToQuantityDto(model.Quantity{Base: total, Unit: product.PriceUnit}),
+		Locations: result,
+	}
+}
+
+func ToReservationResponse(reservation model.Reservation) ReservationResponse {
+	response := ReservationResponse{
+		ID:         reservation.ID,
+		ProductID:  reservation.ProductID,
+		LocationID: reservation.LocationID,
+		Quantity:   ToQuantityDto(model.Quantity{Base: reservation.Quantity, Unit: reservation.Unit}),
+		Reference:  reservation.Reference,
+		Status:     string(reservation.Status),
+		CreatedAt:  reservation.CreatedAt,
+		UpdatedAt:  reservation.UpdatedAt,
+	}
+	if !reservation.ExpiresAt.IsZero() {
+		response.ExpiresAt = &reservation.ExpiresAt
+	}
+	return response
+}
+
+func ToReservationResponses(reservations []model.Reservation) []ReservationResponse {
+	responses := make([]ReservationResponse, 0, len(reservations))
+	for _, reservation := range reservations {
+		responses = append(responses, ToReservationResponse(reservation))
+	}
+	return responses
+}
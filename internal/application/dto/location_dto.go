@@ -0,0 +1,167 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type AddressDto struct {
+	Street    string   `json:"street" example:"12 Nguyễn Huệ"`
+	Ward      string   `json:"ward" example:"Bến Nghé"`
+	District  string   `json:"district" example:"Quận 1"`
+	Province  string   `json:"province" example:"Hồ Chí Minh"`
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90,required_with=Longitude" example:"10.7743"`
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180,required_with=Latitude" example:"106.7038"`
+}
+
+type GeoPointDto struct {
+	Latitude  float64 `json:"latitude" binding:"min=-90,max=90" example:"10.7743"`
+	Longitude float64 `json:"longitude" binding:"min=-180,max=180" example:"106.7038"`
+}
+
+type OpeningHoursDto struct {
+	Weekday int    `json:"weekday" binding:"min=0,max=6" example:"1"` // 0 is Sunday
+	Opens   string `json:"opens" binding:"required" example:"07:00"`
+	Closes  string `json:"closes" binding:"required" example:"21:30"` // "24:00" for midnight
+}
+
+type LocationRequest struct {
+	ID      string            `json:"id" example:"store-district-1"` // required on create, ignored on update
+	Name    string            `json:"name" binding:"required" example:"District 1 store"`
+	Type    string            `json:"type" binding:"required" example:"store"` // warehouse, store or dark_store
+	Address AddressDto        `json:"address"`
+	Phone   string            `json:"phone" example:"028 3822 1234"`
+	Hours   []OpeningHoursDto `json:"hours" binding:"dive"`
+	Active  *bool             `json:"active,omitempty"`
+}
+
+type LocationQuery struct {
+	IncludeInactive bool `form:"include_inactive" example:"false"`
+}
+
+type LocationResponse struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type" example:"store"`
+	Address   AddressDto        `json:"address"`
+	Phone     string            `json:"phone,omitempty"`
+	Hours     []OpeningHoursDto `json:"hours"`
+	OpenNow   bool              `json:"open_now"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func ToAddress(address AddressDto) model.Address {
+	return model.Address{
+		Street:   address.Street,
+		Ward:     address.Ward,
+		District: address.District,
+		Province: address.Province,
+		Point:    toGeoPoint(address.Latitude, address.Longitude),
+	}
+}
+
+func ToAddressDto(address model.Address) AddressDto {
+	latitude, longitude := fromGeoPoint(address.Point)
+	return AddressDto{
+		Street:    address.Street,
+		Ward:      address.Ward,
+		District:  address.District,
+		Province:  address.Province,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+}
+
+func ToGeoPoints(points []GeoPointDto) []model.GeoPoint {
+	result := make([]model.GeoPoint, 0, len(points))
+	for _, point := range points {
+		result = append(result, model.GeoPoint{Latitude: point.Latitude, Longitude: point.Longitude})
+	}
+	return result
+}
+
+func ToGeoPointDtos(points []model.GeoPoint) []GeoPointDto {
+	result := make([]GeoPointDto, 0, len(points))
+	for _, point := range points {
+		result = append(result, GeoPointDto{Latitude: point.Latitude, Longitude: point.Longitude})
+	}
+	return result
+}
+
+// toGeoPoint builds the point of an address geocoded by the client; nil unless both coordinates are given
+func toGeoPoint(latitude *float64, longitude *float64) *model.GeoPoint {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+	return &model.GeoPoint{Latitude: *latitude, Longitude: *longitude}
+}
+
+func fromGeoPoint(point *model.GeoPoint) (latitude *float64, longitude *float64) {
+	if point == nil {
+		return nil, nil
+	}
+	return &point.Latitude, &point.Longitude
+}
+
+// ToOpeningHours parses "HH:MM" times into minutes after midnight
+func ToOpeningHours(request []OpeningHoursDto) ([]model.OpeningHours, error) {
+	hours := make([]model.OpeningHours, 0, len(request))
+	for _, period := range request {
+		opens, err := parseClock(period.Opens)
+		if err != nil {
+			return nil, err
+		}
+		closes, err := parseClock(period.Closes)
+		if err != nil {
+			return nil, err
+		}
+		hours = append(hours, model.OpeningHours{Weekday: time.Weekday(period.Weekday), Opens: opens, Closes: closes})
+	}
+	return hours, nil
+}
+
+func ToOpeningHoursDtos(hours []model.OpeningHours) []OpeningHoursDto {
+	result := make([]OpeningHoursDto, 0, len(hours))
+	for _, period := range hours {
+		result = append(result, OpeningHoursDto{
+			Weekday: int(period.Weekday),
+			Opens:   formatClock(period.Opens),
+			Closes:  formatClock(period.Closes),
+		})
+	}
+	return result
+}
+
+// ToLocationResponse - now must be in the store time zone
+func ToLocationResponse(location *model.Location, now time.Time) LocationResponse {
+	return LocationResponse{
+		ID:        location.ID,
+		Name:      location.Name,
+		Type:      string(location.Type),
+		Address:   ToAddressDto(location.Address),
+		Phone:     location.Phone,
+		Hours:     ToOpeningHoursDtos(location.Hours),
+		OpenNow:   location.OpenAt(now),
+		Active:    location.Active,
+		CreatedAt: location.CreatedAt,
+		UpdatedAt: location.UpdatedAt,
+	}
+}
+
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil || len(value) != 5 {
+		return 0, model.ErrInvalidOpeningHours
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, model.ErrInvalidOpeningHours
+	}
+	return hour*60 + minute, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
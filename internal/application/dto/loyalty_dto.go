@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type LoyaltyHistoryQuery struct {
+	PageRequest
+	Kind string `form:"kind" binding:"omitempty,oneof=earn redeem expire adjust" example:"earn"`
+}
+
+// LoyaltyAdjustRequest gives a customer points, or takes them away when negative
+type LoyaltyAdjustRequest struct {
+	Points int64  `json:"points" binding:"required" example:"200"`
+	Note   string `json:"note" binding:"required" example:"Sorry for the late delivery"`
+}
+
+type LoyaltyTierResponse struct {
+	Tier        string `json:"tier" example:"silver"`
+	MinPoints   int64  `json:"min_points" example:"1000"`
+	EarnPercent int64  `json:"earn_percent" example:"125"` // of the base earn rate
+}
+
+type LoyaltyAccountResponse struct {
+	UserID           string               `json:"user_id"`
+	Balance          int64                `json:"balance" example:"320"`
+	Worth            int64                `json:"worth" example:"32000"` // what the balance takes off an order
+	Tier             LoyaltyTierResponse  `json:"tier"`
+	TierPoints       int64                `json:"tier_points" example:"1450"` // earned within the tier window
+	NextTier         *LoyaltyTierResponse `json:"next_tier,omitempty"`
+	PointsToNextTier int64                `json:"points_to_next_tier,omitempty" example:"3550"`
+	SpendPerPoint    int64                `json:"spend_per_point" example:"10000"` // at the base earn rate
+	PointValue       int64                `json:"point_value" example:"100"`
+	MaxRedeemPercent int64                `json:"max_redeem_percent" example:"50"` // of an order's total
+}
+
+type LoyaltyEntryResponse struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind" example:"earn"`
+	Points    int64      `json:"points" example:"45"` // negative when taken
+	OrderID   string     `json:"order_id,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	ActorID   string     `json:"actor_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type LoyaltyRunResponse struct {
+	Earned    int `json:"earned" example:"12"`
+	GivenBack int `json:"given_back" example:"1"`
+	TakenBack int `json:"taken_back" example:"0"`
+	Expired   int `json:"expired" example:"3"`
+}
+
+func ToLoyaltyAccountResponse(account model.LoyaltyAccount, program model.LoyaltyProgram) LoyaltyAccountResponse {
+	response := LoyaltyAccountResponse{
+		UserID:           account.UserID,
+		Balance:          account.Balance,
+		Worth:            int64(account.Worth),
+		Tier:             toLoyaltyTierResponse(account.Tier),
+		TierPoints:       account.TierPoints,
+		SpendPerPoint:    int64(program.SpendPerPoint),
+		PointValue:       int64(program.PointValue),
+		MaxRedeemPercent: program.MaxRedeemPercent,
+	}
+	if account.Next != nil {
+		next := toLoyaltyTierResponse(*account.Next)
+		response.NextTier = &next
+		response.PointsToNextTier = account.Next.MinPoints - account.TierPoints
+	}
+	return response
+}
+
+func toLoyaltyTierResponse(rule model.TierRule) LoyaltyTierResponse {
+	return LoyaltyTierResponse{Tier: string(rule.Tier), MinPoints: rule.MinPoints, EarnPercent: rule.EarnPercent}
+}
+
+func ToLoyaltyEntryResponse(entry *model.LoyaltyEntry, locale string) LoyaltyEntryResponse {
+	response := LoyaltyEntryResponse{
+		ID:        entry.ID,
+		Kind:      string(entry.Kind),
+		Points:    entry.Points,
+		OrderID:   entry.OrderID,
+		Note:      Localize(entry.Note, locale),
+		ActorID:   entry.ActorID,
+		CreatedAt: entry.CreatedAt,
+	}
+	if !entry.ExpiresAt.IsZero() {
+		response.ExpiresAt = &entry.ExpiresAt
+	}
+	return response
+}
+
+func ToLoyaltyEntryPage(page Page[model.LoyaltyEntry], locale string) Page[LoyaltyEntryResponse] {
+	items := make([]LoyaltyEntryResponse, 0, len(page.Items))
+	for _, entry := range page.Items {
+		items = append(items, ToLoyaltyEntryResponse(&entry, locale))
+	}
+	return Page[LoyaltyEntryResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToLoyaltyRunResponse(run model.LoyaltyRun) LoyaltyRunResponse {
+	return LoyaltyRunResponse{Earned: run.Earned, GivenBack: run.GivenBack, TakenBack: run.TakenBack, Expired: run.Expired}
+}
@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type MovementQuery struct {
+	PageRequest
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"`
+	Type       string `form:"type" binding:"omitempty,oneof=receipt sale return adjustment spoilage transfer" example:"sale"`
+	SourceType string `form:"source_type" example:"transfer"`
+	SourceID   string `form:"source_id"`
+	ActorID    string `form:"actor_id"`
+	From       string `form:"from" example:"2026-10-01"` // store date, inclusive
+	To         string `form:"to" example:"2026-10-31"`   // store date, inclusive
+}
+
+type MovementResponse struct {
+	ID         string      `json:"id"`
+	Sequence   int64       `json:"sequence" example:"1042"`
+	ProductID  string      `json:"product_id"`
+	LocationID string      `json:"location_id"`
+	Type       string      `json:"type" example:"sale"`
+	Quantity   QuantityDto `json:"quantity"` // negative when stock left the location
+	Balance    QuantityDto `json:"balance"`  // stock on hand right after the movement
+	ActorID    string      `json:"actor_id"`
+	Reason     string      `json:"reason" example:"customer_order"`
+	Note       string      `json:"note,omitempty"`
+	SourceType string      `json:"source_type,omitempty" example:"reservation"`
+	SourceID   string      `json:"source_id,omitempty" example:"checkout-7f3a"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type RebuiltLevelDto struct {
+	ProductID  string      `json:"product_id"`
+	LocationID string      `json:"location_id"`
+	Before     QuantityDto `json:"before"`
+	After      QuantityDto `json:"after"`
+}
+
+type RebuildResponse struct {
+	Movements int               `json:"movements" example:"1042"` // ledger entries replayed
+	Corrected []RebuiltLevelDto `json:"corrected"`                // levels whose on-hand quantity disagreed with the ledger
+}
+
+type StocktakeCountDto struct {
+	ProductID string      `json:"product_id" binding:"required"`
+	Quantity  QuantityDto `json:"quantity" binding:"required"` // what is physically on the shelf, may be zero
+}
+
+type StocktakeRequest struct {
+	LocationID string              `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	Note       string              `json:"note" example:"Monthly count, chiller 2"`
+	Counts     []StocktakeCountDto `json:"counts" binding:"required,min=1,dive"`
+}
+
+type StocktakeQuery struct {
+	LocationID string `form:"location_id"`
+	Status     string `form:"status" binding:"omitempty,oneof=counted applied" example:"counted"`
+}
+
+type StocktakeLineDto struct {
+	ProductID string      `json:"product_id"`
+	Expected  QuantityDto `json:"expected"` // on hand according to the ledger when counted
+	Counted   QuantityDto `json:"counted"`
+	Variance  QuantityDto `json:"variance"` // counted minus expected
+}
+
+type StocktakeResponse struct {
+	ID         string             `json:"id"`
+	LocationID string             `json:"location_id"`
+	Status     string             `json:"status" example:"counted"`
+	Note       string             `json:"note,omitempty"`
+	Lines      []StocktakeLineDto `json:"lines"`
+	CountedBy  string             `json:"counted_by"`
+	AppliedBy  string             `json:"applied_by,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	AppliedAt  *time.Time         `json:"applied_at,omitempty"`
+}
+
+func ToMovementResponse(movement model.Movement) MovementResponse {
+	return MovementResponse{
+		ID:         movement.ID,
+		Sequence:   movement.Sequence,
+		ProductID:  movement.ProductID,
+		LocationID: movement.LocationID,
+		Type:       string(movement.Type),
+		Quantity:   ToQuantityDto(model.Quantity{Base: movement.Quantity, Unit: movement.Unit}),
+		Balance:    ToQuantityDto(model.Quantity{Base: movement.Balance, Unit: movement.Unit}),
+		ActorID:    movement.ActorID,
+		Reason:     movement.Reason,
+		Note:       movement.Note,
+		SourceType: movement.SourceType,
+		SourceID:   movement.SourceID,
+		CreatedAt:  movement.CreatedAt,
+	}
+}
+
+func ToMovementPage(page Page[model.Movement]) Page[MovementResponse] {
+	items := make([]MovementResponse, 0, len(page.Items))
+	for _, movement := range page.Items {
+		items = append(items, ToMovementResponse(movement))
+	}
+	return Page[MovementResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToStocktakeResponse(stocktake model.Stocktake) StocktakeResponse {
+	lines := make([]StocktakeLineDto, 0, len(stocktake.Lines))
+	for _, line := range stocktake.Lines {
+		lines = append(lines, StocktakeLineDto{
+			ProductID: line.ProductID,
+			Expected:  ToQuantityDto(model.Quantity{Base: line.Expected, Unit: line.Unit}),
+			Counted:   ToQuantityDto(model.Quantity{Base: line.Counted, Unit: line.Unit}),
+			Variance:  ToQuantityDto(model.Quantity{Base: line.Variance(), Unit: line.Unit}),
+		})
+	}
+	response := StocktakeResponse{
+		ID:         stocktake.ID,
+		LocationID: stocktake.LocationID,
+		Status:     string(stocktake.Status),
+		Note:       stocktake.Note,
+		Lines:      lines,
+		CountedBy:  stocktake.CountedBy,
+		AppliedBy:  stocktake.AppliedBy,
+		CreatedAt:  stocktake.CreatedAt,
+	}
+	if !stocktake.AppliedAt.IsZero() {
+		response.AppliedAt = &stocktake.AppliedAt
+	}
+	return response
+}
+
+func ToStocktakeResponses(stocktakes []model.Stocktake) []StocktakeResponse {
+	responses := make([]StocktakeResponse, 0, len(stocktakes))
+	for _, stocktake := range stocktakes {
+		responses = append(responses, ToStocktakeResponse(stocktake))
+	}
+	return responses
+}
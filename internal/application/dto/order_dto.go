@@ -0,0 +1,201 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type DeliveryAddressDto struct {
+	RecipientName string   `json:"recipient_name" binding:"required" example:"Nguyễn Văn An"`
+	Phone         string   `json:"phone" binding:"required" example:"0903 123 456"`
+	Street        string   `json:"street" binding:"required" example:"12 Nguyễn Huệ"`
+	Ward          string   `json:"ward" example:"Bến Nghé"`
+	District      string   `json:"district" binding:"required" example:"Quận 1"`
+	Province      string   `json:"province" binding:"required" example:"Hồ Chí Minh"`
+	Latitude      *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90,required_with=Longitude" example:"10.7743"`
+	Longitude     *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180,required_with=Latitude" example:"106.7038"`
+	Note          string   `json:"note" example:"Leave it with the building guard"`
+}
+
+// CheckoutRequest places the caller's cart as an order at the prices they last saw
+type CheckoutRequest struct {
+	Address       DeliveryAddressDto `json:"address" binding:"required"`
+	SlotID        string             `json:"slot_id" binding:"required"`
+	PaymentMethod string             `json:"payment_method" binding:"required,oneof=cod bank_transfer card e_wallet" example:"cod"`
+	Note          string             `json:"note" example:"No plastic bags please"`
+	RedeemPoints  int64              `json:"redeem_points" binding:"min=0" example:"200"` // loyalty points to spend on the order
+}
+
+type OrderQuery struct {
+	PageRequest
+	Status string `form:"status" example:"confirmed"`
+}
+
+type StaffOrderQuery struct {
+	PageRequest
+	Status string `form:"status" example:"confirmed"`
+	UserID string `form:"user_id"`
+	SlotID string `form:"slot_id"`
+}
+
+type OrderTransitionRequest struct {
+	Status string `json:"status" binding:"required,oneof=confirmed picking packed out_for_delivery delivered cancelled returned" example:"picking"`
+	Reason string `json:"reason" example:"Customer asked to cancel by phone"` // required to cancel or return
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Ordered the wrong vegetables"`
+}
+
+type OrderLineResponse struct {
+	ProductID     string      `json:"product_id"`
+	Name          string      `json:"name"`
+	Quantity      QuantityDto `json:"quantity"`
+	UnitPrice     int64       `json:"unit_price" example:"45000"` // per price unit, as locked at checkout
+	PriceUnit     string      `json:"price_unit" example:"kg"`
+	Total         int64       `json:"total" example:"22500"`
+	Discount      int64       `json:"discount,omitempty" example:"2250"` // promotions' share of the total
+	SubstituteFor string      `json:"substitute_for,omitempty"`          // product of a subscription box this line replaced
+}
+
+type OrderResponse struct {
+	ID             string                     `json:"id"`
+	Number         string                     `json:"number" example:"ORD-000042"`
+	UserID         string                     `json:"user_id"`
+	Status         string                     `json:"status" example:"confirmed"`
+	Lines          []OrderLineResponse        `json:"lines"`
+	Subtotal       int64                      `json:"subtotal" example:"67500"`
+	DeliveryFee    int64                      `json:"delivery_fee" example:"15000"`
+	Discount       int64                      `json:"discount" example:"15000"` // off the items and the delivery fee
+	Promotions     []AppliedPromotionResponse `json:"promotions"`
+	PointsRedeemed int64                      `json:"points_redeemed,omitempty" example:"200"`
+	PointsDiscount int64                      `json:"points_discount,omitempty" example:"20000"` // what the points took off
+	Total          int64                      `json:"total" example:"67500"`
+	Weight         int64                      `json:"weight" example:"2500"` // grams
+	PaymentMethod  string                     `json:"payment_method" example:"cod"`
+	PaymentDueAt   *time.Time                 `json:"payment_due_at,omitempty"` // unpaid orders are cancelled after this
+	Address        DeliveryAddressDto         `json:"address"`
+	ZoneID         string                     `json:"zone_id,omitempty"`
+	SlotID         string                     `json:"slot_id"`
+	SlotStart      time.Time                  `json:"slot_start"`
+	SlotEnd        time.Time                  `json:"slot_end"`
+	Shipper        *ShipperContactResponse    `json:"shipper,omitempty"` // once the order is assigned to a shipper
+	Note           string                     `json:"note,omitempty"`
+	SubscriptionID string                     `json:"subscription_id,omitempty"` // on boxes ordered for a subscription
+	PlacedAt       time.Time                  `json:"placed_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+type OrderEventResponse struct {
+	From    string    `json:"from,omitempty" example:"confirmed"` // empty for the order being placed
+	To      string    `json:"to" example:"picking"`
+	ActorID string    `json:"actor_id"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+func ToDeliveryAddress(address DeliveryAddressDto) model.DeliveryAddress {
+	return model.DeliveryAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Address: model.Address{
+			Street:   address.Street,
+			Ward:     address.Ward,
+			District: address.District,
+			Province: address.Province,
+			Point:    toGeoPoint(address.Latitude, address.Longitude),
+		},
+		Note: address.Note,
+	}
+}
+
+func ToDeliveryAddressDto(address model.DeliveryAddress) DeliveryAddressDto {
+	latitude, longitude := fromGeoPoint(address.Point)
+	return DeliveryAddressDto{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Street:        address.Street,
+		Ward:          address.Ward,
+		District:      address.District,
+		Province:      address.Province,
+		Latitude:      latitude,
+		Longitude:     longitude,
+		Note:          address.Note,
+	}
+}
+
+func ToOrderResponse(order *model.Order, locale string) OrderResponse {
+	lines := make([]OrderLineResponse, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		lines = append(lines, OrderLineResponse{
+			ProductID:     line.ProductID,
+			Name:          line.Name,
+			Quantity:      ToQuantityDto(line.Quantity),
+			UnitPrice:     int64(line.UnitPrice),
+			PriceUnit:     string(line.PriceUnit),
+			Total:         int64(line.Total),
+			Discount:      int64(line.Discount),
+			SubstituteFor: line.SubstituteFor,
+		})
+	}
+
+	response := OrderResponse{
+		ID:             order.ID,
+		Number:         order.Number,
+		UserID:         order.UserID,
+		Status:         string(order.Status),
+		Lines:          lines,
+		Subtotal:       int64(order.Subtotal),
+		DeliveryFee:    int64(order.DeliveryFee),
+		Discount:       int64(order.Discount),
+		Promotions:     ToAppliedPromotionResponses(order.Promotions, locale),
+		PointsRedeemed: order.PointsRedeemed,
+		PointsDiscount: int64(order.PointsDiscount),
+		Total:          int64(order.Total),
+		Weight:         order.Weight,
+		PaymentMethod:  string(order.PaymentMethod),
+		Address:        ToDeliveryAddressDto(order.Address),
+		ZoneID:         order.ZoneID,
+		SlotID:         order.SlotID,
+		SlotStart:      order.SlotStart,
+		SlotEnd:        order.SlotEnd,
+		Note:           order.Note,
+		SubscriptionID: order.SubscriptionID,
+		PlacedAt:       order.PlacedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+	if !order.PaymentDueAt.IsZero() {
+		response.PaymentDueAt = &order.PaymentDueAt
+	}
+	if order.Shipper != nil {
+		response.Shipper = &ShipperContactResponse{Name: order.Shipper.Name, Phone: order.Shipper.Phone}
+	}
+	return response
+}
+
+func ToOrderPage(page Page[model.Order], locale string) Page[OrderResponse] {
+	items := make([]OrderResponse, 0, len(page.Items))
+	for _, order := range page.Items {
+		items = append(items, ToOrderResponse(&order, locale))
+	}
+	return Page[OrderResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToOrderEventResponses(history []model.OrderEvent) []OrderEventResponse {
+	events := make([]OrderEventResponse, 0, len(history))
+	for _, event := range history {
+		events = append(events, OrderEventResponse{
+			From:    string(event.From),
+			To:      string(event.To),
+			ActorID: event.ActorID,
+			Reason:  event.Reason,
+			At:      event.At,
+		})
+	}
+	return events
+}
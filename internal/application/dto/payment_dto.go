@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type ConfirmTransferRequest struct {
+	TransactionID string `json:"transaction_id" binding:"required" example:"FT26293012345"` // reference on the bank statement
+}
+
+type PaymentResponse struct {
+	ID            string     `json:"id"`
+	OrderID       string     `json:"order_id"`
+	Method        string     `json:"method" example:"card"`
+	Amount        int64      `json:"amount" example:"82500"`
+	Status        string     `json:"status" example:"pending"`
+	RedirectURL   string     `json:"redirect_url,omitempty"` // send the customer here to pay
+	Instructions  string     `json:"instructions,omitempty"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	Refunded      int64      `json:"refunded" example:"0"`
+	RefundDue     bool       `json:"refund_due"` // paid for an order that could not be confirmed; it will be paid back
+	CreatedAt     time.Time  `json:"created_at"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+// PaymentIPNResponse acknowledges a gateway notification; the gateway retries until it reads code "00"
+type PaymentIPNResponse struct {
+	Code    string `json:"code" example:"00"`
+	Message string `json:"message" example:"Confirmed"`
+}
+
+func ToPaymentResponse(payment *model.Payment) PaymentResponse {
+	response := PaymentResponse{
+		ID:            payment.ID,
+		OrderID:       payment.OrderID,
+		Method:        string(payment.Method),
+		Amount:        int64(payment.Amount),
+		Status:        string(payment.Status),
+		RedirectURL:   payment.RedirectURL,
+		Instructions:  payment.Instructions,
+		TransactionID: payment.TransactionID,
+		Refunded:      int64(payment.Refunded),
+		RefundDue:     payment.RefundDue,
+		CreatedAt:     payment.CreatedAt,
+	}
+	if !payment.SettledAt.IsZero() {
+		response.SettledAt = &payment.SettledAt
+	}
+	return response
+}
+
+func ToPaymentResponses(payments []model.Payment) []PaymentResponse {
+	responses := make([]PaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		responses = append(responses, ToPaymentResponse(&payment))
+	}
+	return responses
+}
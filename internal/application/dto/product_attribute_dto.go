@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type CertificationDto struct {
+	Scheme      string `json:"scheme" binding:"required" example:"vietgap"`
+	Reference   string `json:"reference" binding:"required" example:"VG-LD-2026-0142"`
+	Issuer      string `json:"issuer,omitempty" example:"Lam Dong Department of Agriculture"`
+	ValidUntil  string `json:"valid_until,omitempty" example:"2027-06-30"`
+	DocumentURL string `json:"document_url,omitempty" example:"https://cdn.example.com/certs/VG-LD-2026-0142.pdf"`
+}
+
+type NutritionFactDto struct {
+	Nutrient string  `json:"nutrient" example:"protein"`
+	Label    string  `json:"label" example:"Protein"`
+	Amount   float64 `json:"amount" example:"1.2"`
+	Unit     string  `json:"unit" example:"g"`
+}
+
+type AttributeLabelDto struct {
+	Code  string `json:"code" example:"vietgap"`
+	Label string `json:"label" example:"VietGAP"`
+}
+
+type CertificationResponse struct {
+	Scheme      string `json:"scheme" example:"vietgap"`
+	Label       string `json:"label" example:"VietGAP"`
+	Reference   string `json:"reference"`
+	Issuer      string `json:"issuer,omitempty"`
+	ValidUntil  string `json:"valid_until,omitempty"`
+	DocumentURL string `json:"document_url,omitempty"`
+	Valid       bool   `json:"valid"` // false once the certificate has expired
+}
+
+type NutrientDefinitionDto struct {
+	Code  string `json:"code" example:"vitamin_c"`
+	Label string `json:"label" example:"Vitamin C"`
+	Unit  string `json:"unit" example:"mg"`
+}
+
+// AttributesResponse - every filterable attribute with its localized label, used to build catalog filters
+type AttributesResponse struct {
+	Nutrients      []NutrientDefinitionDto `json:"nutrients"`
+	Allergens      []AttributeLabelDto     `json:"allergens"`
+	Certifications []AttributeLabelDto     `json:"certifications"`
+}
+
+func ToNutritionFactDtos(facts model.NutritionFacts, locale string) []NutritionFactDto {
+	result := make([]NutritionFactDto, 0, len(facts))
+	for _, entry := range model.NutrientUnits {
+		if amount, ok := facts[entry.Nutrient]; ok {
+			result = append(result, NutritionFactDto{
+				Nutrient: string(entry.Nutrient),
+				Label:    label(locale, "Nutrient", string(entry.Nutrient)),
+				Amount:   amount,
+				Unit:     entry.Unit,
+			})
+		}
+	}
+	return result
+}
+
+func ToAllergenDtos(allergens []model.Allergen, locale string) []AttributeLabelDto {
+	result := make([]AttributeLabelDto, 0, len(allergens))
+	for _, allergen := range allergens {
+		result = append(result, AttributeLabelDto{Code: string(allergen), Label: label(locale, "Allergen", string(allergen))})
+	}
+	return result
+}
+
+func ToCertificationResponses(certifications []model.Certification, locale string, today time.Time) []CertificationResponse {
+	result := make([]CertificationResponse, 0, len(certifications))
+	for _, certification := range certifications {
+		response := CertificationResponse{
+			Scheme:      string(certification.Scheme),
+			Label:       label(locale, "Certification", string(certification.Scheme)),
+			Reference:   certification.Reference,
+			Issuer:      certification.Issuer,
+			DocumentURL: certification.DocumentURL,
+			Valid:       certification.ValidOn(today),
+		}
+		if !certification.ValidUntil.IsZero() {
+			response.ValidUntil = certification.ValidUntil.Format(util.DateLayout)
+		}
+		result = append(result, response)
+	}
+	return result
+}
+
+func ToCertificationDtos(certifications []model.Certification) []CertificationDto {
+	result := make([]CertificationDto, 0, len(certifications))
+	for _, certification := range certifications {
+		certificationDto := CertificationDto{
+			Scheme:      string(certification.Scheme),
+			Reference:   certification.Reference,
+			Issuer:      certification.Issuer,
+			DocumentURL: certification.DocumentURL,
+		}
+		if !certification.ValidUntil.IsZero() {
+			certificationDto.ValidUntil = certification.ValidUntil.Format(util.DateLayout)
+		}
+		result = append(result, certificationDto)
+	}
+	return result
+}
+
+func ToAttributesResponse(locale string) AttributesResponse {
+	nutrients := make([]NutrientDefinitionDto, 0, len(model.NutrientUnits))
+	for _, entry := range model.NutrientUnits {
+		nutrients = append(nutrients, NutrientDefinitionDto{
+			Code:  string(entry.Nutrient),
+			Label: label(locale, "Nutrient", string(entry.Nutrient)),
+			Unit:  entry.Unit,
+		})
+	}
+	certifications := make([]AttributeLabelDto, 0, len(model.CertificationSchemes))
+	for _, scheme := range model.CertificationSchemes {
+		certifications = append(certifications, AttributeLabelDto{Code: string(scheme), Label: label(locale, "Certification", string(scheme))})
+	}
+	return AttributesResponse{
+		Nutrients:      nutrients,
+		Allergens:      ToAllergenDtos(model.Allergens, locale),
+		Certifications: certifications,
+	}
+}
+
+// label looks up the localized label of an attribute code, e.g. "Allergen.celery"
+func label(locale string, group string, code string) string {
+	if core.Translator == nil {
+		return code
+	}
+	return core.Translator.T(locale, group+"."+code)
+}
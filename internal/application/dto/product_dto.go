@@ -0,0 +1,243 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+// QuantityDto - quantities travel as exact decimal strings so "1.25" kg never goes through a float.
+type QuantityDto struct {
+	Value string `json:"value" binding:"required" example:"1.25"`
+	Unit  string `json:"unit" binding:"required" example:"kg"`
+}
+
+type SaleRuleDto struct {
+	Unit        string `json:"unit" binding:"required" example:"kg"`
+	MinQuantity string `json:"min_quantity" binding:"required" example:"0.5"`
+	Step        string `json:"step" binding:"required" example:"0.25"`
+	MaxQuantity string `json:"max_quantity,omitempty" example:"5"`
+}
+
+type ProductRequest struct {
+	SKU         string      `json:"sku" binding:"required" example:"VEG-TOMATO-001"`
+	Name        string      `json:"name" binding:"required" example:"Cherry tomato"`
+	Description string      `json:"description" example:"Da Lat cherry tomatoes"`
+	CategoryID  string      `json:"category_id,omitempty"`
+	Price       int64       `json:"price" binding:"min=0" example:"45000"`
+	PriceUnit   string      `json:"price_unit" binding:"required" example:"kg"`
+	SaleRule    SaleRuleDto `json:"sale_rule" binding:"required"`
+	UnitWeight  int64       `json:"unit_weight,omitempty" binding:"min=0" example:"300"` // grams in one piece or bunch
+	Active      *bool       `json:"active,omitempty" example:"true"`
+
+	Translations map[string]TranslationDto `json:"translations,omitempty"` // locale → localized content
+	Origins      []string                  `json:"origins,omitempty" example:"lam-dong"`
+	Seasons      []SeasonWindowDto         `json:"seasons,omitempty"` // empty means available all year
+
+	Nutrition      map[string]float64 `json:"nutrition,omitempty"` // nutrient → amount per 100 g, e.g. {"protein": 1.2}
+	Allergens      []string           `json:"allergens,omitempty" example:"celery"`
+	Certifications []CertificationDto `json:"certifications,omitempty"`
+}
+
+// SeasonWindowDto - months are 1-12 and inclusive; From > To wraps the new year (11 → 2 is November to February).
+type SeasonWindowDto struct {
+	From    int      `json:"from" example:"11"`
+	To      int      `json:"to" example:"2"`
+	Regions []string `json:"regions,omitempty" example:"lam-dong"` // empty means every origin of the product
+}
+
+type ProductQuery struct {
+	PageRequest
+	IncludeInactive bool `form:"include_inactive" example:"false"`
+	InSeasonOnly    bool `form:"in_season_only" example:"false"` // hide produce that is out of season this month
+
+	Search           string   `form:"q" example:"rau muong"`             // matches SKU, name and description in any language, accents ignored
+	Certifications   []string `form:"certification" example:"vietgap"`   // holds a valid certificate for any of these schemes
+	ExcludeAllergens []string `form:"exclude_allergen" example:"celery"` // free from all of these allergens
+}
+
+type SeasonalQuery struct {
+	PageRequest
+	Month    int    `form:"month" binding:"omitempty,min=1,max=12" example:"11"` // defaults to the current month
+	Province string `form:"province" example:"lam-dong"`                         // only produce harvested in this province
+}
+
+type UnitPriceDto struct {
+	Amount int64  `json:"amount" example:"4500"`
+	Per    string `json:"per" example:"100 g"`
+}
+
+type ProductResponse struct {
+	ID          string            `json:"id"`
+	SKU         string            `json:"sku"`
+	Locale      string            `json:"locale" example:"vi"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Category    *CategoryResponse `json:"category,omitempty"`
+	Price       int64             `json:"price"`
+	PriceUnit   string            `json:"price_unit"`
+	SaleRule    SaleRuleDto       `json:"sale_rule"`
+	UnitWeight  int64             `json:"unit_weight,omitempty"` // grams in one piece or bunch
+	UnitPrices  []UnitPriceDto    `json:"unit_prices"`
+	Images      []ImageDto        `json:"images"`
+	Origins     []string          `json:"origins"`
+	Seasons     []SeasonWindowDto `json:"seasons"`
+	InSeason    bool              `json:"in_season"` // harvested in the current month, for the "in season" badge
+
+	Nutrition      []NutritionFactDto      `json:"nutrition"` // per 100 g
+	Allergens      []AttributeLabelDto     `json:"allergens"`
+	Certifications []CertificationResponse `json:"certifications"`
+
+	Rating RatingSummaryDto `json:"rating"` // approved reviews only
+
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ImageVariantDto struct {
+	URL    string `json:"url" example:"http://localhost:8080/api/v1/media/products/1/2/thumbnail.jpg"`
+	Width  int    `json:"width" example:"200"`
+	Height int    `json:"height" example:"150"`
+}
+
+type ImageDto struct {
+	ID       string                     `json:"id"`
+	Status   string                     `json:"status" example:"ready"`
+	Variants map[string]ImageVariantDto `json:"variants"` // "original", "thumbnail", "medium"
+}
+
+type PriceQuoteRequest struct {
+	Quantity QuantityDto `json:"quantity" binding:"required"`
+}
+
+type PriceQuoteResponse struct {
+	ProductID string      `json:"product_id"`
+	Quantity  QuantityDto `json:"quantity"`
+	Price     int64       `json:"price"`
+	PriceUnit string      `json:"price_unit"`
+	Total     int64       `json:"total"`
+}
+
+type UnitResponse struct {
+	Code      string `json:"code" example:"kg"`
+	Dimension string `json:"dimension" example:"mass"`
+	Factor    int64  `json:"factor" example:"1000"`
+}
+
+func ToQuantityDto(quantity model.Quantity) QuantityDto {
+	return QuantityDto{Value: quantity.Value(), Unit: string(quantity.Unit)}
+}
+
+func ToSaleRuleDto(rule model.SaleRule) SaleRuleDto {
+	saleRule := SaleRuleDto{
+		Unit:        string(rule.Unit),
+		MinQuantity: model.Quantity{Base: rule.MinQuantity, Unit: rule.Unit}.Value(),
+		Step:        model.Quantity{Base: rule.Step, Unit: rule.Unit}.Value(),
+	}
+	if rule.MaxQuantity > 0 {
+		saleRule.MaxQuantity = model.Quantity{Base: rule.MaxQuantity, Unit: rule.Unit}.Value()
+	}
+	return saleRule
+}
+
+func ToSeasonWindowDtos(windows []model.SeasonWindow) []SeasonWindowDto {
+	result := make([]SeasonWindowDto, 0, len(windows))
+	for _, window := range windows {
+		result = append(result, SeasonWindowDto{
+			From:    int(window.From),
+			To:      int(window.To),
+			Regions: window.Regions,
+		})
+	}
+	return result
+}
+
+func ToSeasonWindows(request []SeasonWindowDto) []model.SeasonWindow {
+	windows := make([]model.SeasonWindow, 0, len(request))
+	for _, window := range request {
+		windows = append(windows, model.SeasonWindow{
+			From:    time.Month(window.From),
+			To:      time.Month(window.To),
+			Regions: window.Regions,
+		})
+	}
+	return windows
+}
+
+func ToProductResponse(product *model.Product, locale string) ProductResponse {
+	unitPrices := make([]UnitPriceDto, 0)
+	for _, unitPrice := range product.UnitPrices() {
+		unitPrices = append(unitPrices, UnitPriceDto{
+			Amount: int64(unitPrice.Amount),
+			Per:    unitPrice.Per.String(),
+		})
+	}
+
+	var category *CategoryResponse
+	if product.Category != nil {
+		response := ToCategoryResponse(product.Category, locale)
+		category = &response
+	}
+
+	name, description := product.Localized(locale)
+	return ProductResponse{
+		ID:          product.ID,
+		SKU:         product.SKU,
+		Locale:      locale,
+		Name:        name,
+		Description: description,
+		Category:    category,
+		Price:       int64(product.Price),
+		PriceUnit:   string(product.PriceUnit),
+		SaleRule:    ToSaleRuleDto(product.SaleRule),
+		UnitWeight:  product.UnitWeight,
+		UnitPrices:  unitPrices,
+		Images:      ToImageDtos(product.Images),
+		Origins:     nonNil(product.Origins),
+		Seasons:     ToSeasonWindowDtos(product.Seasons),
+		InSeason:    product.InSeason(util.StoreNow().Month()),
+
+		Nutrition:      ToNutritionFactDtos(product.Nutrition, locale),
+		Allergens:      ToAllergenDtos(product.Allergens, locale),
+		Certifications: ToCertificationResponses(product.Certifications, locale, util.StoreToday()),
+
+		Rating: ToRatingSummaryDto(product.Rating),
+
+		Active:    product.Active,
+		CreatedAt: product.CreatedAt,
+		UpdatedAt: product.UpdatedAt,
+	}
+}
+
+func ToUnitResponse(unit model.UnitOfMeasure) UnitResponse {
+	return UnitResponse{
+		Code:      string(unit.Code),
+		Dimension: string(unit.Dimension),
+		Factor:    unit.Factor,
+	}
+}
+
+func ToImageDto(image model.ProductImage) ImageDto {
+	variants := map[string]ImageVariantDto{}
+	for _, variant := range append([]model.ImageVariant{image.Original}, image.Variants...) {
+		variants[variant.Name] = ImageVariantDto{URL: variant.URL, Width: variant.Width, Height: variant.Height}
+	}
+	return ImageDto{ID: image.ID, Status: string(image.Status), Variants: variants}
+}
+
+func ToImageDtos(images []model.ProductImage) []ImageDto {
+	result := make([]ImageDto, 0, len(images))
+	for _, image := range images {
+		result = append(result, ToImageDto(image))
+	}
+	return result
+}
+
+// nonNil keeps empty lists as [] rather than null in JSON responses
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,167 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type PromotionRequest struct {
+	Name           string     `json:"name" binding:"required" example:"Leafy greens week"`
+	Description    string     `json:"description" example:"15% off every leafy green"`
+	Code           string     `json:"code" example:"WELCOME10"` // empty applies the promotion without a code
+	Kind           string     `json:"kind" binding:"required,oneof=percentage fixed buy_x_get_y free_shipping" example:"percentage"`
+	Percent        int        `json:"percent" binding:"min=0,max=100" example:"15"` // percentage and buy_x_get_y (100 for free)
+	Amount         int64      `json:"amount" binding:"min=0" example:"20000"`       // fixed
+	MaxDiscount    int64      `json:"max_discount" binding:"min=0" example:"50000"` // cap of a percentage, 0 for none
+	Buy            int        `json:"buy" binding:"min=0" example:"2"`
+	Get            int        `json:"get" binding:"min=0" example:"1"`
+	ProductIDs     []string   `json:"product_ids,omitempty"`                         // with category_ids, the items discounted; both empty for every item
+	CategoryIDs    []string   `json:"category_ids,omitempty" example:"leafy-greens"` // a category-wide discount
+	MinSubtotal    int64      `json:"min_subtotal" binding:"min=0" example:"300000"` // e.g. the free-shipping threshold
+	Segments       []string   `json:"segments,omitempty" binding:"dive,oneof=new returning subscriber" example:"returning"`
+	StartsAt       *time.Time `json:"starts_at,omitempty" example:"2026-10-20T00:00:00+07:00"` // default now
+	EndsAt         *time.Time `json:"ends_at,omitempty" example:"2026-10-27T00:00:00+07:00"`   // default no end
+	FirstOrderOnly bool       `json:"first_order_only"`
+	PerUserLimit   int        `json:"per_user_limit" binding:"min=0" example:"1"` // orders per customer, 0 for no limit
+	UsageLimit     int        `json:"usage_limit" binding:"min=0" example:"500"`  // orders in all, 0 for no limit
+	Priority       int        `json:"priority" example:"10"`                      // higher is tried first
+	Stackable      bool       `json:"stackable"`                                  // combines with other promotions
+	Active         *bool      `json:"active,omitempty"`
+}
+
+type PromotionQuery struct {
+	PageRequest
+	Active string `form:"active" binding:"omitempty,oneof=true false"`
+	Code   string `form:"code" example:"WELCOME10"`
+}
+
+// CouponRequest enters a promotion code in the cart
+type CouponRequest struct {
+	Code string `json:"code" binding:"required" example:"WELCOME10"`
+}
+
+type PromotionResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Code           string     `json:"code,omitempty"`
+	Kind           string     `json:"kind" example:"percentage"`
+	Percent        int        `json:"percent,omitempty" example:"15"`
+	Amount         int64      `json:"amount,omitempty" example:"20000"`
+	MaxDiscount    int64      `json:"max_discount,omitempty" example:"50000"`
+	Buy            int        `json:"buy,omitempty" example:"2"`
+	Get            int        `json:"get,omitempty" example:"1"`
+	ProductIDs     []string   `json:"product_ids"`
+	CategoryIDs    []string   `json:"category_ids"`
+	MinSubtotal    int64      `json:"min_subtotal" example:"300000"`
+	Segments       []string   `json:"segments"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	FirstOrderOnly bool       `json:"first_order_only"`
+	PerUserLimit   int        `json:"per_user_limit"`
+	UsageLimit     int        `json:"usage_limit"`
+	Priority       int        `json:"priority"`
+	Stackable      bool       `json:"stackable"`
+	Active         bool       `json:"active"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type AppliedPromotionResponse struct {
+	PromotionID      string `json:"promotion_id"`
+	Name             string `json:"name"`
+	Code             string `json:"code,omitempty"`
+	Kind             string `json:"kind" example:"buy_x_get_y"`
+	Discount         int64  `json:"discount" example:"15000"` // off the items
+	FreeShipping     bool   `json:"free_shipping"`
+	ShippingDiscount int64  `json:"shipping_discount" example:"0"` // off the delivery fee
+	Explanation      string `json:"explanation" example:"Buy 2 get 1 free, 15000 VND off"`
+}
+
+type RejectedPromotionResponse struct {
+	PromotionID string `json:"promotion_id"`
+	Name        string `json:"name"`
+	Code        string `json:"code,omitempty"`
+	// not_started, ended, segment, first_order_only, user_limit, usage_limit, below_minimum, nothing_to_give or
+	// not_combinable
+	Reason string `json:"reason" example:"below_minimum"`
+}
+
+func ToPromotionResponse(promotion *model.Promotion) PromotionResponse {
+	segments := make([]string, 0, len(promotion.Segments))
+	for _, segment := range promotion.Segments {
+		segments = append(segments, string(segment))
+	}
+	response := PromotionResponse{
+		ID:             promotion.ID,
+		Name:           promotion.Name,
+		Description:    promotion.Description,
+		Code:           promotion.Code,
+		Kind:           string(promotion.Kind),
+		Percent:        promotion.Percent,
+		Amount:         int64(promotion.Amount),
+		MaxDiscount:    int64(promotion.MaxDiscount),
+		Buy:            promotion.Buy,
+		Get:            promotion.Get,
+		ProductIDs:     append([]string{}, promotion.ProductIDs...),
+		CategoryIDs:    append([]string{}, promotion.CategoryIDs...),
+		MinSubtotal:    int64(promotion.MinSubtotal),
+		Segments:       segments,
+		StartsAt:       promotion.StartsAt,
+		FirstOrderOnly: promotion.FirstOrderOnly,
+		PerUserLimit:   promotion.PerUserLimit,
+		UsageLimit:     promotion.UsageLimit,
+		Priority:       promotion.Priority,
+		Stackable:      promotion.Stackable,
+		Active:         promotion.Active,
+		CreatedAt:      promotion.CreatedAt,
+		UpdatedAt:      promotion.UpdatedAt,
+	}
+	if !promotion.EndsAt.IsZero() {
+		response.EndsAt = &promotion.EndsAt
+	}
+	return response
+}
+
+func ToPromotionPage(page Page[model.Promotion]) Page[PromotionResponse] {
+	items := make([]PromotionResponse, 0, len(page.Items))
+	for _, promotion := range page.Items {
+		items = append(items, ToPromotionResponse(&promotion))
+	}
+	return Page[PromotionResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToAppliedPromotionResponses(applied []model.AppliedPromotion, locale string) []AppliedPromotionResponse {
+	responses := make([]AppliedPromotionResponse, 0, len(applied))
+	for _, promotion := range applied {
+		responses = append(responses, AppliedPromotionResponse{
+			PromotionID:      promotion.PromotionID,
+			Name:             promotion.Name,
+			Code:             promotion.Code,
+			Kind:             string(promotion.Kind),
+			Discount:         int64(promotion.Discount),
+			FreeShipping:     promotion.FreeShipping,
+			ShippingDiscount: int64(promotion.ShippingDiscount),
+			Explanation:      Localize(promotion.Explanation, locale),
+		})
+	}
+	return responses
+}
+
+func ToRejectedPromotionResponses(rejected []model.RejectedPromotion) []RejectedPromotionResponse {
+	responses := make([]RejectedPromotionResponse, 0, len(rejected))
+	for _, promotion := range rejected {
+		responses = append(responses, RejectedPromotionResponse{
+			PromotionID: promotion.PromotionID,
+			Name:        promotion.Name,
+			Code:        promotion.Code,
+			Reason:      string(promotion.Reason),
+		})
+	}
+	return responses
+}
@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type PurchaseOrderLineDto struct {
+	ProductID string      `json:"product_id" binding:"required"`
+	Quantity  QuantityDto `json:"quantity" binding:"required"`
+	UnitCost  *int64      `json:"unit_cost,omitempty" binding:"omitempty,min=0" example:"28000"` // defaults to the supplier's cost price
+}
+
+type PurchaseOrderRequest struct {
+	SupplierID string                 `json:"supplier_id" binding:"required"`
+	LocationID string                 `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	Note       string                 `json:"note" example:"Deliver before 6am"`
+	Lines      []PurchaseOrderLineDto `json:"lines" binding:"required,min=1,dive"`
+}
+
+type PurchaseOrderQuery struct {
+	PageRequest
+	SupplierID string `form:"supplier_id"`
+	Status     string `form:"status" binding:"omitempty,oneof=draft sent partially_received received closed" example:"sent"`
+}
+
+type GoodsReceiptLineDto struct {
+	ProductID   string      `json:"product_id" binding:"required"`
+	Code        string      `json:"code" binding:"required" example:"DL-2026-10-15-A"` // lot number on the crate
+	Quantity    QuantityDto `json:"quantity" binding:"required"`
+	HarvestedOn string      `json:"harvested_on" binding:"required" example:"2026-10-15"`
+	BestBefore  string      `json:"best_before" binding:"required" example:"2026-10-22"`
+}
+
+type GoodsReceiptRequest struct {
+	Note  string                `json:"note" example:"Two crates short, rest tomorrow"`
+	Lines []GoodsReceiptLineDto `json:"lines" binding:"required,min=1,dive"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ProductID   string      `json:"product_id"`
+	Ordered     QuantityDto `json:"ordered"`
+	Received    QuantityDto `json:"received"`
+	Outstanding QuantityDto `json:"outstanding"`
+	UnitCost    int64       `json:"unit_cost" example:"28000"`
+	Cost        int64       `json:"cost" example:"1400000"`
+}
+
+type GoodsReceiptLineResponse struct {
+	ProductID string      `json:"product_id"`
+	Quantity  QuantityDto `json:"quantity"`
+	LotID     string      `json:"lot_id"`
+}
+
+type GoodsReceiptResponse struct {
+	ID         string                     `json:"id"`
+	Lines      []GoodsReceiptLineResponse `json:"lines"`
+	Note       string                     `json:"note,omitempty"`
+	ReceivedBy string                     `json:"received_by"`
+	ReceivedAt time.Time                  `json:"received_at"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         string                      `json:"id"`
+	Number     string                      `json:"number" example:"PO-000042"`
+	SupplierID string                      `json:"supplier_id"`
+	LocationID string                      `json:"location_id"`
+	Status     string                      `json:"status" example:"sent"`
+	Lines      []PurchaseOrderLineResponse `json:"lines"`
+	Receipts   []GoodsReceiptResponse      `json:"receipts"`
+	Total      int64                       `json:"total" example:"1400000"`
+	Note       string                      `json:"note,omitempty"`
+	ExpectedOn string                      `json:"expected_on,omitempty" example:"2026-10-21"`
+	CreatedBy  string                      `json:"created_by"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	SentAt     *time.Time                  `json:"sent_at,omitempty"`
+	ClosedAt   *time.Time                  `json:"closed_at,omitempty"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+func ToPurchaseOrderResponse(order *model.PurchaseOrder) PurchaseOrderResponse {
+	lines := make([]PurchaseOrderLineResponse, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		lines = append(lines, PurchaseOrderLineResponse{
+			ProductID:   line.ProductID,
+			Ordered:     ToQuantityDto(model.Quantity{Base: line.Ordered, Unit: line.Unit}),
+			Received:    ToQuantityDto(model.Quantity{Base: line.Received, Unit: line.Unit}),
+			Outstanding: ToQuantityDto(model.Quantity{Base: line.Outstanding(), Unit: line.Unit}),
+			UnitCost:    int64(line.UnitCost),
+			Cost:        int64(line.Cost()),
+		})
+	}
+	units := make(map[string]model.UnitCode, len(order.Lines))
+	for _, line := range order.Lines {
+		units[line.ProductID] = line.Unit
+	}
+	receipts := make([]GoodsReceiptResponse, 0, len(order.Receipts))
+	for _, receipt := range order.Receipts {
+		receiptLines := make([]GoodsReceiptLineResponse, 0, len(receipt.Lines))
+		for _, line := range receipt.Lines {
+			receiptLines = append(receiptLines, GoodsReceiptLineResponse{
+				ProductID: line.ProductID,
+				Quantity:  ToQuantityDto(model.Quantity{Base: line.Quantity, Unit: units[line.ProductID]}),
+				LotID:     line.LotID,
+			})
+		}
+		receipts = append(receipts, GoodsReceiptResponse{
+			ID:         receipt.ID,
+			Lines:      receiptLines,
+			Note:       receipt.Note,
+			ReceivedBy: receipt.ReceivedBy,
+			ReceivedAt: receipt.ReceivedAt,
+		})
+	}
+
+	response := PurchaseOrderResponse{
+		ID:         order.ID,
+		Number:     order.Number,
+		SupplierID: order.SupplierID,
+		LocationID: order.LocationID,
+		Status:     string(order.Status),
+		Lines:      lines,
+		Receipts:   receipts,
+		Total:      int64(order.Total()),
+		Note:       order.Note,
+		CreatedBy:  order.CreatedBy,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+	}
+	if !order.ExpectedOn.IsZero() {
+		response.ExpectedOn = order.ExpectedOn.Format(util.DateLayout)
+	}
+	if !order.SentAt.IsZero() {
+		response.SentAt = &order.SentAt
+	}
+	if !order.ClosedAt.IsZero() {
+		response.ClosedAt = &order.ClosedAt
+	}
+	return response
+}
+
+func ToPurchaseOrderPage(page Page[model.PurchaseOrder]) Page[PurchaseOrderResponse] {
+	items := make([]PurchaseOrderResponse, 0, len(page.Items))
+	for _, order := range page.Items {
+		items = append(items, ToPurchaseOrderResponse(&order))
+	}
+	return Page[PurchaseOrderResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
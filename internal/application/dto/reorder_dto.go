@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type ReorderRuleRequest struct {
+	ProductID    string      `json:"product_id" binding:"required"`
+	LocationID   string      `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	ReorderPoint QuantityDto `json:"reorder_point" binding:"required"`        // available stock at or below this is low, may be zero
+	TargetLevel  QuantityDto `json:"target_level" binding:"required"`         // reorder suggestions top stock up to this
+}
+
+type ReorderRuleQuery struct {
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"`
+}
+
+type DeleteReorderRuleQuery struct {
+	ProductID  string `form:"product_id" binding:"required"`
+	LocationID string `form:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+}
+
+type LowStockAlertQuery struct {
+	PageRequest
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"`
+	Status     string `form:"status" binding:"omitempty,oneof=open resolved" example:"open"`
+}
+
+type ReorderRuleResponse struct {
+	ProductID    string      `json:"product_id"`
+	LocationID   string      `json:"location_id"`
+	ReorderPoint QuantityDto `json:"reorder_point"`
+	TargetLevel  QuantityDto `json:"target_level"`
+	UpdatedBy    string      `json:"updated_by"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type LowStockAlertResponse struct {
+	ID                string      `json:"id"`
+	ProductID         string      `json:"product_id"`
+	LocationID        string      `json:"location_id"`
+	Available         QuantityDto `json:"available"` // when the alert was raised
+	Incoming          QuantityDto `json:"incoming"`  // in transit or on open purchase orders
+	ReorderPoint      QuantityDto `json:"reorder_point"`
+	TargetLevel       QuantityDto `json:"target_level"`
+	SuggestedQuantity QuantityDto `json:"suggested_quantity"`
+	SupplierID        string      `json:"supplier_id,omitempty"`       // preferred supplier, empty when nobody sells the product
+	PurchaseOrderID   string      `json:"purchase_order_id,omitempty"` // suggested draft purchase order
+	Status            string      `json:"status" example:"open"`
+	RaisedAt          time.Time   `json:"raised_at"`
+	ResolvedAt        *time.Time  `json:"resolved_at,omitempty"`
+}
+
+type LowStockCheckResponse struct {
+	Raised         []LowStockAlertResponse `json:"raised"`
+	Resolved       int                     `json:"resolved" example:"2"` // alerts closed because stock recovered
+	PurchaseOrders []PurchaseOrderResponse `json:"purchase_orders"`      // suggested drafts, one per supplier and location
+}
+
+func ToReorderRuleResponse(rule model.ReorderRule) ReorderRuleResponse {
+	return ReorderRuleResponse{
+		ProductID:    rule.ProductID,
+		LocationID:   rule.LocationID,
+		ReorderPoint: ToQuantityDto(model.Quantity{Base: rule.ReorderPoint, Unit: rule.Unit}),
+		TargetLevel:  ToQuantityDto(model.Quantity{Base: rule.TargetLevel, Unit: rule.Unit}),
+		UpdatedBy:    rule.UpdatedBy,
+		UpdatedAt:    rule.UpdatedAt,
+	}
+}
+
+func ToReorderRuleResponses(rules []model.ReorderRule) []ReorderRuleResponse {
+	responses := make([]ReorderRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, ToReorderRuleResponse(rule))
+	}
+	return responses
+}
+
+func ToLowStockAlertResponse(alert model.LowStockAlert) LowStockAlertResponse {
+	response := LowStockAlertResponse{
+		ID:                alert.ID,
+		ProductID:         alert.ProductID,
+		LocationID:        alert.LocationID,
+		Available:         ToQuantityDto(model.Quantity{Base: alert.Available, Unit: alert.Unit}),
+		Incoming:          ToQuantityDto(model.Quantity{Base: alert.Incoming, Unit: alert.Unit}),
+		ReorderPoint:      ToQuantityDto(model.Quantity{Base: alert.ReorderPoint, Unit: alert.Unit}),
+		TargetLevel:       ToQuantityDto(model.Quantity{Base: alert.TargetLevel, Unit: alert.Unit}),
+		SuggestedQuantity: ToQuantityDto(model.Quantity{Base: alert.SuggestedQuantity, Unit: alert.Unit}),
+		SupplierID:        alert.SupplierID,
+		PurchaseOrderID:   alert.PurchaseOrderID,
+		Status:            string(alert.Status),
+		RaisedAt:          alert.RaisedAt,
+	}
+	if !alert.ResolvedAt.IsZero() {
+		response.ResolvedAt = &alert.ResolvedAt
+	}
+	return response
+}
+
+func ToLowStockAlertPage(page Page[model.LowStockAlert]) Page[LowStockAlertResponse] {
+	items := make([]LowStockAlertResponse, 0, len(page.Items))
+	for _, alert := range page.Items {
+		items = append(items, ToLowStockAlertResponse(alert))
+	}
+	return Page[LowStockAlertResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+func ToLowStockCheckResponse(check model.LowStockCheck) LowStockCheckResponse {
+	raised := make([]LowStockAlertResponse, 0, len(check.Raised))
+	for _, alert := range check.Raised {
+		raised = append(raised, ToLowStockAlertResponse(alert))
+	}
+	orders := make([]PurchaseOrderResponse, 0, len(check.PurchaseOrders))
+	for _, order := range check.PurchaseOrders {
+		orders = append(orders, ToPurchaseOrderResponse(&order))
+	}
+	return LowStockCheckResponse{Raised: raised, Resolved: check.Resolved, PurchaseOrders: orders}
+}
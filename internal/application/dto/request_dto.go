@@ -1,6 +1,12 @@
 package dto
 
 type SignInRequest struct {
-	Username string `json:"username" binding:"required" example:"admin"`
-	Password string `json:"password" binding:"required" example:"password123"`
+	Username  string `json:"username" binding:"required" example:"admin"`
+	Password  string `json:"password" binding:"required" example:"password123"`
+	CartToken string `json:"cart_token,omitempty"` // guest cart to merge into the user's cart
+}
+
+type PageRequest struct {
+	Page int `form:"page" example:"1"`
+	Size int `form:"size" example:"20"`
 }
@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+)
+
 type HttpResponse[TData any] struct {
 	HttpStatus int    `json:"http_status"`
 	Code       string `json:"code"`
@@ -13,3 +18,37 @@ type Page[T any] struct {
 	Total int `json:"total"`
 	Items []T `json:"items"`
 }
+
+// Paginate - Usage: dto.Paginate(items, request) to slice an in-memory list into a Page (page starts at 1).
+func Paginate[T any](items []T, request PageRequest) Page[T] {
+	if request.Page < 1 {
+		request.Page = 1
+	}
+	if request.Size < 1 || request.Size > 100 {
+		request.Size = 20
+	}
+
+	start := (request.Page - 1) * request.Size
+	if start > len(items) {
+		start = len(items)
+	}
+	end := min(start+request.Size, len(items))
+
+	return Page[T]{
+		Page:  request.Page,
+		Size:  request.Size,
+		Total: len(items),
+		Items: items[start:end],
+	}
+}
+
+// Localize - Usage: dto.Localize(message, locale) to render a model message in the reader's language ("" for none).
+func Localize(message model.Message, locale string) string {
+	if message.ID == "" {
+		return ""
+	}
+	if core.Translator == nil {
+		return message.ID
+	}
+	return core.Translator.T(locale, message.ID, message.Data)
+}
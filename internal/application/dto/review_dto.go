@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type ReviewRequest struct {
+	ProductID string `json:"product_id" binding:"required"`
+	Rating    int    `json:"rating" binding:"required" example:"5"` // 1-5 stars
+	Text      string `json:"text" example:"Very fresh, delivered still cold"`
+}
+
+type ReviewQuery struct {
+	PageRequest
+	Rating int    `form:"rating" binding:"omitempty,min=1,max=5" example:"5"`                   // only reviews with this many stars
+	Sort   string `form:"sort,default=recent" binding:"oneof=recent helpful" example:"helpful"` // "recent" or "helpful"
+}
+
+type ModerationQuery struct {
+	PageRequest
+	Status    string `form:"status,default=pending" binding:"oneof=pending approved rejected" example:"pending"`
+	ProductID string `form:"product_id"`
+}
+
+type ModerationRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve reject" example:"approve"`
+	Note     string `json:"note" example:"Contains a phone number"` // shown to the author when rejected
+}
+
+type VoteRequest struct {
+	Helpful *bool `json:"helpful" binding:"required" example:"true"`
+}
+
+type ReviewPhotoDto struct {
+	ID  string `json:"id"`
+	URL string `json:"url" example:"http://localhost:8080/api/v1/review/1/photo/2"`
+}
+
+type ReviewResponse struct {
+	ID             string           `json:"id"`
+	ProductID      string           `json:"product_id"`
+	UserID         string           `json:"user_id"`
+	Rating         int              `json:"rating" example:"5"`
+	Text           string           `json:"text"`
+	Photos         []ReviewPhotoDto `json:"photos"`
+	Status         string           `json:"status" example:"approved"`
+	ModerationNote string           `json:"moderation_note,omitempty"`
+	Helpful        int              `json:"helpful" example:"12"`
+	Unhelpful      int              `json:"unhelpful" example:"1"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+type RatingSummaryDto struct {
+	Average   float64     `json:"average" example:"4.6"`
+	Count     int         `json:"count" example:"38"`
+	Histogram map[int]int `json:"histogram"` // stars -> number of reviews, "1" to "5"
+}
+
+type ProductReviewsResponse struct {
+	Summary RatingSummaryDto     `json:"summary"`
+	Reviews Page[ReviewResponse] `json:"reviews"`
+}
+
+func ToRatingSummaryDto(summary model.RatingSummary) RatingSummaryDto {
+	histogram := make(map[int]int, len(summary.Histogram))
+	for i, count := range summary.Histogram {
+		histogram[i+1] = count
+	}
+	return RatingSummaryDto{
+		Average:   summary.Average,
+		Count:     summary.Count,
+		Histogram: histogram,
+	}
+}
+
+func ToReviewResponse(review *model.Review) ReviewResponse {
+	photos := make([]ReviewPhotoDto, 0, len(review.Photos))
+	for _, photo := range review.Photos {
+		photos = append(photos, ReviewPhotoDto{ID: photo.ID, URL: photo.URL})
+	}
+	helpful, unhelpful := review.HelpfulVotes()
+	return ReviewResponse{
+		ID:             review.ID,
+		ProductID:      review.ProductID,
+		UserID:         review.UserID,
+		Rating:         review.Rating,
+		Text:           review.Text,
+		Photos:         photos,
+		Status:         string(review.Status),
+		ModerationNote: review.ModerationNote,
+		Helpful:        helpful,
+		Unhelpful:      unhelpful,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+	}
+}
+
+func ToReviewPage(page Page[model.Review]) Page[ReviewResponse] {
+	items := make([]ReviewResponse, 0, len(page.Items))
+	for _, review := range page.Items {
+		items = append(items, ToReviewResponse(&review))
+	}
+	return Page[ReviewResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type LotRequest struct {
+	ProductID   string      `json:"product_id" binding:"required"`
+	LocationID  string      `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	SupplierID  string      `json:"supplier_id,omitempty"`
+	Code        string      `json:"code" binding:"required" example:"DL-2026-10-15-A"`
+	Quantity    QuantityDto `json:"quantity" binding:"required"`
+	HarvestedOn string      `json:"harvested_on" binding:"required" example:"2026-10-15"`
+	BestBefore  string      `json:"best_before" binding:"required" example:"2026-10-22"`
+}
+
+type LotQuery struct {
+	ProductID  string `form:"product_id"`
+	LocationID string `form:"location_id"`
+}
+
+type ExpiringLotQuery struct {
+	Days int `form:"days,default=3" binding:"min=0,max=365" example:"3"`
+}
+
+type WriteOffRequest struct {
+	Reason string `json:"reason" binding:"required" example:"wilted"`
+}
+
+type PickRequest struct {
+	ProductID  string      `json:"product_id" binding:"required"`
+	LocationID string      `json:"location_id" example:"central-warehouse"` // defaults to the central warehouse
+	Quantity   QuantityDto `json:"quantity" binding:"required"`
+}
+
+type LotResponse struct {
+	ID          string      `json:"id"`
+	ProductID   string      `json:"product_id"`
+	LocationID  string      `json:"location_id"`
+	SupplierID  string      `json:"supplier_id,omitempty"`
+	Code        string      `json:"code"`
+	Received    QuantityDto `json:"received"`
+	Remaining   QuantityDto `json:"remaining"`
+	HarvestedOn string      `json:"harvested_on" example:"2026-10-15"`
+	BestBefore  string      `json:"best_before" example:"2026-10-22"`
+	DaysLeft    int         `json:"days_left" example:"2"` // negative once expired
+	Expired     bool        `json:"expired"`
+	Status      string      `json:"status" example:"active"`
+	Reason      string      `json:"reason,omitempty"`
+	ReceivedAt  time.Time   `json:"received_at"`
+}
+
+type LotAllocationDto struct {
+	LotID      string      `json:"lot_id"`
+	LotCode    string      `json:"lot_code"`
+	BestBefore string      `json:"best_before"`
+	Quantity   QuantityDto `json:"quantity"`
+}
+
+type PickResponse struct {
+	ProductID   string             `json:"product_id"`
+	Quantity    QuantityDto        `json:"quantity"`
+	Allocations []LotAllocationDto `json:"allocations"`
+}
+
+func ToLotResponse(lot *model.StockLot, today time.Time) LotResponse {
+	return LotResponse{
+		ID:          lot.ID,
+		ProductID:   lot.ProductID,
+		LocationID:  lot.LocationID,
+		SupplierID:  lot.SupplierID,
+		Code:        lot.Code,
+		Received:    ToQuantityDto(model.Quantity{Base: lot.Received, Unit: lot.Unit}),
+		Remaining:   ToQuantityDto(model.Quantity{Base: lot.Remaining, Unit: lot.Unit}),
+		HarvestedOn: lot.HarvestedOn.Format(util.DateLayout),
+		BestBefore:  lot.BestBefore.Format(util.DateLayout),
+		DaysLeft:    lot.DaysLeft(today),
+		Expired:     lot.Expired(today),
+		Status:      string(lot.Status),
+		Reason:      lot.Reason,
+		ReceivedAt:  lot.ReceivedAt,
+	}
+}
@@ -0,0 +1,197 @@
+package dto
+
+import (
+	"strings"
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+)
+
+type BoxItemDto struct {
+	ProductID   string      `json:"product_id" binding:"required" example:"cabbage"`
+	Name        string      `json:"name,omitempty" example:"Cabbage"` // in responses
+	Quantity    QuantityDto `json:"quantity" binding:"required"`
+	Substitutes []string    `json:"substitutes,omitempty" example:"bok-choy"` // sent instead, in order, when the product is out of stock
+}
+
+type SubscriptionPlanRequest struct {
+	Name        string       `json:"name" binding:"required" example:"Family veg box"`
+	Description string       `json:"description" example:"Seasonal vegetables for a family of four"`
+	Items       []BoxItemDto `json:"items" binding:"required,min=1,dive"`
+	Frequency   string       `json:"frequency" binding:"required,oneof=weekly fortnightly" example:"weekly"`
+	DeliveryDay int          `json:"delivery_day" binding:"min=0,max=6" example:"5"` // 0 is Sunday
+	Active      *bool        `json:"active,omitempty"`
+}
+
+type SubscriptionPlanResponse struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Items       []BoxItemDto `json:"items"`
+	Frequency   string       `json:"frequency" example:"weekly"`
+	DeliveryDay int          `json:"delivery_day" example:"5"`
+	Active      bool         `json:"active"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// SubscribeRequest starts a plan's boxes on its first delivery day on or after the start date
+type SubscribeRequest struct {
+	PlanID        string             `json:"plan_id" binding:"required"`
+	Address       DeliveryAddressDto `json:"address" binding:"required"`
+	PaymentMethod string             `json:"payment_method" binding:"required,oneof=cod bank_transfer card e_wallet" example:"cod"`
+	StartDate     string             `json:"start_date" example:"2026-10-23"` // default tomorrow
+	Note          string             `json:"note" example:"Leave the box at the door"`
+}
+
+// PauseSubscriptionRequest stops boxes until the subscription is resumed, or until a date when one is given
+type PauseSubscriptionRequest struct {
+	Until string `json:"until" example:"2026-11-20"`
+}
+
+type SkipDeliveryRequest struct {
+	Date string `json:"date" binding:"required" example:"2026-10-30"`
+}
+
+type CancelSubscriptionRequest struct {
+	Reason string `json:"reason" example:"Moving abroad"`
+}
+
+type SubscriptionQuery struct {
+	PageRequest
+	Status string `form:"status" binding:"omitempty,oneof=active paused cancelled" example:"active"`
+	PlanID string `form:"plan_id"`
+}
+
+type BoxRunResponse struct {
+	SubscriptionID string    `json:"subscription_id"`
+	Date           string    `json:"date" example:"2026-10-23"`
+	Outcome        string    `json:"outcome" example:"ordered"` // ordered, skipped or failed
+	OrderID        string    `json:"order_id,omitempty"`
+	Note           string    `json:"note,omitempty" example:"Bok choy instead of cabbage"`
+	At             time.Time `json:"at"`
+}
+
+type SubscriptionResponse struct {
+	ID            string             `json:"id"`
+	UserID        string             `json:"user_id"`
+	PlanID        string             `json:"plan_id"`
+	PlanName      string             `json:"plan_name"`
+	Status        string             `json:"status" example:"active"` // active, paused or cancelled
+	Frequency     string             `json:"frequency" example:"weekly"`
+	DeliveryDay   int                `json:"delivery_day" example:"5"`
+	PaymentMethod string             `json:"payment_method" example:"cod"`
+	Address       DeliveryAddressDto `json:"address"`
+	Note          string             `json:"note,omitempty"`
+	NextDelivery  string             `json:"next_delivery" example:"2026-10-23"`
+	Skipped       []string           `json:"skipped" example:"2026-10-30"`
+	PausedUntil   string             `json:"paused_until,omitempty" example:"2026-11-20"`
+	History       []BoxRunResponse   `json:"history"`
+	CancelReason  string             `json:"cancel_reason,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	CancelledAt   *time.Time         `json:"cancelled_at,omitempty"`
+}
+
+func ToSubscriptionPlanResponse(plan *model.SubscriptionPlan) SubscriptionPlanResponse {
+	items := make([]BoxItemDto, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		items = append(items, BoxItemDto{
+			ProductID:   item.ProductID,
+			Name:        item.Name,
+			Quantity:    ToQuantityDto(item.Quantity),
+			Substitutes: item.Substitutes,
+		})
+	}
+	return SubscriptionPlanResponse{
+		ID:          plan.ID,
+		Name:        plan.Name,
+		Description: plan.Description,
+		Items:       items,
+		Frequency:   string(plan.Frequency),
+		DeliveryDay: int(plan.DeliveryDay),
+		Active:      plan.Active,
+		CreatedAt:   plan.CreatedAt,
+		UpdatedAt:   plan.UpdatedAt,
+	}
+}
+
+func ToSubscriptionPlanResponses(plans []model.SubscriptionPlan) []SubscriptionPlanResponse {
+	responses := make([]SubscriptionPlanResponse, 0, len(plans))
+	for _, plan := range plans {
+		responses = append(responses, ToSubscriptionPlanResponse(&plan))
+	}
+	return responses
+}
+
+func ToSubscriptionResponse(subscription *model.Subscription, locale string) SubscriptionResponse {
+	skipped := make([]string, 0, len(subscription.Skipped))
+	for _, date := range subscription.Skipped {
+		skipped = append(skipped, storeDate(date))
+	}
+	response := SubscriptionResponse{
+		ID:            subscription.ID,
+		UserID:        subscription.UserID,
+		PlanID:        subscription.PlanID,
+		PlanName:      subscription.PlanName,
+		Status:        string(subscription.Status),
+		Frequency:     string(subscription.Frequency),
+		DeliveryDay:   int(subscription.DeliveryDay),
+		PaymentMethod: string(subscription.PaymentMethod),
+		Address:       ToDeliveryAddressDto(subscription.Address),
+		Note:          subscription.Note,
+		NextDelivery:  storeDate(subscription.NextDelivery),
+		Skipped:       skipped,
+		History:       ToBoxRunResponses(subscription.History, locale),
+		CancelReason:  subscription.CancelReason,
+		CreatedAt:     subscription.CreatedAt,
+		UpdatedAt:     subscription.UpdatedAt,
+	}
+	if !subscription.PausedUntil.IsZero() {
+		response.PausedUntil = storeDate(subscription.PausedUntil)
+	}
+	if !subscription.CancelledAt.IsZero() {
+		response.CancelledAt = &subscription.CancelledAt
+	}
+	return response
+}
+
+func ToBoxRunResponses(runs []model.BoxRun, locale string) []BoxRunResponse {
+	responses := make([]BoxRunResponse, 0, len(runs))
+	for _, run := range runs {
+		notes := make([]string, 0, len(run.Notes))
+		for _, note := range run.Notes {
+			notes = append(notes, Localize(note, locale))
+		}
+		responses = append(responses, BoxRunResponse{
+			SubscriptionID: run.SubscriptionID,
+			Date:           storeDate(run.Date),
+			Outcome:        string(run.Outcome),
+			OrderID:        run.OrderID,
+			Note:           strings.Join(notes, "; "),
+			At:             run.At,
+		})
+	}
+	return responses
+}
+
+func ToSubscriptionResponses(subscriptions []model.Subscription, locale string) []SubscriptionResponse {
+	responses := make([]SubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, ToSubscriptionResponse(&subscription, locale))
+	}
+	return responses
+}
+
+func ToSubscriptionPage(page Page[model.Subscription], locale string) Page[SubscriptionResponse] {
+	return Page[SubscriptionResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: ToSubscriptionResponses(page.Items, locale),
+	}
+}
+
+func storeDate(date time.Time) string {
+	return date.In(util.StoreLocation).Format(util.DateLayout)
+}
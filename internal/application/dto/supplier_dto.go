@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+	"veg-store-backend/internal/domain/model"
+)
+
+type SupplierContactDto struct {
+	Name  string `json:"name" binding:"required" example:"Chị Lan"`
+	Role  string `json:"role" example:"sales"`
+	Phone string `json:"phone" example:"0903 123 456"`
+	Email string `json:"email" binding:"omitempty,email" example:"lan@dalatgreen.vn"`
+}
+
+type SuppliedProductDto struct {
+	ProductID   string `json:"product_id" binding:"required"`
+	SupplierSKU string `json:"supplier_sku" example:"XL-01"`
+	CostPrice   int64  `json:"cost_price" binding:"min=0" example:"28000"` // per the product's price unit
+	Preferred   bool   `json:"preferred" example:"true"`                   // first choice for reorder suggestions
+}
+
+type SupplierRequest struct {
+	ID           string               `json:"id" example:"da-lat-green-farm"` // required on create, ignored on update
+	Name         string               `json:"name" binding:"required" example:"Da Lat Green Farm"`
+	Type         string               `json:"type" binding:"required" example:"farm"` // farm or wholesaler
+	Contacts     []SupplierContactDto `json:"contacts" binding:"dive"`
+	LeadTimeDays int                  `json:"lead_time_days" example:"2"`
+	Products     []SuppliedProductDto `json:"products" binding:"dive"`
+	Note         string               `json:"note"`
+	Active       *bool                `json:"active,omitempty"`
+}
+
+type SupplierQuery struct {
+	ProductID       string `form:"product_id"` // only suppliers of this product
+	IncludeInactive bool   `form:"include_inactive" example:"false"`
+}
+
+type SupplierResponse struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Type         string               `json:"type" example:"farm"`
+	Contacts     []SupplierContactDto `json:"contacts"`
+	LeadTimeDays int                  `json:"lead_time_days" example:"2"`
+	Products     []SuppliedProductDto `json:"products"`
+	Note         string               `json:"note,omitempty"`
+	Active       bool                 `json:"active"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+func ToSupplierContacts(contacts []SupplierContactDto) []model.SupplierContact {
+	result := make([]model.SupplierContact, 0, len(contacts))
+	for _, contact := range contacts {
+		result = append(result, model.SupplierContact{Name: contact.Name, Role: contact.Role, Phone: contact.Phone, Email: contact.Email})
+	}
+	return result
+}
+
+func ToSuppliedProducts(products []SuppliedProductDto) []model.SuppliedProduct {
+	result := make([]model.SuppliedProduct, 0, len(products))
+	for _, product := range products {
+		result = append(result, model.SuppliedProduct{
+			ProductID:   product.ProductID,
+			SupplierSKU: product.SupplierSKU,
+			CostPrice:   model.Money(product.CostPrice),
+			Preferred:   product.Preferred,
+		})
+	}
+	return result
+}
+
+func ToSupplierResponse(supplier *model.Supplier) SupplierResponse {
+	contacts := make([]SupplierContactDto, 0, len(supplier.Contacts))
+	for _, contact := range supplier.Contacts {
+		contacts = append(contacts, SupplierContactDto{Name: contact.Name, Role: contact.Role, Phone: contact.Phone, Email: contact.Email})
+	}
+	products := make([]SuppliedProductDto, 0, len(supplier.Products))
+	for _, product := range supplier.Products {
+		products = append(products, SuppliedProductDto{
+			ProductID:   product.ProductID,
+			SupplierSKU: product.SupplierSKU,
+			CostPrice:   int64(product.CostPrice),
+			Preferred:   product.Preferred,
+		})
+	}
+	return SupplierResponse{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		Type:         string(supplier.Type),
+		Contacts:     contacts,
+		LeadTimeDays: supplier.LeadTimeDays,
+		Products:     products,
+		Note:         supplier.Note,
+		Active:       supplier.Active,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
@@ -10,26 +10,153 @@ func (subError SubError) Error() string {
 }
 
 type NotFoundError struct {
-	User    SubError
-	Product SubError
+	User             SubError
+	Product          SubError
+	Image            SubError
+	Category         SubError
+	Lot              SubError
+	Review           SubError
+	Reservation      SubError
+	Location         SubError
+	Transfer         SubError
+	Stocktake        SubError
+	Supplier         SubError
+	PurchaseOrder    SubError
+	ReorderRule      SubError
+	LowStockAlert    SubError
+	Cart             SubError
+	CartLine         SubError
+	DeliverySlot     SubError
+	Order            SubError
+	Payment          SubError
+	Claim            SubError
+	DeliveryZone     SubError
+	SlotTemplate     SubError
+	Holiday          SubError
+	Shipper          SubError
+	Delivery         SubError
+	SubscriptionPlan SubError
+	Subscription     SubError
+	Promotion        SubError
 }
 
 type InvalidError struct {
-	Token    SubError
-	Email    SubError
-	Username SubError
+	Token                SubError
+	Email                SubError
+	Username             SubError
+	Request              SubError
+	Unit                 SubError
+	Quantity             SubError
+	QuantityBelowMinimum SubError
+	QuantityAboveMaximum SubError
+	QuantityStep         SubError
+	Price                SubError
+	ImageType            SubError
+	ImageTooLarge        SubError
+	Locale               SubError
+	Category             SubError
+	SpreadsheetFormat    SubError
+	Spreadsheet          SubError
+	SpreadsheetTooLarge  SubError
+	SpreadsheetValue     SubError
+	DuplicateSKU         SubError
+	Season               SubError
+	Region               SubError
+	Date                 SubError
+	LotDates             SubError
+	Nutrient             SubError
+	Allergen             SubError
+	Certification        SubError
+	Rating               SubError
+	ReviewText           SubError
+	ReviewPhotos         SubError
+	OwnReviewVote        SubError
+	Location             SubError
+	OpeningHours         SubError
+	Transfer             SubError
+	Stocktake            SubError
+	Supplier             SubError
+	SupplierProduct      SubError
+	PurchaseOrder        SubError
+	GoodsReceipt         SubError
+	ReorderRule          SubError
+	DeliverySlot         SubError
+	EmptyCart            SubError
+	Reason               SubError
+	PaymentSignature     SubError
+	IdempotencyKey       SubError
+	IdempotencyKeyReused SubError
+	ClaimLines           SubError
+	ClaimPhotos          SubError
+	RefundAmount         SubError
+	DeliveryZone         SubError
+	SlotTemplate         SubError
+	DeliveryFee          SubError
+	OutOfDeliveryArea    SubError
+	Shipper              SubError
+	DeliveryProofs       SubError
+	SubscriptionPlan     SubError
+	Promotion            SubError
+	RedeemPoints         SubError
+	ClaimDisposition     SubError
+}
+
+type ConflictError struct {
+	SKU                   SubError
+	Slug                  SubError
+	InsufficientStock     SubError
+	LotClosed             SubError
+	Review                SubError
+	ReservationClosed     SubError
+	Location              SubError
+	TransferClosed        SubError
+	StocktakeClosed       SubError
+	Supplier              SubError
+	PurchaseOrderStatus   SubError
+	ProductUnavailable    SubError
+	OutOfStock            SubError
+	PriceChanged          SubError
+	SlotFull              SubError
+	SlotClosed            SubError
+	OrderStatus           SubError
+	PaymentAmount         SubError
+	PaymentMethod         SubError
+	IdempotencyInProgress SubError
+	ClaimStatus           SubError
+	ClaimWindow           SubError
+	RefundMethod          SubError
+	SlotZone              SubError
+	DeliveryZone          SubError
+	Holiday               SubError
+	ProofRequired         SubError
+	DeliveryStatus        SubError
+	Shipper               SubError
+	CashRemitted          SubError
+	SubscriptionStatus    SubError
+	PromotionCode         SubError
+	Coupon                SubError
+	InsufficientPoints    SubError
+	SlotBooked            SubError
 }
 
 type AuthError struct {
-	Unauthenticated SubError
-	WrongPassword   SubError
-	Forbidden       SubError
+	Unauthenticated  SubError
+	WrongPassword    SubError
+	Forbidden        SubError
+	PurchaseRequired SubError
+	OrderTransition  SubError
+}
+
+type UnavailableError struct {
+	PaymentGateway SubError
 }
 
 type AppError struct {
-	NotFound NotFoundError
-	Auth     AuthError
-	Invalid  InvalidError
+	NotFound    NotFoundError
+	Auth        AuthError
+	Invalid     InvalidError
+	Conflict    ConflictError
+	Unavailable UnavailableError
 
 	errorMap map[string]SubError
 }
@@ -50,6 +177,110 @@ func InitAppError() *AppError {
 				Code:       "not_found/product",
 				MessageKey: "NotFound.Product",
 			},
+			Image: SubError{
+				Code:       "not_found/image",
+				MessageKey: "NotFound.Image",
+			},
+			Category: SubError{
+				Code:       "not_found/category",
+				MessageKey: "NotFound.Category",
+			},
+			Lot: SubError{
+				Code:       "not_found/lot",
+				MessageKey: "NotFound.Lot",
+			},
+			Review: SubError{
+				Code:       "not_found/review",
+				MessageKey: "NotFound.Review",
+			},
+			Reservation: SubError{
+				Code:       "not_found/reservation",
+				MessageKey: "NotFound.Reservation",
+			},
+			Location: SubError{
+				Code:       "not_found/location",
+				MessageKey: "NotFound.Location",
+			},
+			Transfer: SubError{
+				Code:       "not_found/transfer",
+				MessageKey: "NotFound.Transfer",
+			},
+			Stocktake: SubError{
+				Code:       "not_found/stocktake",
+				MessageKey: "NotFound.Stocktake",
+			},
+			Supplier: SubError{
+				Code:       "not_found/supplier",
+				MessageKey: "NotFound.Supplier",
+			},
+			PurchaseOrder: SubError{
+				Code:       "not_found/purchase-order",
+				MessageKey: "NotFound.PurchaseOrder",
+			},
+			ReorderRule: SubError{
+				Code:       "not_found/reorder-rule",
+				MessageKey: "NotFound.ReorderRule",
+			},
+			LowStockAlert: SubError{
+				Code:       "not_found/low-stock-alert",
+				MessageKey: "NotFound.LowStockAlert",
+			},
+			Cart: SubError{
+				Code:       "not_found/cart",
+				MessageKey: "NotFound.Cart",
+			},
+			CartLine: SubError{
+				Code:       "not_found/cart-line",
+				MessageKey: "NotFound.CartLine",
+			},
+			DeliverySlot: SubError{
+				Code:       "not_found/delivery-slot",
+				MessageKey: "NotFound.DeliverySlot",
+			},
+			Order: SubError{
+				Code:       "not_found/order",
+				MessageKey: "NotFound.Order",
+			},
+			Payment: SubError{
+				Code:       "not_found/payment",
+				MessageKey: "NotFound.Payment",
+			},
+			Claim: SubError{
+				Code:       "not_found/claim",
+				MessageKey: "NotFound.Claim",
+			},
+			DeliveryZone: SubError{
+				Code:       "not_found/delivery-zone",
+				MessageKey: "NotFound.DeliveryZone",
+			},
+			SlotTemplate: SubError{
+				Code:       "not_found/slot-template",
+				MessageKey: "NotFound.SlotTemplate",
+			},
+			Holiday: SubError{
+				Code:       "not_found/holiday",
+				MessageKey: "NotFound.Holiday",
+			},
+			Shipper: SubError{
+				Code:       "not_found/shipper",
+				MessageKey: "NotFound.Shipper",
+			},
+			Delivery: SubError{
+				Code:       "not_found/delivery",
+				MessageKey: "NotFound.Delivery",
+			},
+			SubscriptionPlan: SubError{
+				Code:       "not_found/subscription-plan",
+				MessageKey: "NotFound.SubscriptionPlan",
+			},
+			Subscription: SubError{
+				Code:       "not_found/subscription",
+				MessageKey: "NotFound.Subscription",
+			},
+			Promotion: SubError{
+				Code:       "not_found/promotion",
+				MessageKey: "NotFound.Promotion",
+			},
 		},
 		Invalid: InvalidError{
 			Token: SubError{
@@ -64,6 +295,368 @@ func InitAppError() *AppError {
 				Code:       "invalid/username",
 				MessageKey: "Invalid.Username",
 			},
+			Request: SubError{
+				Code:       "invalid/request",
+				MessageKey: "Invalid.Request",
+			},
+			Unit: SubError{
+				Code:       "invalid/unit",
+				MessageKey: "Invalid.Unit",
+			},
+			Quantity: SubError{
+				Code:       "invalid/quantity",
+				MessageKey: "Invalid.Quantity",
+			},
+			QuantityBelowMinimum: SubError{
+				Code:       "invalid/quantity-below-minimum",
+				MessageKey: "Invalid.QuantityBelowMinimum",
+			},
+			QuantityAboveMaximum: SubError{
+				Code:       "invalid/quantity-above-maximum",
+				MessageKey: "Invalid.QuantityAboveMaximum",
+			},
+			QuantityStep: SubError{
+				Code:       "invalid/quantity-step",
+				MessageKey: "Invalid.QuantityStep",
+			},
+			Price: SubError{
+				Code:       "invalid/price",
+				MessageKey: "Invalid.Price",
+			},
+			ImageType: SubError{
+				Code:       "invalid/image-type",
+				MessageKey: "Invalid.ImageType",
+			},
+			ImageTooLarge: SubError{
+				Code:       "invalid/image-too-large",
+				MessageKey: "Invalid.ImageTooLarge",
+			},
+			Locale: SubError{
+				Code:       "invalid/locale",
+				MessageKey: "Invalid.Locale",
+			},
+			Category: SubError{
+				Code:       "invalid/category",
+				MessageKey: "Invalid.Category",
+			},
+			SpreadsheetFormat: SubError{
+				Code:       "invalid/spreadsheet-format",
+				MessageKey: "Invalid.SpreadsheetFormat",
+			},
+			Spreadsheet: SubError{
+				Code:       "invalid/spreadsheet",
+				MessageKey: "Invalid.Spreadsheet",
+			},
+			SpreadsheetTooLarge: SubError{
+				Code:       "invalid/spreadsheet-too-large",
+				MessageKey: "Invalid.SpreadsheetTooLarge",
+			},
+			SpreadsheetValue: SubError{
+				Code:       "invalid/spreadsheet-value",
+				MessageKey: "Invalid.SpreadsheetValue",
+			},
+			DuplicateSKU: SubError{
+				Code:       "invalid/duplicate-sku",
+				MessageKey: "Invalid.DuplicateSKU",
+			},
+			Season: SubError{
+				Code:       "invalid/season",
+				MessageKey: "Invalid.Season",
+			},
+			Region: SubError{
+				Code:       "invalid/region",
+				MessageKey: "Invalid.Region",
+			},
+			Date: SubError{
+				Code:       "invalid/date",
+				MessageKey: "Invalid.Date",
+			},
+			LotDates: SubError{
+				Code:       "invalid/lot-dates",
+				MessageKey: "Invalid.LotDates",
+			},
+			Nutrient: SubError{
+				Code:       "invalid/nutrient",
+				MessageKey: "Invalid.Nutrient",
+			},
+			Allergen: SubError{
+				Code:       "invalid/allergen",
+				MessageKey: "Invalid.Allergen",
+			},
+			Certification: SubError{
+				Code:       "invalid/certification",
+				MessageKey: "Invalid.Certification",
+			},
+			Rating: SubError{
+				Code:       "invalid/rating",
+				MessageKey: "Invalid.Rating",
+			},
+			ReviewText: SubError{
+				Code:       "invalid/review-text",
+				MessageKey: "Invalid.ReviewText",
+			},
+			ReviewPhotos: SubError{
+				Code:       "invalid/review-photos",
+				MessageKey: "Invalid.ReviewPhotos",
+			},
+			OwnReviewVote: SubError{
+				Code:       "invalid/own-review-vote",
+				MessageKey: "Invalid.OwnReviewVote",
+			},
+			Location: SubError{
+				Code:       "invalid/location",
+				MessageKey: "Invalid.Location",
+			},
+			OpeningHours: SubError{
+				Code:       "invalid/opening-hours",
+				MessageKey: "Invalid.OpeningHours",
+			},
+			Transfer: SubError{
+				Code:       "invalid/transfer",
+				MessageKey: "Invalid.Transfer",
+			},
+			Stocktake: SubError{
+				Code:       "invalid/stocktake",
+				MessageKey: "Invalid.Stocktake",
+			},
+			Supplier: SubError{
+				Code:       "invalid/supplier",
+				MessageKey: "Invalid.Supplier",
+			},
+			SupplierProduct: SubError{
+				Code:       "invalid/supplier-product",
+				MessageKey: "Invalid.SupplierProduct",
+			},
+			PurchaseOrder: SubError{
+				Code:       "invalid/purchase-order",
+				MessageKey: "Invalid.PurchaseOrder",
+			},
+			GoodsReceipt: SubError{
+				Code:       "invalid/goods-receipt",
+				MessageKey: "Invalid.GoodsReceipt",
+			},
+			ReorderRule: SubError{
+				Code:       "invalid/reorder-rule",
+				MessageKey: "Invalid.ReorderRule",
+			},
+			DeliverySlot: SubError{
+				Code:       "invalid/delivery-slot",
+				MessageKey: "Invalid.DeliverySlot",
+			},
+			EmptyCart: SubError{
+				Code:       "invalid/empty-cart",
+				MessageKey: "Invalid.EmptyCart",
+			},
+			Reason: SubError{
+				Code:       "invalid/reason",
+				MessageKey: "Invalid.Reason",
+			},
+			PaymentSignature: SubError{
+				Code:       "invalid/payment-signature",
+				MessageKey: "Invalid.PaymentSignature",
+			},
+			IdempotencyKey: SubError{
+				Code:       "invalid/idempotency-key",
+				MessageKey: "Invalid.IdempotencyKey",
+			},
+			IdempotencyKeyReused: SubError{
+				Code:       "invalid/idempotency-key-reused",
+				MessageKey: "Invalid.IdempotencyKeyReused",
+			},
+			ClaimLines: SubError{
+				Code:       "invalid/claim-lines",
+				MessageKey: "Invalid.ClaimLines",
+			},
+			ClaimPhotos: SubError{
+				Code:       "invalid/claim-photos",
+				MessageKey: "Invalid.ClaimPhotos",
+			},
+			RefundAmount: SubError{
+				Code:       "invalid/refund-amount",
+				MessageKey: "Invalid.RefundAmount",
+			},
+			DeliveryZone: SubError{
+				Code:       "invalid/delivery-zone",
+				MessageKey: "Invalid.DeliveryZone",
+			},
+			SlotTemplate: SubError{
+				Code:       "invalid/slot-template",
+				MessageKey: "Invalid.SlotTemplate",
+			},
+			DeliveryFee: SubError{
+				Code:       "invalid/delivery-fee",
+				MessageKey: "Invalid.DeliveryFee",
+			},
+			OutOfDeliveryArea: SubError{
+				Code:       "invalid/out-of-delivery-area",
+				MessageKey: "Invalid.OutOfDeliveryArea",
+			},
+			Shipper: SubError{
+				Code:       "invalid/shipper",
+				MessageKey: "Invalid.Shipper",
+			},
+			DeliveryProofs: SubError{
+				Code:       "invalid/delivery-proofs",
+				MessageKey: "Invalid.DeliveryProofs",
+			},
+			SubscriptionPlan: SubError{
+				Code:       "invalid/subscription-plan",
+				MessageKey: "Invalid.SubscriptionPlan",
+			},
+			Promotion: SubError{
+				Code:       "invalid/promotion",
+				MessageKey: "Invalid.Promotion",
+			},
+			RedeemPoints: SubError{
+				Code:       "invalid/redeem-points",
+				MessageKey: "Invalid.RedeemPoints",
+			},
+			ClaimDisposition: SubError{
+				Code:       "invalid/claim-disposition",
+				MessageKey: "Invalid.ClaimDisposition",
+			},
+		},
+		Conflict: ConflictError{
+			SKU: SubError{
+				Code:       "conflict/sku",
+				MessageKey: "Conflict.SKU",
+			},
+			Slug: SubError{
+				Code:       "conflict/slug",
+				MessageKey: "Conflict.Slug",
+			},
+			InsufficientStock: SubError{
+				Code:       "conflict/insufficient-stock",
+				MessageKey: "Conflict.InsufficientStock",
+			},
+			LotClosed: SubError{
+				Code:       "conflict/lot-closed",
+				MessageKey: "Conflict.LotClosed",
+			},
+			Review: SubError{
+				Code:       "conflict/review",
+				MessageKey: "Conflict.Review",
+			},
+			ReservationClosed: SubError{
+				Code:       "conflict/reservation-closed",
+				MessageKey: "Conflict.ReservationClosed",
+			},
+			Location: SubError{
+				Code:       "conflict/location",
+				MessageKey: "Conflict.Location",
+			},
+			TransferClosed: SubError{
+				Code:       "conflict/transfer-closed",
+				MessageKey: "Conflict.TransferClosed",
+			},
+			StocktakeClosed: SubError{
+				Code:       "conflict/stocktake-closed",
+				MessageKey: "Conflict.StocktakeClosed",
+			},
+			Supplier: SubError{
+				Code:       "conflict/supplier",
+				MessageKey: "Conflict.Supplier",
+			},
+			PurchaseOrderStatus: SubError{
+				Code:       "conflict/purchase-order-status",
+				MessageKey: "Conflict.PurchaseOrderStatus",
+			},
+			ProductUnavailable: SubError{
+				Code:       "conflict/product-unavailable",
+				MessageKey: "Conflict.ProductUnavailable",
+			},
+			OutOfStock: SubError{
+				Code:       "conflict/out-of-stock",
+				MessageKey: "Conflict.OutOfStock",
+			},
+			PriceChanged: SubError{
+				Code:       "conflict/price-changed",
+				MessageKey: "Conflict.PriceChanged",
+			},
+			SlotFull: SubError{
+				Code:       "conflict/slot-full",
+				MessageKey: "Conflict.SlotFull",
+			},
+			SlotClosed: SubError{
+				Code:       "conflict/slot-closed",
+				MessageKey: "Conflict.SlotClosed",
+			},
+			OrderStatus: SubError{
+				Code:       "conflict/order-status",
+				MessageKey: "Conflict.OrderStatus",
+			},
+			PaymentAmount: SubError{
+				Code:       "conflict/payment-amount",
+				MessageKey: "Conflict.PaymentAmount",
+			},
+			PaymentMethod: SubError{
+				Code:       "conflict/payment-method",
+				MessageKey: "Conflict.PaymentMethod",
+			},
+			IdempotencyInProgress: SubError{
+				Code:       "conflict/idempotency-in-progress",
+				MessageKey: "Conflict.IdempotencyInProgress",
+			},
+			ClaimStatus: SubError{
+				Code:       "conflict/claim-status",
+				MessageKey: "Conflict.ClaimStatus",
+			},
+			ClaimWindow: SubError{
+				Code:       "conflict/claim-window",
+				MessageKey: "Conflict.ClaimWindow",
+			},
+			RefundMethod: SubError{
+				Code:       "conflict/refund-method",
+				MessageKey: "Conflict.RefundMethod",
+			},
+			SlotZone: SubError{
+				Code:       "conflict/slot-zone",
+				MessageKey: "Conflict.SlotZone",
+			},
+			DeliveryZone: SubError{
+				Code:       "conflict/delivery-zone",
+				MessageKey: "Conflict.DeliveryZone",
+			},
+			Holiday: SubError{
+				Code:       "conflict/holiday",
+				MessageKey: "Conflict.Holiday",
+			},
+			ProofRequired: SubError{
+				Code:       "conflict/proof-required",
+				MessageKey: "Conflict.ProofRequired",
+			},
+			DeliveryStatus: SubError{
+				Code:       "conflict/delivery-status",
+				MessageKey: "Conflict.DeliveryStatus",
+			},
+			Shipper: SubError{
+				Code:       "conflict/shipper",
+				MessageKey: "Conflict.Shipper",
+			},
+			CashRemitted: SubError{
+				Code:       "conflict/cash-remitted",
+				MessageKey: "Conflict.CashRemitted",
+			},
+			SubscriptionStatus: SubError{
+				Code:       "conflict/subscription-status",
+				MessageKey: "Conflict.SubscriptionStatus",
+			},
+			PromotionCode: SubError{
+				Code:       "conflict/promotion-code",
+				MessageKey: "Conflict.PromotionCode",
+			},
+			Coupon: SubError{
+				Code:       "conflict/coupon",
+				MessageKey: "Conflict.Coupon",
+			},
+			InsufficientPoints: SubError{
+				Code:       "conflict/insufficient-points",
+				MessageKey: "Conflict.InsufficientPoints",
+			},
+			SlotBooked: SubError{
+				Code:       "conflict/slot-booked",
+				MessageKey: "Conflict.SlotBooked",
+			},
 		},
 		Auth: AuthError{
 			Unauthenticated: SubError{
@@ -78,6 +671,20 @@ func InitAppError() *AppError {
 				Code:       "auth/forbidden",
 				MessageKey: "Auth.Forbidden",
 			},
+			PurchaseRequired: SubError{
+				Code:       "auth/purchase-required",
+				MessageKey: "Auth.PurchaseRequired",
+			},
+			OrderTransition: SubError{
+				Code:       "auth/order-transition",
+				MessageKey: "Auth.OrderTransition",
+			},
+		},
+		Unavailable: UnavailableError{
+			PaymentGateway: SubError{
+				Code:       "unavailable/payment-gateway",
+				MessageKey: "Unavailable.PaymentGateway",
+			},
 		},
 	}
 
@@ -87,13 +694,132 @@ func InitAppError() *AppError {
 
 func (appError *AppError) buildErrorMap() {
 	appError.errorMap = map[string]SubError{
-		appError.NotFound.User.Code:        appError.NotFound.User,
-		appError.NotFound.Product.Code:     appError.NotFound.Product,
-		appError.Invalid.Token.Code:        appError.Invalid.Token,
-		appError.Invalid.Email.Code:        appError.Invalid.Email,
-		appError.Invalid.Username.Code:     appError.Invalid.Username,
-		appError.Auth.Unauthenticated.Code: appError.Auth.Unauthenticated,
-		appError.Auth.WrongPassword.Code:   appError.Auth.WrongPassword,
-		appError.Auth.Forbidden.Code:       appError.Auth.Forbidden,
+		appError.NotFound.User.Code:                  appError.NotFound.User,
+		appError.NotFound.Product.Code:               appError.NotFound.Product,
+		appError.NotFound.Image.Code:                 appError.NotFound.Image,
+		appError.Invalid.Token.Code:                  appError.Invalid.Token,
+		appError.Invalid.Email.Code:                  appError.Invalid.Email,
+		appError.Invalid.Username.Code:               appError.Invalid.Username,
+		appError.Invalid.Request.Code:                appError.Invalid.Request,
+		appError.Invalid.Unit.Code:                   appError.Invalid.Unit,
+		appError.Invalid.Quantity.Code:               appError.Invalid.Quantity,
+		appError.Invalid.QuantityBelowMinimum.Code:   appError.Invalid.QuantityBelowMinimum,
+		appError.Invalid.QuantityAboveMaximum.Code:   appError.Invalid.QuantityAboveMaximum,
+		appError.Invalid.QuantityStep.Code:           appError.Invalid.QuantityStep,
+		appError.Invalid.Price.Code:                  appError.Invalid.Price,
+		appError.Invalid.ImageType.Code:              appError.Invalid.ImageType,
+		appError.Invalid.ImageTooLarge.Code:          appError.Invalid.ImageTooLarge,
+		appError.NotFound.Category.Code:              appError.NotFound.Category,
+		appError.Invalid.Locale.Code:                 appError.Invalid.Locale,
+		appError.Invalid.Category.Code:               appError.Invalid.Category,
+		appError.Conflict.Slug.Code:                  appError.Conflict.Slug,
+		appError.Conflict.SKU.Code:                   appError.Conflict.SKU,
+		appError.Auth.Unauthenticated.Code:           appError.Auth.Unauthenticated,
+		appError.Auth.WrongPassword.Code:             appError.Auth.WrongPassword,
+		appError.Auth.Forbidden.Code:                 appError.Auth.Forbidden,
+		appError.Invalid.SpreadsheetFormat.Code:      appError.Invalid.SpreadsheetFormat,
+		appError.Invalid.Spreadsheet.Code:            appError.Invalid.Spreadsheet,
+		appError.Invalid.SpreadsheetTooLarge.Code:    appError.Invalid.SpreadsheetTooLarge,
+		appError.Invalid.SpreadsheetValue.Code:       appError.Invalid.SpreadsheetValue,
+		appError.Invalid.DuplicateSKU.Code:           appError.Invalid.DuplicateSKU,
+		appError.Invalid.Season.Code:                 appError.Invalid.Season,
+		appError.Invalid.Region.Code:                 appError.Invalid.Region,
+		appError.NotFound.Lot.Code:                   appError.NotFound.Lot,
+		appError.Invalid.Date.Code:                   appError.Invalid.Date,
+		appError.Invalid.LotDates.Code:               appError.Invalid.LotDates,
+		appError.Conflict.InsufficientStock.Code:     appError.Conflict.InsufficientStock,
+		appError.Conflict.LotClosed.Code:             appError.Conflict.LotClosed,
+		appError.Invalid.Nutrient.Code:               appError.Invalid.Nutrient,
+		appError.Invalid.Allergen.Code:               appError.Invalid.Allergen,
+		appError.Invalid.Certification.Code:          appError.Invalid.Certification,
+		appError.NotFound.Review.Code:                appError.NotFound.Review,
+		appError.Invalid.Rating.Code:                 appError.Invalid.Rating,
+		appError.Invalid.ReviewText.Code:             appError.Invalid.ReviewText,
+		appError.Invalid.ReviewPhotos.Code:           appError.Invalid.ReviewPhotos,
+		appError.Invalid.OwnReviewVote.Code:          appError.Invalid.OwnReviewVote,
+		appError.Conflict.Review.Code:                appError.Conflict.Review,
+		appError.Auth.PurchaseRequired.Code:          appError.Auth.PurchaseRequired,
+		appError.NotFound.Reservation.Code:           appError.NotFound.Reservation,
+		appError.Conflict.ReservationClosed.Code:     appError.Conflict.ReservationClosed,
+		appError.NotFound.Location.Code:              appError.NotFound.Location,
+		appError.NotFound.Transfer.Code:              appError.NotFound.Transfer,
+		appError.Invalid.Location.Code:               appError.Invalid.Location,
+		appError.Invalid.OpeningHours.Code:           appError.Invalid.OpeningHours,
+		appError.Invalid.Transfer.Code:               appError.Invalid.Transfer,
+		appError.Conflict.Location.Code:              appError.Conflict.Location,
+		appError.Conflict.TransferClosed.Code:        appError.Conflict.TransferClosed,
+		appError.NotFound.Stocktake.Code:             appError.NotFound.Stocktake,
+		appError.Invalid.Stocktake.Code:              appError.Invalid.Stocktake,
+		appError.Conflict.StocktakeClosed.Code:       appError.Conflict.StocktakeClosed,
+		appError.NotFound.Supplier.Code:              appError.NotFound.Supplier,
+		appError.NotFound.PurchaseOrder.Code:         appError.NotFound.PurchaseOrder,
+		appError.Invalid.Supplier.Code:               appError.Invalid.Supplier,
+		appError.Invalid.SupplierProduct.Code:        appError.Invalid.SupplierProduct,
+		appError.Invalid.PurchaseOrder.Code:          appError.Invalid.PurchaseOrder,
+		appError.Invalid.GoodsReceipt.Code:           appError.Invalid.GoodsReceipt,
+		appError.Conflict.Supplier.Code:              appError.Conflict.Supplier,
+		appError.Conflict.PurchaseOrderStatus.Code:   appError.Conflict.PurchaseOrderStatus,
+		appError.NotFound.ReorderRule.Code:           appError.NotFound.ReorderRule,
+		appError.NotFound.LowStockAlert.Code:         appError.NotFound.LowStockAlert,
+		appError.Invalid.ReorderRule.Code:            appError.Invalid.ReorderRule,
+		appError.NotFound.Cart.Code:                  appError.NotFound.Cart,
+		appError.NotFound.CartLine.Code:              appError.NotFound.CartLine,
+		appError.Conflict.ProductUnavailable.Code:    appError.Conflict.ProductUnavailable,
+		appError.NotFound.DeliverySlot.Code:          appError.NotFound.DeliverySlot,
+		appError.NotFound.Order.Code:                 appError.NotFound.Order,
+		appError.Invalid.DeliverySlot.Code:           appError.Invalid.DeliverySlot,
+		appError.Invalid.EmptyCart.Code:              appError.Invalid.EmptyCart,
+		appError.Conflict.OutOfStock.Code:            appError.Conflict.OutOfStock,
+		appError.Conflict.PriceChanged.Code:          appError.Conflict.PriceChanged,
+		appError.Conflict.SlotFull.Code:              appError.Conflict.SlotFull,
+		appError.Conflict.SlotClosed.Code:            appError.Conflict.SlotClosed,
+		appError.Invalid.Reason.Code:                 appError.Invalid.Reason,
+		appError.Conflict.OrderStatus.Code:           appError.Conflict.OrderStatus,
+		appError.Auth.OrderTransition.Code:           appError.Auth.OrderTransition,
+		appError.NotFound.Payment.Code:               appError.NotFound.Payment,
+		appError.Invalid.PaymentSignature.Code:       appError.Invalid.PaymentSignature,
+		appError.Conflict.PaymentAmount.Code:         appError.Conflict.PaymentAmount,
+		appError.Conflict.PaymentMethod.Code:         appError.Conflict.PaymentMethod,
+		appError.Unavailable.PaymentGateway.Code:     appError.Unavailable.PaymentGateway,
+		appError.Invalid.IdempotencyKey.Code:         appError.Invalid.IdempotencyKey,
+		appError.Invalid.IdempotencyKeyReused.Code:   appError.Invalid.IdempotencyKeyReused,
+		appError.Conflict.IdempotencyInProgress.Code: appError.Conflict.IdempotencyInProgress,
+		appError.NotFound.Claim.Code:                 appError.NotFound.Claim,
+		appError.Invalid.ClaimLines.Code:             appError.Invalid.ClaimLines,
+		appError.Invalid.ClaimPhotos.Code:            appError.Invalid.ClaimPhotos,
+		appError.Invalid.RefundAmount.Code:           appError.Invalid.RefundAmount,
+		appError.Conflict.ClaimStatus.Code:           appError.Conflict.ClaimStatus,
+		appError.Conflict.ClaimWindow.Code:           appError.Conflict.ClaimWindow,
+		appError.Conflict.RefundMethod.Code:          appError.Conflict.RefundMethod,
+		appError.NotFound.DeliveryZone.Code:          appError.NotFound.DeliveryZone,
+		appError.NotFound.SlotTemplate.Code:          appError.NotFound.SlotTemplate,
+		appError.NotFound.Holiday.Code:               appError.NotFound.Holiday,
+		appError.Invalid.DeliveryZone.Code:           appError.Invalid.DeliveryZone,
+		appError.Invalid.SlotTemplate.Code:           appError.Invalid.SlotTemplate,
+		appError.Conflict.SlotZone.Code:              appError.Conflict.SlotZone,
+		appError.Conflict.DeliveryZone.Code:          appError.Conflict.DeliveryZone,
+		appError.Conflict.Holiday.Code:               appError.Conflict.Holiday,
+		appError.Invalid.DeliveryFee.Code:            appError.Invalid.DeliveryFee,
+		appError.Invalid.OutOfDeliveryArea.Code:      appError.Invalid.OutOfDeliveryArea,
+		appError.NotFound.Shipper.Code:               appError.NotFound.Shipper,
+		appError.NotFound.Delivery.Code:              appError.NotFound.Delivery,
+		appError.Invalid.Shipper.Code:                appError.Invalid.Shipper,
+		appError.Invalid.DeliveryProofs.Code:         appError.Invalid.DeliveryProofs,
+		appError.Conflict.ProofRequired.Code:         appError.Conflict.ProofRequired,
+		appError.Conflict.DeliveryStatus.Code:        appError.Conflict.DeliveryStatus,
+		appError.Conflict.Shipper.Code:               appError.Conflict.Shipper,
+		appError.Conflict.CashRemitted.Code:          appError.Conflict.CashRemitted,
+		appError.NotFound.SubscriptionPlan.Code:      appError.NotFound.SubscriptionPlan,
+		appError.NotFound.Subscription.Code:          appError.NotFound.Subscription,
+		appError.Invalid.SubscriptionPlan.Code:       appError.Invalid.SubscriptionPlan,
+		appError.Conflict.SubscriptionStatus.Code:    appError.Conflict.SubscriptionStatus,
+		appError.NotFound.Promotion.Code:             appError.NotFound.Promotion,
+		appError.Invalid.Promotion.Code:              appError.Invalid.Promotion,
+		appError.Conflict.PromotionCode.Code:         appError.Conflict.PromotionCode,
+		appError.Conflict.Coupon.Code:                appError.Conflict.Coupon,
+		appError.Conflict.InsufficientPoints.Code:    appError.Conflict.InsufficientPoints,
+		appError.Invalid.RedeemPoints.Code:           appError.Invalid.RedeemPoints,
+		appError.Invalid.ClaimDisposition.Code:       appError.Invalid.ClaimDisposition,
+		appError.Conflict.SlotBooked.Code:            appError.Conflict.SlotBooked,
 	}
 }
@@ -0,0 +1,32 @@
+package infra_interface
+
+import "time"
+
+// IdempotentResponse is a response kept so retries of the request that produced it get it again
+type IdempotentResponse struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyRecord is what is known of an idempotency key: the fingerprint of the request that first sent it, and
+// that request's response once it has finished
+type IdempotencyRecord struct {
+	Fingerprint string
+	Response    *IdempotentResponse
+	ExpiresAt   time.Time
+}
+
+type IdempotencyStore interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	// Claim records the key for a request with the fingerprint and reports true. When the key is already held, the
+	// record holding it is returned instead.
+	Claim(key string, fingerprint string) (*IdempotencyRecord, bool)
+	// Complete keeps the response of the request that claimed the key until the key expires
+	Complete(key string, response IdempotentResponse)
+	// Release forgets the key, so the next request sending it runs again
+	Release(key string)
+}
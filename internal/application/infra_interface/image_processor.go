@@ -0,0 +1,18 @@
+package infra_interface
+
+import "errors"
+
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+type ImageInfo struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+type ImageProcessor interface {
+	// Inspect detects the content type from the file's magic bytes and reads the image dimensions.
+	Inspect(content []byte) (*ImageInfo, error)
+	// Resize scales the image so its longest side is at most maxSide pixels, keeping the aspect ratio.
+	Resize(content []byte, maxSide int) ([]byte, *ImageInfo, error)
+}
@@ -0,0 +1,16 @@
+package infra_interface
+
+type Notification struct {
+	Roles   []string // Delivered to every user with one of these roles
+	UserID  string   // Or to this one user
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Notify(notification Notification) error
+}
@@ -0,0 +1,68 @@
+package infra_interface
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrInvalidPaymentSignature    = errors.New("payment callback signature does not match")
+	ErrPaymentCallbackUnsupported = errors.New("payment provider does not send callbacks")
+	ErrRefundUnsupported          = errors.New("payment provider cannot refund")
+)
+
+// PaymentIntent asks a provider to collect an order's amount from the customer
+type PaymentIntent struct {
+	Reference   string // Our payment id; providers echo it back in their callbacks
+	Method      string // cod, bank_transfer, card or e_wallet
+	Amount      int64  // VND
+	Description string // Shown to the customer, e.g. "Veg Store order ORD-000042"
+	ClientIP    string
+	ExpiresAt   time.Time // Zero when the provider's default applies
+}
+
+// PaymentSession is how the customer goes on to pay
+type PaymentSession struct {
+	RedirectURL   string // Gateway page to send the customer to; empty when there is none
+	Instructions  string // What the customer does themselves, e.g. which account to transfer to
+	TransactionID string // Provider's id, when it assigns one up front
+}
+
+// PaymentResult is what a provider reported about a payment, once its signature checked out
+type PaymentResult struct {
+	Reference     string
+	TransactionID string
+	Amount        int64
+	Success       bool
+	Code          string // Provider's response code, kept for support
+}
+
+// PaymentRefund asks a provider to pay back part or all of a payment it collected
+type PaymentRefund struct {
+	Reference     string // Our id for the refund
+	TransactionID string // Provider's id of the payment
+	Amount        int64  // VND
+	Reason        string
+}
+
+// RefundResult is the provider's receipt for a refund it carried out
+type RefundResult struct {
+	TransactionID string
+	Code          string
+}
+
+type PaymentProvider interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(intent PaymentIntent) (*PaymentSession, error)
+	// Verify checks the signature of the parameters a provider sent to the return URL or IPN endpoint and reads them
+	Verify(params url.Values) (*PaymentResult, error)
+	// Refund pays money back to where it came from; providers that cannot return ErrRefundUnsupported
+	Refund(refund PaymentRefund) (*RefundResult, error)
+}
+
+// PaymentProviders - the provider collecting each payment method
+type PaymentProviders map[string]PaymentProvider
@@ -0,0 +1,12 @@
+package infra_interface
+
+import "time"
+
+type Scheduler interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	// Every runs job at the given interval once the scheduler has started; runs of one job never overlap.
+	Every(name string, interval time.Duration, job func() error)
+}
@@ -0,0 +1,44 @@
+package infra_interface
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrUnsupportedSpreadsheet = errors.New("unsupported spreadsheet format")
+	ErrUnreadableSpreadsheet  = errors.New("unreadable spreadsheet")
+)
+
+type SpreadsheetFormat string
+
+const (
+	SpreadsheetCSV  SpreadsheetFormat = "csv"
+	SpreadsheetXLSX SpreadsheetFormat = "xlsx"
+)
+
+// SpreadsheetFormatOf accepts a format name ("xlsx") or a file name ("catalog.xlsx")
+func SpreadsheetFormatOf(name string) (SpreadsheetFormat, error) {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext("."+name), "."))
+	switch SpreadsheetFormat(format) {
+	case SpreadsheetCSV, SpreadsheetXLSX:
+		return SpreadsheetFormat(format), nil
+	default:
+		return "", ErrUnsupportedSpreadsheet
+	}
+}
+
+// RowWriter writes one row at a time so large sheets never sit in memory. Close must be called to flush.
+type RowWriter interface {
+	Write(row []string) error
+	Close() error
+}
+
+type Spreadsheet interface {
+	// Read returns every row of the first sheet, including the header row
+	Read(format SpreadsheetFormat, reader io.Reader) ([][]string, error)
+	NewWriter(format SpreadsheetFormat, writer io.Writer) (RowWriter, error)
+	ContentType(format SpreadsheetFormat) string
+}
@@ -0,0 +1,16 @@
+package infra_interface
+
+import "errors"
+
+var ErrObjectNotFound = errors.New("object not found")
+
+type FileStorage interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Put(key string, contentType string, content []byte) error
+	Get(key string) (content []byte, contentType string, err error)
+	Delete(key string) error
+	URL(key string) string
+}
@@ -0,0 +1,9 @@
+package infra_interface
+
+import "context"
+
+type Transactor interface {
+	// Transaction runs callback all-or-nothing. Repository writes must be made with the context callback gets; a
+	// context that already carries a transaction joins it.
+	Transaction(ctx context.Context, callback func(ctx context.Context) error) error
+}
@@ -0,0 +1,10 @@
+package infra_interface
+
+type BackgroundWorker interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	// Enqueue schedules task to run on a worker goroutine; it returns false if the queue is full or stopped.
+	Enqueue(name string, task func() error) bool
+}
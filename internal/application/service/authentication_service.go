@@ -7,6 +7,7 @@ import (
 	"veg-store-backend/internal/application/infra_interface"
 
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 type AuthenticationService interface {
@@ -19,12 +20,14 @@ type AuthenticationService interface {
 
 type authenticationService struct {
 	userService UserService
+	cartService CartService
 	jwtManager  infra_interface.JWTManager
 }
 
-func NewAuthenticationService(userService UserService, jwtManager infra_interface.JWTManager) AuthenticationService {
+func NewAuthenticationService(userService UserService, cartService CartService, jwtManager infra_interface.JWTManager) AuthenticationService {
 	return &authenticationService{
 		userService: userService,
+		cartService: cartService,
 		jwtManager:  jwtManager,
 	}
 }
@@ -36,15 +39,22 @@ func (service *authenticationService) Tokens(request dto.SignInRequest) (*dto.To
 		return nil, core.Error.Invalid.Username
 	}
 
-	accessToken, err := service.jwtManager.Sign(false, user.ID)
+	accessToken, err := service.jwtManager.Sign(false, user.ID, user.Roles...)
 	if err != nil {
 		return nil, core.Error.Auth.Unauthenticated
 	}
-	refreshToken, err := service.jwtManager.Sign(true, user.ID)
+	refreshToken, err := service.jwtManager.Sign(true, user.ID, user.Roles...)
 	if err != nil {
 		return nil, core.Error.Auth.Unauthenticated
 	}
 
+	// What the guest put in the cart follows them into their account; an expired guest cart must not block sign-in
+	if request.CartToken != "" {
+		if _, err := service.cartService.Merge(user.ID, request.CartToken); err != nil {
+			zap.L().Warn("Could not merge guest cart at sign-in", zap.String("user_id", user.ID), zap.Error(err))
+		}
+	}
+
 	return &dto.Tokens{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
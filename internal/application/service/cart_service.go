@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultGuestCartTTL    = 30 * 24 * time.Hour
+	guestCartSweepInterval = time.Hour
+)
+
+type CartService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Find(owner model.CartOwner) (*model.PricedCart, error)
+	AddLine(owner model.CartOwner, request dto.CartLineRequest) (*model.PricedCart, error)
+	UpdateLine(owner model.CartOwner, productID string, request dto.UpdateCartLineRequest) (*model.PricedCart, error)
+	RemoveLine(owner model.CartOwner, productID string) (*model.PricedCart, error)
+	Merge(userID string, token string) (*model.PricedCart, error)
+	ApplyCoupon(owner model.CartOwner, request dto.CouponRequest) (*model.PricedCart, error)
+	RemoveCoupon(owner model.CartOwner) (*model.PricedCart, error)
+	ExpireGuestCarts() int
+}
+
+type cartService struct {
+	repo          repository.CartRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	promotions    PromotionService
+	transactor    infra_interface.Transactor
+	guestTTL      time.Duration
+}
+
+func NewCartService(
+	repo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	inventoryRepo repository.InventoryRepository,
+	promotions PromotionService,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) CartService {
+	service := &cartService{
+		repo:          repo,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		promotions:    promotions,
+		transactor:    transactor,
+		guestTTL:      configuredDuration(core.Configs.Cart.GuestTTL, defaultGuestCartTTL),
+	}
+
+	scheduler.Every("expire-guest-carts", guestCartSweepInterval, func() error {
+		if expired := service.ExpireGuestCarts(); expired > 0 {
+			zap.L().Info("Deleted abandoned guest carts", zap.Int("count", expired))
+		}
+		return nil
+	})
+	return service
+}
+
+// Find reads the cart at current prices and stock. Without a user or token it is a new, empty cart.
+func (service *cartService) Find(owner model.CartOwner) (*model.PricedCart, error) {
+	if owner.UserID == "" && owner.Token == "" {
+		return &model.PricedCart{}, nil
+	}
+	cart, err := service.cartOf(owner)
+	if err == core.Error.NotFound.Cart && owner.UserID != "" {
+		return &model.PricedCart{Cart: model.Cart{UserID: owner.UserID}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return service.price(*cart)
+}
+
+// AddLine adds the quantity to the product's line, creating the cart (and a guest token) when there is none yet
+func (service *cartService) AddLine(owner model.CartOwner, request dto.CartLineRequest) (*model.PricedCart, error) {
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := model.ParseQuantity(request.Quantity.Value, model.UnitCode(request.Quantity.Unit))
+	if err != nil {
+		return nil, domainError(err)
+	}
+
+	return service.modify(owner, true, func(cart *model.Cart, now time.Time) error {
+		line, ok := cart.Line(product.ID)
+		if !ok {
+			line = model.CartLine{ProductID: product.ID, Quantity: model.Quantity{Unit: quantity.Unit}, AddedAt: now}
+		}
+		total, err := line.Quantity.Add(quantity)
+		if err != nil {
+			return core.Error.Invalid.Unit
+		}
+		if err := service.check(product, total); err != nil {
+			return err
+		}
+		line.Quantity = total
+		line.UnitPrice = product.Price
+		line.UpdatedAt = now
+		cart.Put(line)
+		return nil
+	})
+}
+
+// UpdateLine replaces the quantity of a product already in the cart
+func (service *cartService) UpdateLine(owner model.CartOwner, productID string, request dto.UpdateCartLineRequest) (*model.PricedCart, error) {
+	quantity, err := model.ParseQuantity(request.Quantity.Value, model.UnitCode(request.Quantity.Unit))
+	if err != nil {
+		return nil, domainError(err)
+	}
+
+	return service.modify(owner, false, func(cart *model.Cart, now time.Time) error {
+		line, ok := cart.Line(productID)
+		if !ok {
+			return core.Error.NotFound.CartLine
+		}
+		product, err := service.productRepo.FindByID(productID)
+		if err != nil {
+			return err
+		}
+		if err := service.check(product, quantity); err != nil {
+			return err
+		}
+		line.Quantity = quantity
+		line.UnitPrice = product.Price
+		line.UpdatedAt = now
+		cart.Put(line)
+		return nil
+	})
+}
+
+func (service *cartService) RemoveLine(owner model.CartOwner, productID string) (*model.PricedCart, error) {
+	return service.modify(owner, false, func(cart *model.Cart, now time.Time) error {
+		if !cart.Remove(productID) {
+			return core.Error.NotFound.CartLine
+		}
+		return nil
+	})
+}
+
+// Merge moves a guest cart into the user's cart, e.g. when the guest signs in; the guest cart is deleted
+func (service *cartService) Merge(userID string, token string) (*model.PricedCart, error) {
+	var merged model.Cart
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		guest, err := service.repo.FindByToken(token)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		cart, err := service.repo.FindByUser(userID)
+		if err != nil {
+			cart = &model.Cart{ID: uuid.NewString(), UserID: userID, CreatedAt: now}
+		}
+		cart.Merge(*guest, now)
+		service.repo.Save(ctx, *cart)
+		service.repo.Delete(ctx, *guest)
+		merged = *cart
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return service.price(merged)
+}
+
+// ApplyCoupon enters a promotion code in the cart, replacing any code entered before. The code must belong to an
+// active promotion; whether it applies to the cart is explained with the cart's promotions.
+func (service *cartService) ApplyCoupon(owner model.CartOwner, request dto.CouponRequest) (*model.PricedCart, error) {
+	promotion, err := service.promotions.FindByCode(request.Code)
+	if err != nil {
+		return nil, err
+	}
+	return service.modify(owner, false, func(cart *model.Cart, now time.Time) error {
+		cart.Coupon = promotion.Code
+		return nil
+	})
+}
+
+func (service *cartService) RemoveCoupon(owner model.CartOwner) (*model.PricedCart, error) {
+	return service.modify(owner, false, func(cart *model.Cart, now time.Time) error {
+		cart.Coupon = ""
+		return nil
+	})
+}
+
+// ExpireGuestCarts deletes guest carts nobody touched within the guest cart TTL
+func (service *cartService) ExpireGuestCarts() int {
+	expired := 0
+	_ = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		cutoff := time.Now().Add(-service.guestTTL)
+		for _, cart := range service.repo.FindAll(func(cart model.Cart) bool {
+			return cart.IsGuest() && cart.UpdatedAt.Before(cutoff)
+		}) {
+			service.repo.Delete(ctx, cart)
+			expired++
+		}
+		return nil
+	})
+	return expired
+}
+
+func (service *cartService) cartOf(owner model.CartOwner) (*model.Cart, error) {
+	if owner.UserID != "" {
+		return service.repo.FindByUser(owner.UserID)
+	}
+	return service.repo.FindByToken(owner.Token)
+}
+
+// modify changes the owner's cart in a transaction and prices the result. A missing cart is created when create is
+// set and the owner is signed in or sent no token; an unknown guest token is an error rather than a fresh cart.
+func (service *cartService) modify(owner model.CartOwner, create bool, change func(cart *model.Cart, now time.Time) error) (*model.PricedCart, error) {
+	var saved model.Cart
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		cart, err := service.cartOf(owner)
+		if err != nil {
+			if !create || (owner.UserID == "" && owner.Token != "") {
+				return err
+			}
+			cart = &model.Cart{ID: uuid.NewString(), UserID: owner.UserID, CreatedAt: now}
+			if owner.UserID == "" {
+				cart.Token = util.RandomToken()
+			}
+		}
+		if err := change(cart, now); err != nil {
+			return err
+		}
+		cart.UpdatedAt = now
+		service.repo.Save(ctx, *cart)
+		saved = *cart
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return service.price(saved)
+}
+
+// check is what a quantity must pass to be put in the cart: the product is sold, the sale rule holds and there is stock
+func (service *cartService) check(product *model.Product, quantity model.Quantity) error {
+	if !product.Active {
+		return core.Error.Conflict.ProductUnavailable
+	}
+	if err := product.SaleRule.Check(quantity); err != nil {
+		return domainError(err)
+	}
+	if quantity.Base > service.available(product.ID) {
+		return core.Error.Conflict.InsufficientStock
+	}
+	return nil
+}
+
+// available - online orders are fulfilled from the central warehouse
+func (service *cartService) available(productID string) int64 {
+	level := service.inventoryRepo.FindLevel(productID, model.DefaultLocationID)
+	return level.Available()
+}
+
+// price re-validates every line against the catalog and stock and applies the promotions. Changed prices are
+// reported once and then remembered as seen, so checkout can tell a price the customer has not seen yet.
+func (service *cartService) price(cart model.Cart) (*model.PricedCart, error) {
+	priced := &model.PricedCart{Cart: cart, Lines: make([]model.PricedCartLine, 0, len(cart.Lines))}
+	seen := make(map[string]model.Money)
+	for _, cartLine := range cart.Lines {
+		line := model.PricedCartLine{CartLine: cartLine}
+		product, err := service.productRepo.FindByID(cartLine.ProductID)
+		if err != nil || !product.Active {
+			line.Issues = append(line.Issues, model.CartIssueUnavailable)
+			priced.Lines = append(priced.Lines, line)
+			continue
+		}
+
+		line.Product = product
+		if product.Price != cartLine.UnitPrice {
+			line.PreviousPrice = cartLine.UnitPrice
+			line.UnitPrice = product.Price
+			line.Issues = append(line.Issues, model.CartIssuePriceChanged)
+			seen[product.ID] = product.Price
+		}
+		if err := product.SaleRule.Check(cartLine.Quantity); err != nil {
+			line.Issues = append(line.Issues, model.CartIssueQuantityRule)
+		}
+		line.Available = service.available(product.ID)
+		switch {
+		case line.Available == 0:
+			line.Issues = append(line.Issues, model.CartIssueOutOfStock)
+		case line.Available < cartLine.Quantity.Base:
+			line.Issues = append(line.Issues, model.CartIssueInsufficientStock)
+		}
+		if line.Total, err = product.LineTotal(cartLine.Quantity); err != nil {
+			line.Issues = append(line.Issues, model.CartIssueQuantityRule)
+		}
+		priced.Lines = append(priced.Lines, line)
+	}
+	priced.Promotions = service.promotions.Apply(cart.UserID, priced.PromotionLines(), 0, cart.Coupon, time.Now())
+
+	if len(seen) > 0 {
+		err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+			current, err := service.cartOf(model.CartOwner{UserID: cart.UserID, Token: cart.Token})
+			if err != nil {
+				return nil
+			}
+			lines := make([]model.CartLine, 0, len(current.Lines))
+			for _, line := range current.Lines {
+				if price, ok := seen[line.ProductID]; ok {
+					line.UnitPrice = price
+				}
+				lines = append(lines, line)
+			}
+			current.Lines = lines
+			service.repo.Save(ctx, *current)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return priced, nil
+}
+
+func (service *cartService) Name() string { return "CartService" }
+func (service *cartService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *cartService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var CartServiceModule = fx.Options(fx.Provide(NewCartService))
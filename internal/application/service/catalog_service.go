@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/exception"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+This file implements bulk catalog transfer through CSV and XLSX sheets.
+Logic:
+- A sheet has one product per row keyed by SKU: a known SKU is updated, an unknown one is created.
+- Columns missing from the sheet keep their current value on update, so a sheet with only
+  "sku" and "price" is a valid price list.
+- Every row is validated with the same rules as the product endpoints and errors are reported per row
+  in the caller's language. Rows are applied in a single transaction only when all of them are valid.
+- Export writes the catalog in the same layout, so an exported sheet can be edited and imported back.
+*/
+
+const (
+	columnSKU         = "sku"
+	columnName        = "name"
+	columnDescription = "description"
+	columnCategory    = "category" // category slug
+	columnPrice       = "price"
+	columnPriceUnit   = "price_unit"
+	columnSaleUnit    = "sale_unit"
+	columnMinQuantity = "min_quantity"
+	columnStep        = "step"
+	columnMaxQuantity = "max_quantity"
+	columnActive      = "active"
+	columnOrigins     = "origins"   // comma separated province slugs: "lam-dong,son-la"
+	columnSeasons     = "seasons"   // semicolon separated month windows with optional regions: "11-2;5-7@lam-dong"
+	columnAllergens   = "allergens" // comma separated allergen codes: "celery,mustard"
+	columnNutrition   = "nutrition" // semicolon separated amounts per 100 g: "energy=25;protein=1.2"
+)
+
+const (
+	importActionCreate = "create"
+	importActionUpdate = "update"
+)
+
+type CatalogService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Import(file *multipart.FileHeader, dryRun bool, locale string) (*dto.ImportReport, error)
+	ExportContentType(format string) (string, error)
+	Export(format string, writer io.Writer) error
+}
+
+type catalogService struct {
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	spreadsheet  infra_interface.Spreadsheet
+	transactor   infra_interface.Transactor
+}
+
+func NewCatalogService(
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	spreadsheet infra_interface.Spreadsheet,
+	transactor infra_interface.Transactor,
+) CatalogService {
+	return &catalogService{
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		spreadsheet:  spreadsheet,
+		transactor:   transactor,
+	}
+}
+
+// importRow is a validated row waiting to be written. Its columns are applied to the product as stored when the
+// rows are written, so what others changed since the sheet was checked is kept.
+type importRow struct {
+	productID string
+	sku       string
+	create    bool
+	changes   []func(request *dto.ProductRequest)
+}
+
+func (service *catalogService) Import(file *multipart.FileHeader, dryRun bool, locale string) (*dto.ImportReport, error) {
+	format, err := infra_interface.SpreadsheetFormatOf(file.Filename)
+	if err != nil {
+		return nil, core.Error.Invalid.SpreadsheetFormat
+	}
+	rows, err := service.read(file, format)
+	if err != nil {
+		return nil, err
+	}
+	header, err := parseHeader(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := &dto.ImportReport{DryRun: dryRun, Rows: make([]dto.ImportRowResult, 0, len(rows)-1)}
+	pending := make([]importRow, 0, len(rows)-1)
+	seen := map[string]bool{}
+	for i, cells := range rows[1:] {
+		if isBlankRow(cells) {
+			continue
+		}
+
+		result, row := service.prepare(sheetRow{header: header, cells: cells}, i+2, seen, now, locale)
+		report.Rows = append(report.Rows, result)
+		switch {
+		case len(result.Errors) > 0:
+			report.Failed++
+		case row.create:
+			report.Created++
+			pending = append(pending, row)
+		default:
+			report.Updated++
+			pending = append(pending, row)
+		}
+	}
+	report.Total = len(report.Rows)
+
+	if dryRun || report.Failed > 0 {
+		return report, nil
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		for _, row := range pending {
+			if err := service.write(ctx, row, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Applied = true
+	zap.L().Info("Catalog imported",
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+	)
+	return report, nil
+}
+
+func (service *catalogService) ExportContentType(format string) (string, error) {
+	sheetFormat, err := infra_interface.SpreadsheetFormatOf(format)
+	if err != nil {
+		return "", core.Error.Invalid.SpreadsheetFormat
+	}
+	return service.spreadsheet.ContentType(sheetFormat), nil
+}
+
+func (service *catalogService) Export(format string, writer io.Writer) error {
+	sheetFormat, err := infra_interface.SpreadsheetFormatOf(format)
+	if err != nil {
+		return core.Error.Invalid.SpreadsheetFormat
+	}
+
+	rows, err := service.spreadsheet.NewWriter(sheetFormat, writer)
+	if err != nil {
+		return err
+	}
+
+	locales := translatedLocales()
+	if err := rows.Write(catalogHeader(locales)); err != nil {
+		return err
+	}
+
+	slugs := map[string]string{}
+	for _, category := range service.categoryRepo.FindAll() {
+		slugs[category.ID] = category.Slug
+	}
+	for _, product := range service.productRepo.FindAll() {
+		if err := rows.Write(exportRow(product, slugs[product.CategoryID], locales)); err != nil {
+			return err
+		}
+	}
+	return rows.Close()
+}
+
+func (service *catalogService) Name() string { return "CatalogService" }
+func (service *catalogService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *catalogService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func (service *catalogService) read(file *multipart.FileHeader, format infra_interface.SpreadsheetFormat) ([][]string, error) {
+	maxSize := core.Configs.Upload.MaxImportSize
+	if maxSize > 0 && file.Size > maxSize {
+		return nil, core.Error.Invalid.SpreadsheetTooLarge
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, core.Error.Invalid.Request
+	}
+	defer reader.Close()
+
+	rows, err := service.spreadsheet.Read(format, reader)
+	if err != nil || len(rows) == 0 {
+		return nil, core.Error.Invalid.Spreadsheet
+	}
+	return rows, nil
+}
+
+// prepare validates one sheet row and builds the product it would write.
+func (service *catalogService) prepare(row sheetRow, number int, seen map[string]bool, now time.Time, locale string) (dto.ImportRowResult, importRow) {
+	sku, _ := row.get(columnSKU)
+	result := dto.ImportRowResult{Row: number, SKU: sku}
+	fail := func(column string, err error) {
+		var subError exception.SubError
+		if !errors.As(err, &subError) {
+			subError = core.Error.Invalid.SpreadsheetValue
+		}
+		result.Errors = append(result.Errors, dto.ImportRowError{
+			Column:  column,
+			Code:    subError.Code,
+			Message: core.Translator.T(locale, subError.MessageKey),
+		})
+	}
+
+	if sku == "" {
+		fail(columnSKU, core.Error.Invalid.SpreadsheetValue)
+		return result, importRow{}
+	}
+	if seen[sku] {
+		fail(columnSKU, core.Error.Invalid.DuplicateSKU)
+		return result, importRow{}
+	}
+	seen[sku] = true
+
+	prepared := importRow{sku: sku}
+	var product model.Product
+	if existing, err := service.productRepo.FindBySKU(sku); err == nil {
+		product = *existing
+		prepared.productID = existing.ID
+	} else {
+		prepared.create = true
+		prepared.productID = uuid.NewString()
+		product = model.Product{ID: prepared.productID, Active: true, CreatedAt: now}
+	}
+	request := prepared.base(&product)
+	// change records how the row sets a column and applies it to the request being checked
+	change := func(apply func(request *dto.ProductRequest)) {
+		prepared.changes = append(prepared.changes, apply)
+		apply(&request)
+	}
+
+	if value, ok := row.get(columnName); ok {
+		change(func(request *dto.ProductRequest) { request.Name = value })
+	}
+	if value, ok := row.get(columnDescription); ok {
+		change(func(request *dto.ProductRequest) { request.Description = value })
+	}
+	if value, ok := row.get(columnCategory); ok {
+		categoryID := ""
+		if value != "" {
+			if category, err := service.categoryRepo.FindBySlug(value); err == nil {
+				categoryID = category.ID
+			} else {
+				fail(columnCategory, core.Error.Invalid.Category)
+			}
+		}
+		change(func(request *dto.ProductRequest) { request.CategoryID = categoryID })
+	}
+	if value, ok := row.get(columnPrice); ok && (value != "" || prepared.create) {
+		price, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || price < 0 {
+			fail(columnPrice, core.Error.Invalid.Price)
+		}
+		change(func(request *dto.ProductRequest) { request.Price = price })
+	} else if prepared.create {
+		fail(columnPrice, core.Error.Invalid.Price)
+	}
+	if value, ok := row.get(columnActive); ok && value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			fail(columnActive, core.Error.Invalid.SpreadsheetValue)
+		}
+		change(func(request *dto.ProductRequest) { request.Active = &active })
+	}
+	if value, ok := row.get(columnOrigins); ok {
+		origins := splitList(value, ",")
+		change(func(request *dto.ProductRequest) { request.Origins = origins })
+	}
+	if value, ok := row.get(columnSeasons); ok {
+		seasons, err := parseSeasons(value)
+		if err != nil {
+			fail(columnSeasons, core.Error.Invalid.Season)
+		}
+		change(func(request *dto.ProductRequest) { request.Seasons = seasons })
+	}
+	if value, ok := row.get(columnAllergens); ok {
+		allergens := splitList(value, ",")
+		change(func(request *dto.ProductRequest) { request.Allergens = allergens })
+	}
+	if value, ok := row.get(columnNutrition); ok {
+		nutrition, err := parseNutrition(value)
+		if err != nil {
+			fail(columnNutrition, core.Error.Invalid.Nutrient)
+		}
+		change(func(request *dto.ProductRequest) { request.Nutrition = nutrition })
+	}
+	for _, translated := range translatedLocales() {
+		name, hasName := row.get(columnName + "_" + translated)
+		description, hasDescription := row.get(columnDescription + "_" + translated)
+		if !hasName && !hasDescription {
+			continue
+		}
+		change(func(request *dto.ProductRequest) {
+			translation := request.Translations[translated]
+			if hasName {
+				translation.Name = name
+			}
+			if hasDescription {
+				translation.Description = description
+			}
+			if request.Translations == nil {
+				request.Translations = map[string]dto.TranslationDto{}
+			}
+			request.Translations[translated] = translation
+			if translation.Name == "" && translation.Description == "" {
+				delete(request.Translations, translated)
+			}
+		})
+	}
+
+	for column, target := range map[string]func(request *dto.ProductRequest) *string{
+		columnPriceUnit:   func(request *dto.ProductRequest) *string { return &request.PriceUnit },
+		columnSaleUnit:    func(request *dto.ProductRequest) *string { return &request.SaleRule.Unit },
+		columnMinQuantity: func(request *dto.ProductRequest) *string { return &request.SaleRule.MinQuantity },
+		columnStep:        func(request *dto.ProductRequest) *string { return &request.SaleRule.Step },
+		columnMaxQuantity: func(request *dto.ProductRequest) *string { return &request.SaleRule.MaxQuantity },
+	} {
+		if value, ok := row.get(column); ok {
+			change(func(request *dto.ProductRequest) { *target(request) = value })
+		}
+	}
+
+	if request.Name == "" {
+		fail(columnName, core.Error.Invalid.SpreadsheetValue)
+	}
+	if _, err := model.FindUnit(model.UnitCode(request.PriceUnit)); err != nil {
+		fail(columnPriceUnit, core.Error.Invalid.Unit)
+	}
+	saleUnit := model.UnitCode(request.SaleRule.Unit)
+	if _, err := model.FindUnit(saleUnit); err != nil {
+		fail(columnSaleUnit, core.Error.Invalid.Unit)
+	} else {
+		for _, field := range []struct {
+			column   string
+			value    string
+			optional bool
+		}{
+			{columnMinQuantity, request.SaleRule.MinQuantity, false},
+			{columnStep, request.SaleRule.Step, false},
+			{columnMaxQuantity, request.SaleRule.MaxQuantity, true},
+		} {
+			if field.optional && field.value == "" {
+				continue
+			}
+			if _, err := model.ParseQuantity(field.value, saleUnit); err != nil {
+				fail(field.column, domainError(err))
+			}
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result, importRow{}
+	}
+
+	// Rules spanning several columns (step vs minimum, price unit vs sale unit) are checked here
+	if err := applyProductRequest(service.categoryRepo, &product, request, now); err != nil {
+		fail("", err)
+		return result, importRow{}
+	}
+
+	result.Action = importActionUpdate
+	if prepared.create {
+		result.Action = importActionCreate
+	}
+	return result, prepared
+}
+
+// base is the request the row's changes are made to: the product as it is, or only the SKU for a new one
+func (row importRow) base(product *model.Product) dto.ProductRequest {
+	if row.create {
+		return dto.ProductRequest{SKU: row.sku}
+	}
+	return productRequestOf(product)
+}
+
+// write creates the row's product, or applies the row's columns to it as it is stored now. It must be called within
+// the caller's transaction.
+func (service *catalogService) write(ctx context.Context, row importRow, now time.Time) error {
+	apply := func(product *model.Product) error {
+		// The product was renamed since the sheet was checked, so the row is no longer about it
+		if !row.create && product.SKU != row.sku {
+			return core.Error.Conflict.SKU
+		}
+		request := row.base(product)
+		for _, change := range row.changes {
+			change(&request)
+		}
+		return applyProductRequest(service.categoryRepo, product, request, now)
+	}
+
+	if row.create {
+		product := model.Product{ID: row.productID, Active: true, CreatedAt: now}
+		if err := apply(&product); err != nil {
+			return err
+		}
+		return service.productRepo.Create(ctx, product)
+	}
+	_, err := service.productRepo.Update(ctx, row.productID, apply)
+	return err
+}
+
+// sheetRow gives access to the cells of a row by column name
+type sheetRow struct {
+	header map[string]int
+	cells  []string
+}
+
+// get returns the trimmed cell value and whether the sheet has that column at all
+func (row sheetRow) get(column string) (string, bool) {
+	index, ok := row.header[column]
+	if !ok {
+		return "", false
+	}
+	if index >= len(row.cells) {
+		return "", true
+	}
+	return strings.TrimSpace(row.cells[index]), true
+}
+
+func catalogHeader(locales []string) []string {
+	header := []string{
+		columnSKU, columnName, columnDescription, columnCategory, columnPrice, columnPriceUnit,
+		columnSaleUnit, columnMinQuantity, columnStep, columnMaxQuantity, columnActive, columnOrigins, columnSeasons,
+		columnAllergens, columnNutrition,
+	}
+	for _, locale := range locales {
+		header = append(header, columnName+"_"+locale, columnDescription+"_"+locale)
+	}
+	return header
+}
+
+func parseHeader(cells []string) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, column := range catalogHeader(translatedLocales()) {
+		known[column] = true
+	}
+
+	header := map[string]int{}
+	for index, cell := range cells {
+		column := strings.ToLower(strings.TrimSpace(cell))
+		if column == "" {
+			continue
+		}
+		if _, duplicated := header[column]; duplicated || !known[column] {
+			return nil, core.Error.Invalid.Spreadsheet
+		}
+		header[column] = index
+	}
+	if _, ok := header[columnSKU]; !ok {
+		return nil, core.Error.Invalid.Spreadsheet
+	}
+	return header, nil
+}
+
+func exportRow(product model.Product, categorySlug string, locales []string) []string {
+	saleRule := dto.ToSaleRuleDto(product.SaleRule)
+	row := []string{
+		product.SKU,
+		product.Name,
+		product.Description,
+		categorySlug,
+		strconv.FormatInt(int64(product.Price), 10),
+		string(product.PriceUnit),
+		saleRule.Unit,
+		saleRule.MinQuantity,
+		saleRule.Step,
+		saleRule.MaxQuantity,
+		strconv.FormatBool(product.Active),
+		strings.Join(product.Origins, ","),
+		formatSeasons(product.Seasons),
+		formatAllergens(product.Allergens),
+		formatNutrition(product.Nutrition),
+	}
+	for _, locale := range locales {
+		translation := product.Translations[locale]
+		row = append(row, translation.Name, translation.Description)
+	}
+	return row
+}
+
+// productRequestOf is the request that would recreate product as it is, used as the base of an import update
+func productRequestOf(product *model.Product) dto.ProductRequest {
+	active := product.Active
+	nutrition := map[string]float64{}
+	for nutrient, amount := range product.Nutrition {
+		nutrition[string(nutrient)] = amount
+	}
+	allergens := make([]string, 0, len(product.Allergens))
+	for _, allergen := range product.Allergens {
+		allergens = append(allergens, string(allergen))
+	}
+	translations := map[string]dto.TranslationDto{}
+	for locale, translation := range product.Translations {
+		translations[locale] = dto.TranslationDto{Name: translation.Name, Description: translation.Description}
+	}
+	return dto.ProductRequest{
+		SKU:          product.SKU,
+		Name:         product.Name,
+		Description:  product.Description,
+		CategoryID:   product.CategoryID,
+		Price:        int64(product.Price),
+		PriceUnit:    string(product.PriceUnit),
+		SaleRule:     dto.ToSaleRuleDto(product.SaleRule),
+		Active:       &active,
+		Translations: translations,
+		Origins:      product.Origins,
+		Seasons:      dto.ToSeasonWindowDtos(product.Seasons),
+
+		Nutrition:      nutrition,
+		Allergens:      allergens,
+		Certifications: dto.ToCertificationDtos(product.Certifications),
+	}
+}
+
+// parseSeasons reads the seasons cell, e.g. "11-2;5-7@lam-dong,son-la"
+func parseSeasons(value string) ([]dto.SeasonWindowDto, error) {
+	windows := make([]dto.SeasonWindowDto, 0)
+	for _, item := range splitList(value, ";") {
+		months, regions, _ := strings.Cut(item, "@")
+		from, to, found := strings.Cut(months, "-")
+		if !found {
+			to = from // a single month: "7"
+		}
+		fromMonth, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, err
+		}
+		toMonth, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, dto.SeasonWindowDto{From: fromMonth, To: toMonth, Regions: splitList(regions, ",")})
+	}
+	return windows, nil
+}
+
+func formatSeasons(windows []model.SeasonWindow) string {
+	items := make([]string, 0, len(windows))
+	for _, window := range windows {
+		item := fmt.Sprintf("%d-%d", window.From, window.To)
+		if len(window.Regions) > 0 {
+			item += "@" + strings.Join(window.Regions, ",")
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, ";")
+}
+
+// parseNutrition reads the nutrition cell, e.g. "energy=25;protein=1.2"
+func parseNutrition(value string) (map[string]float64, error) {
+	nutrition := map[string]float64{}
+	for _, item := range splitList(value, ";") {
+		nutrient, amount, found := strings.Cut(item, "=")
+		if !found {
+			return nil, model.ErrUnknownNutrient
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		if err != nil {
+			return nil, err
+		}
+		nutrition[strings.TrimSpace(nutrient)] = parsed
+	}
+	return nutrition, nil
+}
+
+func formatNutrition(facts model.NutritionFacts) string {
+	items := make([]string, 0, len(facts))
+	for _, entry := range model.NutrientUnits {
+		if amount, ok := facts[entry.Nutrient]; ok {
+			items = append(items, fmt.Sprintf("%s=%s", entry.Nutrient, strconv.FormatFloat(amount, 'f', -1, 64)))
+		}
+	}
+	return strings.Join(items, ";")
+}
+
+func formatAllergens(allergens []model.Allergen) string {
+	items := make([]string, 0, len(allergens))
+	for _, allergen := range allergens {
+		items = append(items, string(allergen))
+	}
+	return strings.Join(items, ",")
+}
+
+func splitList(value string, separator string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+var CatalogServiceModule = fx.Options(fx.Provide(NewCatalogService))
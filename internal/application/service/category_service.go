@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+type CategoryService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.CategoryRequest) (*model.Category, error)
+	Update(id string, request dto.CategoryRequest) (*model.Category, error)
+	FindById(id string) (*model.Category, error)
+	FindAll() []model.Category
+}
+
+type categoryService struct {
+	repo       repository.CategoryRepository
+	transactor infra_interface.Transactor
+}
+
+func NewCategoryService(repo repository.CategoryRepository, transactor infra_interface.Transactor) CategoryService {
+	return &categoryService{repo: repo, transactor: transactor}
+}
+
+func (service *categoryService) Create(request dto.CategoryRequest) (*model.Category, error) {
+	if err := validateTranslations(request.Translations); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	category := model.Category{
+		ID:           uuid.NewString(),
+		Slug:         request.Slug,
+		Name:         request.Name,
+		Description:  request.Description,
+		Translations: dto.ToTranslations(request.Translations),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (service *categoryService) Update(id string, request dto.CategoryRequest) (*model.Category, error) {
+	if err := validateTranslations(request.Translations); err != nil {
+		return nil, err
+	}
+
+	category, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	category.Slug = request.Slug
+	category.Name = request.Name
+	category.Description = request.Description
+	category.Translations = dto.ToTranslations(request.Translations)
+	category.UpdatedAt = time.Now()
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Update(ctx, *category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (service *categoryService) FindById(id string) (*model.Category, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *categoryService) FindAll() []model.Category {
+	return service.repo.FindAll()
+}
+
+func (service *categoryService) Name() string { return "CategoryService" }
+func (service *categoryService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *categoryService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var CategoryServiceModule = fx.Options(fx.Provide(NewCategoryService))
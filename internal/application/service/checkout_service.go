@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+const defaultPaymentTTL = 30 * time.Minute
+
+type CheckoutService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Checkout(userID string, request dto.CheckoutRequest) (*model.Order, error)
+}
+
+type checkoutService struct {
+	orderRepo     repository.OrderRepository
+	cartRepo      repository.CartRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	slotRepo      repository.DeliverySlotRepository
+	zoneRepo      repository.DeliveryZoneRepository
+	locationRepo  repository.LocationRepository
+	promotions    PromotionService
+	loyalty       LoyaltyService
+	transactor    infra_interface.Transactor
+	paymentTTL    time.Duration
+}
+
+func NewCheckoutService(
+	orderRepo repository.OrderRepository,
+	cartRepo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	inventoryRepo repository.InventoryRepository,
+	slotRepo repository.DeliverySlotRepository,
+	zoneRepo repository.DeliveryZoneRepository,
+	locationRepo repository.LocationRepository,
+	promotions PromotionService,
+	loyalty LoyaltyService,
+	transactor infra_interface.Transactor,
+) CheckoutService {
+	return &checkoutService{
+		orderRepo:     orderRepo,
+		cartRepo:      cartRepo,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		slotRepo:      slotRepo,
+		zoneRepo:      zoneRepo,
+		locationRepo:  locationRepo,
+		promotions:    promotions,
+		loyalty:       loyalty,
+		transactor:    transactor,
+		paymentTTL:    configuredDuration(core.Configs.Checkout.PaymentTTL, defaultPaymentTTL),
+	}
+}
+
+// Checkout turns the user's cart into an order in one transaction: every line is checked and its stock held at the
+// price the customer last saw, delivery is priced by the address's zone as the fee quote does, promotions are taken
+// off (a coupon in the cart must still apply) and then any loyalty points the customer spends, the slot is booked for
+// the order's weight in that zone, and the cart is emptied. Any failure leaves nothing behind.
+// Orders paid on delivery are confirmed at once; the others hold their stock until payment is due.
+func (service *checkoutService) Checkout(userID string, request dto.CheckoutRequest) (*model.Order, error) {
+	var placed model.Order
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		cart, err := service.cartRepo.FindByUser(userID)
+		if err != nil || len(cart.Lines) == 0 {
+			return core.Error.Invalid.EmptyCart
+		}
+
+		order := model.Order{
+			ID:            uuid.NewString(),
+			UserID:        userID,
+			Status:        model.OrderConfirmed,
+			PaymentMethod: model.PaymentMethod(request.PaymentMethod),
+			Address:       dto.ToDeliveryAddress(request.Address),
+			LocationID:    model.DefaultLocationID,
+			Note:          request.Note,
+			PlacedAt:      now,
+			UpdatedAt:     now,
+		}
+		zone, err := resolveZone(service.zoneRepo, order.Address.Address)
+		if err != nil {
+			return err
+		}
+		order.ZoneID = zone.ID
+		if !order.PaymentMethod.PaidOnDelivery() {
+			order.Status = model.OrderPendingPayment
+			order.PaymentDueAt = now.Add(service.paymentTTL)
+		}
+		order.History = []model.OrderEvent{{To: order.Status, ActorID: userID, At: now}}
+
+		basket := make([]model.PromotionLine, 0, len(cart.Lines))
+		for _, cartLine := range cart.Lines {
+			product, err := service.productRepo.FindByID(cartLine.ProductID)
+			if err != nil {
+				return core.Error.Conflict.ProductUnavailable
+			}
+			line, err := service.placeLine(ctx, &order, product, cartLine, now)
+			if err != nil {
+				return err
+			}
+			order.Lines = append(order.Lines, line)
+			order.Subtotal += line.Total
+			order.Weight += line.Weight
+			basket = append(basket, model.PromotionLine{
+				ProductID:  product.ID,
+				CategoryID: product.CategoryID,
+				Quantity:   line.Quantity,
+				Total:      line.Total,
+			})
+		}
+		distance := deliveryDistance(service.locationRepo, order.LocationID, order.Address.Address)
+		order.DeliveryFee = zone.Quote(order.Subtotal, order.Weight, distance).Fee
+
+		promotions := service.promotions.Apply(userID, basket, order.DeliveryFee, cart.Coupon, now)
+		if cart.Coupon != "" && !slices.ContainsFunc(promotions.Applied, func(applied model.AppliedPromotion) bool {
+			return applied.Code == cart.Coupon
+		}) {
+			return core.Error.Conflict.Coupon
+		}
+		for index := range order.Lines {
+			order.Lines[index].Discount = promotions.LineDiscounts[index]
+		}
+		order.Discount = promotions.Discount + promotions.ShippingDiscount
+		order.Promotions = promotions.Applied
+		order.Total = promotions.Total()
+		if request.RedeemPoints > 0 {
+			if err := service.loyalty.Redeem(ctx, &order, request.RedeemPoints, now); err != nil {
+				return err
+			}
+		}
+
+		slot, err := service.slotRepo.Update(ctx, request.SlotID, func(slot *model.DeliverySlot) error {
+			if !slot.Serves(order.ZoneID) {
+				return model.ErrSlotZone
+			}
+			return slot.Book(order.Weight, now)
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		order.SlotID = slot.ID
+		order.SlotStart = slot.Start
+		order.SlotEnd = slot.End
+
+		placed = service.orderRepo.Create(ctx, order)
+		service.cartRepo.Delete(ctx, *cart)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &placed, nil
+}
+
+// placeLine locks the price of a cart line of the product and holds its stock for the order
+func (service *checkoutService) placeLine(ctx context.Context, order *model.Order, product *model.Product, cartLine model.CartLine, now time.Time) (model.OrderLine, error) {
+	if !product.Active {
+		return model.OrderLine{}, core.Error.Conflict.ProductUnavailable
+	}
+	if product.Price != cartLine.UnitPrice {
+		return model.OrderLine{}, core.Error.Conflict.PriceChanged
+	}
+	if err := product.SaleRule.Check(cartLine.Quantity); err != nil {
+		return model.OrderLine{}, domainError(err)
+	}
+	return reserveOrderLine(ctx, service.inventoryRepo, order, product, cartLine.Quantity, now)
+}
+
+// reserveOrderLine prices quantity of the product at its current price and holds its stock for the order until
+// payment is due
+func reserveOrderLine(ctx context.Context, inventoryRepo repository.InventoryRepository, order *model.Order, product *model.Product, quantity model.Quantity, now time.Time) (model.OrderLine, error) {
+	total, err := product.LineTotal(quantity)
+	if err != nil {
+		return model.OrderLine{}, domainError(err)
+	}
+
+	reservation := model.Reservation{
+		ID:         uuid.NewString(),
+		ProductID:  product.ID,
+		LocationID: order.LocationID,
+		Unit:       quantity.Unit,
+		Quantity:   quantity.Base,
+		Reference:  order.ID,
+		Status:     model.ReservationActive,
+		ExpiresAt:  order.PaymentDueAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	err = holdStock(ctx, inventoryRepo, reservation, product.PriceUnit, now)
+	if err == core.Error.Conflict.InsufficientStock {
+		return model.OrderLine{}, core.Error.Conflict.OutOfStock
+	}
+	if err != nil {
+		return model.OrderLine{}, err
+	}
+
+	return model.OrderLine{
+		ProductID:     product.ID,
+		Name:          product.Name,
+		Quantity:      quantity,
+		UnitPrice:     product.Price,
+		PriceUnit:     product.PriceUnit,
+		Total:         total,
+		Weight:        product.Weight(quantity),
+		ReservationID: reservation.ID,
+	}, nil
+}
+
+func (service *checkoutService) Name() string { return "CheckoutService" }
+func (service *checkoutService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *checkoutService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var CheckoutServiceModule = fx.Options(fx.Provide(NewCheckoutService))
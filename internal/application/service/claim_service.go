@@ -0,0 +1,474 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const defaultClaimWindow = 48 * time.Hour
+
+type ClaimService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(userID string, orderID string, request dto.ClaimRequest, locale string) (*model.Claim, error)
+	AddPhoto(userID string, claimID string, file *multipart.FileHeader) (*model.Claim, error)
+	FindMine(userID string, query dto.ClaimQuery) dto.Page[model.Claim]
+	FindMineByID(userID string, id string) (*model.Claim, error)
+	Photo(actor model.OrderActor, claimID string, photoID string) ([]byte, string, error)
+	FindAll(query dto.ClaimQuery) dto.Page[model.Claim]
+	FindByID(id string) (*model.Claim, error)
+	Approve(actorID string, id string, request dto.ApproveClaimRequest) (*model.Claim, error)
+	Reject(actorID string, id string, request dto.RejectClaimRequest) (*model.Claim, error)
+}
+
+type claimService struct {
+	repo          repository.ClaimRepository
+	orderRepo     repository.OrderRepository
+	paymentRepo   repository.PaymentRepository
+	inventoryRepo repository.InventoryRepository
+	creditRepo    repository.StoreCreditRepository
+	providers     infra_interface.PaymentProviders
+	storage       infra_interface.FileStorage
+	processor     infra_interface.ImageProcessor
+	notifier      infra_interface.Notifier
+	transactor    infra_interface.Transactor
+	window        time.Duration
+}
+
+func NewClaimService(
+	repo repository.ClaimRepository,
+	orderRepo repository.OrderRepository,
+	paymentRepo repository.PaymentRepository,
+	inventoryRepo repository.InventoryRepository,
+	creditRepo repository.StoreCreditRepository,
+	providers infra_interface.PaymentProviders,
+	storage infra_interface.FileStorage,
+	processor infra_interface.ImageProcessor,
+	notifier infra_interface.Notifier,
+	transactor infra_interface.Transactor,
+) ClaimService {
+	return &claimService{
+		repo:          repo,
+		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		inventoryRepo: inventoryRepo,
+		creditRepo:    creditRepo,
+		providers:     providers,
+		storage:       storage,
+		processor:     processor,
+		notifier:      notifier,
+		transactor:    transactor,
+		window:        configuredDuration(core.Configs.Claim.Window, defaultClaimWindow),
+	}
+}
+
+// Create claims lines of the user's order within 'claim.window' of its delivery. Several claims can be made on one
+// order, but together they never claim more of a line than was delivered.
+func (service *claimService) Create(userID string, orderID string, request dto.ClaimRequest, locale string) (*model.Claim, error) {
+	order, err := service.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, core.Error.NotFound.Order
+	}
+	now := time.Now()
+	if err := model.CheckClaimable(order, service.window, now); err != nil {
+		return nil, domainError(err)
+	}
+
+	claim := model.Claim{
+		ID:          uuid.NewString(),
+		OrderID:     order.ID,
+		OrderNumber: order.Number,
+		UserID:      userID,
+		Description: strings.TrimSpace(request.Description),
+		Status:      model.ClaimPending,
+		Locale:      locale,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	// Inside a transaction so two claims sent at once cannot both take what is left of a line
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		earlier := service.repo.FindAll(func(claim model.Claim) bool { return claim.OrderID == order.ID })
+		for _, requested := range request.Lines {
+			quantity, err := model.ParseQuantity(requested.Quantity.Value, model.UnitCode(requested.Quantity.Unit))
+			if err != nil {
+				return err
+			}
+			line, err := model.NewClaimLine(order, requested.ProductID, quantity, model.ClaimReason(requested.Reason),
+				model.ClaimedQuantity(earlier, requested.ProductID))
+			if err != nil {
+				return err
+			}
+			claim.Lines = append(claim.Lines, line)
+			claim.Claimed += line.Amount
+		}
+		if err := claim.Validate(); err != nil {
+			return err
+		}
+		service.repo.Create(ctx, claim)
+		return nil
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+
+	service.notify(infra_interface.Notification{
+		Roles:   []string{model.RoleAdmin, model.RoleStaff},
+		Subject: dto.Localize(model.Message{ID: "Claim.NewSubject", Data: map[string]any{"Number": order.Number}}, util.DefaultLocale),
+		Body: dto.Localize(model.Message{ID: "Claim.NewBody", Data: map[string]any{
+			"Claimed": claim.Claimed, "Count": len(claim.Lines), "Description": claim.Description,
+		}}, util.DefaultLocale),
+	})
+	return &claim, nil
+}
+
+// AddPhoto attaches a photo of the damage to the author's claim while it waits for a decision
+func (service *claimService) AddPhoto(userID string, claimID string, file *multipart.FileHeader) (*model.Claim, error) {
+	claim, err := service.FindMineByID(userID, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != model.ClaimPending {
+		return nil, core.Error.Conflict.ClaimStatus
+	}
+	if len(claim.Photos) >= model.MaxClaimPhotos {
+		return nil, core.Error.Invalid.ClaimPhotos
+	}
+
+	content, err := readLimited(file, core.Configs.Upload.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+	info, err := service.processor.Inspect(content)
+	if err != nil || !isAllowedImageType(info.ContentType) {
+		return nil, core.Error.Invalid.ImageType
+	}
+
+	photo := model.ClaimPhoto{ID: uuid.NewString(), ContentType: info.ContentType}
+	photo.Key = fmt.Sprintf("claims/%s/%s%s", claim.ID, photo.ID, imageExtension(info.ContentType))
+	photo.URL = apiURL("claim", claim.ID, "photo", photo.ID)
+	if err := service.storage.Put(photo.Key, info.ContentType, content); err != nil {
+		zap.L().Error("Failed to store claim photo", zap.String("claim_id", claim.ID), zap.Error(err))
+		return nil, err
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, claimID, func(claim *model.Claim) error {
+			if claim.Status != model.ClaimPending {
+				return model.ErrClaimStatus
+			}
+			claim.Photos = append(slices.Clone(claim.Photos), photo)
+			claim.UpdatedAt = time.Now()
+			return claim.Validate()
+		})
+		claim = updated
+		return err
+	})
+	if err != nil {
+		if deleteErr := service.storage.Delete(photo.Key); deleteErr != nil {
+			zap.L().Warn("Failed to delete claim photo", zap.String("key", photo.Key), zap.Error(deleteErr))
+		}
+		return nil, domainError(err)
+	}
+	return claim, nil
+}
+
+// FindMine pages through the user's claims, the latest first
+func (service *claimService) FindMine(userID string, query dto.ClaimQuery) dto.Page[model.Claim] {
+	claims := service.repo.FindAll(func(claim model.Claim) bool {
+		return claim.UserID == userID && matchesClaimQuery(claim, query)
+	})
+	return dto.Paginate(claims, query.PageRequest)
+}
+
+// FindMineByID finds one of the user's claims; other people's claims are not found
+func (service *claimService) FindMineByID(userID string, id string) (*model.Claim, error) {
+	claim, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if claim.UserID != userID {
+		return nil, core.Error.NotFound.Claim
+	}
+	return claim, nil
+}
+
+// Photo serves a photo of the claim to the customer who made it and to staff
+func (service *claimService) Photo(actor model.OrderActor, claimID string, photoID string) ([]byte, string, error) {
+	claim, err := service.repo.FindByID(claimID)
+	if err != nil {
+		return nil, "", err
+	}
+	if claim.UserID != actor.ID && !actor.Staff() {
+		return nil, "", core.Error.NotFound.Claim
+	}
+	index := slices.IndexFunc(claim.Photos, func(photo model.ClaimPhoto) bool { return photo.ID == photoID })
+	if index < 0 {
+		return nil, "", core.Error.NotFound.Image
+	}
+	content, contentType, err := service.storage.Get(claim.Photos[index].Key)
+	if err != nil {
+		return nil, "", core.Error.NotFound.Image
+	}
+	return content, contentType, nil
+}
+
+func (service *claimService) FindAll(query dto.ClaimQuery) dto.Page[model.Claim] {
+	claims := service.repo.FindAll(func(claim model.Claim) bool { return matchesClaimQuery(claim, query) })
+	return dto.Paginate(claims, query.PageRequest)
+}
+
+func (service *claimService) FindByID(id string) (*model.Claim, error) {
+	return service.repo.FindByID(id)
+}
+
+// Approve pays the claim back, in full unless staff give a smaller amount, and records what became of the goods.
+// A refund goes back through the provider that took the payment; cash and bank transfers cannot be refunded that
+// way, so those claims are settled with store credit.
+func (service *claimService) Approve(actorID string, id string, request dto.ApproveClaimRequest) (*model.Claim, error) {
+	claim, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	amount := model.Money(request.Amount)
+	if amount == 0 {
+		amount = claim.Claimed
+	}
+	disposition := model.ClaimDisposition(request.Disposition)
+	if disposition == "" {
+		disposition = model.DispositionWriteOff
+	}
+	if err := claim.CheckDisposition(disposition); err != nil {
+		return nil, domainError(err)
+	}
+
+	if model.ClaimResolution(request.Resolution) == model.ResolutionStoreCredit {
+		return service.settle(claim.ID, func(claim *model.Claim, now time.Time) error {
+			return claim.Approve(actorID, model.ResolutionStoreCredit, amount, disposition, request.Note, now)
+		})
+	}
+	return service.refund(actorID, claim, amount, disposition, request.Note)
+}
+
+// refund books the refund on the claim and the payment first, so nobody can refund them twice while the provider
+// is being called, and takes the booking back if the provider does not carry it out
+func (service *claimService) refund(actorID string, claim *model.Claim, amount model.Money, disposition model.ClaimDisposition, note string) (*model.Claim, error) {
+	payments := service.paymentRepo.FindAll(func(payment model.Payment) bool {
+		return payment.OrderID == claim.OrderID && payment.Status == model.PaymentPaid
+	})
+	if len(payments) == 0 {
+		return nil, core.Error.Conflict.RefundMethod
+	}
+	payment := payments[len(payments)-1]
+	provider, ok := service.providers[string(payment.Method)]
+	if !ok {
+		return nil, core.Error.Conflict.RefundMethod
+	}
+
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		if _, err := service.repo.Update(ctx, claim.ID, func(claim *model.Claim) error {
+			return claim.StartRefund(actorID, amount, now)
+		}); err != nil {
+			return domainError(err)
+		}
+		_, err := service.paymentRepo.Update(ctx, payment.ID, func(payment *model.Payment) error {
+			return payment.Refund(amount, now)
+		})
+		return domainError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Refund(infra_interface.PaymentRefund{
+		Reference:     claim.ID,
+		TransactionID: payment.TransactionID,
+		Amount:        int64(amount),
+		Reason:        fmt.Sprintf("Claim on order %s", claim.OrderNumber),
+	})
+	if err != nil {
+		_ = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+			now := time.Now()
+			_, _ = service.repo.Update(ctx, claim.ID, func(claim *model.Claim) error {
+				claim.CancelRefund(now)
+				return nil
+			})
+			_, _ = service.paymentRepo.Update(ctx, payment.ID, func(payment *model.Payment) error {
+				payment.CancelRefund(amount, now)
+				return nil
+			})
+			return nil
+		})
+		if errors.Is(err, infra_interface.ErrRefundUnsupported) {
+			return nil, core.Error.Conflict.RefundMethod
+		}
+		zap.L().Warn("Payment provider failed to refund a claim", zap.String("provider", provider.Name()),
+			zap.String("claim_id", claim.ID), zap.Error(err))
+		return nil, core.Error.Unavailable.PaymentGateway
+	}
+
+	return service.settle(claim.ID, func(claim *model.Claim, now time.Time) error {
+		if err := claim.Approve(actorID, model.ResolutionRefund, amount, disposition, note, now); err != nil {
+			return err
+		}
+		claim.PaymentID = payment.ID
+		claim.TransactionID = result.TransactionID
+		return nil
+	})
+}
+
+// settle approves the claim with approve, pays store credit out when it was chosen, then records the claimed goods
+// coming back in the stock ledger
+func (service *claimService) settle(id string, approve func(claim *model.Claim, now time.Time) error) (*model.Claim, error) {
+	var approved *model.Claim
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		claim, err := service.repo.Update(ctx, id, func(claim *model.Claim) error { return approve(claim, now) })
+		if err != nil {
+			return domainError(err)
+		}
+		approved = claim
+		if claim.Resolution == model.ResolutionStoreCredit {
+			service.creditRepo.Append(ctx, model.CreditEntry{
+				ID:         uuid.NewString(),
+				UserID:     claim.UserID,
+				Amount:     claim.Amount,
+				Note:       fmt.Sprintf("Claim on order %s", claim.OrderNumber),
+				SourceType: model.CreditSourceClaim,
+				SourceID:   claim.ID,
+				ActorID:    claim.DecidedBy,
+				CreatedAt:  now,
+			})
+		}
+		return service.dispose(ctx, claim, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service.notify(infra_interface.Notification{
+		UserID:  approved.UserID,
+		Subject: dto.Localize(model.Message{ID: "Claim.ApprovedSubject", Data: map[string]any{"Number": approved.OrderNumber}}, approved.Locale),
+		Body: dto.Localize(model.Message{ID: paidBack(approved.Resolution), Data: map[string]any{
+			"Amount": approved.Amount, "Note": approved.DecisionNote,
+		}}, approved.Locale),
+	})
+	return approved, nil
+}
+
+// dispose records the claimed goods that come back as returned to the store where the order was picked; written-off
+// goods then leave the shelf again as spoiled. Missing and wrong items move no stock. Callers hold a transaction.
+func (service *claimService) dispose(ctx context.Context, claim *model.Claim, now time.Time) error {
+	order, err := service.orderRepo.FindByID(claim.OrderID)
+	if err != nil {
+		return err
+	}
+	note := fmt.Sprintf("Claim on order %s", claim.OrderNumber)
+	for _, line := range claim.Lines {
+		if !line.Reason.Returned() {
+			continue
+		}
+		movement := model.Movement{
+			ProductID:  line.ProductID,
+			LocationID: locationOrDefault(order.LocationID),
+			Type:       model.MovementReturn,
+			ActorID:    claim.DecidedBy,
+			Reason:     model.ReasonCustomerReturn,
+			Note:       note,
+			SourceType: model.SourceClaim,
+			SourceID:   claim.ID,
+			CreatedAt:  now,
+		}
+		quantity := line.Quantity.Base
+		if _, err := recordMovement(ctx, service.inventoryRepo, movement, func(*model.StockLevel) (int64, error) {
+			return quantity, nil
+		}); err != nil {
+			return domainError(err)
+		}
+		if claim.Disposition != model.DispositionWriteOff {
+			continue
+		}
+
+		movement.Type = model.MovementSpoilage
+		movement.Reason = model.ReasonSpoiled
+		if _, err := recordMovement(ctx, service.inventoryRepo, movement, func(*model.StockLevel) (int64, error) {
+			return -quantity, nil
+		}); err != nil {
+			return domainError(err)
+		}
+	}
+	return nil
+}
+
+// Reject turns the claim down; the note tells the customer why
+func (service *claimService) Reject(actorID string, id string, request dto.RejectClaimRequest) (*model.Claim, error) {
+	var rejected *model.Claim
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		claim, err := service.repo.Update(ctx, id, func(claim *model.Claim) error {
+			return claim.Reject(actorID, request.Note, time.Now())
+		})
+		rejected = claim
+		return domainError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service.notify(infra_interface.Notification{
+		UserID:  rejected.UserID,
+		Subject: dto.Localize(model.Message{ID: "Claim.RejectedSubject", Data: map[string]any{"Number": rejected.OrderNumber}}, rejected.Locale),
+		Body:    rejected.DecisionNote,
+	})
+	return rejected, nil
+}
+
+func (service *claimService) notify(notification infra_interface.Notification) {
+	if err := service.notifier.Notify(notification); err != nil {
+		zap.L().Warn("Could not send claim notification", zap.String("subject", notification.Subject), zap.Error(err))
+	}
+}
+
+func (service *claimService) Name() string { return "ClaimService" }
+func (service *claimService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *claimService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// paidBack is the message telling the customer where the approved amount went
+func paidBack(resolution model.ClaimResolution) string {
+	if resolution == model.ResolutionStoreCredit {
+		return "Claim.ApprovedStoreCredit"
+	}
+	return "Claim.ApprovedRefund"
+}
+
+func matchesClaimQuery(claim model.Claim, query dto.ClaimQuery) bool {
+	return (query.Status == "" || claim.Status == model.ClaimStatus(query.Status)) &&
+		(query.OrderID == "" || claim.OrderID == query.OrderID)
+}
+
+var ClaimServiceModule = fx.Options(fx.Provide(NewClaimService))
@@ -0,0 +1,512 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+Deliveries take an order from the packing bench to the customer's door:
+- Dispatchers register shippers and assign them orders that are confirmed and not yet on their way. The customer
+  sees the shipper's name and phone on the order from then on; until pickup the order can be handed to another one.
+- Shippers work from their run sheet: the orders assigned to them whose slots fall on a store day. Picking an order
+  up sends it out for delivery. At the door they add photos or the recipient's signature as proof, collect the cash
+  of cash-on-delivery orders and mark them delivered, or mark them failed and bring the goods back.
+- The cash collected stays with the shipper until they hand it over. The daily cash report shows, per shipper, what
+  they collected on a store day and what they still hold; staff record the hand-over against it.
+*/
+
+type DeliveryService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	CreateShipper(request dto.ShipperRequest) (*model.Shipper, error)
+	UpdateShipper(id string, request dto.ShipperRequest) (*model.Shipper, error)
+	FindShippers() []model.Shipper
+	Assign(actorID string, orderID string, request dto.AssignDeliveryRequest) (*model.Delivery, error)
+	FindByOrder(orderID string) (*model.Delivery, error)
+	Proof(actor model.OrderActor, orderID string, proofID string) ([]byte, string, error)
+	RunSheet(shipperID string, query dto.RunSheetQuery) ([]model.RunSheetStop, error)
+	PickUp(actor model.OrderActor, orderID string) (*model.Delivery, error)
+	AddProof(actor model.OrderActor, orderID string, kind string, file *multipart.FileHeader) (*model.Delivery, error)
+	Deliver(actor model.OrderActor, orderID string, request dto.DeliverRequest) (*model.Delivery, error)
+	Fail(actor model.OrderActor, orderID string, request dto.FailDeliveryRequest) (*model.Delivery, error)
+	CashReport(query dto.CashReportQuery) ([]model.CashReport, error)
+	Remit(actorID string, shipperID string, request dto.RemitCashRequest) (*model.CashReport, error)
+}
+
+type deliveryService struct {
+	repo        repository.DeliveryRepository
+	orderRepo   repository.OrderRepository
+	paymentRepo repository.PaymentRepository
+	workflow    *orderWorkflow
+	storage     infra_interface.FileStorage
+	processor   infra_interface.ImageProcessor
+	notifier    infra_interface.Notifier
+	transactor  infra_interface.Transactor
+}
+
+func NewDeliveryService(
+	repo repository.DeliveryRepository,
+	orderRepo repository.OrderRepository,
+	paymentRepo repository.PaymentRepository,
+	inventoryRepo repository.InventoryRepository,
+	slotRepo repository.DeliverySlotRepository,
+	storage infra_interface.FileStorage,
+	processor infra_interface.ImageProcessor,
+	notifier infra_interface.Notifier,
+	transactor infra_interface.Transactor,
+) DeliveryService {
+	return &deliveryService{
+		repo:        repo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		workflow:    &orderWorkflow{orders: orderRepo, inventory: inventoryRepo, slots: slotRepo},
+		storage:     storage,
+		processor:   processor,
+		notifier:    notifier,
+		transactor:  transactor,
+	}
+}
+
+func (service *deliveryService) CreateShipper(request dto.ShipperRequest) (*model.Shipper, error) {
+	now := time.Now()
+	shipper := model.Shipper{ID: strings.TrimSpace(request.UserID), Active: true, CreatedAt: now}
+	applyShipperRequest(&shipper, request, now)
+	if err := shipper.Validate(); err != nil {
+		return nil, domainError(err)
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.CreateShipper(ctx, shipper)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &shipper, nil
+}
+
+// UpdateShipper changes a shipper's details; orders already assigned keep the contact the customer was given
+func (service *deliveryService) UpdateShipper(id string, request dto.ShipperRequest) (*model.Shipper, error) {
+	var shipper *model.Shipper
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.UpdateShipper(ctx, id, func(shipper *model.Shipper) error {
+			applyShipperRequest(shipper, request, time.Now())
+			return shipper.Validate()
+		})
+		if err != nil {
+			return err
+		}
+		shipper = updated
+		return nil
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return shipper, nil
+}
+
+func (service *deliveryService) FindShippers() []model.Shipper {
+	return service.repo.FindShippers(func(model.Shipper) bool { return true })
+}
+
+// Assign gives an order that is confirmed and not yet on its way to an active shipper, or hands it to another one
+func (service *deliveryService) Assign(actorID string, orderID string, request dto.AssignDeliveryRequest) (*model.Delivery, error) {
+	var delivery *model.Delivery
+	var order *model.Order
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		shipper, err := service.repo.FindShipper(request.ShipperID)
+		if err != nil {
+			return err
+		}
+		if !shipper.Active {
+			return core.Error.Invalid.Shipper
+		}
+
+		order, err = service.orderRepo.Update(ctx, orderID, func(order *model.Order) error {
+			if !slices.Contains([]model.OrderStatus{model.OrderConfirmed, model.OrderPicking, model.OrderPacked}, order.Status) {
+				return model.ErrOrderStatus
+			}
+			order.Shipper = shipper.Contact()
+			order.UpdatedAt = now
+			return nil
+		})
+		if err != nil {
+			return domainError(err)
+		}
+
+		delivery, err = service.repo.Upsert(ctx, orderID, func(delivery *model.Delivery) error {
+			delivery.OrderNumber = order.Number
+			delivery.CODDue = 0
+			if order.PaymentMethod.PaidOnDelivery() {
+				delivery.CODDue = order.Total
+			}
+			return delivery.Assign(shipper.ID, actorID, now)
+		})
+		return domainError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service.notify(infra_interface.Notification{
+		UserID:  delivery.ShipperID,
+		Subject: fmt.Sprintf("Order %s was added to your run sheet", order.Number),
+		Body: fmt.Sprintf("Deliver to %s between %s and %s", order.Address.District,
+			order.SlotStart.In(util.StoreLocation).Format("15:04 02/01"), order.SlotEnd.In(util.StoreLocation).Format("15:04")),
+	})
+	return delivery, nil
+}
+
+func (service *deliveryService) FindByOrder(orderID string) (*model.Delivery, error) {
+	return service.repo.FindByOrder(orderID)
+}
+
+// Proof serves a proof of delivery to the customer of the order, the shipper who delivered it and staff
+func (service *deliveryService) Proof(actor model.OrderActor, orderID string, proofID string) ([]byte, string, error) {
+	order, err := service.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, "", err
+	}
+	delivery, err := service.repo.FindByOrder(orderID)
+	if err != nil {
+		return nil, "", err
+	}
+	if order.UserID != actor.ID && delivery.ShipperID != actor.ID && !actor.Staff() {
+		return nil, "", core.Error.NotFound.Delivery
+	}
+	index := slices.IndexFunc(delivery.Proofs, func(proof model.DeliveryProof) bool { return proof.ID == proofID })
+	if index < 0 {
+		return nil, "", core.Error.NotFound.Image
+	}
+	content, contentType, err := service.storage.Get(delivery.Proofs[index].Key)
+	if err != nil {
+		return nil, "", core.Error.NotFound.Image
+	}
+	return content, contentType, nil
+}
+
+// RunSheet lists the shipper's deliveries with slots on a store date, in slot order. Cancelled orders drop off it.
+func (service *deliveryService) RunSheet(shipperID string, query dto.RunSheetQuery) ([]model.RunSheetStop, error) {
+	from, err := storeDateOrToday(query.Date)
+	if err != nil {
+		return nil, err
+	}
+	to := from.AddDate(0, 0, 1)
+
+	stops := make([]model.RunSheetStop, 0)
+	for _, delivery := range service.repo.FindAll(func(delivery model.Delivery) bool {
+		return delivery.ShipperID == shipperID
+	}) {
+		order, err := service.orderRepo.FindByID(delivery.OrderID)
+		if err != nil || order.Status == model.OrderCancelled || order.SlotStart.Before(from) || !order.SlotStart.Before(to) {
+			continue
+		}
+		stops = append(stops, model.RunSheetStop{Delivery: delivery, Order: *order})
+	}
+	slices.SortFunc(stops, func(a, b model.RunSheetStop) int {
+		return cmp.Or(a.Order.SlotStart.Compare(b.Order.SlotStart), cmp.Compare(a.Order.Number, b.Order.Number))
+	})
+	return stops, nil
+}
+
+// PickUp takes a packed order off the bench: it goes out for delivery and its stock leaves the shelf
+func (service *deliveryService) PickUp(actor model.OrderActor, orderID string) (*model.Delivery, error) {
+	var delivery *model.Delivery
+	var order *model.Order
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		delivery, err = service.updateOwn(ctx, actor, orderID, func(delivery *model.Delivery) error {
+			return delivery.PickUp(now)
+		})
+		if err != nil {
+			return err
+		}
+		order, err = service.workflow.move(ctx, orderID, model.OrderOutForDelivery, actor, "", now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service.notify(infra_interface.Notification{
+		UserID:  order.UserID,
+		Subject: fmt.Sprintf("Your order %s is on its way", order.Number),
+		Body:    fmt.Sprintf("%s (%s) is bringing it to you", order.Shipper.Name, order.Shipper.Phone),
+	})
+	return delivery, nil
+}
+
+// AddProof attaches a photo of the handed-over order, or an image of the recipient's signature, while it is on its way
+func (service *deliveryService) AddProof(actor model.OrderActor, orderID string, kind string, file *multipart.FileHeader) (*model.Delivery, error) {
+	proofKind := model.DeliveryProofKind(cmp.Or(kind, string(model.ProofPhoto)))
+	if proofKind != model.ProofPhoto && proofKind != model.ProofSignature {
+		return nil, core.Error.Invalid.Request
+	}
+	delivery, err := service.repo.FindByOrder(orderID)
+	if err != nil || delivery.ShipperID != actor.ID {
+		return nil, core.Error.NotFound.Delivery
+	}
+	if delivery.Status != model.DeliveryPickedUp {
+		return nil, core.Error.Conflict.DeliveryStatus
+	}
+	if len(delivery.Proofs) >= model.MaxDeliveryProofs {
+		return nil, core.Error.Invalid.DeliveryProofs
+	}
+
+	content, err := readLimited(file, core.Configs.Upload.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+	info, err := service.processor.Inspect(content)
+	if err != nil || !isAllowedImageType(info.ContentType) {
+		return nil, core.Error.Invalid.ImageType
+	}
+
+	now := time.Now()
+	proof := model.DeliveryProof{ID: uuid.NewString(), Kind: proofKind, ContentType: info.ContentType, CreatedAt: now}
+	proof.Key = fmt.Sprintf("deliveries/%s/%s%s", orderID, proof.ID, imageExtension(info.ContentType))
+	proof.URL = apiURL("delivery", orderID, "proof", proof.ID)
+	if err := service.storage.Put(proof.Key, info.ContentType, content); err != nil {
+		zap.L().Error("Failed to store proof of delivery", zap.String("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		delivery, err = service.updateOwn(ctx, actor, orderID, func(delivery *model.Delivery) error {
+			return delivery.AddProof(proof, now)
+		})
+		return err
+	})
+	if err != nil {
+		if deleteErr := service.storage.Delete(proof.Key); deleteErr != nil {
+			zap.L().Warn("Failed to delete proof of delivery", zap.String("key", proof.Key), zap.Error(deleteErr))
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Deliver marks the order delivered once it has proof. The cash of a cash-on-delivery order settles its payment
+// and stays with the shipper until they hand it over.
+func (service *deliveryService) Deliver(actor model.OrderActor, orderID string, request dto.DeliverRequest) (*model.Delivery, error) {
+	var delivery *model.Delivery
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		delivery, err = service.updateOwn(ctx, actor, orderID, func(delivery *model.Delivery) error {
+			return delivery.Deliver(model.Money(request.CODCollected), now)
+		})
+		if err != nil {
+			return err
+		}
+		order, err := service.workflow.move(ctx, orderID, model.OrderDelivered, actor, "", now)
+		if err != nil {
+			return err
+		}
+		if delivery.Collected == 0 {
+			return nil
+		}
+
+		payment, err := service.settleCOD(ctx, actor, order, delivery.Collected, now)
+		if err != nil {
+			return err
+		}
+		delivery, err = service.repo.Update(ctx, orderID, func(delivery *model.Delivery) error {
+			delivery.PaymentID = payment.ID
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Fail records why the order could not be handed over; it is returned and the shipper brings the goods back
+func (service *deliveryService) Fail(actor model.OrderActor, orderID string, request dto.FailDeliveryRequest) (*model.Delivery, error) {
+	var delivery *model.Delivery
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		delivery, err = service.updateOwn(ctx, actor, orderID, func(delivery *model.Delivery) error {
+			return delivery.Fail(request.Reason, now)
+		})
+		if err != nil {
+			return err
+		}
+		_, err = service.workflow.move(ctx, orderID, model.OrderReturned, actor, delivery.FailureReason, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// CashReport sums, per shipper, the deliveries they finished on a store date and the cash they collected for them
+func (service *deliveryService) CashReport(query dto.CashReportQuery) ([]model.CashReport, error) {
+	date, err := storeDateOrToday(query.Date)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]model.CashReport, 0)
+	for _, shipper := range service.FindShippers() {
+		deliveries := service.finishedOn(shipper.ID, date)
+		if len(deliveries) > 0 {
+			reports = append(reports, model.NewCashReport(shipper, date, deliveries))
+		}
+	}
+	return reports, nil
+}
+
+// Remit records the shipper handing over the cash they still hold for a store date, which must be all of it
+func (service *deliveryService) Remit(actorID string, shipperID string, request dto.RemitCashRequest) (*model.CashReport, error) {
+	date, err := util.ParseStoreDate(request.Date)
+	if err != nil {
+		return nil, core.Error.Invalid.Date
+	}
+	var report model.CashReport
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		shipper, err := service.repo.FindShipper(shipperID)
+		if err != nil {
+			return err
+		}
+		deliveries := service.finishedOn(shipperID, date)
+		if model.NewCashReport(*shipper, date, deliveries).Outstanding != model.Money(request.Amount) {
+			return core.Error.Conflict.CashRemitted
+		}
+
+		now := time.Now()
+		for index, delivery := range deliveries {
+			if delivery.Outstanding() == 0 {
+				continue
+			}
+			remitted, err := service.repo.Update(ctx, delivery.OrderID, func(delivery *model.Delivery) error {
+				delivery.RemittedTo = actorID
+				delivery.RemittedAt = now
+				delivery.UpdatedAt = now
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			deliveries[index] = *remitted
+		}
+		report = model.NewCashReport(*shipper, date, deliveries)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (service *deliveryService) Name() string { return "DeliveryService" }
+func (service *deliveryService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *deliveryService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// updateOwn applies modify to a delivery of the shipper; other shippers' deliveries are not found
+func (service *deliveryService) updateOwn(ctx context.Context, actor model.OrderActor, orderID string, modify func(delivery *model.Delivery) error) (*model.Delivery, error) {
+	delivery, err := service.repo.Update(ctx, orderID, func(delivery *model.Delivery) error {
+		if delivery.ShipperID != actor.ID {
+			return core.Error.NotFound.Delivery
+		}
+		return modify(delivery)
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return delivery, nil
+}
+
+// settleCOD settles the cash-on-delivery payment the customer started, or records one when they did not
+func (service *deliveryService) settleCOD(ctx context.Context, actor model.OrderActor, order *model.Order, amount model.Money, now time.Time) (*model.Payment, error) {
+	pending := service.paymentRepo.FindAll(func(payment model.Payment) bool {
+		return payment.OrderID == order.ID && payment.Method == model.PaymentCOD && payment.Status == model.PaymentPending
+	})
+	if len(pending) > 0 {
+		payment, err := service.paymentRepo.Update(ctx, pending[0].ID, func(payment *model.Payment) error {
+			_, err := payment.Settle(actor.ID, "", amount, true, "", now)
+			return err
+		})
+		return payment, domainError(err)
+	}
+
+	payment := model.Payment{
+		ID:        uuid.NewString(),
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		Method:    model.PaymentCOD,
+		Amount:    amount,
+		Status:    model.PaymentPending,
+		CreatedAt: now,
+	}
+	if _, err := payment.Settle(actor.ID, "", amount, true, "", now); err != nil {
+		return nil, domainError(err)
+	}
+	service.paymentRepo.Create(ctx, payment)
+	return &payment, nil
+}
+
+// finishedOn lists the shipper's deliveries delivered or failed on a store date
+func (service *deliveryService) finishedOn(shipperID string, date time.Time) []model.Delivery {
+	next := date.AddDate(0, 0, 1)
+	return service.repo.FindAll(func(delivery model.Delivery) bool {
+		return delivery.ShipperID == shipperID && !delivery.FinishedAt.IsZero() &&
+			!delivery.FinishedAt.Before(date) && delivery.FinishedAt.Before(next)
+	})
+}
+
+func (service *deliveryService) notify(notification infra_interface.Notification) {
+	if err := service.notifier.Notify(notification); err != nil {
+		zap.L().Warn("Could not send delivery notification", zap.String("subject", notification.Subject), zap.Error(err))
+	}
+}
+
+func applyShipperRequest(shipper *model.Shipper, request dto.ShipperRequest, now time.Time) {
+	shipper.Name = strings.TrimSpace(request.Name)
+	shipper.Phone = strings.TrimSpace(request.Phone)
+	shipper.UpdatedAt = now
+	if request.Active != nil {
+		shipper.Active = *request.Active
+	}
+}
+
+// storeDateOrToday parses an optional store date, defaulting to today
+func storeDateOrToday(date string) (time.Time, error) {
+	if date == "" {
+		return util.StoreToday(), nil
+	}
+	parsed, err := util.ParseStoreDate(date)
+	if err != nil {
+		return time.Time{}, core.Error.Invalid.Date
+	}
+	return parsed, nil
+}
+
+var DeliveryServiceModule = fx.Options(fx.Provide(NewDeliveryService))
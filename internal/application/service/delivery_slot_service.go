@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+Delivery slots are opened by hand, or generated from weekly templates per delivery zone.
+Logic:
+- Every hour, each active template gets its slot on every matching weekday within 'delivery.slot_horizon' (14 days
+  by default). Slot ids are derived from the template and the date, so a day is never generated twice.
+- All dates and times are store dates and times (Asia/Ho_Chi_Minh), whatever zone the server runs in.
+- A slot takes bookings until its cutoff, while it has room for one more order of the order's weight.
+- Holidays close the slots of a day, in one zone or everywhere. Slots generated later on a holiday are born closed,
+  and removing the holiday opens them again. Orders already booked on the day keep their slot.
+*/
+
+const (
+	defaultSlotHorizon     = 14 * 24 * time.Hour
+	slotGenerationInterval = time.Hour
+)
+
+type DeliverySlotService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.DeliverySlotRequest) (*model.DeliverySlot, error)
+	Update(id string, request dto.DeliverySlotRequest) (*model.DeliverySlot, error)
+	FindAvailable(query dto.DeliverySlotQuery) ([]model.DeliverySlot, error)
+
+	CreateTemplate(request dto.SlotTemplateRequest) (*model.SlotTemplate, error)
+	UpdateTemplate(id string, request dto.SlotTemplateRequest) (*model.SlotTemplate, error)
+	FindTemplates() []model.SlotTemplate
+	// Generate opens the template slots missing within the horizon and returns how many it opened
+	Generate() int
+
+	CreateHoliday(request dto.HolidayRequest) (*model.Holiday, error)
+	DeleteHoliday(id string) (*model.Holiday, error)
+	FindHolidays() []model.Holiday
+}
+
+type deliverySlotService struct {
+	repo       repository.DeliverySlotRepository
+	zoneRepo   repository.DeliveryZoneRepository
+	orderRepo  repository.OrderRepository
+	transactor infra_interface.Transactor
+	horizon    time.Duration
+}
+
+func NewDeliverySlotService(
+	repo repository.DeliverySlotRepository,
+	zoneRepo repository.DeliveryZoneRepository,
+	orderRepo repository.OrderRepository,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) DeliverySlotService {
+	service := &deliverySlotService{
+		repo:       repo,
+		zoneRepo:   zoneRepo,
+		orderRepo:  orderRepo,
+		transactor: transactor,
+		horizon:    configuredDuration(core.Configs.Delivery.SlotHorizon, defaultSlotHorizon),
+	}
+	scheduler.Every("generate-delivery-slots", slotGenerationInterval, func() error {
+		if generated := service.Generate(); generated > 0 {
+			zap.L().Info("Generated delivery slots", zap.Int("count", generated))
+		}
+		return nil
+	})
+	return service
+}
+
+func (service *deliverySlotService) Create(request dto.DeliverySlotRequest) (*model.DeliverySlot, error) {
+	if err := service.checkZone(request.ZoneID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	slot := model.DeliverySlot{ID: uuid.NewString(), Active: true, CreatedAt: now}
+	applyDeliverySlotRequest(&slot, request, now)
+	if err := slot.Validate(); err != nil {
+		return nil, domainError(err)
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		slot.HolidayID = closingHoliday(service.repo.FindHolidays(func(model.Holiday) bool { return true }), &slot)
+		service.repo.Create(ctx, slot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// Update reschedules or resizes a slot. Orders already booked keep their booking, even when the capacity drops below them,
+// and move with the slot when it is retimed. A booked slot cannot be narrowed to another zone than the one it serves.
+// A slot moved onto a holiday closes, and one moved off it opens again.
+func (service *deliverySlotService) Update(id string, request dto.DeliverySlotRequest) (*model.DeliverySlot, error) {
+	if err := service.checkZone(request.ZoneID); err != nil {
+		return nil, err
+	}
+	var slot *model.DeliverySlot
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		holidays := service.repo.FindHolidays(func(model.Holiday) bool { return true })
+		now := time.Now()
+		updated, err := service.repo.Update(ctx, id, func(slot *model.DeliverySlot) error {
+			zoneID := slot.ZoneID
+			applyDeliverySlotRequest(slot, request, now)
+			if slot.Booked > 0 && slot.ZoneID != "" && slot.ZoneID != zoneID {
+				return model.ErrSlotBooked
+			}
+			slot.HolidayID = closingHoliday(holidays, slot)
+			return slot.Validate()
+		})
+		if err != nil {
+			return err
+		}
+		slot = updated
+		for _, order := range service.orderRepo.FindAll(func(order model.Order) bool {
+			return order.SlotID == id && !order.Status.Final() &&
+				(!order.SlotStart.Equal(updated.Start) || !order.SlotEnd.Equal(updated.End))
+		}) {
+			if _, err := service.orderRepo.Update(ctx, order.ID, func(order *model.Order) error {
+				order.SlotStart = updated.Start
+				order.SlotEnd = updated.End
+				order.UpdatedAt = now
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return slot, nil
+}
+
+// FindAvailable lists the slots between two store dates that can still be booked: they have room for one more order,
+// of the query's weight when it gives one. With an address, only the slots of its delivery zone and those serving
+// every zone are listed, and an address outside every zone is an error.
+func (service *deliverySlotService) FindAvailable(query dto.DeliverySlotQuery) ([]model.DeliverySlot, error) {
+	from := util.StoreToday()
+	if query.From != "" {
+		date, err := util.ParseStoreDate(query.From)
+		if err != nil {
+			return nil, core.Error.Invalid.Date
+		}
+		from = date
+	}
+	to := from.AddDate(0, 0, 7)
+	if query.To != "" {
+		date, err := util.ParseStoreDate(query.To)
+		if err != nil {
+			return nil, core.Error.Invalid.Date
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+
+	address := query.Address()
+	byAddress := strings.TrimSpace(address.District) != "" || address.Point != nil
+	zoneID := ""
+	if byAddress {
+		zone, err := resolveZone(service.zoneRepo, address)
+		if err != nil {
+			return nil, err
+		}
+		zoneID = zone.ID
+	}
+
+	// A slot with no weight left takes no order, however light
+	weight := max(query.Weight, 1)
+	now := time.Now()
+	return service.repo.FindAll(func(slot model.DeliverySlot) bool {
+		return !slot.Start.Before(from) && slot.Start.Before(to) && slot.Open(now) && slot.Fits(weight) &&
+			(!byAddress || slot.Serves(zoneID))
+	}), nil
+}
+
+// CreateTemplate adds a weekly slot and opens it at once within the horizon
+func (service *deliverySlotService) CreateTemplate(request dto.SlotTemplateRequest) (*model.SlotTemplate, error) {
+	if err := service.checkZone(request.ZoneID); err != nil {
+		return nil, err
+	}
+	template, err := dto.ToSlotTemplate(request)
+	if err != nil {
+		return nil, domainError(err)
+	}
+	now := time.Now()
+	template.ID = uuid.NewString()
+	template.Active = request.Active == nil || *request.Active
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	if err := template.Validate(); err != nil {
+		return nil, domainError(err)
+	}
+	service.repo.SaveTemplate(context.Background(), template)
+	service.Generate()
+	return &template, nil
+}
+
+// UpdateTemplate changes the slots generated from now on; slots already open keep their times and capacity and are
+// changed one by one
+func (service *deliverySlotService) UpdateTemplate(id string, request dto.SlotTemplateRequest) (*model.SlotTemplate, error) {
+	if err := service.checkZone(request.ZoneID); err != nil {
+		return nil, err
+	}
+	changes, err := dto.ToSlotTemplate(request)
+	if err != nil {
+		return nil, domainError(err)
+	}
+	template, err := service.repo.UpdateTemplate(context.Background(), id, func(template *model.SlotTemplate) error {
+		template.ZoneID = changes.ZoneID
+		template.Weekday = changes.Weekday
+		template.Starts = changes.Starts
+		template.Ends = changes.Ends
+		template.Cutoff = changes.Cutoff
+		template.Capacity = changes.Capacity
+		template.WeightCapacity = changes.WeightCapacity
+		template.UpdatedAt = time.Now()
+		if request.Active != nil {
+			template.Active = *request.Active
+		}
+		return template.Validate()
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	service.Generate()
+	return template, nil
+}
+
+func (service *deliverySlotService) FindTemplates() []model.SlotTemplate {
+	return service.repo.FindTemplates(func(model.SlotTemplate) bool { return true })
+}
+
+func (service *deliverySlotService) Generate() int {
+	generated := 0
+	_ = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		today := util.StoreToday()
+		templates := service.repo.FindTemplates(func(template model.SlotTemplate) bool { return template.Active })
+		holidays := service.repo.FindHolidays(func(holiday model.Holiday) bool { return !holiday.Date.Before(today) })
+
+		for day := today; day.Before(today.Add(service.horizon)); day = day.AddDate(0, 0, 1) {
+			for _, template := range templates {
+				if template.Weekday != day.Weekday() {
+					continue
+				}
+				slot := template.SlotOn(day, now)
+				if !now.Before(slot.Cutoff()) {
+					continue
+				}
+				slot.HolidayID = closingHoliday(holidays, &slot)
+				if service.repo.Insert(ctx, slot) {
+					generated++
+				}
+			}
+		}
+		return nil
+	})
+	return generated
+}
+
+// CreateHoliday closes the slots of the day, including those generated later
+func (service *deliverySlotService) CreateHoliday(request dto.HolidayRequest) (*model.Holiday, error) {
+	date, err := util.ParseStoreDate(request.Date)
+	if err != nil {
+		return nil, core.Error.Invalid.Date
+	}
+	if err := service.checkZone(request.ZoneID); err != nil {
+		return nil, err
+	}
+	holiday := model.Holiday{
+		ID:        uuid.NewString(),
+		Date:      date,
+		ZoneID:    request.ZoneID,
+		Name:      strings.TrimSpace(request.Name),
+		CreatedAt: time.Now(),
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		if err := service.repo.InsertHoliday(ctx, holiday); err != nil {
+			return err
+		}
+		for _, slot := range service.repo.FindAll(func(slot model.DeliverySlot) bool { return slot.HolidayID == "" && holiday.Closes(&slot) }) {
+			if _, err := service.repo.Update(ctx, slot.ID, func(slot *model.DeliverySlot) error {
+				slot.HolidayID = holiday.ID
+				slot.UpdatedAt = holiday.CreatedAt
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// DeleteHoliday opens the slots it closed again, unless another holiday still closes them
+func (service *deliverySlotService) DeleteHoliday(id string) (*model.Holiday, error) {
+	var deleted *model.Holiday
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		holiday, err := service.repo.DeleteHoliday(ctx, id)
+		if err != nil {
+			return err
+		}
+		deleted = holiday
+		remaining := service.repo.FindHolidays(func(model.Holiday) bool { return true })
+		now := time.Now()
+		for _, slot := range service.repo.FindAll(func(slot model.DeliverySlot) bool { return slot.HolidayID == id }) {
+			if _, err := service.repo.Update(ctx, slot.ID, func(slot *model.DeliverySlot) error {
+				slot.HolidayID = closingHoliday(remaining, slot)
+				slot.UpdatedAt = now
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (service *deliverySlotService) FindHolidays() []model.Holiday {
+	today := util.StoreToday()
+	return service.repo.FindHolidays(func(holiday model.Holiday) bool { return !holiday.Date.Before(today) })
+}
+
+// checkZone accepts an empty zone id, for every zone, or the id of a known zone
+func (service *deliverySlotService) checkZone(zoneID string) error {
+	if zoneID == "" {
+		return nil
+	}
+	if _, err := service.zoneRepo.FindByID(zoneID); err != nil {
+		return core.Error.Invalid.DeliveryZone
+	}
+	return nil
+}
+
+func (service *deliverySlotService) Name() string { return "DeliverySlotService" }
+func (service *deliverySlotService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *deliverySlotService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func applyDeliverySlotRequest(slot *model.DeliverySlot, request dto.DeliverySlotRequest, now time.Time) {
+	slot.ZoneID = request.ZoneID
+	slot.Start = request.Start
+	slot.End = request.End
+	slot.CutoffAt = time.Time{}
+	if request.CutoffAt != nil {
+		slot.CutoffAt = *request.CutoffAt
+	}
+	slot.Capacity = request.Capacity
+	slot.WeightCapacity = request.WeightCapacity
+	slot.UpdatedAt = now
+	if request.Active != nil {
+		slot.Active = *request.Active
+	}
+}
+
+// closingHoliday returns the id of a holiday closing the slot, or "" when none does
+func closingHoliday(holidays []model.Holiday, slot *model.DeliverySlot) string {
+	for _, holiday := range holidays {
+		if holiday.Closes(slot) {
+			return holiday.ID
+		}
+	}
+	return ""
+}
+
+var DeliverySlotServiceModule = fx.Options(fx.Provide(NewDeliverySlotService))
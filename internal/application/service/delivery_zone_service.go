@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type DeliveryZoneService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.DeliveryZoneRequest) (*model.DeliveryZone, error)
+	Update(id string, request dto.DeliveryZoneRequest) (*model.DeliveryZone, error)
+	FindByID(id string) (*model.DeliveryZone, error)
+	FindAll() []model.DeliveryZone
+	Quote(request dto.DeliveryQuoteRequest) (*model.DeliveryZone, model.DeliveryQuote, error)
+}
+
+type deliveryZoneService struct {
+	repo         repository.DeliveryZoneRepository
+	locationRepo repository.LocationRepository
+	transactor   infra_interface.Transactor
+}
+
+func NewDeliveryZoneService(
+	repo repository.DeliveryZoneRepository,
+	locationRepo repository.LocationRepository,
+	transactor infra_interface.Transactor,
+) DeliveryZoneService {
+	return &deliveryZoneService{repo: repo, locationRepo: locationRepo, transactor: transactor}
+}
+
+func (service *deliveryZoneService) Create(request dto.DeliveryZoneRequest) (*model.DeliveryZone, error) {
+	now := time.Now()
+	zone := model.DeliveryZone{ID: request.ID, Active: true, CreatedAt: now}
+	applyDeliveryZoneRequest(&zone, request, now)
+	if err := zone.Validate(); err != nil {
+		return nil, domainError(err)
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Create(ctx, zone)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// Update redraws or renames a zone. Slots and orders already booked in it are not moved.
+func (service *deliveryZoneService) Update(id string, request dto.DeliveryZoneRequest) (*model.DeliveryZone, error) {
+	var zone *model.DeliveryZone
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, id, func(zone *model.DeliveryZone) error {
+			applyDeliveryZoneRequest(zone, request, time.Now())
+			return zone.Validate()
+		})
+		if err != nil {
+			return err
+		}
+		zone = updated
+		return nil
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return zone, nil
+}
+
+func (service *deliveryZoneService) FindByID(id string) (*model.DeliveryZone, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *deliveryZoneService) FindAll() []model.DeliveryZone {
+	return service.repo.FindAll(func(model.DeliveryZone) bool { return true })
+}
+
+// Quote prices delivering an order to an address the way checkout will, shipped from the default location
+func (service *deliveryZoneService) Quote(request dto.DeliveryQuoteRequest) (*model.DeliveryZone, model.DeliveryQuote, error) {
+	address := dto.ToAddress(request.Address)
+	zone, err := resolveZone(service.repo, address)
+	if err != nil {
+		return nil, model.DeliveryQuote{}, err
+	}
+	distance := deliveryDistance(service.locationRepo, model.DefaultLocationID, address)
+	return zone, zone.Quote(model.Money(request.Subtotal), request.Weight, distance), nil
+}
+
+func (service *deliveryZoneService) Name() string { return "DeliveryZoneService" }
+func (service *deliveryZoneService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *deliveryZoneService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func applyDeliveryZoneRequest(zone *model.DeliveryZone, request dto.DeliveryZoneRequest, now time.Time) {
+	zone.Name = strings.TrimSpace(request.Name)
+	zone.Areas = dto.ToZoneAreas(request.Areas)
+	zone.Polygon = dto.ToGeoPoints(request.Polygon)
+	zone.Fees = dto.ToDeliveryFeeRule(request.Fees)
+	zone.UpdatedAt = now
+	if request.Active != nil {
+		zone.Active = *request.Active
+	}
+}
+
+// resolveZone finds the active zone delivering to the address, the first by id when zones overlap
+func resolveZone(zoneRepo repository.DeliveryZoneRepository, address model.Address) (*model.DeliveryZone, error) {
+	zones := zoneRepo.FindAll(func(zone model.DeliveryZone) bool {
+		return zone.Active && zone.Covers(address, util.FoldText)
+	})
+	if len(zones) == 0 {
+		return nil, core.Error.Invalid.OutOfDeliveryArea
+	}
+	return &zones[0], nil
+}
+
+// deliveryDistance is how far the address is from the location in whole meters, or 0 when either is not geocoded
+func deliveryDistance(locationRepo repository.LocationRepository, locationID string, address model.Address) int64 {
+	location, err := locationRepo.FindByID(locationID)
+	if err != nil || location.Address.Point == nil || address.Point == nil {
+		return 0
+	}
+	return int64(math.Round(location.Address.Point.DistanceTo(*address.Point)))
+}
+
+var DeliveryZoneServiceModule = fx.Options(fx.Provide(NewDeliveryZoneService))
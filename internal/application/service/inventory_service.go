@@ -0,0 +1,531 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReservationTTL = 15 * time.Minute
+	defaultSweepInterval  = time.Minute
+)
+
+type InventoryService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	FindLevels(query dto.StockLevelQuery) []model.StockLevel
+	Adjust(actorID string, request dto.AdjustStockRequest) (*model.StockLevel, error)
+	Reserve(request dto.ReserveRequest) ([]model.Reservation, error)
+	FindReservations(query dto.ReservationQuery) []model.Reservation
+	Release(id string) (*model.Reservation, error)
+	Commit(actorID string, id string) (*model.Reservation, error)
+	ExpireReservations() int
+
+	Availability(productID string) (*dto.ProductAvailabilityResponse, error)
+	Transfer(actorID string, request dto.TransferRequest) (*model.Transfer, error)
+	ReceiveTransfer(actorID string, id string) (*model.Transfer, error)
+	CancelTransfer(actorID string, id string) (*model.Transfer, error)
+	FindTransfers(query dto.TransferQuery) []model.Transfer
+}
+
+type inventoryService struct {
+	repo         repository.InventoryRepository
+	productRepo  repository.ProductRepository
+	locationRepo repository.LocationRepository
+	transactor   infra_interface.Transactor
+	ttl          time.Duration
+}
+
+func NewInventoryService(
+	repo repository.InventoryRepository,
+	productRepo repository.ProductRepository,
+	locationRepo repository.LocationRepository,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) InventoryService {
+	service := &inventoryService{
+		repo:         repo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		transactor:   transactor,
+		ttl:          configuredDuration(core.Configs.Inventory.ReservationTTL, defaultReservationTTL),
+	}
+
+	// Abandoned checkouts give their stock back even if nobody touches the product again
+	interval := configuredDuration(core.Configs.Inventory.SweepInterval, defaultSweepInterval)
+	scheduler.Every("expire-stock-reservations", interval, func() error {
+		if expired := service.ExpireReservations(); expired > 0 {
+			zap.L().Info("Expired stock reservations", zap.Int("count", expired))
+		}
+		return nil
+	})
+	return service
+}
+
+func (service *inventoryService) FindLevels(query dto.StockLevelQuery) []model.StockLevel {
+	return service.repo.FindLevels(func(level model.StockLevel) bool {
+		return (query.ProductID == "" || level.ProductID == query.ProductID) &&
+			(query.LocationID == "" || level.LocationID == query.LocationID)
+	})
+}
+
+// Adjust corrects the stock on hand, e.g. after a recount. Stock held by reservations cannot be removed.
+func (service *inventoryService) Adjust(actorID string, request dto.AdjustStockRequest) (*model.StockLevel, error) {
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := productQuantity(product, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	location, err := service.locationRepo.FindByID(locationOrDefault(request.LocationID))
+	if err != nil {
+		return nil, err
+	}
+	delta := quantity.Base
+	if request.Direction == "out" {
+		delta = -delta
+	}
+
+	movement := model.Movement{
+		ProductID:  product.ID,
+		LocationID: location.ID,
+		Type:       model.MovementAdjustment,
+		Unit:       product.PriceUnit,
+		ActorID:    actorID,
+		Reason:     request.Reason,
+		Note:       request.Note,
+		CreatedAt:  time.Now(),
+	}
+	var level *model.StockLevel
+	// A transaction even for one movement: the stock level and its ledger entry are written together or not at all
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := recordMovement(ctx, service.repo, movement, func(level *model.StockLevel) (int64, error) {
+			if delta < 0 && level.Available() < -delta {
+				return 0, model.ErrInsufficientStock
+			}
+			return delta, nil
+		})
+		level = updated
+		return err
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return level, nil
+}
+
+// Reserve holds stock for every item or for none of them. Concurrent calls are serialized by the transaction,
+// so two customers can never both reserve the last unit.
+func (service *inventoryService) Reserve(request dto.ReserveRequest) ([]model.Reservation, error) {
+	location, err := service.activeLocation(request.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	locationID := location.ID
+	type item struct {
+		product  *model.Product
+		quantity model.Quantity
+	}
+	items := make([]item, 0, len(request.Items))
+	for _, requested := range request.Items {
+		product, err := service.productRepo.FindByID(requested.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := productQuantity(product, requested.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item{product: product, quantity: quantity})
+	}
+
+	reservations := make([]model.Reservation, 0, len(items))
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		for _, item := range items {
+			reservation := model.Reservation{
+				ID:         uuid.NewString(),
+				ProductID:  item.product.ID,
+				LocationID: locationID,
+				Unit:       item.quantity.Unit,
+				Quantity:   item.quantity.Base,
+				Reference:  request.Reference,
+				Status:     model.ReservationActive,
+				ExpiresAt:  now.Add(service.ttl),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}
+			if err := holdStock(ctx, service.repo, reservation, item.product.PriceUnit, now); err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (service *inventoryService) FindReservations(query dto.ReservationQuery) []model.Reservation {
+	return service.repo.FindReservations(func(reservation model.Reservation) bool {
+		return (query.Reference == "" || reservation.Reference == query.Reference) &&
+			(query.Status == "" || reservation.Status == model.ReservationStatus(query.Status))
+	})
+}
+
+// Release gives the held stock back, e.g. when a checkout is abandoned
+func (service *inventoryService) Release(id string) (*model.Reservation, error) {
+	reservation, _, err := service.close(model.SystemActor, id, model.ReservationReleased)
+	return reservation, err
+}
+
+// Commit turns the held stock into a sale, removing it from stock on hand. An overdue reservation is expired instead.
+func (service *inventoryService) Commit(actorID string, id string) (*model.Reservation, error) {
+	reservation, status, err := service.close(actorID, id, model.ReservationCommitted)
+	if err != nil {
+		return nil, err
+	}
+	if status != model.ReservationCommitted {
+		return nil, core.Error.Conflict.ReservationClosed
+	}
+	return reservation, nil
+}
+
+// ExpireReservations releases every reservation that outlived its TTL and returns how many there were
+func (service *inventoryService) ExpireReservations() int {
+	now := time.Now()
+	overdue := service.repo.FindReservations(func(reservation model.Reservation) bool {
+		return reservation.Overdue(now)
+	})
+
+	expired := 0
+	for _, reservation := range overdue {
+		if _, _, err := service.close(model.SystemActor, reservation.ID, model.ReservationExpired); err == nil {
+			expired++
+		}
+	}
+	return expired
+}
+
+// close moves a reservation to status and updates the stock level in one transaction.
+// It returns the status actually applied: an overdue reservation always ends up expired.
+func (service *inventoryService) close(actorID string, id string, status model.ReservationStatus) (*model.Reservation, model.ReservationStatus, error) {
+	var reservation *model.Reservation
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		closed, err := closeReservation(ctx, service.repo, actorID, id, status, time.Now())
+		reservation = closed
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return reservation, reservation.Status, nil
+}
+
+// holdStock reserves stock for a new reservation and saves it, letting overdue holds on the product go first.
+// levelUnit is the product's price unit, shown on the stock level. Callers hold a transaction.
+func holdStock(ctx context.Context, repo repository.InventoryRepository, reservation model.Reservation, levelUnit model.UnitCode, now time.Time) error {
+	if err := expireOverdue(ctx, repo, reservation.ProductID, reservation.LocationID, now); err != nil {
+		return err
+	}
+	_, err := repo.UpdateLevel(ctx, reservation.ProductID, reservation.LocationID, func(level *model.StockLevel) error {
+		if err := level.Reserve(reservation.Quantity); err != nil {
+			return err
+		}
+		level.Unit = levelUnit
+		level.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return domainError(err)
+	}
+	repo.SaveReservation(ctx, reservation)
+	return nil
+}
+
+// closeReservation moves an active reservation to status and settles its stock; an overdue reservation always
+// ends up expired. Callers hold a transaction.
+func closeReservation(ctx context.Context, repo repository.InventoryRepository, actorID string, id string, status model.ReservationStatus, now time.Time) (*model.Reservation, error) {
+	reservation, err := repo.UpdateReservation(ctx, id, func(reservation *model.Reservation) error {
+		if reservation.Overdue(now) {
+			status = model.ReservationExpired
+		}
+		return reservation.Close(status, now)
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	if err := settleReservation(ctx, repo, actorID, reservation, now); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// settleReservation applies a closed reservation to its stock level; a committed one is recorded in the ledger as a sale
+func settleReservation(ctx context.Context, repo repository.InventoryRepository, actorID string, reservation *model.Reservation, now time.Time) error {
+	if reservation.Status == model.ReservationCommitted {
+		movement := model.Movement{
+			ProductID:  reservation.ProductID,
+			LocationID: reservation.LocationID,
+			Type:       model.MovementSale,
+			ActorID:    actorID,
+			Reason:     model.ReasonCustomerOrder,
+			SourceType: model.SourceReservation,
+			SourceID:   reservation.Reference,
+			CreatedAt:  now,
+		}
+		_, err := recordMovement(ctx, repo, movement, func(level *model.StockLevel) (int64, error) {
+			// A reservation never holds more than was on hand, so a shortfall means the stock was miscounted since
+			if level.OnHand < reservation.Quantity {
+				zap.L().Warn("Committed reservation exceeds stock on hand",
+					zap.String("reservation", reservation.ID), zap.Int64("quantity", reservation.Quantity), zap.Int64("on_hand", level.OnHand))
+				return 0, model.ErrInsufficientStock
+			}
+			level.Release(reservation.Quantity)
+			return -reservation.Quantity, nil
+		})
+		return domainError(err)
+	}
+
+	_, err := repo.UpdateLevel(ctx, reservation.ProductID, reservation.LocationID, func(level *model.StockLevel) error {
+		level.Release(reservation.Quantity)
+		level.UpdatedAt = now
+		return nil
+	})
+	return err
+}
+
+// expireOverdue releases the overdue reservations of one product at one location; callers hold a transaction
+func expireOverdue(ctx context.Context, repo repository.InventoryRepository, productID string, locationID string, now time.Time) error {
+	overdue := repo.FindReservations(func(reservation model.Reservation) bool {
+		return reservation.ProductID == productID && reservation.LocationID == locationID && reservation.Overdue(now)
+	})
+	for _, reservation := range overdue {
+		expired, err := repo.UpdateReservation(ctx, reservation.ID, func(reservation *model.Reservation) error {
+			return reservation.Close(model.ReservationExpired, now)
+		})
+		if err != nil {
+			return err
+		}
+		if err := settleReservation(ctx, repo, model.SystemActor, expired, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Availability shows how much of a product every active location can sell right now
+func (service *inventoryService) Availability(productID string) (*dto.ProductAvailabilityResponse, error) {
+	product, err := service.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]model.Location, 0)
+	for _, location := range service.locationRepo.FindAll() {
+		if location.Active {
+			locations = append(locations, location)
+		}
+	}
+	levels := service.repo.FindLevels(func(level model.StockLevel) bool {
+		return level.ProductID == productID
+	})
+	response := dto.ToProductAvailabilityResponse(product, locations, levels, util.StoreNow())
+	return &response, nil
+}
+
+// Transfer ships stock from one location to another. It leaves the source at once and is on hand
+// at the destination only once received; in between it shows as in transit there.
+func (service *inventoryService) Transfer(actorID string, request dto.TransferRequest) (*model.Transfer, error) {
+	if request.FromLocationID == request.ToLocationID {
+		return nil, core.Error.Invalid.Transfer
+	}
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := productQuantity(product, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := service.locationRepo.FindByID(request.FromLocationID); err != nil {
+		return nil, err
+	}
+	if _, err := service.activeLocation(request.ToLocationID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transfer := model.Transfer{
+		ID:             uuid.NewString(),
+		ProductID:      product.ID,
+		FromLocationID: request.FromLocationID,
+		ToLocationID:   request.ToLocationID,
+		Unit:           quantity.Unit,
+		Quantity:       quantity.Base,
+		Status:         model.TransferInTransit,
+		Note:           request.Note,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		movement := transferMovement(actorID, &transfer, transfer.FromLocationID, model.ReasonTransferOut, now)
+		movement.Unit = product.PriceUnit
+		_, err := recordMovement(ctx, service.repo, movement, func(level *model.StockLevel) (int64, error) {
+			// Stock promised to customers at the source cannot be shipped away
+			if level.Available() < transfer.Quantity {
+				return 0, model.ErrInsufficientStock
+			}
+			return -transfer.Quantity, nil
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		_, err = service.repo.UpdateLevel(ctx, product.ID, transfer.ToLocationID, func(level *model.StockLevel) error {
+			level.Unit = product.PriceUnit
+			level.InTransit += transfer.Quantity
+			level.UpdatedAt = now
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		service.repo.SaveTransfer(ctx, transfer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ReceiveTransfer puts the transferred stock on hand at the destination
+func (service *inventoryService) ReceiveTransfer(actorID string, id string) (*model.Transfer, error) {
+	return service.closeTransfer(actorID, id, model.TransferReceived)
+}
+
+// CancelTransfer returns the transferred stock to the source, e.g. when the van turns back
+func (service *inventoryService) CancelTransfer(actorID string, id string) (*model.Transfer, error) {
+	return service.closeTransfer(actorID, id, model.TransferCancelled)
+}
+
+func (service *inventoryService) FindTransfers(query dto.TransferQuery) []model.Transfer {
+	return service.repo.FindTransfers(func(transfer model.Transfer) bool {
+		return (query.ProductID == "" || transfer.ProductID == query.ProductID) &&
+			(query.LocationID == "" || transfer.FromLocationID == query.LocationID || transfer.ToLocationID == query.LocationID) &&
+			(query.Status == "" || transfer.Status == model.TransferStatus(query.Status))
+	})
+}
+
+func (service *inventoryService) closeTransfer(actorID string, id string, status model.TransferStatus) (*model.Transfer, error) {
+	var transfer *model.Transfer
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		updated, err := service.repo.UpdateTransfer(ctx, id, func(transfer *model.Transfer) error {
+			return transfer.Close(status, now)
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		transfer = updated
+
+		if status == model.TransferReceived {
+			movement := transferMovement(actorID, transfer, transfer.ToLocationID, model.ReasonTransferIn, now)
+			_, err = recordMovement(ctx, service.repo, movement, func(level *model.StockLevel) (int64, error) {
+				level.InTransit = max(level.InTransit-transfer.Quantity, 0)
+				return transfer.Quantity, nil
+			})
+			return err
+		}
+
+		_, err = service.repo.UpdateLevel(ctx, transfer.ProductID, transfer.ToLocationID, func(level *model.StockLevel) error {
+			level.InTransit = max(level.InTransit-transfer.Quantity, 0)
+			level.UpdatedAt = now
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		movement := transferMovement(actorID, transfer, transfer.FromLocationID, model.ReasonTransferBack, now)
+		_, err = recordMovement(ctx, service.repo, movement, func(*model.StockLevel) (int64, error) {
+			return transfer.Quantity, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// transferMovement starts the ledger entry of one leg of a transfer
+func transferMovement(actorID string, transfer *model.Transfer, locationID string, reason string, now time.Time) model.Movement {
+	return model.Movement{
+		ProductID:  transfer.ProductID,
+		LocationID: locationID,
+		Type:       model.MovementTransfer,
+		ActorID:    actorID,
+		Reason:     reason,
+		Note:       transfer.Note,
+		SourceType: model.SourceTransfer,
+		SourceID:   transfer.ID,
+		CreatedAt:  now,
+	}
+}
+
+// activeLocation finds a location that can take part in sales and transfers; an inactive one counts as missing
+func (service *inventoryService) activeLocation(id string) (*model.Location, error) {
+	location, err := service.locationRepo.FindByID(locationOrDefault(id))
+	if err != nil {
+		return nil, err
+	}
+	if !location.Active {
+		return nil, core.Error.NotFound.Location
+	}
+	return location, nil
+}
+
+func (service *inventoryService) Name() string { return "InventoryService" }
+func (service *inventoryService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *inventoryService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func locationOrDefault(locationID string) string {
+	if locationID == "" {
+		return model.DefaultLocationID
+	}
+	return locationID
+}
+
+// configuredDuration parses a duration setting such as "15m" or "7d", falling back when it is missing or invalid
+func configuredDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := util.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
+
+var InventoryServiceModule = fx.Options(fx.Provide(NewInventoryService))
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+)
+
+type LocationService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.LocationRequest) (*model.Location, error)
+	Update(id string, request dto.LocationRequest) (*model.Location, error)
+	FindById(id string) (*model.Location, error)
+	FindAll(query dto.LocationQuery) []model.Location
+}
+
+type locationService struct {
+	repo       repository.LocationRepository
+	transactor infra_interface.Transactor
+}
+
+func NewLocationService(repo repository.LocationRepository, transactor infra_interface.Transactor) LocationService {
+	return &locationService{repo: repo, transactor: transactor}
+}
+
+func (service *locationService) Create(request dto.LocationRequest) (*model.Location, error) {
+	now := time.Now()
+	location := model.Location{ID: request.ID, Active: true, CreatedAt: now}
+	if err := applyLocationRequest(&location, request, now); err != nil {
+		return nil, err
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Create(ctx, location)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (service *locationService) Update(id string, request dto.LocationRequest) (*model.Location, error) {
+	location, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyLocationRequest(location, request, time.Now()); err != nil {
+		return nil, err
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Update(ctx, *location)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+func (service *locationService) FindById(id string) (*model.Location, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *locationService) FindAll(query dto.LocationQuery) []model.Location {
+	locations := make([]model.Location, 0)
+	for _, location := range service.repo.FindAll() {
+		if location.Active || query.IncludeInactive {
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+func (service *locationService) Name() string { return "LocationService" }
+func (service *locationService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *locationService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func applyLocationRequest(location *model.Location, request dto.LocationRequest, now time.Time) error {
+	hours, err := dto.ToOpeningHours(request.Hours)
+	if err != nil {
+		return locationError(err)
+	}
+
+	location.Name = request.Name
+	location.Type = model.LocationType(request.Type)
+	location.Address = dto.ToAddress(request.Address)
+	location.Phone = request.Phone
+	location.Hours = hours
+	location.UpdatedAt = now
+	if request.Active != nil {
+		location.Active = *request.Active
+	}
+	return locationError(location.Validate())
+}
+
+// locationError maps errors raised by the location model to their API error.
+func locationError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidLocation):
+		return core.Error.Invalid.Location
+	case errors.Is(err, model.ErrInvalidOpeningHours):
+		return core.Error.Invalid.OpeningHours
+	default:
+		return err
+	}
+}
+
+var LocationServiceModule = fx.Options(fx.Provide(NewLocationService))
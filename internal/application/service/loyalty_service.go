@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+Customers earn loyalty points on what they pay for delivered orders and spend them at checkout:
+- A scheduled run books the points of every delivered order once, at the rate of the customer's tier then, even
+  when they come to nothing. Points spent on an order that was cancelled or returned are given back, points earned
+  on a returned order are taken back, and points past their expiry are recorded as expired.
+- Points are spent in the checkout transaction, up to the program's share of the order's total.
+- Admins can give or take points of a known user with a note; they cannot take more than the customer has.
+*/
+
+const (
+	defaultSpendPerPoint      = 10000
+	defaultPointValue         = 100
+	defaultMaxRedeemPercent   = 50
+	defaultPointsTTL          = 365 * 24 * time.Hour
+	defaultTierWindow         = 365 * 24 * time.Hour
+	defaultSilverPoints       = 1000
+	defaultSilverEarnPercent  = 125
+	defaultGoldPoints         = 5000
+	defaultGoldEarnPercent    = 150
+	defaultLoyaltyRunInterval = time.Hour
+)
+
+type LoyaltyService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Program() model.LoyaltyProgram
+	FindAccount(userID string) model.LoyaltyAccount
+	FindHistory(userID string, query dto.LoyaltyHistoryQuery) dto.Page[model.LoyaltyEntry]
+	Adjust(actorID string, userID string, request dto.LoyaltyAdjustRequest) (*model.LoyaltyEntry, error)
+	// Redeem spends the customer's points on an order being placed, taking their value off its total. It must be
+	// called within the caller's transaction.
+	Redeem(ctx context.Context, order *model.Order, points int64, now time.Time) error
+	Run() model.LoyaltyRun
+}
+
+type loyaltyService struct {
+	repo       repository.LoyaltyRepository
+	orderRepo  repository.OrderRepository
+	userRepo   repository.UserRepository
+	transactor infra_interface.Transactor
+	program    model.LoyaltyProgram
+}
+
+func NewLoyaltyService(
+	repo repository.LoyaltyRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) LoyaltyService {
+	config := core.Configs.Loyalty
+	service := &loyaltyService{
+		repo:       repo,
+		orderRepo:  orderRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		program: model.LoyaltyProgram{
+			SpendPerPoint:    model.Money(configuredInt(config.SpendPerPoint, defaultSpendPerPoint)),
+			PointValue:       model.Money(configuredInt(config.PointValue, defaultPointValue)),
+			MaxRedeemPercent: configuredInt(config.MaxRedeemPercent, defaultMaxRedeemPercent),
+			PointsTTL:        configuredDuration(config.PointsTTL, defaultPointsTTL),
+			TierWindow:       configuredDuration(config.TierWindow, defaultTierWindow),
+			Tiers: []model.TierRule{
+				{Tier: model.TierMember, EarnPercent: 100},
+				{
+					Tier:        model.TierSilver,
+					MinPoints:   configuredInt(config.SilverPoints, defaultSilverPoints),
+					EarnPercent: configuredInt(config.SilverEarnPercent, defaultSilverEarnPercent),
+				},
+				{
+					Tier:        model.TierGold,
+					MinPoints:   configuredInt(config.GoldPoints, defaultGoldPoints),
+					EarnPercent: configuredInt(config.GoldEarnPercent, defaultGoldEarnPercent),
+				},
+			},
+		},
+	}
+
+	interval := configuredDuration(config.RunInterval, defaultLoyaltyRunInterval)
+	scheduler.Every("loyalty-points", interval, func() error {
+		if run := service.Run(); run != (model.LoyaltyRun{}) {
+			zap.L().Info("Booked loyalty points",
+				zap.Int("earned", run.Earned), zap.Int("given_back", run.GivenBack), zap.Int("taken_back", run.TakenBack),
+				zap.Int("expired", run.Expired))
+		}
+		return nil
+	})
+	return service
+}
+
+func (service *loyaltyService) Program() model.LoyaltyProgram {
+	return service.program
+}
+
+func (service *loyaltyService) FindAccount(userID string) model.LoyaltyAccount {
+	return service.program.Account(userID, service.repo.FindByUser(userID), time.Now())
+}
+
+// FindHistory returns the user's entries, the latest first
+func (service *loyaltyService) FindHistory(userID string, query dto.LoyaltyHistoryQuery) dto.Page[model.LoyaltyEntry] {
+	entries := service.repo.FindAll(func(entry model.LoyaltyEntry) bool {
+		return entry.UserID == userID && (query.Kind == "" || string(entry.Kind) == query.Kind)
+	})
+	slices.Reverse(entries)
+	return dto.Paginate(entries, query.PageRequest)
+}
+
+func (service *loyaltyService) Adjust(actorID string, userID string, request dto.LoyaltyAdjustRequest) (*model.LoyaltyEntry, error) {
+	if _, err := service.userRepo.FindByID(userID); err != nil {
+		return nil, err
+	}
+	var entry model.LoyaltyEntry
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		account := service.program.Account(userID, service.repo.FindByUser(userID), now)
+		if account.Balance+request.Points < 0 {
+			return domainError(model.ErrInsufficientPoints)
+		}
+		entry = model.LoyaltyEntry{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Kind:      model.LoyaltyAdjust,
+			Points:    request.Points,
+			Note:      model.Message{ID: "Loyalty.Adjusted", Data: map[string]any{"Note": request.Note}},
+			ActorID:   actorID,
+			CreatedAt: now,
+		}
+		if entry.Points > 0 {
+			entry.ExpiresAt = now.Add(service.program.PointsTTL)
+		}
+		service.repo.Append(ctx, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (service *loyaltyService) Redeem(ctx context.Context, order *model.Order, points int64, now time.Time) error {
+	account := service.program.Account(order.UserID, service.repo.FindByUser(order.UserID), now)
+	value, err := service.program.Redeem(points, account.Balance, order.Total)
+	if err != nil {
+		return domainError(err)
+	}
+	order.PointsRedeemed = points
+	order.PointsDiscount = value
+	order.Total -= value
+	service.repo.Append(ctx, model.LoyaltyEntry{
+		ID:        uuid.NewString(),
+		UserID:    order.UserID,
+		Kind:      model.LoyaltyRedeem,
+		Points:    -points,
+		OrderID:   order.ID,
+		Note:      model.Message{ID: "Loyalty.Redeemed"},
+		ActorID:   order.UserID,
+		CreatedAt: now,
+	})
+	return nil
+}
+
+// Run books the points of delivered orders, settles the points of cancelled and returned orders and records expired
+// points, each order and customer in a transaction of its own
+func (service *loyaltyService) Run() model.LoyaltyRun {
+	now := time.Now()
+	var run model.LoyaltyRun
+	// Orders booked in earlier runs are left out without a transaction each
+	earned, adjusted := map[string]bool{}, map[string]bool{}
+	owed := map[string]int64{} // Points the order's entries came to
+	for _, entry := range service.repo.FindAll(func(entry model.LoyaltyEntry) bool { return entry.OrderID != "" }) {
+		owed[entry.OrderID] += entry.Points
+		switch entry.Kind {
+		case model.LoyaltyEarn:
+			earned[entry.OrderID] = true
+		case model.LoyaltyAdjust:
+			adjusted[entry.OrderID] = true
+		}
+	}
+	for _, order := range service.orderRepo.FindAll(func(order model.Order) bool {
+		switch order.Status {
+		case model.OrderDelivered:
+			return !earned[order.ID]
+		case model.OrderCancelled, model.OrderReturned:
+			return !adjusted[order.ID] && owed[order.ID] != 0
+		}
+		return false
+	}) {
+		booked, err := service.settle(order.ID, now)
+		if err != nil {
+			zap.L().Warn("Could not book loyalty points", zap.String("order", order.ID), zap.Error(err))
+			continue
+		}
+		switch {
+		case booked == nil:
+		case booked.Kind == model.LoyaltyEarn:
+			run.Earned++
+		case booked.Points > 0:
+			run.GivenBack++
+		default:
+			run.TakenBack++
+		}
+	}
+
+	var users []string
+	for _, entry := range service.repo.FindAll(func(entry model.LoyaltyEntry) bool {
+		return entry.Points > 0 && !entry.ExpiresAt.IsZero() && !now.Before(entry.ExpiresAt)
+	}) {
+		if !slices.Contains(users, entry.UserID) {
+			users = append(users, entry.UserID)
+		}
+	}
+	for _, userID := range users {
+		expired, err := service.expire(userID, now)
+		if err != nil {
+			zap.L().Warn("Could not expire loyalty points", zap.String("user", userID), zap.Error(err))
+			continue
+		}
+		if expired {
+			run.Expired++
+		}
+	}
+	return run
+}
+
+// settle books what an order owes its customer in points, if it has not been booked yet, and returns the entry it
+// made. A delivered order is booked even when it earns nothing, so it is settled once. A cancelled or returned order
+// gives back the points spent on it and takes back those it earned; the customer's balance can go below zero when
+// they already spent them.
+func (service *loyaltyService) settle(orderID string, now time.Time) (*model.LoyaltyEntry, error) {
+	var booked *model.LoyaltyEntry
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		booked = nil
+		order, err := service.orderRepo.FindByID(orderID)
+		if err != nil {
+			return err
+		}
+		entries := service.repo.FindByOrder(order.ID)
+		entry := model.LoyaltyEntry{
+			ID:        uuid.NewString(),
+			UserID:    order.UserID,
+			OrderID:   order.ID,
+			ActorID:   model.SystemActor,
+			CreatedAt: now,
+		}
+		switch order.Status {
+		case model.OrderDelivered:
+			if slices.ContainsFunc(entries, func(entry model.LoyaltyEntry) bool { return entry.Kind == model.LoyaltyEarn }) {
+				return nil
+			}
+			account := service.program.Account(order.UserID, service.repo.FindByUser(order.UserID), now)
+			entry.Kind = model.LoyaltyEarn
+			entry.Points = service.program.Earn(order.EarnBase(), account.Tier)
+			entry.Note = model.Message{ID: "Loyalty.OrderDelivered", Data: map[string]any{"Number": order.Number}}
+		case model.OrderCancelled, model.OrderReturned:
+			if slices.ContainsFunc(entries, func(entry model.LoyaltyEntry) bool { return entry.Kind == model.LoyaltyAdjust }) {
+				return nil
+			}
+			entry.Kind = model.LoyaltyAdjust
+			entry.Points = -model.LoyaltyBalance(entries)
+			entry.Note = model.Message{ID: "Loyalty.OrderCancelled", Data: map[string]any{"Number": order.Number}}
+			if order.Status == model.OrderReturned {
+				entry.Note.ID = "Loyalty.OrderReturned"
+			}
+			if entry.Points == 0 {
+				return nil
+			}
+		default:
+			return nil
+		}
+		if entry.Points > 0 {
+			entry.ExpiresAt = now.Add(service.program.PointsTTL)
+		}
+		service.repo.Append(ctx, entry)
+		booked = &entry
+		return nil
+	})
+	return booked, err
+}
+
+// expire records the user's points past their expiry and reports whether there were any
+func (service *loyaltyService) expire(userID string, now time.Time) (bool, error) {
+	var expired bool
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		points := model.ExpiredPoints(service.repo.FindByUser(userID), now)
+		expired = points > 0
+		if !expired {
+			return nil
+		}
+		service.repo.Append(ctx, model.LoyaltyEntry{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Kind:      model.LoyaltyExpire,
+			Points:    -points,
+			Note:      model.Message{ID: "Loyalty.Expired"},
+			ActorID:   model.SystemActor,
+			CreatedAt: now,
+		})
+		return nil
+	})
+	return expired, err
+}
+
+// configuredInt is the configured number, or fallback when it is not set
+func configuredInt(value int64, fallback int64) int64 {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func (service *loyaltyService) Name() string { return "LoyaltyService" }
+func (service *loyaltyService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *loyaltyService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var LoyaltyServiceModule = fx.Options(fx.Provide(NewLoyaltyService))
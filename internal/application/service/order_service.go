@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	unpaidOrderSweepInterval = time.Minute
+	unpaidOrderReason        = "Payment not received in time"
+)
+
+type OrderService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	FindMine(userID string, query dto.OrderQuery) dto.Page[model.Order]
+	FindMineByID(userID string, id string) (*model.Order, error)
+	Cancel(userID string, id string, request dto.CancelOrderRequest) (*model.Order, error)
+	FindAll(query dto.StaffOrderQuery) dto.Page[model.Order]
+	FindByID(id string) (*model.Order, error)
+	Transition(actor model.OrderActor, id string, request dto.OrderTransitionRequest) (*model.Order, error)
+	CancelUnpaid() int
+}
+
+type orderService struct {
+	repo       repository.OrderRepository
+	workflow   *orderWorkflow
+	transactor infra_interface.Transactor
+}
+
+func NewOrderService(
+	repo repository.OrderRepository,
+	inventoryRepo repository.InventoryRepository,
+	slotRepo repository.DeliverySlotRepository,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) OrderService {
+	service := &orderService{
+		repo:       repo,
+		workflow:   &orderWorkflow{orders: repo, inventory: inventoryRepo, slots: slotRepo},
+		transactor: transactor,
+	}
+
+	scheduler.Every("cancel-unpaid-orders", unpaidOrderSweepInterval, func() error {
+		if cancelled := service.CancelUnpaid(); cancelled > 0 {
+			zap.L().Info("Cancelled unpaid orders", zap.Int("count", cancelled))
+		}
+		return nil
+	})
+	return service
+}
+
+// FindMine pages through the user's orders, the latest first
+func (service *orderService) FindMine(userID string, query dto.OrderQuery) dto.Page[model.Order] {
+	orders := service.repo.FindAll(func(order model.Order) bool {
+		return order.UserID == userID && (query.Status == "" || order.Status == model.OrderStatus(query.Status))
+	})
+	return dto.Paginate(orders, query.PageRequest)
+}
+
+// FindMineByID finds one of the user's orders; other people's orders are not found
+func (service *orderService) FindMineByID(userID string, id string) (*model.Order, error) {
+	order, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, core.Error.NotFound.Order
+	}
+	return order, nil
+}
+
+// Cancel lets the customer call off their order while the store has not started on it
+func (service *orderService) Cancel(userID string, id string, request dto.CancelOrderRequest) (*model.Order, error) {
+	if _, err := service.FindMineByID(userID, id); err != nil {
+		return nil, err
+	}
+	return service.Transition(model.OrderActor{ID: userID}, id, dto.OrderTransitionRequest{
+		Status: string(model.OrderCancelled),
+		Reason: request.Reason,
+	})
+}
+
+func (service *orderService) FindAll(query dto.StaffOrderQuery) dto.Page[model.Order] {
+	orders := service.repo.FindAll(func(order model.Order) bool {
+		return (query.Status == "" || order.Status == model.OrderStatus(query.Status)) &&
+			(query.UserID == "" || order.UserID == query.UserID) &&
+			(query.SlotID == "" || order.SlotID == query.SlotID)
+	})
+	return dto.Paginate(orders, query.PageRequest)
+}
+
+func (service *orderService) FindByID(id string) (*model.Order, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *orderService) Transition(actor model.OrderActor, id string, request dto.OrderTransitionRequest) (*model.Order, error) {
+	var moved *model.Order
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		order, err := service.workflow.move(ctx, id, model.OrderStatus(request.Status), actor, request.Reason, time.Now())
+		moved = order
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
+// CancelUnpaid cancels orders whose payment did not arrive before it was due, giving back their stock and slot. Each
+// order is cancelled in a transaction of its own, so one that cannot be cancelled does not hold back the others.
+func (service *orderService) CancelUnpaid() int {
+	now := time.Now()
+	cancelled := 0
+	for _, order := range service.repo.FindAll(func(order model.Order) bool {
+		return order.Status == model.OrderPendingPayment && !now.Before(order.PaymentDueAt)
+	}) {
+		moved := false
+		err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+			// Paid in the meantime
+			current, err := service.repo.FindByID(order.ID)
+			if err != nil || current.Status != model.OrderPendingPayment {
+				return err
+			}
+			_, err = service.workflow.move(ctx, order.ID, model.OrderCancelled, model.SystemOrderActor, unpaidOrderReason, now)
+			moved = err == nil
+			return err
+		})
+		if err != nil {
+			zap.L().Warn("Could not cancel unpaid order", zap.String("order", order.ID), zap.Error(err))
+			continue
+		}
+		if moved {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+func (service *orderService) Name() string { return "OrderService" }
+func (service *orderService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *orderService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// orderWorkflow moves orders between statuses together with what each move means for stock and delivery slots
+type orderWorkflow struct {
+	orders    repository.OrderRepository
+	inventory repository.InventoryRepository
+	slots     repository.DeliverySlotRepository
+}
+
+// confirmable reports whether move can confirm the order now: it still awaits payment and holds its stock
+func (workflow *orderWorkflow) confirmable(order *model.Order, now time.Time) bool {
+	if order.Status != model.OrderPendingPayment {
+		return false
+	}
+	for _, line := range order.Lines {
+		reservation, err := workflow.inventory.FindReservation(line.ReservationID)
+		if err != nil || reservation.Status != model.ReservationActive || reservation.Overdue(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// move transitions the order and applies its effects; callers hold a transaction.
+//   - confirmed: the held stock no longer expires
+//   - out_for_delivery: the held stock leaves the shelf as a sale
+//   - cancelled: the held stock and the delivery slot are given back
+//
+// Returned goods are not restocked here: they are inspected before going back on the shelf.
+func (workflow *orderWorkflow) move(ctx context.Context, id string, to model.OrderStatus, actor model.OrderActor, reason string, now time.Time) (*model.Order, error) {
+	order, err := workflow.orders.Update(ctx, id, func(order *model.Order) error {
+		return order.Transition(to, actor, reason, now)
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+
+	switch to {
+	case model.OrderConfirmed:
+		for _, line := range order.Lines {
+			_, err := workflow.inventory.UpdateReservation(ctx, line.ReservationID, func(reservation *model.Reservation) error {
+				if reservation.Status != model.ReservationActive || reservation.Overdue(now) {
+					return model.ErrReservationClosed
+				}
+				reservation.ExpiresAt = time.Time{}
+				reservation.UpdatedAt = now
+				return nil
+			})
+			if err != nil {
+				return nil, domainError(err)
+			}
+		}
+	case model.OrderOutForDelivery:
+		for _, line := range order.Lines {
+			if _, err := closeReservation(ctx, workflow.inventory, actor.ID, line.ReservationID, model.ReservationCommitted, now); err != nil {
+				return nil, err
+			}
+		}
+	case model.OrderCancelled:
+		for _, line := range order.Lines {
+			reservation, err := workflow.inventory.FindReservation(line.ReservationID)
+			if err != nil || reservation.Status != model.ReservationActive {
+				continue // Already expired and given back
+			}
+			if _, err := closeReservation(ctx, workflow.inventory, actor.ID, line.ReservationID, model.ReservationReleased, now); err != nil {
+				return nil, err
+			}
+		}
+		_, err := workflow.slots.Update(ctx, order.SlotID, func(slot *model.DeliverySlot) error {
+			slot.Release(order.Weight, now)
+			return nil
+		})
+		if err != nil && err != core.Error.NotFound.DeliverySlot {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+var OrderServiceModule = fx.Options(fx.Provide(NewOrderService))
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type PaymentService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Pay(userID string, orderID string, clientIP string) (*model.Payment, error)
+	FindByOrder(userID string, orderID string) ([]model.Payment, error)
+	HandleCallback(params url.Values) (*model.Payment, error)
+	ConfirmTransfer(actor model.OrderActor, id string, request dto.ConfirmTransferRequest) (*model.Payment, error)
+}
+
+type paymentService struct {
+	repo       repository.PaymentRepository
+	orderRepo  repository.OrderRepository
+	workflow   *orderWorkflow
+	providers  infra_interface.PaymentProviders
+	transactor infra_interface.Transactor
+}
+
+func NewPaymentService(
+	repo repository.PaymentRepository,
+	orderRepo repository.OrderRepository,
+	inventoryRepo repository.InventoryRepository,
+	slotRepo repository.DeliverySlotRepository,
+	providers infra_interface.PaymentProviders,
+	transactor infra_interface.Transactor,
+) PaymentService {
+	return &paymentService{
+		repo:       repo,
+		orderRepo:  orderRepo,
+		workflow:   &orderWorkflow{orders: orderRepo, inventory: inventoryRepo, slots: slotRepo},
+		providers:  providers,
+		transactor: transactor,
+	}
+}
+
+// Pay starts a payment of the user's order with the provider of its payment method: a gateway page to redirect
+// the customer to, or instructions for paying themselves. Orders paid online can be paid while they await payment;
+// cash on delivery while the order is on its way.
+func (service *paymentService) Pay(userID string, orderID string, clientIP string) (*model.Payment, error) {
+	order, err := service.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, core.Error.NotFound.Order
+	}
+	if order.Status != model.OrderPendingPayment && !(order.PaymentMethod.PaidOnDelivery() && !order.Status.Final()) {
+		return nil, core.Error.Conflict.OrderStatus
+	}
+	provider, ok := service.providers[string(order.PaymentMethod)]
+	if !ok {
+		return nil, core.Error.Conflict.PaymentMethod
+	}
+
+	now := time.Now()
+	payment := model.Payment{
+		ID:        uuid.NewString(),
+		OrderID:   order.ID,
+		UserID:    userID,
+		Method:    order.PaymentMethod,
+		Amount:    order.Total,
+		Status:    model.PaymentPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// Stored before the provider hears of it, so a callback arriving at once finds the payment it settles
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.repo.Create(ctx, payment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := provider.Create(infra_interface.PaymentIntent{
+		Reference:   payment.ID,
+		Method:      string(payment.Method),
+		Amount:      int64(payment.Amount),
+		Description: order.Number,
+		ClientIP:    clientIP,
+		ExpiresAt:   order.PaymentDueAt,
+	})
+	if err != nil {
+		zap.L().Warn("Payment provider failed to create a payment", zap.String("provider", provider.Name()), zap.Error(err))
+		_ = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+			_, err := service.repo.Update(ctx, payment.ID, func(payment *model.Payment) error {
+				_, err := payment.Settle(model.SystemActor, "", 0, false, "", time.Now())
+				return err
+			})
+			return err
+		})
+		return nil, core.Error.Unavailable.PaymentGateway
+	}
+
+	var started *model.Payment
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, payment.ID, func(payment *model.Payment) error {
+			payment.RedirectURL = session.RedirectURL
+			payment.Instructions = session.Instructions
+			if payment.TransactionID == "" {
+				payment.TransactionID = session.TransactionID
+			}
+			return nil
+		})
+		started = updated
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return started, nil
+}
+
+// FindByOrder lists the payment attempts of the user's order, the earliest first
+func (service *paymentService) FindByOrder(userID string, orderID string) ([]model.Payment, error) {
+	order, err := service.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, core.Error.NotFound.Order
+	}
+	return service.repo.FindAll(func(payment model.Payment) bool {
+		return payment.OrderID == orderID
+	}), nil
+}
+
+// HandleCallback applies a result a provider sent to the return URL or IPN endpoint once its signature checks out.
+// Providers retry their notifications and the customer's browser may come back too: a payment already settled is
+// returned as it is.
+func (service *paymentService) HandleCallback(params url.Values) (*model.Payment, error) {
+	result, err := service.verify(params)
+	if err != nil {
+		return nil, err
+	}
+	return service.settle(model.SystemOrderActor, result.Reference, result.TransactionID, model.Money(result.Amount), result.Success, result.Code)
+}
+
+// ConfirmTransfer records a bank transfer staff found on the statement as paid
+func (service *paymentService) ConfirmTransfer(actor model.OrderActor, id string, request dto.ConfirmTransferRequest) (*model.Payment, error) {
+	payment, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Method != model.PaymentBankTransfer {
+		return nil, core.Error.Conflict.PaymentMethod
+	}
+	return service.settle(actor, id, request.TransactionID, payment.Amount, true, "")
+}
+
+// verify asks each provider in turn to check the callback; only the one that signed it accepts it
+func (service *paymentService) verify(params url.Values) (*infra_interface.PaymentResult, error) {
+	asked := make(map[infra_interface.PaymentProvider]bool)
+	for _, method := range model.PaymentMethods {
+		provider, ok := service.providers[string(method)]
+		if !ok || asked[provider] {
+			continue
+		}
+		asked[provider] = true
+		if result, err := provider.Verify(params); err == nil {
+			return result, nil
+		}
+	}
+	return nil, core.Error.Invalid.PaymentSignature
+}
+
+// settle records the payment's outcome and, when the payment settled its order, confirms the order in the same
+// transaction. A payment arriving for an order that can no longer be confirmed, e.g. one cancelled in the meantime,
+// is kept as paid and marked as due a refund for staff to pay back.
+func (service *paymentService) settle(actor model.OrderActor, id string, transactionID string, amount model.Money, success bool, code string) (*model.Payment, error) {
+	var payment *model.Payment
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		current, err := service.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		order, err := service.orderRepo.FindByID(current.OrderID)
+		confirm := err == nil && service.workflow.confirmable(order, now)
+
+		settled := false
+		payment, err = service.repo.Update(ctx, id, func(payment *model.Payment) error {
+			var err error
+			if settled, err = payment.Settle(actor.ID, transactionID, amount, success, code, now); err != nil {
+				return err
+			}
+			if settled && payment.Status == model.PaymentPaid && !confirm && !payment.Method.PaidOnDelivery() {
+				payment.RefundDue = true
+			}
+			return nil
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		if !settled || payment.Status != model.PaymentPaid || !confirm {
+			return nil
+		}
+		_, err = service.workflow.move(ctx, order.ID, model.OrderConfirmed, actor, "", now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if payment.RefundDue {
+		zap.L().Warn("Payment received for an order that cannot be confirmed; it needs a refund",
+			zap.String("payment_id", payment.ID), zap.String("order_id", payment.OrderID))
+	}
+	return payment, nil
+}
+
+func (service *paymentService) Name() string { return "PaymentService" }
+func (service *paymentService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *paymentService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var PaymentServiceModule = fx.Options(fx.Provide(NewPaymentService))
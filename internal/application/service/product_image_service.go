@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// productImagePrefix starts the storage keys of product images, the only files served publicly
+const productImagePrefix = "products/"
+
+type ProductImageService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Upload(productID string, file *multipart.FileHeader) (*model.ProductImage, error)
+	Delete(productID string, imageID string) error
+	FindByProduct(productID string) ([]model.ProductImage, error)
+	Media(key string) ([]byte, string, error)
+}
+
+type productImageService struct {
+	productRepo repository.ProductRepository
+	imageRepo   repository.ProductImageRepository
+	storage     infra_interface.FileStorage
+	processor   infra_interface.ImageProcessor
+	worker      infra_interface.BackgroundWorker
+	transactor  infra_interface.Transactor
+}
+
+func NewProductImageService(
+	productRepo repository.ProductRepository,
+	imageRepo repository.ProductImageRepository,
+	storage infra_interface.FileStorage,
+	processor infra_interface.ImageProcessor,
+	worker infra_interface.BackgroundWorker,
+	transactor infra_interface.Transactor,
+) ProductImageService {
+	return &productImageService{
+		productRepo: productRepo,
+		imageRepo:   imageRepo,
+		storage:     storage,
+		processor:   processor,
+		worker:      worker,
+		transactor:  transactor,
+	}
+}
+
+func (service *productImageService) Upload(productID string, file *multipart.FileHeader) (*model.ProductImage, error) {
+	if _, err := service.productRepo.FindByID(productID); err != nil {
+		return nil, err
+	}
+
+	content, err := readLimited(file, core.Configs.Upload.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := service.processor.Inspect(content)
+	if err != nil || !isAllowedImageType(info.ContentType) {
+		return nil, core.Error.Invalid.ImageType
+	}
+
+	image := model.ProductImage{
+		ID:        uuid.NewString(),
+		ProductID: productID,
+		Status:    model.ImageProcessing,
+		CreatedAt: time.Now(),
+	}
+	image.Original = model.ImageVariant{
+		Name:        "original",
+		Key:         imageKey(image, "original", info.ContentType),
+		ContentType: info.ContentType,
+		Width:       info.Width,
+		Height:      info.Height,
+		Size:        len(content),
+	}
+	image.Original.URL = service.storage.URL(image.Original.Key)
+
+	if err := service.storage.Put(image.Original.Key, info.ContentType, content); err != nil {
+		zap.L().Error("Failed to store original image", zap.String("product_id", productID), zap.Error(err))
+		return nil, err
+	}
+	_ = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.imageRepo.Save(ctx, image)
+		return nil
+	})
+
+	// Thumbnails are generated in the background; run inline if the queue is saturated
+	task := func() error { return service.generateVariants(image.ID) }
+	if !service.worker.Enqueue("product-image-variants", task) {
+		if err := task(); err != nil {
+			zap.L().Warn("Failed to generate image variants", zap.String("image_id", image.ID), zap.Error(err))
+		}
+	}
+
+	return service.imageRepo.FindByID(image.ID)
+}
+
+func (service *productImageService) Delete(productID string, imageID string) error {
+	image, err := service.imageRepo.FindByID(imageID)
+	if err != nil {
+		return err
+	}
+	if image.ProductID != productID {
+		return core.Error.NotFound.Image
+	}
+
+	for _, variant := range append([]model.ImageVariant{image.Original}, image.Variants...) {
+		if err := service.storage.Delete(variant.Key); err != nil {
+			zap.L().Warn("Failed to delete stored image", zap.String("key", variant.Key), zap.Error(err))
+		}
+	}
+	return service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.imageRepo.Delete(ctx, imageID)
+	})
+}
+
+func (service *productImageService) FindByProduct(productID string) ([]model.ProductImage, error) {
+	if _, err := service.productRepo.FindByID(productID); err != nil {
+		return nil, err
+	}
+	return service.imageRepo.FindByProduct(productID), nil
+}
+
+// Media serves product images to anyone. Claim photos, proofs of delivery and review photos are kept in the same
+// storage but only served through their own endpoints, to those allowed to see them.
+func (service *productImageService) Media(key string) ([]byte, string, error) {
+	if !strings.HasPrefix(key, productImagePrefix) || strings.Contains(key, "..") {
+		return nil, "", core.Error.NotFound.Image
+	}
+	content, contentType, err := service.storage.Get(key)
+	if err != nil {
+		return nil, "", core.Error.NotFound.Image
+	}
+	return content, contentType, nil
+}
+
+// generateVariants resizes the original image into every model.ImageVariantSizes and marks the image ready
+func (service *productImageService) generateVariants(imageID string) error {
+	image, err := service.imageRepo.FindByID(imageID)
+	if err != nil {
+		return err
+	}
+
+	variants, resizeErr := service.resizeAll(image)
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		_, err := service.imageRepo.Update(ctx, imageID, func(image *model.ProductImage) {
+			if resizeErr != nil {
+				image.Status = model.ImageFailed
+				return
+			}
+			image.Variants = variants
+			image.Status = model.ImageReady
+		})
+		return err
+	})
+	if resizeErr != nil {
+		return resizeErr
+	}
+	return err
+}
+
+func (service *productImageService) resizeAll(image *model.ProductImage) ([]model.ImageVariant, error) {
+	original, _, err := service.storage.Get(image.Original.Key)
+	if err != nil {
+		return nil, fmt.Errorf("read original image: %w", err)
+	}
+
+	variants := make([]model.ImageVariant, 0, len(model.ImageVariantSizes))
+	for _, size := range model.ImageVariantSizes {
+		resized, info, err := service.processor.Resize(original, size.MaxSide)
+		if err != nil {
+			return nil, fmt.Errorf("resize to %s: %w", size.Name, err)
+		}
+
+		key := imageKey(*image, size.Name, info.ContentType)
+		if err := service.storage.Put(key, info.ContentType, resized); err != nil {
+			return nil, fmt.Errorf("store %s: %w", size.Name, err)
+		}
+		variants = append(variants, model.ImageVariant{
+			Name:        size.Name,
+			Key:         key,
+			URL:         service.storage.URL(key),
+			ContentType: info.ContentType,
+			Width:       info.Width,
+			Height:      info.Height,
+			Size:        len(resized),
+		})
+	}
+	return variants, nil
+}
+
+func (service *productImageService) Name() string { return "ProductImageService" }
+func (service *productImageService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *productImageService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// readLimited reads the uploaded file but never more than maxSize+1 bytes, whatever size the client claims
+func readLimited(file *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	if maxSize > 0 && file.Size > maxSize {
+		return nil, core.Error.Invalid.ImageTooLarge
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, core.Error.Invalid.Request
+	}
+	defer reader.Close()
+
+	var limited io.Reader = reader
+	if maxSize > 0 {
+		limited = io.LimitReader(reader, maxSize+1)
+	}
+	content, err := io.ReadAll(limited)
+	if err != nil {
+		return nil, core.Error.Invalid.Request
+	}
+	if maxSize > 0 && int64(len(content)) > maxSize {
+		return nil, core.Error.Invalid.ImageTooLarge
+	}
+	if len(content) == 0 {
+		return nil, core.Error.Invalid.ImageType
+	}
+	return content, nil
+}
+
+func isAllowedImageType(contentType string) bool {
+	allowed := core.Configs.Upload.AllowedImageTypes
+	return len(allowed) == 0 || slices.Contains(allowed, contentType)
+}
+
+func imageKey(image model.ProductImage, variant string, contentType string) string {
+	return fmt.Sprintf(productImagePrefix+"%s/%s/%s%s", image.ProductID, image.ID, variant, imageExtension(contentType))
+}
+
+// apiURL links to a path of the API, for files that are only served to those allowed to see them
+func apiURL(path ...string) string {
+	return strings.TrimRight(core.Configs.Server.PublicURL, "/") + "/" + strings.Join(path, "/")
+}
+
+func imageExtension(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".jpg"
+	}
+}
+
+var ProductImageServiceModule = fx.Options(fx.Provide(NewProductImageService))
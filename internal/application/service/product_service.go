@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+type ProductService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.ProductRequest) (*model.Product, error)
+	Update(id string, request dto.ProductRequest) (*model.Product, error)
+	FindById(id string) (*model.Product, error)
+	FindAll(query dto.ProductQuery) dto.Page[model.Product]
+	FindInSeason(query dto.SeasonalQuery) dto.Page[model.Product]
+	Quote(id string, request dto.PriceQuoteRequest) (*dto.PriceQuoteResponse, error)
+	Units() []model.UnitOfMeasure
+}
+
+type productService struct {
+	repo         repository.ProductRepository
+	imageRepo    repository.ProductImageRepository
+	categoryRepo repository.CategoryRepository
+	transactor   infra_interface.Transactor
+}
+
+func NewProductService(
+	repo repository.ProductRepository,
+	imageRepo repository.ProductImageRepository,
+	categoryRepo repository.CategoryRepository,
+	transactor infra_interface.Transactor,
+) ProductService {
+	return &productService{repo: repo, imageRepo: imageRepo, categoryRepo: categoryRepo, transactor: transactor}
+}
+
+func (service *productService) Create(request dto.ProductRequest) (*model.Product, error) {
+	now := time.Now()
+	product := model.Product{
+		ID:        uuid.NewString(),
+		Active:    true,
+		CreatedAt: now,
+	}
+	if err := applyProductRequest(service.categoryRepo, &product, request, now); err != nil {
+		return nil, err
+	}
+
+	// In a transaction so the SKU check and the write are not interleaved with another writer's
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Create(ctx, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.load(&product)
+	return &product, nil
+}
+
+// Update applies the request to the product as stored when the transaction runs, so fields it does not cover, such
+// as the rating reviews keep up to date, are not overwritten with what was read before
+func (service *productService) Update(id string, request dto.ProductRequest) (*model.Product, error) {
+	var updated *model.Product
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		product, err := service.repo.Update(ctx, id, func(product *model.Product) error {
+			return applyProductRequest(service.categoryRepo, product, request, time.Now())
+		})
+		updated = product
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.load(updated)
+	return updated, nil
+}
+
+func (service *productService) FindById(id string) (*model.Product, error) {
+	product, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	service.load(product)
+	return product, nil
+}
+
+func (service *productService) FindAll(query dto.ProductQuery) dto.Page[model.Product] {
+	month := util.StoreNow().Month()
+	today := util.StoreToday()
+	search := util.FoldText(strings.TrimSpace(query.Search))
+	products := make([]model.Product, 0)
+	for _, product := range service.repo.FindAll() {
+		if !product.Active && !query.IncludeInactive {
+			continue
+		}
+		if query.InSeasonOnly && !product.InSeason(month) {
+			continue
+		}
+		if search != "" && !matchesSearch(&product, search) {
+			continue
+		}
+		if len(query.Certifications) > 0 && !slices.ContainsFunc(query.Certifications, func(scheme string) bool {
+			return product.CertifiedFor(model.CertificationScheme(scheme), today)
+		}) {
+			continue
+		}
+		if slices.ContainsFunc(product.Allergens, func(allergen model.Allergen) bool {
+			return slices.Contains(query.ExcludeAllergens, string(allergen))
+		}) {
+			continue
+		}
+		products = append(products, product)
+	}
+	return service.paginate(products, query.PageRequest)
+}
+
+func (service *productService) FindInSeason(query dto.SeasonalQuery) dto.Page[model.Product] {
+	month := util.StoreNow().Month()
+	if query.Month != 0 {
+		month = time.Month(query.Month)
+	}
+
+	products := make([]model.Product, 0)
+	for _, product := range service.repo.FindAll() {
+		if !product.Active {
+			continue
+		}
+		inSeason := product.InSeason(month)
+		if query.Province != "" {
+			inSeason = product.InSeasonFrom(month, query.Province)
+		}
+		if inSeason {
+			products = append(products, product)
+		}
+	}
+	return service.paginate(products, query.PageRequest)
+}
+
+func (service *productService) Quote(id string, request dto.PriceQuoteRequest) (*dto.PriceQuoteResponse, error) {
+	product, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := model.ParseQuantity(request.Quantity.Value, model.UnitCode(request.Quantity.Unit))
+	if err != nil {
+		return nil, domainError(err)
+	}
+	if err := product.SaleRule.Check(quantity); err != nil {
+		return nil, domainError(err)
+	}
+	total, err := product.LineTotal(quantity)
+	if err != nil {
+		return nil, domainError(err)
+	}
+
+	return &dto.PriceQuoteResponse{
+		ProductID: product.ID,
+		Quantity:  dto.ToQuantityDto(quantity),
+		Price:     int64(product.Price),
+		PriceUnit: string(product.PriceUnit),
+		Total:     int64(total),
+	}, nil
+}
+
+func (service *productService) Units() []model.UnitOfMeasure {
+	return model.Units()
+}
+
+func (service *productService) Name() string { return "ProductService" }
+func (service *productService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *productService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// matchesSearch compares the folded search text with the product's SKU and content in every language
+func matchesSearch(product *model.Product, search string) bool {
+	texts := []string{product.SKU, product.Name, product.Description}
+	for _, translation := range product.Translations {
+		texts = append(texts, translation.Name, translation.Description)
+	}
+	return slices.ContainsFunc(texts, func(text string) bool {
+		return strings.Contains(util.FoldText(text), search)
+	})
+}
+
+func (service *productService) paginate(products []model.Product, request dto.PageRequest) dto.Page[model.Product] {
+	page := dto.Paginate(products, request)
+	for i := range page.Items {
+		service.load(&page.Items[i])
+	}
+	return page
+}
+
+// load attaches the associations that are not stored with the product row
+func (service *productService) load(product *model.Product) {
+	product.Images = service.imageRepo.FindByProduct(product.ID)
+	if product.CategoryID != "" {
+		product.Category, _ = service.categoryRepo.FindByID(product.CategoryID)
+	}
+}
+
+// applyProductRequest copies request fields onto product, converting decimal quantities into base units.
+// It is shared by the product endpoints and the catalog import so both enforce the same rules.
+func applyProductRequest(categoryRepo repository.CategoryRepository, product *model.Product, request dto.ProductRequest, now time.Time) error {
+	saleRule, err := parseSaleRule(request.SaleRule)
+	if err != nil {
+		return err
+	}
+	if err := validateTranslations(request.Translations); err != nil {
+		return err
+	}
+	if request.CategoryID != "" {
+		if _, err := categoryRepo.FindByID(request.CategoryID); err != nil {
+			return core.Error.Invalid.Category
+		}
+	}
+
+	product.SKU = request.SKU
+	product.Name = request.Name
+	product.Description = request.Description
+	product.CategoryID = request.CategoryID
+	product.Translations = dto.ToTranslations(request.Translations)
+	product.Origins = request.Origins
+	product.Seasons = dto.ToSeasonWindows(request.Seasons)
+	product.Nutrition = model.NutritionFacts{}
+	for nutrient, amount := range request.Nutrition {
+		product.Nutrition[model.Nutrient(nutrient)] = amount
+	}
+	product.Allergens = make([]model.Allergen, 0, len(request.Allergens))
+	for _, allergen := range request.Allergens {
+		product.Allergens = append(product.Allergens, model.Allergen(allergen))
+	}
+	product.Certifications = make([]model.Certification, 0, len(request.Certifications))
+	for _, certification := range request.Certifications {
+		validUntil := time.Time{}
+		if certification.ValidUntil != "" {
+			date, err := util.ParseStoreDate(certification.ValidUntil)
+			if err != nil {
+				return core.Error.Invalid.Date
+			}
+			validUntil = date
+		}
+		product.Certifications = append(product.Certifications, model.Certification{
+			Scheme:      model.CertificationScheme(certification.Scheme),
+			Reference:   certification.Reference,
+			Issuer:      certification.Issuer,
+			ValidUntil:  validUntil,
+			DocumentURL: certification.DocumentURL,
+		})
+	}
+	product.Price = model.Money(request.Price)
+	product.PriceUnit = model.UnitCode(request.PriceUnit)
+	product.SaleRule = saleRule
+	product.UnitWeight = request.UnitWeight
+	product.UpdatedAt = now
+	if request.Active != nil {
+		product.Active = *request.Active
+	}
+
+	if err := product.Validate(); err != nil {
+		return domainError(err)
+	}
+	return nil
+}
+
+func parseSaleRule(request dto.SaleRuleDto) (model.SaleRule, error) {
+	unit := model.UnitCode(request.Unit)
+	minQuantity, err := model.ParseQuantity(request.MinQuantity, unit)
+	if err != nil {
+		return model.SaleRule{}, domainError(err)
+	}
+	step, err := model.ParseQuantity(request.Step, unit)
+	if err != nil {
+		return model.SaleRule{}, domainError(err)
+	}
+
+	rule := model.SaleRule{Unit: unit, MinQuantity: minQuantity.Base, Step: step.Base}
+	if request.MaxQuantity != "" {
+		maxQuantity, err := model.ParseQuantity(request.MaxQuantity, unit)
+		if err != nil {
+			return model.SaleRule{}, domainError(err)
+		}
+		rule.MaxQuantity = maxQuantity.Base
+	}
+	return rule, nil
+}
+
+// domainError maps errors raised by the domain model to their API error.
+func domainError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnknownUnit), errors.Is(err, model.ErrIncompatibleUnits):
+		return core.Error.Invalid.Unit
+	case errors.Is(err, model.ErrQuantityBelowMinimum):
+		return core.Error.Invalid.QuantityBelowMinimum
+	case errors.Is(err, model.ErrQuantityAboveMaximum):
+		return core.Error.Invalid.QuantityAboveMaximum
+	case errors.Is(err, model.ErrQuantityNotMultipleOfStep):
+		return core.Error.Invalid.QuantityStep
+	case errors.Is(err, model.ErrInvalidQuantity):
+		return core.Error.Invalid.Quantity
+	case errors.Is(err, model.ErrInvalidPrice):
+		return core.Error.Invalid.Price
+	case errors.Is(err, model.ErrInvalidSeason):
+		return core.Error.Invalid.Season
+	case errors.Is(err, model.ErrInvalidRegion):
+		return core.Error.Invalid.Region
+	case errors.Is(err, model.ErrUnknownNutrient), errors.Is(err, model.ErrInvalidNutrient):
+		return core.Error.Invalid.Nutrient
+	case errors.Is(err, model.ErrUnknownAllergen):
+		return core.Error.Invalid.Allergen
+	case errors.Is(err, model.ErrUnknownCertification):
+		return core.Error.Invalid.Certification
+	case errors.Is(err, model.ErrInvalidLotDates):
+		return core.Error.Invalid.LotDates
+	case errors.Is(err, model.ErrInsufficientStock):
+		return core.Error.Conflict.InsufficientStock
+	case errors.Is(err, model.ErrReservationClosed):
+		return core.Error.Conflict.ReservationClosed
+	case errors.Is(err, model.ErrTransferClosed):
+		return core.Error.Conflict.TransferClosed
+	case errors.Is(err, model.ErrStocktakeClosed):
+		return core.Error.Conflict.StocktakeClosed
+	case errors.Is(err, model.ErrPurchaseOrderStatus):
+		return core.Error.Conflict.PurchaseOrderStatus
+	case errors.Is(err, model.ErrInvalidReceipt):
+		return core.Error.Invalid.GoodsReceipt
+	case errors.Is(err, model.ErrInvalidReorderRule):
+		return core.Error.Invalid.ReorderRule
+	case errors.Is(err, model.ErrInvalidDeliverySlot):
+		return core.Error.Invalid.DeliverySlot
+	case errors.Is(err, model.ErrSlotFull):
+		return core.Error.Conflict.SlotFull
+	case errors.Is(err, model.ErrSlotClosed):
+		return core.Error.Conflict.SlotClosed
+	case errors.Is(err, model.ErrSlotZone):
+		return core.Error.Conflict.SlotZone
+	case errors.Is(err, model.ErrSlotBooked):
+		return core.Error.Conflict.SlotBooked
+	case errors.Is(err, model.ErrInvalidSlotTemplate):
+		return core.Error.Invalid.SlotTemplate
+	case errors.Is(err, model.ErrInvalidDeliveryZone):
+		return core.Error.Invalid.DeliveryZone
+	case errors.Is(err, model.ErrInvalidDeliveryFee):
+		return core.Error.Invalid.DeliveryFee
+	case errors.Is(err, model.ErrOrderStatus):
+		return core.Error.Conflict.OrderStatus
+	case errors.Is(err, model.ErrOrderTransitionActor):
+		return core.Error.Auth.OrderTransition
+	case errors.Is(err, model.ErrReasonRequired):
+		return core.Error.Invalid.Reason
+	case errors.Is(err, model.ErrPaymentAmount):
+		return core.Error.Conflict.PaymentAmount
+	case errors.Is(err, model.ErrRefundAmount):
+		return core.Error.Invalid.RefundAmount
+	case errors.Is(err, model.ErrClaimStatus):
+		return core.Error.Conflict.ClaimStatus
+	case errors.Is(err, model.ErrClaimLines):
+		return core.Error.Invalid.ClaimLines
+	case errors.Is(err, model.ErrClaimDisposition):
+		return core.Error.Invalid.ClaimDisposition
+	case errors.Is(err, model.ErrTooManyClaimPhotos):
+		return core.Error.Invalid.ClaimPhotos
+	case errors.Is(err, model.ErrInvalidShipper):
+		return core.Error.Invalid.Shipper
+	case errors.Is(err, model.ErrDeliveryStatus):
+		return core.Error.Conflict.DeliveryStatus
+	case errors.Is(err, model.ErrProofRequired):
+		return core.Error.Conflict.ProofRequired
+	case errors.Is(err, model.ErrTooManyDeliveryProofs):
+		return core.Error.Invalid.DeliveryProofs
+	case errors.Is(err, model.ErrCashRemitted):
+		return core.Error.Conflict.CashRemitted
+	case errors.Is(err, model.ErrClaimWindow):
+		return core.Error.Conflict.ClaimWindow
+	case errors.Is(err, model.ErrInvalidSubscriptionPlan):
+		return core.Error.Invalid.SubscriptionPlan
+	case errors.Is(err, model.ErrSubscriptionStatus):
+		return core.Error.Conflict.SubscriptionStatus
+	case errors.Is(err, model.ErrSubscriptionDate):
+		return core.Error.Invalid.Date
+	case errors.Is(err, model.ErrInvalidPromotion):
+		return core.Error.Invalid.Promotion
+	case errors.Is(err, model.ErrInsufficientPoints):
+		return core.Error.Conflict.InsufficientPoints
+	case errors.Is(err, model.ErrRedeemLimit):
+		return core.Error.Invalid.RedeemPoints
+	default:
+		return err
+	}
+}
+
+var ProductServiceModule = fx.Options(fx.Provide(NewProductService))
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+type PromotionService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.PromotionRequest) (*model.Promotion, error)
+	Update(id string, request dto.PromotionRequest) (*model.Promotion, error)
+	FindAll(query dto.PromotionQuery) dto.Page[model.Promotion]
+	FindByID(id string) (*model.Promotion, error)
+	FindCurrent() []model.Promotion
+	FindByCode(code string) (*model.Promotion, error)
+	Apply(userID string, lines []model.PromotionLine, deliveryFee model.Money, code string, now time.Time) model.PromotionResult
+}
+
+type promotionService struct {
+	repo             repository.PromotionRepository
+	orderRepo        repository.OrderRepository
+	subscriptionRepo repository.SubscriptionRepository
+	transactor       infra_interface.Transactor
+}
+
+func NewPromotionService(
+	repo repository.PromotionRepository,
+	orderRepo repository.OrderRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	transactor infra_interface.Transactor,
+) PromotionService {
+	return &promotionService{
+		repo:             repo,
+		orderRepo:        orderRepo,
+		subscriptionRepo: subscriptionRepo,
+		transactor:       transactor,
+	}
+}
+
+func (service *promotionService) Create(request dto.PromotionRequest) (*model.Promotion, error) {
+	now := time.Now()
+	promotion := model.Promotion{ID: uuid.NewString(), StartsAt: now, Active: true, CreatedAt: now}
+	if err := applyPromotionRequest(&promotion, request, now); err != nil {
+		return nil, err
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		if err := service.checkCode(promotion); err != nil {
+			return err
+		}
+		service.repo.Create(ctx, promotion)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// Update changes the promotion for baskets priced from now on; orders keep the discounts they were placed with
+func (service *promotionService) Update(id string, request dto.PromotionRequest) (*model.Promotion, error) {
+	var promotion *model.Promotion
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := applyPromotionRequest(updated, request, time.Now()); err != nil {
+			return err
+		}
+		if err := service.checkCode(*updated); err != nil {
+			return err
+		}
+		promotion, err = service.repo.Update(ctx, id, func(existing *model.Promotion) error {
+			*existing = *updated
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (service *promotionService) FindAll(query dto.PromotionQuery) dto.Page[model.Promotion] {
+	code := model.NormalizePromotionCode(query.Code)
+	promotions := service.repo.FindAll(func(promotion model.Promotion) bool {
+		return (query.Active == "" || fmt.Sprint(promotion.Active) == query.Active) &&
+			(code == "" || promotion.Code == code)
+	})
+	return dto.Paginate(promotions, query.PageRequest)
+}
+
+func (service *promotionService) FindByID(id string) (*model.Promotion, error) {
+	return service.repo.FindByID(id)
+}
+
+// FindCurrent lists the promotions running now that apply without a code, for the storefront to advertise
+func (service *promotionService) FindCurrent() []model.Promotion {
+	now := time.Now()
+	return service.repo.FindAll(func(promotion model.Promotion) bool {
+		return promotion.Active && promotion.Code == "" && !now.Before(promotion.StartsAt) &&
+			(promotion.EndsAt.IsZero() || now.Before(promotion.EndsAt))
+	})
+}
+
+// FindByCode finds the active promotion a customer enters the code of
+func (service *promotionService) FindByCode(code string) (*model.Promotion, error) {
+	promotion, err := service.repo.FindByCode(model.NormalizePromotionCode(code))
+	if err != nil || !promotion.Active {
+		return nil, core.Error.NotFound.Promotion
+	}
+	return promotion, nil
+}
+
+// Apply prices the lines with the active promotions for the user, a guest when empty. Orders that were not cancelled
+// count towards first orders and usage limits, so cancelling an order gives its promotions back.
+func (service *promotionService) Apply(userID string, lines []model.PromotionLine, deliveryFee model.Money, code string, now time.Time) model.PromotionResult {
+	basket := model.PromotionBasket{
+		Lines:       lines,
+		DeliveryFee: deliveryFee,
+		Code:        code,
+		Customer:    model.PromotionCustomer{UserID: userID, Uses: make(map[string]int)},
+		Redeemed:    make(map[string]int),
+		At:          now,
+	}
+	for _, order := range service.orderRepo.FindAll(func(order model.Order) bool { return order.Status != model.OrderCancelled }) {
+		mine := userID != "" && order.UserID == userID
+		if mine {
+			basket.Customer.Orders++
+		}
+		for _, applied := range order.Promotions {
+			basket.Redeemed[applied.PromotionID]++
+			if mine {
+				basket.Customer.Uses[applied.PromotionID]++
+			}
+		}
+	}
+
+	basket.Customer.Segments = []model.CustomerSegment{model.SegmentNew}
+	if basket.Customer.Orders > 0 {
+		basket.Customer.Segments = []model.CustomerSegment{model.SegmentReturning}
+	}
+	if userID != "" && len(service.subscriptionRepo.FindAll(func(subscription model.Subscription) bool {
+		return subscription.UserID == userID && subscription.Status != model.SubscriptionCancelled
+	})) > 0 {
+		basket.Customer.Segments = append(basket.Customer.Segments, model.SegmentSubscriber)
+	}
+
+	promotions := service.repo.FindAll(func(promotion model.Promotion) bool { return promotion.Active })
+	return model.ApplyPromotions(promotions, basket)
+}
+
+// checkCode keeps promotion codes unique
+func (service *promotionService) checkCode(promotion model.Promotion) error {
+	if promotion.Code == "" {
+		return nil
+	}
+	if existing, err := service.repo.FindByCode(promotion.Code); err == nil && existing.ID != promotion.ID {
+		return core.Error.Conflict.PromotionCode
+	}
+	return nil
+}
+
+func applyPromotionRequest(promotion *model.Promotion, request dto.PromotionRequest, now time.Time) error {
+	promotion.Name = strings.TrimSpace(request.Name)
+	promotion.Description = strings.TrimSpace(request.Description)
+	promotion.Code = model.NormalizePromotionCode(request.Code)
+	promotion.Kind = model.PromotionKind(request.Kind)
+	promotion.Percent = request.Percent
+	promotion.Amount = model.Money(request.Amount)
+	promotion.MaxDiscount = model.Money(request.MaxDiscount)
+	promotion.Buy = request.Buy
+	promotion.Get = request.Get
+	promotion.ProductIDs = request.ProductIDs
+	promotion.CategoryIDs = request.CategoryIDs
+	promotion.MinSubtotal = model.Money(request.MinSubtotal)
+	promotion.Segments = make([]model.CustomerSegment, 0, len(request.Segments))
+	for _, segment := range request.Segments {
+		promotion.Segments = append(promotion.Segments, model.CustomerSegment(segment))
+	}
+	if request.StartsAt != nil {
+		promotion.StartsAt = *request.StartsAt
+	}
+	promotion.EndsAt = time.Time{}
+	if request.EndsAt != nil {
+		promotion.EndsAt = *request.EndsAt
+	}
+	promotion.FirstOrderOnly = request.FirstOrderOnly
+	promotion.PerUserLimit = request.PerUserLimit
+	promotion.UsageLimit = request.UsageLimit
+	promotion.Priority = request.Priority
+	promotion.Stackable = request.Stackable
+	if request.Active != nil {
+		promotion.Active = *request.Active
+	}
+	promotion.UpdatedAt = now
+	return domainError(promotion.Validate())
+}
+
+func (service *promotionService) Name() string { return "PromotionService" }
+func (service *promotionService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *promotionService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var PromotionServiceModule = fx.Options(fx.Provide(NewPromotionService))
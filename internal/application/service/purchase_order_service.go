@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type PurchaseOrderService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(actorID string, request dto.PurchaseOrderRequest) (*model.PurchaseOrder, error)
+	Update(id string, request dto.PurchaseOrderRequest) (*model.PurchaseOrder, error)
+	FindById(id string) (*model.PurchaseOrder, error)
+	FindAll(query dto.PurchaseOrderQuery) dto.Page[model.PurchaseOrder]
+	Send(id string) (*model.PurchaseOrder, error)
+	Receive(actorID string, id string, request dto.GoodsReceiptRequest) (*model.PurchaseOrder, error)
+	Close(id string) (*model.PurchaseOrder, error)
+}
+
+type purchaseOrderService struct {
+	repo          repository.PurchaseOrderRepository
+	supplierRepo  repository.SupplierRepository
+	productRepo   repository.ProductRepository
+	locationRepo  repository.LocationRepository
+	lotRepo       repository.StockLotRepository
+	inventoryRepo repository.InventoryRepository
+	transactor    infra_interface.Transactor
+}
+
+func NewPurchaseOrderService(
+	repo repository.PurchaseOrderRepository,
+	supplierRepo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	locationRepo repository.LocationRepository,
+	lotRepo repository.StockLotRepository,
+	inventoryRepo repository.InventoryRepository,
+	transactor infra_interface.Transactor,
+) PurchaseOrderService {
+	return &purchaseOrderService{
+		repo:          repo,
+		supplierRepo:  supplierRepo,
+		productRepo:   productRepo,
+		locationRepo:  locationRepo,
+		lotRepo:       lotRepo,
+		inventoryRepo: inventoryRepo,
+		transactor:    transactor,
+	}
+}
+
+// Create drafts a purchase order; costs default to the supplier's cost prices
+func (service *purchaseOrderService) Create(actorID string, request dto.PurchaseOrderRequest) (*model.PurchaseOrder, error) {
+	now := time.Now()
+	order := model.PurchaseOrder{
+		ID:        uuid.NewString(),
+		Status:    model.PurchaseOrderDraft,
+		CreatedBy: actorID,
+		CreatedAt: now,
+	}
+	if err := service.apply(&order, request, now); err != nil {
+		return nil, err
+	}
+
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		order = service.repo.Create(ctx, order)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Update replaces the supplier, destination and lines of a draft
+func (service *purchaseOrderService) Update(id string, request dto.PurchaseOrderRequest) (*model.PurchaseOrder, error) {
+	draft := model.PurchaseOrder{}
+	if err := service.apply(&draft, request, time.Now()); err != nil {
+		return nil, err
+	}
+
+	order, err := service.update(id, func(order *model.PurchaseOrder) error {
+		if order.Status != model.PurchaseOrderDraft {
+			return model.ErrPurchaseOrderStatus
+		}
+		order.SupplierID = draft.SupplierID
+		order.LocationID = draft.LocationID
+		order.Note = draft.Note
+		order.Lines = draft.Lines
+		order.UpdatedAt = draft.UpdatedAt
+		return nil
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return order, nil
+}
+
+func (service *purchaseOrderService) FindById(id string) (*model.PurchaseOrder, error) {
+	return service.repo.FindByID(id)
+}
+
+// FindAll pages through purchase orders, the latest first
+func (service *purchaseOrderService) FindAll(query dto.PurchaseOrderQuery) dto.Page[model.PurchaseOrder] {
+	orders := service.repo.FindAll(func(order model.PurchaseOrder) bool {
+		return (query.SupplierID == "" || order.SupplierID == query.SupplierID) &&
+			(query.Status == "" || order.Status == model.PurchaseOrderStatus(query.Status))
+	})
+	slices.SortFunc(orders, func(a, b model.PurchaseOrder) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return dto.Paginate(orders, query.PageRequest)
+}
+
+// Send marks the order as sent to the supplier and expects the delivery after the supplier's lead time
+func (service *purchaseOrderService) Send(id string) (*model.PurchaseOrder, error) {
+	order, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := service.supplierRepo.FindByID(order.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedOn := util.StoreToday().AddDate(0, 0, supplier.LeadTimeDays)
+	order, err = service.update(id, func(order *model.PurchaseOrder) error {
+		return order.Send(expectedOn, time.Now())
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	zap.L().Info("Purchase order sent",
+		zap.String("number", order.Number),
+		zap.String("supplier_id", order.SupplierID),
+		zap.Int64("total", int64(order.Total())),
+	)
+	return order, nil
+}
+
+// Receive books a delivery against the order. Every line becomes a stock lot at the order's location and a
+// receipt in the ledger, all in one transaction.
+func (service *purchaseOrderService) Receive(actorID string, id string, request dto.GoodsReceiptRequest) (*model.PurchaseOrder, error) {
+	order, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	receipt := model.GoodsReceipt{ID: uuid.NewString(), Note: request.Note, ReceivedBy: actorID, ReceivedAt: now}
+	lots := make([]model.StockLot, 0, len(request.Lines))
+	units := make([]model.UnitCode, 0, len(request.Lines))
+	for _, line := range request.Lines {
+		product, err := service.productRepo.FindByID(line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		lot, err := newLot(product, line.Code, line.Quantity, line.HarvestedOn, line.BestBefore, now)
+		if err != nil {
+			return nil, err
+		}
+		lot.LocationID = order.LocationID
+		lot.SupplierID = order.SupplierID
+		receipt.Lines = append(receipt.Lines, model.GoodsReceiptLine{ProductID: product.ID, Quantity: lot.Received, LotID: lot.ID})
+		lots = append(lots, lot)
+		units = append(units, product.PriceUnit)
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, id, func(order *model.PurchaseOrder) error {
+			return order.Receive(receipt)
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		order = updated
+
+		for i, lot := range lots {
+			service.lotRepo.Save(ctx, lot)
+			movement := model.Movement{
+				ProductID:  lot.ProductID,
+				LocationID: lot.LocationID,
+				Type:       model.MovementReceipt,
+				Unit:       units[i],
+				ActorID:    actorID,
+				Reason:     model.ReasonGoodsReceived,
+				Note:       order.Number,
+				SourceType: model.SourcePurchaseOrder,
+				SourceID:   order.ID,
+				CreatedAt:  now,
+			}
+			_, err := recordMovement(ctx, service.inventoryRepo, movement, func(*model.StockLevel) (int64, error) {
+				return lot.Received, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Close finishes a received order, or a partially received one whose rest will not come
+func (service *purchaseOrderService) Close(id string) (*model.PurchaseOrder, error) {
+	order, err := service.update(id, func(order *model.PurchaseOrder) error {
+		return order.Close(time.Now())
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return order, nil
+}
+
+// update changes the order in a transaction of its own
+func (service *purchaseOrderService) update(id string, modify func(order *model.PurchaseOrder) error) (*model.PurchaseOrder, error) {
+	var updated *model.PurchaseOrder
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		order, err := service.repo.Update(ctx, id, modify)
+		if err != nil {
+			return err
+		}
+		updated = order
+		return nil
+	})
+	return updated, err
+}
+
+func (service *purchaseOrderService) Name() string { return "PurchaseOrderService" }
+func (service *purchaseOrderService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *purchaseOrderService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// apply fills the editable part of an order from the request, checking every line against the supplier's products
+func (service *purchaseOrderService) apply(order *model.PurchaseOrder, request dto.PurchaseOrderRequest, now time.Time) error {
+	supplier, err := service.supplierRepo.FindByID(request.SupplierID)
+	if err != nil {
+		return err
+	}
+	if !supplier.Active {
+		return core.Error.NotFound.Supplier
+	}
+	location, err := service.locationRepo.FindByID(locationOrDefault(request.LocationID))
+	if err != nil {
+		return err
+	}
+
+	lines := make([]model.PurchaseOrderLine, 0, len(request.Lines))
+	for _, requested := range request.Lines {
+		if slices.ContainsFunc(lines, func(line model.PurchaseOrderLine) bool { return line.ProductID == requested.ProductID }) {
+			return core.Error.Invalid.PurchaseOrder
+		}
+		product, err := service.productRepo.FindByID(requested.ProductID)
+		if err != nil {
+			return err
+		}
+		supplied, ok := supplier.Supplies(product.ID)
+		if !ok {
+			return core.Error.Invalid.SupplierProduct
+		}
+		quantity, err := productQuantity(product, requested.Quantity)
+		if err != nil {
+			return err
+		}
+
+		line := model.PurchaseOrderLine{
+			ProductID: product.ID,
+			Unit:      product.PriceUnit,
+			Ordered:   quantity.Base,
+			UnitCost:  supplied.CostPrice,
+		}
+		if requested.UnitCost != nil {
+			line.UnitCost = model.Money(*requested.UnitCost)
+		}
+		lines = append(lines, line)
+	}
+
+	order.SupplierID = supplier.ID
+	order.LocationID = location.ID
+	order.Note = request.Note
+	order.Lines = lines
+	order.UpdatedAt = now
+	return nil
+}
+
+var PurchaseOrderServiceModule = fx.Options(fx.Provide(NewPurchaseOrderService))
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+)
+
+// PurchaseVerifier tells whether a customer has received a product, which entitles them to review it
+type PurchaseVerifier interface {
+	HasReceived(userID string, productID string) bool
+}
+
+// orderHistory looks for a delivered order of the customer containing the product
+type orderHistory struct {
+	orders repository.OrderRepository
+}
+
+func NewPurchaseVerifier(orders repository.OrderRepository) PurchaseVerifier {
+	return orderHistory{orders: orders}
+}
+
+func (history orderHistory) HasReceived(userID string, productID string) bool {
+	return len(history.orders.FindAll(func(order model.Order) bool {
+		return order.UserID == userID && order.Status == model.OrderDelivered && order.Contains(productID)
+	})) > 0
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const defaultReorderInterval = time.Hour
+
+type ReorderService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	SetRule(actorID string, request dto.ReorderRuleRequest) (*model.ReorderRule, error)
+	DeleteRule(productID string, locationID string) error
+	FindRules(query dto.ReorderRuleQuery) []model.ReorderRule
+	FindAlerts(query dto.LowStockAlertQuery) dto.Page[model.LowStockAlert]
+	CheckStock() (*model.LowStockCheck, error)
+}
+
+type reorderService struct {
+	repo              repository.ReorderRepository
+	productRepo       repository.ProductRepository
+	locationRepo      repository.LocationRepository
+	inventoryRepo     repository.InventoryRepository
+	supplierRepo      repository.SupplierRepository
+	purchaseOrderRepo repository.PurchaseOrderRepository
+	transactor        infra_interface.Transactor
+	notifier          infra_interface.Notifier
+}
+
+func NewReorderService(
+	repo repository.ReorderRepository,
+	productRepo repository.ProductRepository,
+	locationRepo repository.LocationRepository,
+	inventoryRepo repository.InventoryRepository,
+	supplierRepo repository.SupplierRepository,
+	purchaseOrderRepo repository.PurchaseOrderRepository,
+	transactor infra_interface.Transactor,
+	notifier infra_interface.Notifier,
+	scheduler infra_interface.Scheduler,
+) ReorderService {
+	service := &reorderService{
+		repo:              repo,
+		productRepo:       productRepo,
+		locationRepo:      locationRepo,
+		inventoryRepo:     inventoryRepo,
+		supplierRepo:      supplierRepo,
+		purchaseOrderRepo: purchaseOrderRepo,
+		transactor:        transactor,
+		notifier:          notifier,
+	}
+
+	interval := configuredDuration(core.Configs.Inventory.ReorderInterval, defaultReorderInterval)
+	scheduler.Every("check-low-stock", interval, func() error {
+		check, err := service.CheckStock()
+		if err != nil {
+			return err
+		}
+		if len(check.Raised) > 0 || check.Resolved > 0 {
+			zap.L().Info("Checked stock against reorder points",
+				zap.Int("raised", len(check.Raised)),
+				zap.Int("resolved", check.Resolved),
+				zap.Int("purchase_orders", len(check.PurchaseOrders)),
+			)
+		}
+		return nil
+	})
+	return service
+}
+
+// SetRule creates or replaces the reorder point and target level of a product at a location
+func (service *reorderService) SetRule(actorID string, request dto.ReorderRuleRequest) (*model.ReorderRule, error) {
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := service.locationRepo.FindByID(locationOrDefault(request.LocationID))
+	if err != nil {
+		return nil, err
+	}
+	reorderPoint, err := countedQuantity(product, request.ReorderPoint)
+	if err != nil {
+		return nil, err
+	}
+	targetLevel, err := countedQuantity(product, request.TargetLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := model.ReorderRule{
+		ProductID:    product.ID,
+		LocationID:   location.ID,
+		Unit:         product.PriceUnit,
+		ReorderPoint: reorderPoint,
+		TargetLevel:  targetLevel,
+		UpdatedBy:    actorID,
+		UpdatedAt:    time.Now(),
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, domainError(err)
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.repo.SaveRule(ctx, rule)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteRule stops watching the product at the location; its alerts stay in the history
+func (service *reorderService) DeleteRule(productID string, locationID string) error {
+	return service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.DeleteRule(ctx, productID, locationOrDefault(locationID))
+	})
+}
+
+func (service *reorderService) FindRules(query dto.ReorderRuleQuery) []model.ReorderRule {
+	return service.repo.FindRules(func(rule model.ReorderRule) bool {
+		return (query.ProductID == "" || rule.ProductID == query.ProductID) &&
+			(query.LocationID == "" || rule.LocationID == query.LocationID)
+	})
+}
+
+// FindAlerts pages through the alert history, the latest first
+func (service *reorderService) FindAlerts(query dto.LowStockAlertQuery) dto.Page[model.LowStockAlert] {
+	alerts := service.repo.FindAlerts(func(alert model.LowStockAlert) bool {
+		return (query.ProductID == "" || alert.ProductID == query.ProductID) &&
+			(query.LocationID == "" || alert.LocationID == query.LocationID) &&
+			(query.Status == "" || alert.Status == model.LowStockAlertStatus(query.Status))
+	})
+	return dto.Paginate(alerts, query.PageRequest)
+}
+
+// supplyKey groups suggested lines into one purchase order per supplier and delivery location
+type supplyKey struct {
+	SupplierID string
+	LocationID string
+}
+
+// CheckStock compares available stock with every reorder rule. A product that ran low gets one open alert until
+// it recovers, and what it lacks is suggested on a draft purchase order to its preferred supplier. Staff are
+// notified of new alerts once the check is saved.
+func (service *reorderService) CheckStock() (*model.LowStockCheck, error) {
+	var check model.LowStockCheck
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		check = model.LowStockCheck{}
+		open := make(map[model.StockKey]model.LowStockAlert)
+		for _, alert := range service.repo.FindAlerts(func(alert model.LowStockAlert) bool { return alert.Status == model.LowStockOpen }) {
+			open[model.StockKey{ProductID: alert.ProductID, LocationID: alert.LocationID}] = alert
+		}
+		incoming := service.incoming()
+		suppliers := service.activeSuppliers()
+		orders := make(map[supplyKey]*model.PurchaseOrder)
+
+		for _, rule := range service.repo.FindRules(func(model.ReorderRule) bool { return true }) {
+			location, err := service.locationRepo.FindByID(rule.LocationID)
+			if err != nil || !location.Active {
+				continue
+			}
+			level := service.inventoryRepo.FindLevel(rule.ProductID, rule.LocationID)
+			available := level.Available()
+			alert, alerted := open[rule.Key()]
+
+			if !rule.IsLow(available) {
+				if alerted {
+					if _, err := service.repo.UpdateAlert(ctx, alert.ID, func(alert *model.LowStockAlert) error {
+						alert.Resolve(now)
+						return nil
+					}); err != nil {
+						return err
+					}
+					check.Resolved++
+				}
+				continue
+			}
+			if alerted {
+				continue
+			}
+
+			alert = model.LowStockAlert{
+				ID:           uuid.NewString(),
+				ProductID:    rule.ProductID,
+				LocationID:   rule.LocationID,
+				Unit:         rule.Unit,
+				Available:    available,
+				Incoming:     level.InTransit + incoming[rule.Key()],
+				ReorderPoint: rule.ReorderPoint,
+				TargetLevel:  rule.TargetLevel,
+				Status:       model.LowStockOpen,
+				RaisedAt:     now,
+			}
+			alert.SuggestedQuantity = rule.Shortfall(alert.Available, alert.Incoming)
+			if supplier, supplied, ok := preferredSupplier(suppliers, rule.ProductID); ok && alert.SuggestedQuantity > 0 {
+				key := supplyKey{SupplierID: supplier.ID, LocationID: rule.LocationID}
+				order, ok := orders[key]
+				if !ok {
+					order = &model.PurchaseOrder{
+						ID:         uuid.NewString(),
+						SupplierID: supplier.ID,
+						LocationID: rule.LocationID,
+						Status:     model.PurchaseOrderDraft,
+						Note:       "Suggested by the low-stock check",
+						CreatedBy:  model.SystemActor,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}
+					orders[key] = order
+				}
+				order.Lines = append(order.Lines, model.PurchaseOrderLine{
+					ProductID: rule.ProductID,
+					Unit:      rule.Unit,
+					Ordered:   alert.SuggestedQuantity,
+					UnitCost:  supplied.CostPrice,
+				})
+				alert.SupplierID = supplier.ID
+				alert.PurchaseOrderID = order.ID
+			}
+			check.Raised = append(check.Raised, alert)
+		}
+
+		// Numbered in a stable order so repeated checks read the same way
+		keys := make([]supplyKey, 0, len(orders))
+		for key := range orders {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(a, b supplyKey) int {
+			return cmp.Or(cmp.Compare(a.SupplierID, b.SupplierID), cmp.Compare(a.LocationID, b.LocationID))
+		})
+		for _, key := range keys {
+			check.PurchaseOrders = append(check.PurchaseOrders, service.purchaseOrderRepo.Create(ctx, *orders[key]))
+		}
+		for _, alert := range check.Raised {
+			service.repo.SaveAlert(ctx, alert)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(check.Raised) > 0 {
+		if err := service.notifier.Notify(lowStockNotification(check)); err != nil {
+			zap.L().Warn("Could not notify staff of low stock", zap.Error(err))
+		}
+	}
+	return &check, nil
+}
+
+// incoming sums what open purchase orders still have to deliver, per product and location
+func (service *reorderService) incoming() map[model.StockKey]int64 {
+	incoming := make(map[model.StockKey]int64)
+	for _, order := range service.purchaseOrderRepo.FindAll(func(order model.PurchaseOrder) bool { return order.Open() }) {
+		for _, line := range order.Lines {
+			incoming[model.StockKey{ProductID: line.ProductID, LocationID: order.LocationID}] += line.Outstanding()
+		}
+	}
+	return incoming
+}
+
+func (service *reorderService) activeSuppliers() []model.Supplier {
+	suppliers := slices.DeleteFunc(service.supplierRepo.FindAll(), func(supplier model.Supplier) bool {
+		return !supplier.Active
+	})
+	slices.SortFunc(suppliers, func(a, b model.Supplier) int { return cmp.Compare(a.ID, b.ID) })
+	return suppliers
+}
+
+// preferredSupplier picks who to reorder a product from: a supplier marked preferred for it, else the cheapest
+func preferredSupplier(suppliers []model.Supplier, productID string) (*model.Supplier, model.SuppliedProduct, bool) {
+	var best *model.Supplier
+	var bestTerms model.SuppliedProduct
+	for index := range suppliers {
+		terms, ok := suppliers[index].Supplies(productID)
+		if !ok {
+			continue
+		}
+		if best == nil ||
+			(terms.Preferred && !bestTerms.Preferred) ||
+			(terms.Preferred == bestTerms.Preferred && terms.CostPrice < bestTerms.CostPrice) {
+			best, bestTerms = &suppliers[index], terms
+		}
+	}
+	return best, bestTerms, best != nil
+}
+
+func lowStockNotification(check model.LowStockCheck) infra_interface.Notification {
+	numbers := make(map[string]string, len(check.PurchaseOrders))
+	for _, order := range check.PurchaseOrders {
+		numbers[order.ID] = order.Number
+	}
+
+	var body strings.Builder
+	for _, alert := range check.Raised {
+		fmt.Fprintf(&body, "%s at %s: %s %s available, reorder point %s",
+			alert.ProductID,
+			alert.LocationID,
+			model.Quantity{Base: alert.Available, Unit: alert.Unit}.Value(),
+			alert.Unit,
+			model.Quantity{Base: alert.ReorderPoint, Unit: alert.Unit}.Value(),
+		)
+		switch {
+		case alert.PurchaseOrderID != "":
+			fmt.Fprintf(&body, "; suggested %s %s on %s from %s",
+				model.Quantity{Base: alert.SuggestedQuantity, Unit: alert.Unit}.Value(),
+				alert.Unit,
+				numbers[alert.PurchaseOrderID],
+				alert.SupplierID,
+			)
+		case alert.SuggestedQuantity > 0:
+			body.WriteString("; no active supplier sells it")
+		default:
+			body.WriteString("; enough is already on its way")
+		}
+		body.WriteString("\n")
+	}
+
+	return infra_interface.Notification{
+		Roles:   []string{model.RoleAdmin, model.RoleStaff},
+		Subject: fmt.Sprintf("Low stock: %d product(s) at or below their reorder point", len(check.Raised)),
+		Body:    body.String(),
+	}
+}
+
+func (service *reorderService) Name() string { return "ReorderService" }
+func (service *reorderService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *reorderService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var ReorderServiceModule = fx.Options(fx.Provide(NewReorderService))
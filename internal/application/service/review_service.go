@@ -0,0 +1,321 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type ReviewService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(userID string, request dto.ReviewRequest) (*model.Review, error)
+	AddPhoto(userID string, reviewID string, file *multipart.FileHeader) (*model.Review, error)
+	FindByProduct(productID string, query dto.ReviewQuery) (dto.Page[model.Review], model.RatingSummary, error)
+	FindByUser(userID string) []model.Review
+	FindForModeration(query dto.ModerationQuery) dto.Page[model.Review]
+	Moderate(moderatorID string, reviewID string, request dto.ModerationRequest) (*model.Review, error)
+	Vote(userID string, reviewID string, request dto.VoteRequest) (*model.Review, error)
+	Photo(actor model.OrderActor, reviewID string, photoID string) ([]byte, string, error)
+}
+
+type reviewService struct {
+	repo        repository.ReviewRepository
+	productRepo repository.ProductRepository
+	purchases   PurchaseVerifier
+	storage     infra_interface.FileStorage
+	processor   infra_interface.ImageProcessor
+	transactor  infra_interface.Transactor
+}
+
+func NewReviewService(
+	repo repository.ReviewRepository,
+	productRepo repository.ProductRepository,
+	purchases PurchaseVerifier,
+	storage infra_interface.FileStorage,
+	processor infra_interface.ImageProcessor,
+	transactor infra_interface.Transactor,
+) ReviewService {
+	return &reviewService{
+		repo:        repo,
+		productRepo: productRepo,
+		purchases:   purchases,
+		storage:     storage,
+		processor:   processor,
+		transactor:  transactor,
+	}
+}
+
+// Create stores the review as pending; it is published and counted once staff approve it
+func (service *reviewService) Create(userID string, request dto.ReviewRequest) (*model.Review, error) {
+	if _, err := service.productRepo.FindByID(request.ProductID); err != nil {
+		return nil, err
+	}
+	if !service.purchases.HasReceived(userID, request.ProductID) {
+		return nil, core.Error.Auth.PurchaseRequired
+	}
+
+	now := time.Now()
+	review := model.Review{
+		ID:        uuid.NewString(),
+		ProductID: request.ProductID,
+		UserID:    userID,
+		Rating:    request.Rating,
+		Text:      strings.TrimSpace(request.Text),
+		Status:    model.ReviewPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := review.Validate(); err != nil {
+		return nil, reviewError(err)
+	}
+	// Inside a transaction so two concurrent submissions cannot both pass the one-review-per-product check
+	if err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error { return service.repo.Create(ctx, review) }); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// AddPhoto attaches a photo to the author's review. A published review goes back to moderation.
+func (service *reviewService) AddPhoto(userID string, reviewID string, file *multipart.FileHeader) (*model.Review, error) {
+	review, err := service.repo.FindByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, core.Error.Auth.Forbidden
+	}
+	if len(review.Photos) >= model.MaxReviewPhotos {
+		return nil, core.Error.Invalid.ReviewPhotos
+	}
+
+	content, err := readLimited(file, core.Configs.Upload.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+	info, err := service.processor.Inspect(content)
+	if err != nil || !isAllowedImageType(info.ContentType) {
+		return nil, core.Error.Invalid.ImageType
+	}
+
+	photo := model.ReviewPhoto{ID: uuid.NewString(), ContentType: info.ContentType}
+	photo.Key = fmt.Sprintf("reviews/%s/%s%s", review.ID, photo.ID, imageExtension(info.ContentType))
+	photo.URL = apiURL("review", review.ID, "photo", photo.ID)
+	if err := service.storage.Put(photo.Key, info.ContentType, content); err != nil {
+		zap.L().Error("Failed to store review photo", zap.String("review_id", review.ID), zap.Error(err))
+		return nil, err
+	}
+
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, reviewID, func(review *model.Review) error {
+			review.Photos = append(slices.Clone(review.Photos), photo)
+			review.Status = model.ReviewPending
+			review.UpdatedAt = time.Now()
+			return review.Validate()
+		})
+		if err != nil {
+			return err
+		}
+		review = updated
+		return service.refreshRating(ctx, review.ProductID)
+	})
+	if err != nil {
+		if deleteErr := service.storage.Delete(photo.Key); deleteErr != nil {
+			zap.L().Warn("Failed to delete review photo", zap.String("key", photo.Key), zap.Error(deleteErr))
+		}
+		return nil, reviewError(err)
+	}
+	return review, nil
+}
+
+// FindByProduct lists the published reviews of a product with its rating summary
+func (service *reviewService) FindByProduct(productID string, query dto.ReviewQuery) (dto.Page[model.Review], model.RatingSummary, error) {
+	product, err := service.productRepo.FindByID(productID)
+	if err != nil {
+		return dto.Page[model.Review]{}, model.RatingSummary{}, err
+	}
+
+	reviews := make([]model.Review, 0)
+	for _, review := range service.repo.FindByProduct(productID) {
+		if review.Status != model.ReviewApproved {
+			continue
+		}
+		if query.Rating != 0 && review.Rating != query.Rating {
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+
+	if query.Sort == "helpful" {
+		slices.SortStableFunc(reviews, func(a, b model.Review) int {
+			helpfulA, _ := a.HelpfulVotes()
+			helpfulB, _ := b.HelpfulVotes()
+			return cmp.Or(cmp.Compare(helpfulB, helpfulA), b.CreatedAt.Compare(a.CreatedAt))
+		})
+	} else {
+		sortByNewest(reviews)
+	}
+	return dto.Paginate(reviews, query.PageRequest), product.Rating, nil
+}
+
+func (service *reviewService) FindByUser(userID string) []model.Review {
+	reviews := make([]model.Review, 0)
+	for _, review := range service.repo.FindAll() {
+		if review.UserID == userID {
+			reviews = append(reviews, review)
+		}
+	}
+	sortByNewest(reviews)
+	return reviews
+}
+
+// FindForModeration lists reviews by status, oldest first so the queue is worked in order
+func (service *reviewService) FindForModeration(query dto.ModerationQuery) dto.Page[model.Review] {
+	reviews := make([]model.Review, 0)
+	for _, review := range service.repo.FindAll() {
+		if review.Status != model.ReviewStatus(query.Status) {
+			continue
+		}
+		if query.ProductID != "" && review.ProductID != query.ProductID {
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+	slices.SortFunc(reviews, func(a, b model.Review) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+	return dto.Paginate(reviews, query.PageRequest)
+}
+
+// Moderate approves or rejects a review and updates the product's rating summary in the same transaction
+func (service *reviewService) Moderate(moderatorID string, reviewID string, request dto.ModerationRequest) (*model.Review, error) {
+	status := model.ReviewRejected
+	if request.Decision == "approve" {
+		status = model.ReviewApproved
+	}
+
+	var review *model.Review
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, reviewID, func(review *model.Review) error {
+			review.Status = status
+			review.ModeratorID = moderatorID
+			review.ModerationNote = strings.TrimSpace(request.Note)
+			review.ModeratedAt = time.Now()
+			review.UpdatedAt = review.ModeratedAt
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		review = updated
+		return service.refreshRating(ctx, review.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Vote records whether the user found a published review helpful; voting again replaces the previous vote
+func (service *reviewService) Vote(userID string, reviewID string, request dto.VoteRequest) (*model.Review, error) {
+	var review *model.Review
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		updated, err := service.repo.Update(ctx, reviewID, func(review *model.Review) error {
+			if review.Status != model.ReviewApproved {
+				return core.Error.NotFound.Review
+			}
+			return reviewError(review.Vote(userID, *request.Helpful))
+		})
+		if err != nil {
+			return err
+		}
+		review = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Photo serves a photo of a published review to anyone; until it is published only its author and staff see it
+func (service *reviewService) Photo(actor model.OrderActor, reviewID string, photoID string) ([]byte, string, error) {
+	review, err := service.repo.FindByID(reviewID)
+	if err != nil {
+		return nil, "", err
+	}
+	if review.Status != model.ReviewApproved && review.UserID != actor.ID && !actor.Staff() {
+		return nil, "", core.Error.NotFound.Review
+	}
+	index := slices.IndexFunc(review.Photos, func(photo model.ReviewPhoto) bool { return photo.ID == photoID })
+	if index < 0 {
+		return nil, "", core.Error.NotFound.Image
+	}
+	content, contentType, err := service.storage.Get(review.Photos[index].Key)
+	if err != nil {
+		return nil, "", core.Error.NotFound.Image
+	}
+	return content, contentType, nil
+}
+
+// refreshRating recomputes the product's stored summary from its approved reviews
+func (service *reviewService) refreshRating(ctx context.Context, productID string) error {
+	_, err := service.productRepo.Update(ctx, productID, func(product *model.Product) error {
+		product.Rating = model.SummarizeRatings(service.repo.FindByProduct(productID))
+		return nil
+	})
+	return err
+}
+
+func (service *reviewService) Name() string { return "ReviewService" }
+func (service *reviewService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *reviewService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func sortByNewest(reviews []model.Review) {
+	slices.SortStableFunc(reviews, func(a, b model.Review) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+}
+
+// reviewError maps errors raised by the review model to their API error.
+func reviewError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidRating):
+		return core.Error.Invalid.Rating
+	case errors.Is(err, model.ErrReviewTooLong):
+		return core.Error.Invalid.ReviewText
+	case errors.Is(err, model.ErrTooManyPhotos):
+		return core.Error.Invalid.ReviewPhotos
+	case errors.Is(err, model.ErrVoteOnOwnReview):
+		return core.Error.Invalid.OwnReviewVote
+	default:
+		return err
+	}
+}
+
+var ReviewServiceModule = fx.Options(
+	fx.Provide(NewReviewService),
+	fx.Provide(NewPurchaseVerifier),
+)
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type StockLedgerService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	FindMovements(query dto.MovementQuery) (dto.Page[model.Movement], error)
+	Rebuild(actorID string) (*dto.RebuildResponse, error)
+
+	CreateStocktake(actorID string, request dto.StocktakeRequest) (*model.Stocktake, error)
+	ApplyStocktake(actorID string, id string) (*model.Stocktake, error)
+	FindStocktake(id string) (*model.Stocktake, error)
+	FindStocktakes(query dto.StocktakeQuery) []model.Stocktake
+}
+
+type stockLedgerService struct {
+	repo         repository.InventoryRepository
+	productRepo  repository.ProductRepository
+	locationRepo repository.LocationRepository
+	transactor   infra_interface.Transactor
+}
+
+func NewStockLedgerService(
+	repo repository.InventoryRepository,
+	productRepo repository.ProductRepository,
+	locationRepo repository.LocationRepository,
+	transactor infra_interface.Transactor,
+) StockLedgerService {
+	return &stockLedgerService{repo: repo, productRepo: productRepo, locationRepo: locationRepo, transactor: transactor}
+}
+
+// FindMovements pages through the ledger, newest movement first
+func (service *stockLedgerService) FindMovements(query dto.MovementQuery) (dto.Page[model.Movement], error) {
+	var from, to time.Time
+	var err error
+	if query.From != "" {
+		if from, err = util.ParseStoreDate(query.From); err != nil {
+			return dto.Page[model.Movement]{}, core.Error.Invalid.Date
+		}
+	}
+	if query.To != "" {
+		if to, err = util.ParseStoreDate(query.To); err != nil {
+			return dto.Page[model.Movement]{}, core.Error.Invalid.Date
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+
+	movements := service.repo.FindMovements(func(movement model.Movement) bool {
+		return (query.ProductID == "" || movement.ProductID == query.ProductID) &&
+			(query.LocationID == "" || movement.LocationID == query.LocationID) &&
+			(query.Type == "" || movement.Type == model.MovementType(query.Type)) &&
+			(query.SourceType == "" || movement.SourceType == query.SourceType) &&
+			(query.SourceID == "" || movement.SourceID == query.SourceID) &&
+			(query.ActorID == "" || movement.ActorID == query.ActorID) &&
+			(from.IsZero() || !movement.CreatedAt.Before(from)) &&
+			(to.IsZero() || movement.CreatedAt.Before(to))
+	})
+	slices.Reverse(movements)
+	return dto.Paginate(movements, query.PageRequest), nil
+}
+
+// Rebuild replays the whole ledger and overwrites stock on hand wherever it disagrees. Reservations and
+// stock in transit are not part of the ledger and stay as they are.
+func (service *stockLedgerService) Rebuild(actorID string) (*dto.RebuildResponse, error) {
+	response := &dto.RebuildResponse{Corrected: make([]dto.RebuiltLevelDto, 0)}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		movements := service.repo.FindMovements(func(model.Movement) bool { return true })
+		sums := model.SumMovements(movements)
+		response.Movements = len(movements)
+
+		// Every movement creates its level, so walking the levels covers every ledger key
+		now := time.Now()
+		for _, level := range service.repo.FindLevels(func(model.StockLevel) bool { return true }) {
+			onHand := sums[model.StockKey{ProductID: level.ProductID, LocationID: level.LocationID}]
+			if level.OnHand == onHand {
+				continue
+			}
+			_, err := service.repo.UpdateLevel(ctx, level.ProductID, level.LocationID, func(level *model.StockLevel) error {
+				level.OnHand = onHand
+				level.UpdatedAt = now
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Corrected = append(response.Corrected, dto.RebuiltLevelDto{
+				ProductID:  level.ProductID,
+				LocationID: level.LocationID,
+				Before:     dto.ToQuantityDto(model.Quantity{Base: level.OnHand, Unit: level.Unit}),
+				After:      dto.ToQuantityDto(model.Quantity{Base: onHand, Unit: level.Unit}),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(response.Corrected, func(a, b dto.RebuiltLevelDto) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LocationID, b.LocationID))
+	})
+	zap.L().Info("Stock on hand rebuilt from the ledger",
+		zap.String("actor_id", actorID),
+		zap.Int("movements", response.Movements),
+		zap.Int("corrected", len(response.Corrected)),
+	)
+	return response, nil
+}
+
+// CreateStocktake records a physical count against the stock on hand the ledger expects right now.
+// Nothing changes until the stocktake is applied.
+func (service *stockLedgerService) CreateStocktake(actorID string, request dto.StocktakeRequest) (*model.Stocktake, error) {
+	location, err := service.locationRepo.FindByID(locationOrDefault(request.LocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]model.StocktakeLine, 0, len(request.Counts))
+	for _, count := range request.Counts {
+		if slices.ContainsFunc(lines, func(line model.StocktakeLine) bool { return line.ProductID == count.ProductID }) {
+			return nil, core.Error.Invalid.Stocktake
+		}
+		product, err := service.productRepo.FindByID(count.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		counted, err := countedQuantity(product, count.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, model.StocktakeLine{ProductID: product.ID, Unit: product.PriceUnit, Counted: counted})
+	}
+
+	now := time.Now()
+	stocktake := model.Stocktake{
+		ID:         uuid.NewString(),
+		LocationID: location.ID,
+		Status:     model.StocktakeCounted,
+		Note:       request.Note,
+		CountedBy:  actorID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		for _, line := range lines {
+			line.Expected = service.repo.FindLevel(line.ProductID, location.ID).OnHand
+			stocktake.Lines = append(stocktake.Lines, line)
+		}
+		service.repo.SaveStocktake(ctx, stocktake)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stocktake, nil
+}
+
+// ApplyStocktake posts every variance to the ledger as an adjustment. Sales made since the count already
+// left the ledger, so the variance is applied as a difference rather than overwriting stock on hand.
+func (service *stockLedgerService) ApplyStocktake(actorID string, id string) (*model.Stocktake, error) {
+	var stocktake *model.Stocktake
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		now := time.Now()
+		updated, err := service.repo.UpdateStocktake(ctx, id, func(stocktake *model.Stocktake) error {
+			return stocktake.Apply(actorID, now)
+		})
+		if err != nil {
+			return domainError(err)
+		}
+		stocktake = updated
+
+		for _, line := range stocktake.Lines {
+			if line.Variance() == 0 {
+				continue
+			}
+			movement := model.Movement{
+				ProductID:  line.ProductID,
+				LocationID: stocktake.LocationID,
+				Type:       model.MovementAdjustment,
+				Unit:       line.Unit,
+				ActorID:    actorID,
+				Reason:     model.ReasonStocktake,
+				Note:       stocktake.Note,
+				SourceType: model.SourceStocktake,
+				SourceID:   stocktake.ID,
+				CreatedAt:  now,
+			}
+			_, err := recordMovement(ctx, service.repo, movement, func(level *model.StockLevel) (int64, error) {
+				return max(line.Variance(), -level.OnHand), nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+func (service *stockLedgerService) FindStocktake(id string) (*model.Stocktake, error) {
+	return service.repo.FindStocktake(id)
+}
+
+// FindStocktakes lists stocktakes, the latest first
+func (service *stockLedgerService) FindStocktakes(query dto.StocktakeQuery) []model.Stocktake {
+	stocktakes := service.repo.FindStocktakes(func(stocktake model.Stocktake) bool {
+		return (query.LocationID == "" || stocktake.LocationID == query.LocationID) &&
+			(query.Status == "" || stocktake.Status == model.StocktakeStatus(query.Status))
+	})
+	slices.SortFunc(stocktakes, func(a, b model.Stocktake) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return stocktakes
+}
+
+func (service *stockLedgerService) Name() string { return "StockLedgerService" }
+func (service *stockLedgerService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *stockLedgerService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// recordMovement is the only way stock on hand changes: it applies a movement to its level and appends it to the ledger.
+// apply may change the rest of the level, e.g. release a reservation, and returns the change of stock on hand.
+// Callers hold a transaction.
+func recordMovement(ctx context.Context, repo repository.InventoryRepository, movement model.Movement, apply func(level *model.StockLevel) (int64, error)) (*model.StockLevel, error) {
+	level, err := repo.UpdateLevel(ctx, movement.ProductID, movement.LocationID, func(level *model.StockLevel) error {
+		delta, err := apply(level)
+		if err != nil {
+			return err
+		}
+		if err := level.Adjust(delta); err != nil {
+			return err
+		}
+		if movement.Unit != "" {
+			level.Unit = movement.Unit
+		}
+		level.UpdatedAt = movement.CreatedAt
+		movement.Unit = level.Unit
+		movement.Quantity = delta
+		movement.Balance = level.OnHand
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if movement.Quantity != 0 {
+		movement.ID = uuid.NewString()
+		repo.AppendMovement(ctx, movement)
+	}
+	return level, nil
+}
+
+var StockLedgerServiceModule = fx.Options(fx.Provide(NewStockLedgerService))
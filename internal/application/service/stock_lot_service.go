@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+type StockLotService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Receive(actorID string, request dto.LotRequest) (*model.StockLot, error)
+	FindById(id string) (*model.StockLot, error)
+	FindAll(query dto.LotQuery) []model.StockLot
+	FindExpiring(days int) []model.StockLot
+	WriteOff(actorID string, id string, reason string) (*model.StockLot, error)
+	Pick(request dto.PickRequest) (*dto.PickResponse, error)
+}
+
+type stockLotService struct {
+	repo          repository.StockLotRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	locationRepo  repository.LocationRepository
+	supplierRepo  repository.SupplierRepository
+	transactor    infra_interface.Transactor
+}
+
+func NewStockLotService(
+	repo repository.StockLotRepository,
+	productRepo repository.ProductRepository,
+	inventoryRepo repository.InventoryRepository,
+	locationRepo repository.LocationRepository,
+	supplierRepo repository.SupplierRepository,
+	transactor infra_interface.Transactor,
+) StockLotService {
+	return &stockLotService{
+		repo:          repo,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		locationRepo:  locationRepo,
+		supplierRepo:  supplierRepo,
+		transactor:    transactor,
+	}
+}
+
+func (service *stockLotService) Receive(actorID string, request dto.LotRequest) (*model.StockLot, error) {
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := service.locationRepo.FindByID(locationOrDefault(request.LocationID))
+	if err != nil {
+		return nil, err
+	}
+	if request.SupplierID != "" {
+		if _, err := service.supplierRepo.FindByID(request.SupplierID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	lot, err := newLot(product, request.Code, request.Quantity, request.HarvestedOn, request.BestBefore, now)
+	if err != nil {
+		return nil, err
+	}
+	lot.LocationID = location.ID
+	lot.SupplierID = request.SupplierID
+
+	// The received quantity goes on hand at the receiving location together with the lot
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.repo.Save(ctx, lot)
+		movement := lotMovement(actorID, &lot, model.MovementReceipt, model.ReasonGoodsReceived, now)
+		movement.Unit = product.PriceUnit
+		_, err := recordMovement(ctx, service.inventoryRepo, movement, func(*model.StockLevel) (int64, error) {
+			return lot.Received, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+func (service *stockLotService) FindById(id string) (*model.StockLot, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *stockLotService) FindAll(query dto.LotQuery) []model.StockLot {
+	lots := service.repo.FindAll()
+	if query.ProductID != "" {
+		lots = service.repo.FindByProduct(query.ProductID)
+	}
+	if query.LocationID != "" {
+		lots = atLocation(lots, query.LocationID)
+	}
+	sortByBestBefore(lots)
+	return lots
+}
+
+// FindExpiring lists lots with stock left whose best-before date is at most days away, already expired ones included
+func (service *stockLotService) FindExpiring(days int) []model.StockLot {
+	today := util.StoreToday()
+	lots := make([]model.StockLot, 0)
+	for _, lot := range service.repo.FindAll() {
+		if lot.Status == model.LotActive && lot.Remaining > 0 && lot.DaysLeft(today) <= days {
+			lots = append(lots, lot)
+		}
+	}
+	sortByBestBefore(lots)
+	return lots
+}
+
+// WriteOff discards what is left of a lot and takes it off the stock on hand where the lot is kept
+func (service *stockLotService) WriteOff(actorID string, id string, reason string) (*model.StockLot, error) {
+	var lot *model.StockLot
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		var discarded int64
+		updated, err := service.repo.Update(ctx, id, func(lot *model.StockLot) error {
+			if lot.Status != model.LotActive {
+				return core.Error.Conflict.LotClosed
+			}
+			discarded = lot.Remaining
+			lot.Status = model.LotWrittenOff
+			lot.Reason = reason
+			lot.Remaining = 0
+			lot.UpdatedAt = time.Now()
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		lot = updated
+
+		// Sales already took their share off stock on hand, so never remove more than is left
+		movement := lotMovement(actorID, lot, model.MovementSpoilage, model.ReasonSpoiled, lot.UpdatedAt)
+		movement.Note = reason
+		_, err = recordMovement(ctx, service.inventoryRepo, movement, func(level *model.StockLevel) (int64, error) {
+			return -min(discarded, level.OnHand), nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// Pick takes stock out of the lots at one location that expire first. All lots are updated in one transaction.
+func (service *stockLotService) Pick(request dto.PickRequest) (*dto.PickResponse, error) {
+	product, err := service.productRepo.FindByID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := productQuantity(product, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PickResponse{ProductID: product.ID, Quantity: dto.ToQuantityDto(quantity)}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		lots := atLocation(service.repo.FindByProduct(product.ID), locationOrDefault(request.LocationID))
+		allocations, err := model.AllocateFEFO(lots, quantity.Base, util.StoreToday())
+		if err != nil {
+			return domainError(err)
+		}
+
+		for _, allocation := range allocations {
+			lot, err := service.repo.Update(ctx, allocation.LotID, func(lot *model.StockLot) error {
+				lot.Remaining -= allocation.Quantity
+				if lot.Remaining == 0 {
+					lot.Status = model.LotDepleted
+				}
+				lot.UpdatedAt = time.Now()
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Allocations = append(response.Allocations, dto.LotAllocationDto{
+				LotID:      lot.ID,
+				LotCode:    lot.Code,
+				BestBefore: lot.BestBefore.Format(util.DateLayout),
+				Quantity:   dto.ToQuantityDto(model.Quantity{Base: allocation.Quantity, Unit: quantity.Unit}),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// newLot builds an active lot from a delivery of product; the caller sets where it is kept and who supplied it
+func newLot(product *model.Product, code string, request dto.QuantityDto, harvested string, bestBefore string, now time.Time) (model.StockLot, error) {
+	quantity, err := productQuantity(product, request)
+	if err != nil {
+		return model.StockLot{}, err
+	}
+	harvestedOn, err := util.ParseStoreDate(harvested)
+	if err != nil {
+		return model.StockLot{}, core.Error.Invalid.Date
+	}
+	bestBeforeOn, err := util.ParseStoreDate(bestBefore)
+	if err != nil {
+		return model.StockLot{}, core.Error.Invalid.Date
+	}
+
+	lot := model.StockLot{
+		ID:          uuid.NewString(),
+		ProductID:   product.ID,
+		Code:        code,
+		Unit:        quantity.Unit,
+		Received:    quantity.Base,
+		Remaining:   quantity.Base,
+		HarvestedOn: harvestedOn,
+		BestBefore:  bestBeforeOn,
+		Status:      model.LotActive,
+		ReceivedAt:  now,
+		UpdatedAt:   now,
+	}
+	if err := lot.Validate(); err != nil {
+		return model.StockLot{}, domainError(err)
+	}
+	return lot, nil
+}
+
+// lotMovement starts the ledger entry of stock arriving with or leaving from a lot
+func lotMovement(actorID string, lot *model.StockLot, movementType model.MovementType, reason string, now time.Time) model.Movement {
+	return model.Movement{
+		ProductID:  lot.ProductID,
+		LocationID: locationOrDefault(lot.LocationID),
+		Type:       movementType,
+		ActorID:    actorID,
+		Reason:     reason,
+		SourceType: model.SourceLot,
+		SourceID:   lot.ID,
+		CreatedAt:  now,
+	}
+}
+
+// atLocation keeps the lots kept at a location; lots received before locations existed belong to the central warehouse
+func atLocation(lots []model.StockLot, locationID string) []model.StockLot {
+	result := make([]model.StockLot, 0, len(lots))
+	for _, lot := range lots {
+		if locationOrDefault(lot.LocationID) == locationID {
+			result = append(result, lot)
+		}
+	}
+	return result
+}
+
+func (service *stockLotService) Name() string { return "StockLotService" }
+func (service *stockLotService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *stockLotService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// productQuantity parses a stock quantity, which must measure the same thing the product is priced by
+func productQuantity(product *model.Product, request dto.QuantityDto) (model.Quantity, error) {
+	quantity, err := measuredQuantity(product, request)
+	if err != nil {
+		return model.Quantity{}, err
+	}
+	if quantity.Base <= 0 {
+		return model.Quantity{}, core.Error.Invalid.Quantity
+	}
+	return quantity, nil
+}
+
+// countedQuantity is like productQuantity but accepts zero, e.g. an empty shelf in a stocktake; it returns base units
+func countedQuantity(product *model.Product, request dto.QuantityDto) (int64, error) {
+	quantity, err := measuredQuantity(product, request)
+	if err != nil {
+		return 0, err
+	}
+	if quantity.Base < 0 {
+		return 0, core.Error.Invalid.Quantity
+	}
+	return quantity.Base, nil
+}
+
+// measuredQuantity parses a quantity in a unit of the same dimension as the product's price unit
+func measuredQuantity(product *model.Product, request dto.QuantityDto) (model.Quantity, error) {
+	quantity, err := model.ParseQuantity(request.Value, model.UnitCode(request.Unit))
+	if err != nil {
+		return model.Quantity{}, domainError(err)
+	}
+	priceUnit, err := model.FindUnit(product.PriceUnit)
+	if err != nil || quantity.Dimension() != priceUnit.Dimension {
+		return model.Quantity{}, core.Error.Invalid.Unit
+	}
+	return quantity, nil
+}
+
+func sortByBestBefore(lots []model.StockLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].BestBefore.Before(lots[j].BestBefore)
+	})
+}
+
+var StockLotServiceModule = fx.Options(fx.Provide(NewStockLotService))
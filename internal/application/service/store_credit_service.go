@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+)
+
+type StoreCreditService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	// FindMine returns the user's balance and ledger entries, the earliest first
+	FindMine(userID string) (model.Money, []model.CreditEntry)
+}
+
+type storeCreditService struct {
+	repo repository.StoreCreditRepository
+}
+
+func NewStoreCreditService(repo repository.StoreCreditRepository) StoreCreditService {
+	return &storeCreditService{repo: repo}
+}
+
+func (service *storeCreditService) FindMine(userID string) (model.Money, []model.CreditEntry) {
+	entries := service.repo.FindByUser(userID)
+	return model.CreditBalance(entries), entries
+}
+
+func (service *storeCreditService) Name() string { return "StoreCreditService" }
+func (service *storeCreditService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *storeCreditService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var StoreCreditServiceModule = fx.Options(fx.Provide(NewStoreCreditService))
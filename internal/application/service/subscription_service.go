@@ -0,0 +1,619 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+Subscriptions deliver a plan's box on its cycle without the customer checking out every week:
+- Staff define plans: the products in the box with substitutes for each, how often it comes and on which weekday.
+- Customers subscribe with an address and a payment method, then pause (until a date or until they resume), skip
+  single delivery days or cancel.
+- A scheduled run orders every box whose delivery day is within the lead time, before the slot cutoffs that day:
+  stock is held at the day's prices, out-of-stock items are replaced by their first substitute in stock or left
+  out, and the earliest slot with room is booked. Boxes paid on delivery are confirmed at once; the others start a
+  payment with the provider and must be paid by the start of the delivery day. The customer is told what was sent
+  and how to pay, or why no box could be ordered.
+- Every delivery day leaves a record on the subscription: ordered, skipped or failed.
+*/
+
+const (
+	defaultSubscriptionLeadTime    = 48 * time.Hour
+	defaultSubscriptionRunInterval = time.Hour
+)
+
+type SubscriptionService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	CreatePlan(request dto.SubscriptionPlanRequest) (*model.SubscriptionPlan, error)
+	UpdatePlan(id string, request dto.SubscriptionPlanRequest) (*model.SubscriptionPlan, error)
+	FindPlans(activeOnly bool) []model.SubscriptionPlan
+	Subscribe(userID string, request dto.SubscribeRequest, locale string) (*model.Subscription, error)
+	FindMine(userID string) []model.Subscription
+	FindMineByID(userID string, id string) (*model.Subscription, error)
+	Pause(userID string, id string, request dto.PauseSubscriptionRequest) (*model.Subscription, error)
+	Resume(userID string, id string) (*model.Subscription, error)
+	Skip(userID string, id string, request dto.SkipDeliveryRequest) (*model.Subscription, error)
+	Unskip(userID string, id string, date string) (*model.Subscription, error)
+	Cancel(userID string, id string, request dto.CancelSubscriptionRequest) (*model.Subscription, error)
+	FindAll(query dto.SubscriptionQuery) dto.Page[model.Subscription]
+	FindByID(id string) (*model.Subscription, error)
+	Generate() []model.BoxRun
+}
+
+type subscriptionService struct {
+	repo          repository.SubscriptionRepository
+	orderRepo     repository.OrderRepository
+	productRepo   repository.ProductRepository
+	inventoryRepo repository.InventoryRepository
+	slotRepo      repository.DeliverySlotRepository
+	zoneRepo      repository.DeliveryZoneRepository
+	locationRepo  repository.LocationRepository
+	payments      PaymentService
+	notifier      infra_interface.Notifier
+	transactor    infra_interface.Transactor
+	leadTime      time.Duration
+}
+
+func NewSubscriptionService(
+	repo repository.SubscriptionRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	inventoryRepo repository.InventoryRepository,
+	slotRepo repository.DeliverySlotRepository,
+	zoneRepo repository.DeliveryZoneRepository,
+	locationRepo repository.LocationRepository,
+	payments PaymentService,
+	notifier infra_interface.Notifier,
+	transactor infra_interface.Transactor,
+	scheduler infra_interface.Scheduler,
+) SubscriptionService {
+	service := &subscriptionService{
+		repo:          repo,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		inventoryRepo: inventoryRepo,
+		slotRepo:      slotRepo,
+		zoneRepo:      zoneRepo,
+		locationRepo:  locationRepo,
+		payments:      payments,
+		notifier:      notifier,
+		transactor:    transactor,
+		leadTime:      configuredDuration(core.Configs.Subscription.LeadTime, defaultSubscriptionLeadTime),
+	}
+
+	interval := configuredDuration(core.Configs.Subscription.RunInterval, defaultSubscriptionRunInterval)
+	scheduler.Every("order-subscription-boxes", interval, func() error {
+		if runs := service.Generate(); len(runs) > 0 {
+			zap.L().Info("Ran subscriptions due for delivery", zap.Int("count", len(runs)))
+		}
+		return nil
+	})
+	return service
+}
+
+func (service *subscriptionService) CreatePlan(request dto.SubscriptionPlanRequest) (*model.SubscriptionPlan, error) {
+	now := time.Now()
+	plan := model.SubscriptionPlan{ID: uuid.NewString(), Active: true, CreatedAt: now}
+	if err := service.applyPlanRequest(&plan, request, now); err != nil {
+		return nil, err
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.repo.CreatePlan(ctx, plan)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// UpdatePlan changes what later boxes contain; subscribers keep the frequency and delivery day they signed up for
+func (service *subscriptionService) UpdatePlan(id string, request dto.SubscriptionPlanRequest) (*model.SubscriptionPlan, error) {
+	var plan *model.SubscriptionPlan
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		var err error
+		plan, err = service.repo.UpdatePlan(ctx, id, func(plan *model.SubscriptionPlan) error {
+			return service.applyPlanRequest(plan, request, time.Now())
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (service *subscriptionService) FindPlans(activeOnly bool) []model.SubscriptionPlan {
+	return service.repo.FindPlans(func(plan model.SubscriptionPlan) bool {
+		return plan.Active || !activeOnly
+	})
+}
+
+// Subscribe starts the plan's boxes on its first delivery day on or after the start date, tomorrow at the earliest
+func (service *subscriptionService) Subscribe(userID string, request dto.SubscribeRequest, locale string) (*model.Subscription, error) {
+	plan, err := service.repo.FindPlan(request.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Active {
+		return nil, core.Error.NotFound.SubscriptionPlan
+	}
+	address := dto.ToDeliveryAddress(request.Address)
+	if _, err := resolveZone(service.zoneRepo, address.Address); err != nil {
+		return nil, err
+	}
+	start := util.StoreToday().AddDate(0, 0, 1)
+	if request.StartDate != "" {
+		date, err := util.ParseStoreDate(request.StartDate)
+		if err != nil || date.Before(start) {
+			return nil, core.Error.Invalid.Date
+		}
+		start = date
+	}
+
+	now := time.Now()
+	subscription := model.Subscription{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		PlanID:        plan.ID,
+		PlanName:      plan.Name,
+		Status:        model.SubscriptionActive,
+		Frequency:     plan.Frequency,
+		DeliveryDay:   plan.DeliveryDay,
+		PaymentMethod: model.PaymentMethod(request.PaymentMethod),
+		Address:       address,
+		Note:          strings.TrimSpace(request.Note),
+		Locale:        locale,
+		NextDelivery:  plan.FirstDelivery(start),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		service.repo.Create(ctx, subscription)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (service *subscriptionService) FindMine(userID string) []model.Subscription {
+	return service.repo.FindAll(func(subscription model.Subscription) bool {
+		return subscription.UserID == userID
+	})
+}
+
+func (service *subscriptionService) FindMineByID(userID string, id string) (*model.Subscription, error) {
+	subscription, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != userID {
+		return nil, core.Error.NotFound.Subscription
+	}
+	return subscription, nil
+}
+
+func (service *subscriptionService) Pause(userID string, id string, request dto.PauseSubscriptionRequest) (*model.Subscription, error) {
+	var until time.Time
+	if request.Until != "" {
+		date, err := util.ParseStoreDate(request.Until)
+		if err != nil {
+			return nil, core.Error.Invalid.Date
+		}
+		until = date
+	}
+	return service.updateMine(userID, id, func(subscription *model.Subscription, now time.Time) error {
+		return subscription.Pause(until, util.StoreToday(), now)
+	})
+}
+
+func (service *subscriptionService) Resume(userID string, id string) (*model.Subscription, error) {
+	return service.updateMine(userID, id, func(subscription *model.Subscription, now time.Time) error {
+		return subscription.Resume(now)
+	})
+}
+
+// Skip leaves out the box of a delivery day that has not been ordered yet
+func (service *subscriptionService) Skip(userID string, id string, request dto.SkipDeliveryRequest) (*model.Subscription, error) {
+	date, err := util.ParseStoreDate(request.Date)
+	if err != nil {
+		return nil, core.Error.Invalid.Date
+	}
+	return service.updateMine(userID, id, func(subscription *model.Subscription, now time.Time) error {
+		return subscription.Skip(date, now)
+	})
+}
+
+func (service *subscriptionService) Unskip(userID string, id string, date string) (*model.Subscription, error) {
+	skipped, err := util.ParseStoreDate(date)
+	if err != nil {
+		return nil, core.Error.Invalid.Date
+	}
+	return service.updateMine(userID, id, func(subscription *model.Subscription, now time.Time) error {
+		return subscription.Unskip(skipped, now)
+	})
+}
+
+// Cancel ends the subscription; boxes already ordered are delivered, or cancelled like any other order
+func (service *subscriptionService) Cancel(userID string, id string, request dto.CancelSubscriptionRequest) (*model.Subscription, error) {
+	return service.updateMine(userID, id, func(subscription *model.Subscription, now time.Time) error {
+		return subscription.Cancel(request.Reason, now)
+	})
+}
+
+func (service *subscriptionService) FindAll(query dto.SubscriptionQuery) dto.Page[model.Subscription] {
+	subscriptions := service.repo.FindAll(func(subscription model.Subscription) bool {
+		return (query.Status == "" || subscription.Status == model.SubscriptionStatus(query.Status)) &&
+			(query.PlanID == "" || subscription.PlanID == query.PlanID)
+	})
+	return dto.Paginate(subscriptions, query.PageRequest)
+}
+
+func (service *subscriptionService) FindByID(id string) (*model.Subscription, error) {
+	return service.repo.FindByID(id)
+}
+
+// Generate runs every subscription that is not cancelled: paused ones whose pause ended resume, and those whose
+// next delivery day is within the lead time have it ordered or recorded as skipped. It returns what became of the
+// delivery days it reached.
+func (service *subscriptionService) Generate() []model.BoxRun {
+	now := time.Now()
+	var runs []model.BoxRun
+	for _, subscription := range service.repo.FindAll(func(subscription model.Subscription) bool {
+		return subscription.Status != model.SubscriptionCancelled
+	}) {
+		run, order, err := service.run(subscription.ID, now)
+		if err != nil {
+			zap.L().Warn("Could not run subscription", zap.String("subscription", subscription.ID), zap.Error(err))
+			continue
+		}
+		if run == nil {
+			continue
+		}
+		runs = append(runs, *run)
+		service.announce(&subscription, run, order)
+	}
+	return runs
+}
+
+// run brings one subscription up to date. A box that cannot be placed leaves no stock or slot behind: the day is
+// recorded as failed in a second transaction.
+func (service *subscriptionService) run(id string, now time.Time) (*model.BoxRun, *model.Order, error) {
+	today := util.StoreToday()
+	var run *model.BoxRun
+	var placed *model.Order
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		run, placed = nil, nil
+		subscription, err := service.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		resume := subscription.PauseEnded(today)
+		if resume {
+			_ = subscription.Resume(now)
+		}
+		if subscription.Status != model.SubscriptionCancelled && subscription.NextDelivery.Sub(now) <= service.leadTime {
+			run = &model.BoxRun{SubscriptionID: id, Date: subscription.NextDelivery, Outcome: model.BoxSkipped, At: now}
+			switch {
+			case !subscription.NextDelivery.After(now):
+				run.Outcome, run.Notes = model.BoxFailed, []model.Message{{ID: "Subscription.TooLate"}}
+			case subscription.Status == model.SubscriptionPaused:
+				run.Notes = []model.Message{{ID: "Subscription.Paused"}}
+			case subscription.Skips(subscription.NextDelivery):
+				run.Notes = []model.Message{{ID: "Subscription.Skipped"}}
+			default:
+				placed, run.Notes, err = service.placeBox(ctx, subscription, now)
+				if err != nil {
+					return err
+				}
+				run.Outcome, run.OrderID = model.BoxOrdered, placed.ID
+			}
+		}
+		if run == nil && !resume {
+			return nil
+		}
+
+		_, err = service.repo.Update(ctx, id, func(subscription *model.Subscription) error {
+			if resume {
+				_ = subscription.Resume(now)
+			}
+			if run != nil {
+				subscription.Record(*run, now)
+			}
+			return nil
+		})
+		return err
+	})
+	if err == nil || run == nil {
+		return run, placed, err
+	}
+
+	failed := model.BoxRun{SubscriptionID: id, Date: run.Date, Outcome: model.BoxFailed, Notes: []model.Message{boxFailure(err)}, At: now}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		_, err := service.repo.Update(ctx, id, func(subscription *model.Subscription) error {
+			if subscription.PauseEnded(today) {
+				_ = subscription.Resume(now)
+			}
+			subscription.Record(failed, now)
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &failed, nil, nil
+}
+
+// placeBox orders the subscription's box for its next delivery day at the day's prices. Items out of stock are
+// replaced by their first substitute in stock or left out; the notes say which.
+func (service *subscriptionService) placeBox(ctx context.Context, subscription *model.Subscription, now time.Time) (*model.Order, []model.Message, error) {
+	plan, err := service.repo.FindPlan(subscription.PlanID)
+	if err != nil {
+		return nil, nil, err
+	}
+	zone, err := resolveZone(service.zoneRepo, subscription.Address.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order := model.Order{
+		ID:             uuid.NewString(),
+		UserID:         subscription.UserID,
+		Status:         model.OrderConfirmed,
+		PaymentMethod:  subscription.PaymentMethod,
+		Address:        subscription.Address,
+		ZoneID:         zone.ID,
+		LocationID:     model.DefaultLocationID,
+		Note:           subscription.Note,
+		SubscriptionID: subscription.ID,
+		PlacedAt:       now,
+		UpdatedAt:      now,
+	}
+	if !order.PaymentMethod.PaidOnDelivery() {
+		order.Status = model.OrderPendingPayment
+		order.PaymentDueAt = subscription.NextDelivery
+	}
+	order.History = []model.OrderEvent{{To: order.Status, ActorID: model.SystemActor, At: now}}
+
+	var notes []model.Message
+	for _, item := range plan.Items {
+		line, ok := service.boxLine(ctx, &order, item, now)
+		if !ok {
+			notes = append(notes, model.Message{ID: "Subscription.LeftOut", Data: map[string]any{"Item": item.Name}})
+			continue
+		}
+		if line.SubstituteFor != "" {
+			notes = append(notes, model.Message{ID: "Subscription.Substituted", Data: map[string]any{"Substitute": line.Name, "Item": item.Name}})
+		}
+		order.Lines = append(order.Lines, line)
+		order.Subtotal += line.Total
+		order.Weight += line.Weight
+	}
+	if len(order.Lines) == 0 {
+		return nil, nil, core.Error.Conflict.OutOfStock
+	}
+	distance := deliveryDistance(service.locationRepo, order.LocationID, order.Address.Address)
+	order.DeliveryFee = zone.Quote(order.Subtotal, order.Weight, distance).Fee
+	order.Total = order.Subtotal + order.DeliveryFee
+
+	slot, err := service.bookSlot(ctx, &order, subscription.NextDelivery, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	order.SlotID = slot.ID
+	order.SlotStart = slot.Start
+	order.SlotEnd = slot.End
+
+	placed := service.orderRepo.Create(ctx, order)
+	return &placed, notes, nil
+}
+
+// boxLine holds stock of the item for the order, or of its first substitute that is on sale and in stock
+func (service *subscriptionService) boxLine(ctx context.Context, order *model.Order, item model.BoxItem, now time.Time) (model.OrderLine, bool) {
+	for _, productID := range append([]string{item.ProductID}, item.Substitutes...) {
+		product, err := service.productRepo.FindByID(productID)
+		if err != nil || !product.Active || product.SaleRule.Check(item.Quantity) != nil {
+			continue
+		}
+		line, err := reserveOrderLine(ctx, service.inventoryRepo, order, product, item.Quantity, now)
+		if err != nil {
+			continue
+		}
+		if productID != item.ProductID {
+			line.SubstituteFor = item.ProductID
+		}
+		return line, true
+	}
+	return model.OrderLine{}, false
+}
+
+// bookSlot books the earliest slot of the date that serves the order's zone and has room for it
+func (service *subscriptionService) bookSlot(ctx context.Context, order *model.Order, date time.Time, now time.Time) (*model.DeliverySlot, error) {
+	end := date.AddDate(0, 0, 1)
+	slots := service.slotRepo.FindAll(func(slot model.DeliverySlot) bool {
+		return !slot.Start.Before(date) && slot.Start.Before(end) && slot.Serves(order.ZoneID) &&
+			slot.Open(now) && slot.Fits(order.Weight)
+	})
+	if len(slots) == 0 {
+		return nil, core.Error.Conflict.SlotFull
+	}
+	slices.SortFunc(slots, func(a, b model.DeliverySlot) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.ID, b.ID))
+	})
+	slot, err := service.slotRepo.Update(ctx, slots[0].ID, func(slot *model.DeliverySlot) error {
+		return slot.Book(order.Weight, now)
+	})
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return slot, nil
+}
+
+// boxFailure tells the customer why no box could be ordered
+func boxFailure(err error) model.Message {
+	switch err {
+	case core.Error.Conflict.OutOfStock:
+		return model.Message{ID: "Subscription.NothingInStock"}
+	case core.Error.Conflict.SlotFull:
+		return model.Message{ID: "Subscription.NoSlot"}
+	case core.Error.Invalid.OutOfDeliveryArea:
+		return model.Message{ID: "Subscription.OutOfDeliveryArea"}
+	case core.Error.NotFound.SubscriptionPlan:
+		return model.Message{ID: "Subscription.PlanGone"}
+	}
+	zap.L().Warn("Could not order subscription box", zap.Error(err))
+	return model.Message{ID: "Subscription.BoxFailed"}
+}
+
+// announce tells the customer about an ordered or failed box, in the language they subscribed in. Boxes paid online
+// start their payment here, once the order is saved, so the customer gets the gateway link or transfer instructions
+// with the news.
+func (service *subscriptionService) announce(subscription *model.Subscription, run *model.BoxRun, order *model.Order) {
+	locale := subscription.Locale
+	date := run.Date.In(util.StoreLocation).Format("02/01")
+	switch run.Outcome {
+	case model.BoxOrdered:
+		body := []string{dto.Localize(model.Message{ID: "Subscription.OrderedBody", Data: map[string]any{
+			"Total": order.Total,
+			"Start": order.SlotStart.In(util.StoreLocation).Format("15:04 02/01"),
+			"End":   order.SlotEnd.In(util.StoreLocation).Format("15:04"),
+		}}, locale)}
+		for _, note := range run.Notes {
+			body = append(body, dto.Localize(note, locale)+".")
+		}
+		if !order.PaymentMethod.PaidOnDelivery() {
+			payment, err := service.payments.Pay(order.UserID, order.ID, "")
+			switch {
+			case err != nil:
+				zap.L().Warn("Could not start subscription payment", zap.String("order", order.ID), zap.Error(err))
+				body = append(body, dto.Localize(model.Message{ID: "Subscription.PayInApp", Data: map[string]any{
+					"DueAt": order.PaymentDueAt.In(util.StoreLocation).Format("15:04 02/01"),
+				}}, locale))
+			case payment.RedirectURL != "":
+				body = append(body, dto.Localize(model.Message{ID: "Subscription.PayHere", Data: map[string]any{
+					"URL": payment.RedirectURL,
+				}}, locale))
+			default:
+				body = append(body, payment.Instructions)
+			}
+		}
+		service.notify(infra_interface.Notification{
+			UserID: subscription.UserID,
+			Subject: dto.Localize(model.Message{ID: "Subscription.OrderedSubject", Data: map[string]any{
+				"Plan": subscription.PlanName, "Date": date, "Number": order.Number,
+			}}, locale),
+			Body: strings.Join(body, " "),
+		})
+	case model.BoxFailed:
+		body := make([]string, 0, len(run.Notes))
+		for _, note := range run.Notes {
+			body = append(body, dto.Localize(note, locale))
+		}
+		service.notify(infra_interface.Notification{
+			UserID: subscription.UserID,
+			Subject: dto.Localize(model.Message{ID: "Subscription.FailedSubject", Data: map[string]any{
+				"Plan": subscription.PlanName, "Date": date,
+			}}, locale),
+			Body: strings.Join(body, "; "),
+		})
+	}
+}
+
+// updateMine applies modify to the user's own subscription
+func (service *subscriptionService) updateMine(userID string, id string, modify func(subscription *model.Subscription, now time.Time) error) (*model.Subscription, error) {
+	var updated *model.Subscription
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		var err error
+		updated, err = service.repo.Update(ctx, id, func(subscription *model.Subscription) error {
+			if subscription.UserID != userID {
+				return core.Error.NotFound.Subscription
+			}
+			return modify(subscription, time.Now())
+		})
+		return domainError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// applyPlanRequest sets the plan's contents; every product and substitute must exist and be measured like the item
+func (service *subscriptionService) applyPlanRequest(plan *model.SubscriptionPlan, request dto.SubscriptionPlanRequest, now time.Time) error {
+	items := make([]model.BoxItem, 0, len(request.Items))
+	for _, requested := range request.Items {
+		product, err := service.productRepo.FindByID(requested.ProductID)
+		if err != nil {
+			return err
+		}
+		quantity, err := productQuantity(product, requested.Quantity)
+		if err != nil {
+			return err
+		}
+		for _, substituteID := range requested.Substitutes {
+			substitute, err := service.productRepo.FindByID(substituteID)
+			if err != nil {
+				return err
+			}
+			if _, err := quantity.In(substitute.PriceUnit); err != nil {
+				return core.Error.Invalid.Unit
+			}
+		}
+		items = append(items, model.BoxItem{
+			ProductID:   product.ID,
+			Name:        product.Name,
+			Quantity:    quantity,
+			Substitutes: requested.Substitutes,
+		})
+	}
+
+	plan.Name = strings.TrimSpace(request.Name)
+	plan.Description = strings.TrimSpace(request.Description)
+	plan.Items = items
+	plan.Frequency = model.SubscriptionFrequency(request.Frequency)
+	plan.DeliveryDay = time.Weekday(request.DeliveryDay)
+	if request.Active != nil {
+		plan.Active = *request.Active
+	}
+	plan.UpdatedAt = now
+	return domainError(plan.Validate())
+}
+
+func (service *subscriptionService) notify(notification infra_interface.Notification) {
+	if err := service.notifier.Notify(notification); err != nil {
+		zap.L().Warn("Could not send subscription notification", zap.String("subject", notification.Subject), zap.Error(err))
+	}
+}
+
+func (service *subscriptionService) Name() string { return "SubscriptionService" }
+func (service *subscriptionService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *subscriptionService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+var SubscriptionServiceModule = fx.Options(fx.Provide(NewSubscriptionService))
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+)
+
+type SupplierService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(request dto.SupplierRequest) (*model.Supplier, error)
+	Update(id string, request dto.SupplierRequest) (*model.Supplier, error)
+	FindById(id string) (*model.Supplier, error)
+	FindAll(query dto.SupplierQuery) []model.Supplier
+}
+
+type supplierService struct {
+	repo        repository.SupplierRepository
+	productRepo repository.ProductRepository
+	transactor  infra_interface.Transactor
+}
+
+func NewSupplierService(
+	repo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	transactor infra_interface.Transactor,
+) SupplierService {
+	return &supplierService{repo: repo, productRepo: productRepo, transactor: transactor}
+}
+
+func (service *supplierService) Create(request dto.SupplierRequest) (*model.Supplier, error) {
+	now := time.Now()
+	supplier := model.Supplier{ID: request.ID, Active: true, CreatedAt: now}
+	if err := service.apply(&supplier, request, now); err != nil {
+		return nil, err
+	}
+	err := service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Create(ctx, supplier)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (service *supplierService) Update(id string, request dto.SupplierRequest) (*model.Supplier, error) {
+	supplier, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.apply(supplier, request, time.Now()); err != nil {
+		return nil, err
+	}
+	err = service.transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return service.repo.Update(ctx, *supplier)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (service *supplierService) FindById(id string) (*model.Supplier, error) {
+	return service.repo.FindByID(id)
+}
+
+func (service *supplierService) FindAll(query dto.SupplierQuery) []model.Supplier {
+	suppliers := make([]model.Supplier, 0)
+	for _, supplier := range service.repo.FindAll() {
+		if !supplier.Active && !query.IncludeInactive {
+			continue
+		}
+		if _, ok := supplier.Supplies(query.ProductID); query.ProductID != "" && !ok {
+			continue
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	return suppliers
+}
+
+func (service *supplierService) Name() string { return "SupplierService" }
+func (service *supplierService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *supplierService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+func (service *supplierService) apply(supplier *model.Supplier, request dto.SupplierRequest, now time.Time) error {
+	for _, product := range request.Products {
+		if _, err := service.productRepo.FindByID(product.ProductID); err != nil {
+			return err
+		}
+	}
+
+	supplier.Name = request.Name
+	supplier.Type = model.SupplierType(request.Type)
+	supplier.Contacts = dto.ToSupplierContacts(request.Contacts)
+	supplier.LeadTimeDays = request.LeadTimeDays
+	supplier.Products = dto.ToSuppliedProducts(request.Products)
+	supplier.Note = request.Note
+	supplier.UpdatedAt = now
+	if request.Active != nil {
+		supplier.Active = *request.Active
+	}
+	if err := supplier.Validate(); errors.Is(err, model.ErrInvalidSupplier) {
+		return core.Error.Invalid.Supplier
+	}
+	return nil
+}
+
+var SupplierServiceModule = fx.Options(fx.Provide(NewSupplierService))
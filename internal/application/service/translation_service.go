@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type TranslationService interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Locales() []string
+	FindMissing(query dto.MissingTranslationQuery) (dto.Page[dto.MissingTranslationResponse], error)
+}
+
+type translationService struct {
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+}
+
+func NewTranslationService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository) TranslationService {
+	return &translationService{productRepo: productRepo, categoryRepo: categoryRepo}
+}
+
+func (service *translationService) Locales() []string {
+	return supportedLocales()
+}
+
+// FindMissing lists every product and category field that has no translation in a non-default locale
+func (service *translationService) FindMissing(query dto.MissingTranslationQuery) (dto.Page[dto.MissingTranslationResponse], error) {
+	locales := translatedLocales()
+	if query.Locale != "" {
+		if !slices.Contains(locales, query.Locale) {
+			return dto.Page[dto.MissingTranslationResponse]{}, core.Error.Invalid.Locale
+		}
+		locales = []string{query.Locale}
+	}
+
+	missing := make([]dto.MissingTranslationResponse, 0)
+	for _, locale := range locales {
+		for _, category := range service.categoryRepo.FindAll() {
+			if fields := category.Translations.Missing(locale, category.Description); len(fields) > 0 {
+				missing = append(missing, dto.MissingTranslationResponse{
+					EntityType: "category",
+					EntityID:   category.ID,
+					Reference:  category.Slug,
+					Locale:     locale,
+					Fields:     fields,
+				})
+			}
+		}
+		for _, product := range service.productRepo.FindAll() {
+			if fields := product.Translations.Missing(locale, product.Description); len(fields) > 0 {
+				missing = append(missing, dto.MissingTranslationResponse{
+					EntityType: "product",
+					EntityID:   product.ID,
+					Reference:  product.SKU,
+					Locale:     locale,
+					Fields:     fields,
+				})
+			}
+		}
+	}
+	return dto.Paginate(missing, query.PageRequest), nil
+}
+
+func (service *translationService) Name() string { return "TranslationService" }
+func (service *translationService) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+func (service *translationService) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", service.Name()))
+	return nil
+}
+
+// supportedLocales - locales that have an i18n message file; the catalog accepts content for these only
+func supportedLocales() []string {
+	if core.Translator == nil {
+		return []string{util.DefaultLocale}
+	}
+	return core.Translator.Locales()
+}
+
+// translatedLocales - supported locales other than the default one, whose content is stored as translations
+func translatedLocales() []string {
+	return slices.DeleteFunc(supportedLocales(), func(locale string) bool {
+		return locale == util.DefaultLocale
+	})
+}
+
+func validateTranslations(translations map[string]dto.TranslationDto) error {
+	locales := translatedLocales()
+	for locale := range translations {
+		if !slices.Contains(locales, locale) {
+			return core.Error.Invalid.Locale
+		}
+	}
+	return nil
+}
+
+var TranslationServiceModule = fx.Options(fx.Provide(NewTranslationService))
@@ -32,16 +32,7 @@ func (service *userService) Greeting() string {
 }
 
 func (service *userService) FindById(id string) (*model.User, error) {
-	if id == "1" {
-		return nil, core.Error.NotFound.User
-
-	} else {
-		return &model.User{
-			Name: "Test",
-			Age:  18,
-			Sex:  true,
-		}, nil
-	}
+	return service.repo.FindByID(id)
 }
 
 func (service *userService) FindByUsername(username string) (*model.User, error) {
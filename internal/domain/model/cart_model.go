@@ -0,0 +1,144 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// CartOwner addresses a cart: the signed-in user's, else the guest cart with the token
+type CartOwner struct {
+	UserID string
+	Token  string
+}
+
+// CartLine is a product in a cart, in the unit the customer picked
+type CartLine struct {
+	ProductID string
+	Quantity  Quantity
+	UnitPrice Money // Price per the product's price unit when the customer last saw the line
+	AddedAt   time.Time
+	UpdatedAt time.Time
+}
+
+// Cart belongs to a signed-in user, or to a guest who addresses it with an anonymous token
+type Cart struct {
+	ID        string
+	UserID    string // Empty for a guest cart
+	Token     string // Guest carts only, an unguessable token the app keeps
+	Lines     []CartLine
+	Coupon    string // Promotion code the customer entered
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (cart *Cart) IsGuest() bool {
+	return cart.UserID == ""
+}
+
+func (cart *Cart) Line(productID string) (CartLine, bool) {
+	index := slices.IndexFunc(cart.Lines, func(line CartLine) bool { return line.ProductID == productID })
+	if index < 0 {
+		return CartLine{}, false
+	}
+	return cart.Lines[index], true
+}
+
+// Put adds the line, or replaces the line of the same product keeping when it was first added
+func (cart *Cart) Put(line CartLine) {
+	lines := slices.Clone(cart.Lines)
+	index := slices.IndexFunc(lines, func(existing CartLine) bool { return existing.ProductID == line.ProductID })
+	if index < 0 {
+		cart.Lines = append(lines, line)
+		return
+	}
+	line.AddedAt = lines[index].AddedAt
+	lines[index] = line
+	cart.Lines = lines
+}
+
+func (cart *Cart) Remove(productID string) bool {
+	lines := slices.DeleteFunc(slices.Clone(cart.Lines), func(line CartLine) bool { return line.ProductID == productID })
+	removed := len(lines) != len(cart.Lines)
+	cart.Lines = lines
+	return removed
+}
+
+// Merge moves the lines of another cart into this one. Quantities of a product in both carts add up,
+// unless they are measured differently, in which case this cart's line wins; so does this cart's coupon.
+func (cart *Cart) Merge(other Cart, now time.Time) {
+	for _, line := range other.Lines {
+		existing, ok := cart.Line(line.ProductID)
+		if !ok {
+			line.UpdatedAt = now
+			cart.Put(line)
+			continue
+		}
+		total, err := existing.Quantity.Add(line.Quantity)
+		if err != nil {
+			continue
+		}
+		existing.Quantity = total
+		existing.UpdatedAt = now
+		cart.Put(existing)
+	}
+	if cart.Coupon == "" {
+		cart.Coupon = other.Coupon
+	}
+	cart.UpdatedAt = now
+}
+
+type CartIssue string
+
+const (
+	CartIssueUnavailable       CartIssue = "unavailable"        // Product removed or no longer sold
+	CartIssueOutOfStock        CartIssue = "out_of_stock"       // Nothing left to sell
+	CartIssueInsufficientStock CartIssue = "insufficient_stock" // Less left than the cart asks for
+	CartIssuePriceChanged      CartIssue = "price_changed"      // Price differs from when the customer last saw it
+	CartIssueQuantityRule      CartIssue = "quantity_rule"      // Quantity no longer fits the product's sale rule
+)
+
+// PricedCartLine is a cart line checked against the current catalog and stock
+type PricedCartLine struct {
+	CartLine
+	Product       *Product // Nil when the product no longer exists
+	Total         Money
+	Available     int64 // Base units
+	PreviousPrice Money // Set with CartIssuePriceChanged
+	Issues        []CartIssue
+}
+
+// PricedCart is a cart as the customer sees it: current prices, stock and whatever needs their attention
+type PricedCart struct {
+	Cart       Cart
+	Lines      []PricedCartLine
+	Promotions PromotionResult // Before delivery, which is priced at checkout
+}
+
+// Subtotal sums the line totals at current prices; lines of unavailable products count for nothing
+func (cart *PricedCart) Subtotal() Money {
+	total := Money(0)
+	for _, line := range cart.Lines {
+		total += line.Total
+	}
+	return total
+}
+
+// PromotionLines are the cart's lines as promotions see them, in the same order
+func (cart *PricedCart) PromotionLines() []PromotionLine {
+	lines := make([]PromotionLine, 0, len(cart.Lines))
+	for _, line := range cart.Lines {
+		promotionLine := PromotionLine{ProductID: line.ProductID, Quantity: line.Quantity, Total: line.Total}
+		if line.Product != nil {
+			promotionLine.CategoryID = line.Product.CategoryID
+		}
+		lines = append(lines, promotionLine)
+	}
+	return lines
+}
+
+// Ready reports whether the cart can go to checkout as it is
+func (cart *PricedCart) Ready() bool {
+	return len(cart.Lines) > 0 && !slices.ContainsFunc(cart.Lines, func(line PricedCartLine) bool {
+		return slices.ContainsFunc(line.Issues, func(issue CartIssue) bool { return issue != CartIssuePriceChanged })
+	})
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Category struct {
+	ID           string
+	Slug         string
+	Name         string // Default locale
+	Description  string // Default locale
+	Translations Translations
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (category *Category) Localized(locale string) (string, string) {
+	return category.Translations.Resolve(locale, category.Name, category.Description)
+}
@@ -0,0 +1,255 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrClaimStatus        = errors.New("claim has already been decided")
+	ErrClaimLines         = errors.New("claim lines do not match what was delivered")
+	ErrTooManyClaimPhotos = errors.New("claim has too many photos")
+	ErrClaimWindow        = errors.New("the order can no longer be claimed")
+	ErrClaimDisposition   = errors.New("missing goods cannot be restocked")
+)
+
+const MaxClaimPhotos = 5
+
+type ClaimReason string
+
+const (
+	ClaimBruised   ClaimReason = "bruised"
+	ClaimSpoiled   ClaimReason = "spoiled"
+	ClaimMissing   ClaimReason = "missing"
+	ClaimWrongItem ClaimReason = "wrong_item"
+	ClaimOther     ClaimReason = "other"
+)
+
+// Returned reports whether goods claimed for the reason come back to the store: missing goods never reached the
+// customer, and a wrong item is not the product claimed
+func (reason ClaimReason) Returned() bool {
+	return reason != ClaimMissing && reason != ClaimWrongItem
+}
+
+type ClaimStatus string
+
+const (
+	ClaimPending   ClaimStatus = "pending"
+	ClaimRefunding ClaimStatus = "refunding" // Approved as a refund the payment provider is carrying out
+	ClaimApproved  ClaimStatus = "approved"
+	ClaimRejected  ClaimStatus = "rejected"
+)
+
+// ClaimResolution is how an approved claim is paid back
+type ClaimResolution string
+
+const (
+	ResolutionRefund      ClaimResolution = "refund"       // To the payment the order was paid with
+	ResolutionStoreCredit ClaimResolution = "store_credit" // To the customer's store credit
+)
+
+// ClaimDisposition is what happens to the claimed goods
+type ClaimDisposition string
+
+const (
+	DispositionWriteOff ClaimDisposition = "write_off" // Unsellable: recorded as returned, then spoiled
+	DispositionRestock  ClaimDisposition = "restock"   // Back on the shelf after inspection
+)
+
+// ClaimLine is part of an order line the customer is unhappy with
+type ClaimLine struct {
+	ProductID string
+	Name      string
+	Quantity  Quantity // In the order line's unit
+	Reason    ClaimReason
+	Amount    Money // What the quantity cost at the order's locked price
+}
+
+type ClaimPhoto struct {
+	ID          string
+	Key         string
+	URL         string
+	ContentType string
+}
+
+// Claim is a customer asking for their money back on delivered produce, with photos as evidence.
+// Staff approve it with a full or partial refund or store credit, or reject it.
+type Claim struct {
+	ID          string
+	OrderID     string
+	OrderNumber string
+	UserID      string
+	Lines       []ClaimLine
+	Description string
+	Photos      []ClaimPhoto
+	Status      ClaimStatus
+	Claimed     Money  // Sum of the lines
+	Locale      string // Language the customer claimed in, which the decision is sent in
+
+	Resolution    ClaimResolution
+	Disposition   ClaimDisposition
+	Amount        Money  // Paid back; at most what was claimed
+	PaymentID     string // Refunded payment
+	TransactionID string // Provider's id of the refund
+	DecidedBy     string
+	DecisionNote  string // Shown to the customer
+	DecidedAt     time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewClaimLine prices quantity of the order's line of the product at what was paid for it. The quantity must measure
+// the same thing as the line and not exceed what is left of it once earlier claims are taken off.
+func NewClaimLine(order *Order, productID string, quantity Quantity, reason ClaimReason, claimed int64) (ClaimLine, error) {
+	line, ok := order.Line(productID)
+	if !ok {
+		return ClaimLine{}, ErrClaimLines
+	}
+	quantity, err := quantity.In(line.Quantity.Unit)
+	if err != nil {
+		return ClaimLine{}, err
+	}
+	if quantity.Base <= 0 || quantity.Base > line.Quantity.Base-claimed {
+		return ClaimLine{}, ErrClaimLines
+	}
+	return ClaimLine{
+		ProductID: productID,
+		Name:      line.Name,
+		Quantity:  quantity,
+		Reason:    reason,
+		Amount:    line.Paid().MulRatio(quantity.Base, line.Quantity.Base),
+	}, nil
+}
+
+func (claim *Claim) Validate() error {
+	if len(claim.Lines) == 0 {
+		return ErrClaimLines
+	}
+	for index, line := range claim.Lines {
+		if slices.ContainsFunc(claim.Lines[:index], func(other ClaimLine) bool { return other.ProductID == line.ProductID }) {
+			return ErrClaimLines
+		}
+	}
+	if len(claim.Photos) > MaxClaimPhotos {
+		return ErrTooManyClaimPhotos
+	}
+	return nil
+}
+
+// Open reports whether the claim still counts against its order lines
+func (claim *Claim) Open() bool {
+	return claim.Status != ClaimRejected
+}
+
+// ClaimedQuantity sums what open claims ask for of a product
+func ClaimedQuantity(claims []Claim, productID string) int64 {
+	var claimed int64
+	for _, claim := range claims {
+		if !claim.Open() {
+			continue
+		}
+		for _, line := range claim.Lines {
+			if line.ProductID == productID {
+				claimed += line.Quantity.Base
+			}
+		}
+	}
+	return claimed
+}
+
+// StartRefund holds a pending claim while the provider refunds it, so nobody decides it twice
+func (claim *Claim) StartRefund(actorID string, amount Money, now time.Time) error {
+	if claim.Status != ClaimPending {
+		return ErrClaimStatus
+	}
+	if amount <= 0 || amount > claim.Claimed {
+		return ErrRefundAmount
+	}
+	claim.Status = ClaimRefunding
+	claim.Resolution = ResolutionRefund
+	claim.Amount = amount
+	claim.DecidedBy = actorID
+	claim.UpdatedAt = now
+	return nil
+}
+
+// CancelRefund puts the claim back to pending when the provider did not refund it
+func (claim *Claim) CancelRefund(now time.Time) {
+	if claim.Status == ClaimRefunding {
+		claim.Status = ClaimPending
+		claim.Resolution = ""
+		claim.Amount = 0
+		claim.DecidedBy = ""
+		claim.UpdatedAt = now
+	}
+}
+
+// Approve settles the claim. A refund is approved from refunding once the provider carried it out; store credit
+// straight from pending.
+func (claim *Claim) Approve(actorID string, resolution ClaimResolution, amount Money, disposition ClaimDisposition, note string, now time.Time) error {
+	switch {
+	case resolution == ResolutionRefund && claim.Status != ClaimRefunding,
+		resolution == ResolutionStoreCredit && claim.Status != ClaimPending:
+		return ErrClaimStatus
+	}
+	if amount <= 0 || amount > claim.Claimed {
+		return ErrRefundAmount
+	}
+	if err := claim.CheckDisposition(disposition); err != nil {
+		return err
+	}
+	claim.Status = ClaimApproved
+	claim.Resolution = resolution
+	claim.Disposition = disposition
+	claim.Amount = amount
+	claim.DecidedBy = actorID
+	claim.DecisionNote = strings.TrimSpace(note)
+	claim.DecidedAt = now
+	claim.UpdatedAt = now
+	return nil
+}
+
+// CheckDisposition refuses to restock a claim with missing goods: there is nothing to put back on the shelf
+func (claim *Claim) CheckDisposition(disposition ClaimDisposition) error {
+	if disposition != DispositionRestock {
+		return nil
+	}
+	for _, line := range claim.Lines {
+		if line.Reason == ClaimMissing {
+			return ErrClaimDisposition
+		}
+	}
+	return nil
+}
+
+// Reject turns the claim down; the customer is told why
+func (claim *Claim) Reject(actorID string, note string, now time.Time) error {
+	if claim.Status != ClaimPending {
+		return ErrClaimStatus
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return ErrReasonRequired
+	}
+	claim.Status = ClaimRejected
+	claim.DecidedBy = actorID
+	claim.DecisionNote = note
+	claim.DecidedAt = now
+	claim.UpdatedAt = now
+	return nil
+}
+
+// CheckClaimable allows claims on an order delivered less than window ago
+func CheckClaimable(order *Order, window time.Duration, now time.Time) error {
+	deliveredAt, delivered := order.ReachedAt(OrderDelivered)
+	if order.Status != OrderDelivered || !delivered {
+		return ErrOrderStatus
+	}
+	if !now.Before(deliveredAt.Add(window)) {
+		return ErrClaimWindow
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidShipper        = errors.New("shipper needs a name and a phone number")
+	ErrDeliveryStatus        = errors.New("delivery cannot do that in its current status")
+	ErrProofRequired         = errors.New("a delivery needs a photo or signature as proof")
+	ErrTooManyDeliveryProofs = errors.New("delivery has too many proofs")
+	ErrCashRemitted          = errors.New("cash handed over differs from what the shipper collected")
+)
+
+const MaxDeliveryProofs = 5
+
+// Shipper is a user with the shipper role, as registered by dispatchers. Customers see their name and phone.
+type Shipper struct {
+	ID        string // User id
+	Name      string
+	Phone     string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (shipper *Shipper) Validate() error {
+	if strings.TrimSpace(shipper.ID) == "" || strings.TrimSpace(shipper.Name) == "" || strings.TrimSpace(shipper.Phone) == "" {
+		return ErrInvalidShipper
+	}
+	return nil
+}
+
+func (shipper *Shipper) Contact() *ShipperContact {
+	return &ShipperContact{ID: shipper.ID, Name: shipper.Name, Phone: shipper.Phone}
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryAssigned  DeliveryStatus = "assigned"
+	DeliveryPickedUp  DeliveryStatus = "picked_up"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed" // Refused or nobody home; the goods come back
+)
+
+type DeliveryProofKind string
+
+const (
+	ProofPhoto     DeliveryProofKind = "photo"
+	ProofSignature DeliveryProofKind = "signature" // Image of the recipient's signature
+)
+
+type DeliveryProof struct {
+	ID          string
+	Kind        DeliveryProofKind
+	Key         string
+	URL         string
+	ContentType string
+	CreatedAt   time.Time
+}
+
+// Delivery is an order on a shipper's run sheet, from assignment to the door, and the cash collected for it until
+// the shipper hands it over
+type Delivery struct {
+	OrderID       string // One delivery per order
+	OrderNumber   string
+	ShipperID     string
+	Status        DeliveryStatus
+	CODDue        Money // Cash to collect; 0 for orders paid online
+	Collected     Money
+	PaymentID     string // Cash-on-delivery payment the collection settled
+	Proofs        []DeliveryProof
+	FailureReason string
+	AssignedBy    string
+	RemittedTo    string // Staff who took the cash
+	AssignedAt    time.Time
+	PickedUpAt    time.Time
+	FinishedAt    time.Time // Delivered or failed
+	RemittedAt    time.Time
+	UpdatedAt     time.Time
+}
+
+// Assign gives the delivery to a shipper; it can be handed to another one until it is picked up
+func (delivery *Delivery) Assign(shipperID string, actorID string, now time.Time) error {
+	if delivery.Status != "" && delivery.Status != DeliveryAssigned {
+		return ErrDeliveryStatus
+	}
+	delivery.Status = DeliveryAssigned
+	delivery.ShipperID = shipperID
+	delivery.AssignedBy = actorID
+	delivery.AssignedAt = now
+	delivery.UpdatedAt = now
+	return nil
+}
+
+func (delivery *Delivery) PickUp(now time.Time) error {
+	if delivery.Status != DeliveryAssigned {
+		return ErrDeliveryStatus
+	}
+	delivery.Status = DeliveryPickedUp
+	delivery.PickedUpAt = now
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// AddProof attaches a photo or signature while the delivery is on its way
+func (delivery *Delivery) AddProof(proof DeliveryProof, now time.Time) error {
+	if delivery.Status != DeliveryPickedUp {
+		return ErrDeliveryStatus
+	}
+	if len(delivery.Proofs) >= MaxDeliveryProofs {
+		return ErrTooManyDeliveryProofs
+	}
+	delivery.Proofs = append(slices.Clone(delivery.Proofs), proof)
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// Deliver completes the delivery once there is proof of it, with exactly the cash due collected
+func (delivery *Delivery) Deliver(collected Money, now time.Time) error {
+	if delivery.Status != DeliveryPickedUp {
+		return ErrDeliveryStatus
+	}
+	if len(delivery.Proofs) == 0 {
+		return ErrProofRequired
+	}
+	if collected != delivery.CODDue {
+		return ErrPaymentAmount
+	}
+	delivery.Status = DeliveryDelivered
+	delivery.Collected = collected
+	delivery.FinishedAt = now
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// Fail records why the order could not be handed over; the shipper brings it back
+func (delivery *Delivery) Fail(reason string, now time.Time) error {
+	if delivery.Status != DeliveryPickedUp {
+		return ErrDeliveryStatus
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	delivery.Status = DeliveryFailed
+	delivery.FailureReason = reason
+	delivery.FinishedAt = now
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// Outstanding is the cash the shipper collected and still holds
+func (delivery *Delivery) Outstanding() Money {
+	if delivery.RemittedAt.IsZero() {
+		return delivery.Collected
+	}
+	return 0
+}
+
+// RunSheetStop is a delivery on a shipper's run sheet with the order it brings
+type RunSheetStop struct {
+	Delivery Delivery
+	Order    Order
+}
+
+// CashReport is what one shipper delivered on a store day and the cash they owe the store for it
+type CashReport struct {
+	Shipper     Shipper
+	Date        time.Time // Midnight in the store time zone
+	Deliveries  []Delivery
+	Delivered   int
+	Failed      int
+	Collected   Money
+	Remitted    Money
+	Outstanding Money
+}
+
+// NewCashReport sums the shipper's deliveries finished on the day
+func NewCashReport(shipper Shipper, date time.Time, deliveries []Delivery) CashReport {
+	report := CashReport{Shipper: shipper, Date: date, Deliveries: deliveries}
+	for _, delivery := range deliveries {
+		switch delivery.Status {
+		case DeliveryDelivered:
+			report.Delivered++
+		case DeliveryFailed:
+			report.Failed++
+		}
+		report.Collected += delivery.Collected
+		report.Outstanding += delivery.Outstanding()
+	}
+	report.Remitted = report.Collected - report.Outstanding
+	return report
+}
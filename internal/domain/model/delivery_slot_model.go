@@ -0,0 +1,176 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidDeliverySlot = errors.New("delivery slot must end after it starts and take a limited number or weight of orders")
+	ErrSlotFull            = errors.New("delivery slot is fully booked")
+	ErrSlotClosed          = errors.New("delivery slot can no longer be booked")
+	ErrSlotZone            = errors.New("delivery slot does not serve the address")
+	ErrSlotBooked          = errors.New("a booked delivery slot cannot stop serving the zone it was booked in")
+	ErrInvalidSlotTemplate = errors.New("slot template must fit in one day and take a limited number or weight of orders")
+)
+
+// DeliverySlot is a window in which orders are delivered, taking a limited number of orders, a limited weight, or both
+type DeliverySlot struct {
+	ID             string
+	ZoneID         string // Empty for a slot serving every zone
+	TemplateID     string // Template the slot was generated from; empty for slots staff opened by hand
+	Start          time.Time
+	End            time.Time
+	CutoffAt       time.Time // Bookings close; at the start when zero
+	Capacity       int       // Orders; 0 for no limit
+	WeightCapacity int64     // Grams; 0 for no limit
+	Booked         int
+	BookedWeight   int64
+	Active         bool
+	HolidayID      string // Set while a holiday closes the slot
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (slot *DeliverySlot) Validate() error {
+	if !slot.End.After(slot.Start) || slot.Capacity < 0 || slot.WeightCapacity < 0 {
+		return ErrInvalidDeliverySlot
+	}
+	if slot.Capacity == 0 && slot.WeightCapacity == 0 {
+		return ErrInvalidDeliverySlot
+	}
+	if slot.CutoffAt.After(slot.Start) {
+		return ErrInvalidDeliverySlot
+	}
+	return nil
+}
+
+// Remaining returns how many more orders the slot takes; limited is false when only the weight is
+func (slot *DeliverySlot) Remaining() (remaining int, limited bool) {
+	if slot.Capacity == 0 {
+		return 0, false
+	}
+	return max(slot.Capacity-slot.Booked, 0), true
+}
+
+// RemainingWeight returns how many more grams the slot takes; limited is false when only the number of orders is
+func (slot *DeliverySlot) RemainingWeight() (remaining int64, limited bool) {
+	if slot.WeightCapacity == 0 {
+		return 0, false
+	}
+	return max(slot.WeightCapacity-slot.BookedWeight, 0), true
+}
+
+// Fits reports whether one more order of weight grams stays within the slot's capacity
+func (slot *DeliverySlot) Fits(weight int64) bool {
+	if remaining, limited := slot.Remaining(); limited && remaining == 0 {
+		return false
+	}
+	if remaining, limited := slot.RemainingWeight(); limited && weight > remaining {
+		return false
+	}
+	return true
+}
+
+func (slot *DeliverySlot) Cutoff() time.Time {
+	if slot.CutoffAt.IsZero() {
+		return slot.Start
+	}
+	return slot.CutoffAt
+}
+
+// Open reports whether the slot still takes orders at now
+func (slot *DeliverySlot) Open(now time.Time) bool {
+	return slot.Active && slot.HolidayID == "" && now.Before(slot.Cutoff())
+}
+
+// Serves reports whether the slot delivers to the zone; an empty zone id is an address outside every zone
+func (slot *DeliverySlot) Serves(zoneID string) bool {
+	return slot.ZoneID == "" || slot.ZoneID == zoneID
+}
+
+// Book takes an order of weight grams
+func (slot *DeliverySlot) Book(weight int64, now time.Time) error {
+	if !slot.Open(now) {
+		return ErrSlotClosed
+	}
+	if !slot.Fits(weight) {
+		return ErrSlotFull
+	}
+	slot.Booked++
+	slot.BookedWeight += weight
+	slot.UpdatedAt = now
+	return nil
+}
+
+// Release frees a booking of weight grams, e.g. when the order is cancelled
+func (slot *DeliverySlot) Release(weight int64, now time.Time) {
+	slot.Booked = max(slot.Booked-1, 0)
+	slot.BookedWeight = max(slot.BookedWeight-weight, 0)
+	slot.UpdatedAt = now
+}
+
+// SlotTemplate opens a slot in a zone every week on a weekday. Times are minutes after midnight in the store time zone.
+type SlotTemplate struct {
+	ID             string
+	ZoneID         string // Empty for every zone
+	Weekday        time.Weekday
+	Starts         int
+	Ends           int
+	Cutoff         int   // Minutes before the start when bookings close, e.g. 600 closes an 08:00 slot at 22:00 the day before
+	Capacity       int   // Orders; 0 for no limit
+	WeightCapacity int64 // Grams; 0 for no limit
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (template *SlotTemplate) Validate() error {
+	if template.Weekday < time.Sunday || template.Weekday > time.Saturday {
+		return ErrInvalidSlotTemplate
+	}
+	if template.Starts < 0 || template.Ends > 24*60 || template.Ends <= template.Starts || template.Cutoff < 0 {
+		return ErrInvalidSlotTemplate
+	}
+	if template.Capacity < 0 || template.WeightCapacity < 0 || (template.Capacity == 0 && template.WeightCapacity == 0) {
+		return ErrInvalidSlotTemplate
+	}
+	return nil
+}
+
+// SlotOn builds the template's slot on date, which must be midnight in the store time zone. The id depends only on
+// the template and the date, so generating the same day twice finds the slot already there.
+func (template *SlotTemplate) SlotOn(date time.Time, now time.Time) DeliverySlot {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, template.Starts, 0, 0, date.Location())
+	end := time.Date(date.Year(), date.Month(), date.Day(), 0, template.Ends, 0, 0, date.Location())
+	return DeliverySlot{
+		ID:             fmt.Sprintf("%s-%s", template.ID, date.Format("20060102")),
+		ZoneID:         template.ZoneID,
+		TemplateID:     template.ID,
+		Start:          start,
+		End:            end,
+		CutoffAt:       start.Add(-time.Duration(template.Cutoff) * time.Minute),
+		Capacity:       template.Capacity,
+		WeightCapacity: template.WeightCapacity,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Holiday closes every slot starting on a store date, in one zone or all of them
+type Holiday struct {
+	ID        string
+	Date      time.Time // Midnight in the store time zone
+	ZoneID    string    // Empty for every zone
+	Name      string
+	CreatedAt time.Time
+}
+
+// Closes reports whether the holiday falls on the slot. Zone-wide slots close only for holidays of every zone.
+func (holiday *Holiday) Closes(slot *DeliverySlot) bool {
+	start := slot.Start.In(holiday.Date.Location())
+	sameDay := start.Year() == holiday.Date.Year() && start.YearDay() == holiday.Date.YearDay()
+	return sameDay && (holiday.ZoneID == "" || holiday.ZoneID == slot.ZoneID)
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDeliveryZone = errors.New("delivery zone needs a name, and districts or a polygon")
+	ErrInvalidDeliveryFee  = errors.New("delivery fee rule amounts must not be negative")
+)
+
+// ZoneArea is a district of a province, or only some of its wards
+type ZoneArea struct {
+	Province string
+	District string
+	Wards    []string // Empty for the whole district
+}
+
+// DeliveryFeeRule prices delivery in a zone. Distance is measured in a straight line from the location the order
+// ships from, and is only charged when both ends are geocoded.
+type DeliveryFeeRule struct {
+	BaseFee          Money
+	IncludedDistance int64 // Meters the base fee covers
+	PerKm            Money // Per started kilometer beyond the included distance
+	IncludedWeight   int64 // Grams the base fee covers
+	PerKg            Money // Per started kilogram beyond the included weight
+	FreeFrom         Money // Subtotal from which delivery is free; 0 for never
+}
+
+func (rule *DeliveryFeeRule) Validate() error {
+	if rule.BaseFee < 0 || rule.PerKm < 0 || rule.PerKg < 0 || rule.FreeFrom < 0 {
+		return ErrInvalidDeliveryFee
+	}
+	if rule.IncludedDistance < 0 || rule.IncludedWeight < 0 {
+		return ErrInvalidDeliveryFee
+	}
+	return nil
+}
+
+// DeliveryQuote is what delivering an order costs and why
+type DeliveryQuote struct {
+	ZoneID            string
+	Distance          int64 // Meters; 0 when it could not be measured
+	Weight            int64 // Grams
+	BaseFee           Money
+	DistanceSurcharge Money
+	WeightSurcharge   Money
+	FreeShipping      bool // The subtotal reached the zone's threshold, so nothing is charged
+	Fee               Money
+}
+
+// Quote prices delivering an order of subtotal and weight grams over distance meters
+func (rule *DeliveryFeeRule) Quote(subtotal Money, weight int64, distance int64) DeliveryQuote {
+	quote := DeliveryQuote{Distance: distance, Weight: weight, BaseFee: rule.BaseFee}
+	quote.DistanceSurcharge = rule.PerKm * Money(startedThousands(distance-rule.IncludedDistance))
+	quote.WeightSurcharge = rule.PerKg * Money(startedThousands(weight-rule.IncludedWeight))
+	quote.FreeShipping = rule.FreeFrom > 0 && subtotal >= rule.FreeFrom
+	if !quote.FreeShipping {
+		quote.Fee = quote.BaseFee + quote.DistanceSurcharge + quote.WeightSurcharge
+	}
+	return quote
+}
+
+// startedThousands counts the started thousands in amount, e.g. 1001 meters start two kilometers
+func startedThousands(amount int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+	return (amount + 999) / 1000
+}
+
+// DeliveryZone is an area the store delivers to, with its own delivery slots and fees. It is drawn as districts and
+// wards, as a polygon, or both: an address is in the zone when either matches.
+type DeliveryZone struct {
+	ID        string // Slug chosen by staff, e.g. "inner-city"
+	Name      string
+	Areas     []ZoneArea
+	Polygon   []GeoPoint // Vertices in order; the last joins back to the first
+	Fees      DeliveryFeeRule
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (zone *DeliveryZone) Validate() error {
+	if !slugPattern.MatchString(zone.ID) || strings.TrimSpace(zone.Name) == "" {
+		return ErrInvalidDeliveryZone
+	}
+	if len(zone.Areas) == 0 && len(zone.Polygon) == 0 {
+		return ErrInvalidDeliveryZone
+	}
+	for _, area := range zone.Areas {
+		if strings.TrimSpace(area.Province) == "" || strings.TrimSpace(area.District) == "" {
+			return ErrInvalidDeliveryZone
+		}
+	}
+	if len(zone.Polygon) > 0 && len(zone.Polygon) < 3 {
+		return ErrInvalidDeliveryZone
+	}
+	for _, point := range zone.Polygon {
+		if !point.Valid() {
+			return ErrInvalidDeliveryZone
+		}
+	}
+	return zone.Fees.Validate()
+}
+
+// Covers reports whether the zone delivers to the address: its geocoded point lies in the polygon, or its district
+// and ward are listed. Names are compared after fold, which should ignore case and accents so "Quận 1" matches
+// "quan 1".
+func (zone *DeliveryZone) Covers(address Address, fold func(string) string) bool {
+	if address.Point != nil && zone.Contains(*address.Point) {
+		return true
+	}
+	same := func(a string, b string) bool {
+		return strings.Join(strings.Fields(fold(a)), " ") == strings.Join(strings.Fields(fold(b)), " ")
+	}
+	for _, area := range zone.Areas {
+		if !same(area.Province, address.Province) || !same(area.District, address.District) {
+			continue
+		}
+		if len(area.Wards) == 0 {
+			return true
+		}
+		for _, ward := range area.Wards {
+			if same(ward, address.Ward) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Contains reports whether the point lies inside the zone's polygon, by counting how many of its edges a ray cast
+// east from the point crosses. Longitude is treated as flat, which is fine at the size of a city.
+func (zone *DeliveryZone) Contains(point GeoPoint) bool {
+	inside := false
+	for index, previous := 0, len(zone.Polygon)-1; index < len(zone.Polygon); previous, index = index, index+1 {
+		a, b := zone.Polygon[index], zone.Polygon[previous]
+		if (a.Latitude > point.Latitude) == (b.Latitude > point.Latitude) {
+			continue
+		}
+		crossing := a.Longitude + (point.Latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if point.Longitude < crossing {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Quote prices delivering an order to the zone
+func (zone *DeliveryZone) Quote(subtotal Money, weight int64, distance int64) DeliveryQuote {
+	quote := zone.Fees.Quote(subtotal, weight, distance)
+	quote.ZoneID = zone.ID
+	return quote
+}
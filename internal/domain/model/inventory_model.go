@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrReservationClosed = errors.New("reservation is no longer active")
+
+// DefaultLocationID - the central warehouse, used when a request does not name a location
+const DefaultLocationID = "central-warehouse"
+
+// StockLevel is the stock of one product at one location, in base units of the product's price dimension
+type StockLevel struct {
+	ProductID  string
+	LocationID string
+	Unit       UnitCode // Display unit, the product's price unit
+	OnHand     int64    // Physically at the location
+	Reserved   int64    // Held by active reservations, still on hand
+	InTransit  int64    // Transferred here and not received yet, so not available
+	UpdatedAt  time.Time
+}
+
+// Available is what can still be promised to a customer
+func (level *StockLevel) Available() int64 {
+	return max(level.OnHand-level.Reserved, 0)
+}
+
+func (level *StockLevel) Reserve(quantity int64) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if level.Available() < quantity {
+		return ErrInsufficientStock
+	}
+	level.Reserved += quantity
+	return nil
+}
+
+func (level *StockLevel) Release(quantity int64) {
+	level.Reserved = max(level.Reserved-quantity, 0)
+}
+
+// Adjust adds (positive delta) or removes stock on hand. Removing more than is on hand fails.
+func (level *StockLevel) Adjust(delta int64) error {
+	if level.OnHand+delta < 0 {
+		return ErrInsufficientStock
+	}
+	level.OnHand += delta
+	return nil
+}
+
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation holds stock for a checkout until it is committed, released or its TTL runs out
+type Reservation struct {
+	ID         string
+	ProductID  string
+	LocationID string
+	Unit       UnitCode
+	Quantity   int64  // Base units
+	Reference  string // What holds the stock, e.g. a checkout or order id
+	Status     ReservationStatus
+	ExpiresAt  time.Time // Zero for no expiry
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Overdue reports whether an active reservation has outlived its TTL. A reservation without an expiry,
+// e.g. for a confirmed order, holds until it is committed or released.
+func (reservation *Reservation) Overdue(now time.Time) bool {
+	return reservation.Status == ReservationActive && !reservation.ExpiresAt.IsZero() && !now.Before(reservation.ExpiresAt)
+}
+
+// Close moves an active reservation to a final status
+func (reservation *Reservation) Close(status ReservationStatus, now time.Time) error {
+	if reservation.Status != ReservationActive {
+		return ErrReservationClosed
+	}
+	reservation.Status = status
+	reservation.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidLocation     = errors.New("location id must be a lowercase slug, the type must be known and coordinates within range")
+	ErrInvalidOpeningHours = errors.New("opening hours must be within the day and close after opening")
+)
+
+type LocationType string
+
+const (
+	LocationWarehouse LocationType = "warehouse"
+	LocationStore     LocationType = "store"
+	LocationDarkStore LocationType = "dark_store" // Picking-only site for deliveries, closed to walk-in customers
+)
+
+var LocationTypes = []LocationType{LocationWarehouse, LocationStore, LocationDarkStore}
+
+type Address struct {
+	Street   string
+	Ward     string
+	District string
+	Province string
+	Point    *GeoPoint // Where the address was geocoded, if it was
+}
+
+// GeoPoint is a WGS 84 coordinate in degrees
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (point GeoPoint) Valid() bool {
+	return point.Latitude >= -90 && point.Latitude <= 90 && point.Longitude >= -180 && point.Longitude <= 180
+}
+
+// DistanceTo is the great-circle distance to other in meters
+func (point GeoPoint) DistanceTo(other GeoPoint) float64 {
+	const earthRadius = 6371000.0
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	latitude1, latitude2 := toRadians(point.Latitude), toRadians(other.Latitude)
+	deltaLatitude := latitude2 - latitude1
+	deltaLongitude := toRadians(other.Longitude - point.Longitude)
+	haversine := math.Pow(math.Sin(deltaLatitude/2), 2) +
+		math.Cos(latitude1)*math.Cos(latitude2)*math.Pow(math.Sin(deltaLongitude/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(haversine))
+}
+
+// OpeningHours is one opening period on a weekday, in minutes after midnight in the store time zone
+type OpeningHours struct {
+	Weekday time.Weekday
+	Opens   int
+	Closes  int
+}
+
+// Location is a site that holds stock: the central warehouse, a retail store or a dark store
+type Location struct {
+	ID        string // Slug chosen by staff, e.g. "store-district-1"
+	Name      string
+	Type      LocationType
+	Address   Address
+	Phone     string
+	Hours     []OpeningHours // Empty means the location has no public opening hours
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (location *Location) Validate() error {
+	if !slugPattern.MatchString(location.ID) || !slices.Contains(LocationTypes, location.Type) {
+		return ErrInvalidLocation
+	}
+	if location.Address.Point != nil && !location.Address.Point.Valid() {
+		return ErrInvalidLocation
+	}
+	for _, hours := range location.Hours {
+		if hours.Weekday < time.Sunday || hours.Weekday > time.Saturday {
+			return ErrInvalidOpeningHours
+		}
+		if hours.Opens < 0 || hours.Closes > 24*60 || hours.Closes <= hours.Opens {
+			return ErrInvalidOpeningHours
+		}
+	}
+	return nil
+}
+
+// OpenAt reports whether the location is open at moment, which must already be in the store time zone
+func (location *Location) OpenAt(moment time.Time) bool {
+	minute := moment.Hour()*60 + moment.Minute()
+	return slices.ContainsFunc(location.Hours, func(hours OpeningHours) bool {
+		return hours.Weekday == moment.Weekday() && minute >= hours.Opens && minute < hours.Closes
+	})
+}
+
+var ErrTransferClosed = errors.New("transfer is no longer in transit")
+
+type TransferStatus string
+
+const (
+	TransferInTransit TransferStatus = "in_transit"
+	TransferReceived  TransferStatus = "received"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// Transfer moves stock between two locations. While in transit the stock is on hand at neither.
+type Transfer struct {
+	ID             string
+	ProductID      string
+	FromLocationID string
+	ToLocationID   string
+	Unit           UnitCode
+	Quantity       int64 // Base units
+	Status         TransferStatus
+	Note           string
+	CreatedAt      time.Time
+	ReceivedAt     time.Time
+	UpdatedAt      time.Time
+}
+
+// Close moves an in-transit transfer to a final status
+func (transfer *Transfer) Close(status TransferStatus, now time.Time) error {
+	if transfer.Status != TransferInTransit {
+		return ErrTransferClosed
+	}
+	transfer.Status = status
+	transfer.UpdatedAt = now
+	if status == TransferReceived {
+		transfer.ReceivedAt = now
+	}
+	return nil
+}
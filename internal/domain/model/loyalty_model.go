@@ -0,0 +1,194 @@
+package model
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"time"
+)
+
+/*
+This file defines the loyalty points ledger and the program that prices it.
+Logic:
+- Every change to a customer's points is an entry: positive when points are earned or given, negative when they are
+  redeemed, expire or are taken away. The balance is the sum of the entries.
+- Points given (earned, or adjusted up) expire a while after they are given. Points taken are taken from those
+  expiring first, so expiring points are whatever is left of a lot once its expiry passes.
+- The tier counts the points earned on orders within a trailing window; a higher tier earns more points for the
+  same spend.
+*/
+
+var (
+	ErrInsufficientPoints = errors.New("not enough loyalty points")
+	ErrRedeemLimit        = errors.New("points can pay for only part of an order")
+)
+
+type LoyaltyEntryKind string
+
+const (
+	LoyaltyEarn   LoyaltyEntryKind = "earn"   // On a delivered order
+	LoyaltyRedeem LoyaltyEntryKind = "redeem" // Spent at checkout
+	LoyaltyExpire LoyaltyEntryKind = "expire"
+	LoyaltyAdjust LoyaltyEntryKind = "adjust" // By staff, or the points of a cancelled or returned order settled
+)
+
+// LoyaltyEntry is one entry of a customer's points ledger
+type LoyaltyEntry struct {
+	ID        string
+	UserID    string
+	Kind      LoyaltyEntryKind
+	Points    int64 // Positive when given, negative when taken
+	OrderID   string
+	Note      Message // What the entry is for; a staff adjustment carries the staff's own words
+	ActorID   string
+	ExpiresAt time.Time // Points given expire after this; zero for entries taking points
+	CreatedAt time.Time
+}
+
+type LoyaltyTier string
+
+const (
+	TierMember LoyaltyTier = "member"
+	TierSilver LoyaltyTier = "silver"
+	TierGold   LoyaltyTier = "gold"
+)
+
+// TierRule is what a tier takes and gives
+type TierRule struct {
+	Tier        LoyaltyTier
+	MinPoints   int64 // Points earned within the tier window
+	EarnPercent int64 // Of the base earn rate, e.g. 150 earns half as much again
+}
+
+// LoyaltyProgram is how points are earned and what they are worth
+type LoyaltyProgram struct {
+	SpendPerPoint    Money // Paid for one point at the base rate
+	PointValue       Money // Taken off an order per point redeemed
+	MaxRedeemPercent int64 // Share of an order's total points may pay for
+	PointsTTL        time.Duration
+	TierWindow       time.Duration
+	Tiers            []TierRule // The lowest first, starting at 0 points
+}
+
+// LoyaltyAccount is where a customer stands in the program
+type LoyaltyAccount struct {
+	UserID     string
+	Balance    int64 // Points that can be spent now
+	Worth      Money // What the balance takes off an order
+	Tier       TierRule
+	TierPoints int64
+	Next       *TierRule // Nil at the top tier
+}
+
+// Account sums the customer's entries as of now. Points past their expiry are left out of the balance even before
+// the expiry is recorded.
+func (program *LoyaltyProgram) Account(userID string, entries []LoyaltyEntry, now time.Time) LoyaltyAccount {
+	account := LoyaltyAccount{
+		UserID:     userID,
+		Balance:    LoyaltyBalance(entries) - ExpiredPoints(entries, now),
+		TierPoints: program.TierPoints(entries, now),
+	}
+	account.Worth = program.PointValue * Money(account.Balance)
+	account.Tier, account.Next = program.Tier(account.TierPoints)
+	return account
+}
+
+// LoyaltyRun is what a run of the loyalty job recorded, in entries
+type LoyaltyRun struct {
+	Earned    int // Delivered orders
+	GivenBack int // Points spent on orders that were cancelled or returned
+	TakenBack int // Points earned on orders that were returned
+	Expired   int // Customers whose points expired
+}
+
+// LoyaltyBalance sums a customer's entries
+func LoyaltyBalance(entries []LoyaltyEntry) int64 {
+	var balance int64
+	for _, entry := range entries {
+		balance += entry.Points
+	}
+	return balance
+}
+
+// TierPoints sums the points earned on orders within the tier window before now
+func (program *LoyaltyProgram) TierPoints(entries []LoyaltyEntry, now time.Time) int64 {
+	var points int64
+	since := now.Add(-program.TierWindow)
+	for _, entry := range entries {
+		if entry.Kind == LoyaltyEarn && entry.CreatedAt.After(since) {
+			points += entry.Points
+		}
+	}
+	return points
+}
+
+// Tier is the highest tier the points reach; next is the one after it, nil at the top
+func (program *LoyaltyProgram) Tier(points int64) (tier TierRule, next *TierRule) {
+	tier = TierRule{Tier: TierMember, EarnPercent: 100}
+	for index, rule := range program.Tiers {
+		if points < rule.MinPoints {
+			return tier, &program.Tiers[index]
+		}
+		tier = rule
+	}
+	return tier, nil
+}
+
+// Earn is the points paid earns in the tier, rounded down
+func (program *LoyaltyProgram) Earn(paid Money, tier TierRule) int64 {
+	if paid <= 0 || program.SpendPerPoint <= 0 {
+		return 0
+	}
+	return int64(paid) * tier.EarnPercent / (int64(program.SpendPerPoint) * 100)
+}
+
+// Redeem prices points spent on an order of total; they must be in the balance and pay for no more than the
+// program's share of the order
+func (program *LoyaltyProgram) Redeem(points int64, balance int64, total Money) (Money, error) {
+	if points > balance {
+		return 0, ErrInsufficientPoints
+	}
+	value := program.PointValue * Money(points)
+	if value*100 > total*Money(program.MaxRedeemPercent) {
+		return 0, ErrRedeemLimit
+	}
+	return value, nil
+}
+
+// ExpiredPoints returns how many of the points given to a customer expired by now without being taken. Points taken
+// are taken from the lots expiring first; entries recording expiry take points too, so expired points are counted once.
+func ExpiredPoints(entries []LoyaltyEntry, now time.Time) int64 {
+	var lots []LoyaltyEntry
+	var taken int64
+	for _, entry := range entries {
+		if entry.Points > 0 {
+			lots = append(lots, entry)
+		} else {
+			taken -= entry.Points
+		}
+	}
+	slices.SortStableFunc(lots, func(a, b LoyaltyEntry) int {
+		if a.ExpiresAt.IsZero() || b.ExpiresAt.IsZero() {
+			return cmp.Compare(boolRank(a.ExpiresAt.IsZero()), boolRank(b.ExpiresAt.IsZero()))
+		}
+		return a.ExpiresAt.Compare(b.ExpiresAt)
+	})
+
+	var expired int64
+	for _, lot := range lots {
+		left := lot.Points - min(taken, lot.Points)
+		taken -= lot.Points - left
+		if left > 0 && !lot.ExpiresAt.IsZero() && !now.Before(lot.ExpiresAt) {
+			expired += left
+		}
+	}
+	return expired
+}
+
+// boolRank sorts false before true
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,8 @@
+package model
+
+// Message is text for a person to read, kept as the ID of a message in the i18n files and the data of its template,
+// so that it is rendered in the reader's language when it is shown. A Count in the data picks the plural form.
+type Message struct {
+	ID   string
+	Data map[string]any
+}
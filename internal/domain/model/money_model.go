@@ -0,0 +1,32 @@
+package model
+
+import (
+	"math/big"
+)
+
+// Money is an amount in Vietnamese đồng. VND has no minor unit, so all prices and totals are whole integers.
+type Money int64
+
+// MulRatio returns money * numerator / denominator rounded half up to the nearest đồng.
+// Intermediate values are computed with big integers so large quantities cannot overflow.
+func (money Money) MulRatio(numerator, denominator int64) Money {
+	if denominator == 0 {
+		return 0
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(money)), big.NewInt(numerator))
+	divisor := big.NewInt(denominator)
+
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+
+	// Round half up (away from zero for negative amounts)
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	if twiceRemainder.Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		if product.Sign()*divisor.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
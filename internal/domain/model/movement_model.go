@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrStocktakeClosed = errors.New("stocktake has already been applied")
+
+type MovementType string
+
+const (
+	MovementReceipt    MovementType = "receipt"
+	MovementSale       MovementType = "sale"
+	MovementReturn     MovementType = "return"
+	MovementAdjustment MovementType = "adjustment"
+	MovementSpoilage   MovementType = "spoilage"
+	MovementTransfer   MovementType = "transfer"
+)
+
+var MovementTypes = []MovementType{
+	MovementReceipt, MovementSale, MovementReturn, MovementAdjustment, MovementSpoilage, MovementTransfer,
+}
+
+// Reason codes say why a movement happened, within its type
+const (
+	ReasonGoodsReceived  = "goods_received"
+	ReasonCustomerOrder  = "customer_order"
+	ReasonCustomerReturn = "customer_return"
+	ReasonRecount        = "recount"
+	ReasonDamaged        = "damaged"
+	ReasonFound          = "found"
+	ReasonCorrection     = "correction"
+	ReasonStocktake      = "stocktake"
+	ReasonSpoiled        = "spoiled"
+	ReasonTransferOut    = "transfer_out"
+	ReasonTransferIn     = "transfer_in"
+	ReasonTransferBack   = "transfer_cancelled"
+)
+
+// Source documents a movement can come from
+const (
+	SourceLot           = "lot"
+	SourceReservation   = "reservation" // The id is the reservation's reference, e.g. a checkout
+	SourceTransfer      = "transfer"
+	SourceStocktake     = "stocktake"
+	SourcePurchaseOrder = "purchase_order"
+	SourceClaim         = "claim"
+)
+
+// SystemActor records movements nobody triggered by hand, e.g. from a scheduled job
+const SystemActor = "system"
+
+// Movement is one entry of the append-only stock ledger. Stock on hand at a location is the sum of its movements.
+type Movement struct {
+	ID         string
+	Sequence   int64 // Position in the ledger, starting at 1
+	ProductID  string
+	LocationID string
+	Type       MovementType
+	Unit       UnitCode
+	Quantity   int64 // Signed change of stock on hand in base units
+	Balance    int64 // Stock on hand right after the movement
+	ActorID    string
+	Reason     string
+	Note       string
+	SourceType string
+	SourceID   string
+	CreatedAt  time.Time
+}
+
+type StocktakeStatus string
+
+const (
+	StocktakeCounted StocktakeStatus = "counted"
+	StocktakeApplied StocktakeStatus = "applied"
+)
+
+// StocktakeLine compares the counted stock of a product with what the ledger expected at counting time
+type StocktakeLine struct {
+	ProductID string
+	Unit      UnitCode
+	Expected  int64
+	Counted   int64
+}
+
+// Variance is positive when more was found than expected
+func (line StocktakeLine) Variance() int64 {
+	return line.Counted - line.Expected
+}
+
+// Stocktake is a physical count at one location. Applying it posts the variances to the ledger.
+type Stocktake struct {
+	ID         string
+	LocationID string
+	Lines      []StocktakeLine
+	Status     StocktakeStatus
+	Note       string
+	CountedBy  string
+	AppliedBy  string
+	CreatedAt  time.Time
+	AppliedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (stocktake *Stocktake) Apply(actorID string, now time.Time) error {
+	if stocktake.Status != StocktakeCounted {
+		return ErrStocktakeClosed
+	}
+	stocktake.Status = StocktakeApplied
+	stocktake.AppliedBy = actorID
+	stocktake.AppliedAt = now
+	stocktake.UpdatedAt = now
+	return nil
+}
+
+// StockKey identifies the stock of one product at one location
+type StockKey struct {
+	ProductID  string
+	LocationID string
+}
+
+// SumMovements rebuilds stock on hand per product and location from the ledger
+func SumMovements(movements []Movement) map[StockKey]int64 {
+	sums := make(map[StockKey]int64)
+	for _, movement := range movements {
+		sums[StockKey{ProductID: movement.ProductID, LocationID: movement.LocationID}] += movement.Quantity
+	}
+	return sums
+}
@@ -0,0 +1,255 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOrderStatus          = errors.New("order cannot move to that status from its current one")
+	ErrOrderTransitionActor = errors.New("actor may not move the order to that status")
+	ErrReasonRequired       = errors.New("a reason is required")
+)
+
+type OrderStatus string
+
+const (
+	OrderPendingPayment OrderStatus = "pending_payment"
+	OrderConfirmed      OrderStatus = "confirmed"
+	OrderPicking        OrderStatus = "picking"
+	OrderPacked         OrderStatus = "packed"
+	OrderOutForDelivery OrderStatus = "out_for_delivery"
+	OrderDelivered      OrderStatus = "delivered"
+	OrderCancelled      OrderStatus = "cancelled"
+	OrderReturned       OrderStatus = "returned"
+)
+
+// orderParty is who may make a transition: the customer who placed the order, store staff, the shipper the order
+// is assigned to, or the system (payment callbacks, expiry jobs)
+type orderParty int
+
+const (
+	partyCustomer orderParty = 1 << iota
+	partyStaff
+	partySystem
+	partyShipper
+)
+
+// orderTransitions lists the legal moves from each status and who may make them
+var orderTransitions = map[OrderStatus]map[OrderStatus]orderParty{
+	OrderPendingPayment: {
+		OrderConfirmed: partyStaff | partySystem,
+		OrderCancelled: partyCustomer | partyStaff | partySystem,
+	},
+	OrderConfirmed: {
+		OrderPicking:   partyStaff,
+		OrderCancelled: partyCustomer | partyStaff,
+	},
+	OrderPicking: {
+		OrderPacked:    partyStaff,
+		OrderCancelled: partyStaff,
+	},
+	OrderPacked: {
+		OrderOutForDelivery: partyStaff | partyShipper, // Picked up
+		OrderCancelled:      partyStaff,
+	},
+	OrderOutForDelivery: {
+		OrderDelivered: partyStaff | partyShipper,
+		OrderReturned:  partyStaff | partyShipper, // Refused at the door
+	},
+	OrderDelivered: {
+		OrderReturned: partyStaff,
+	},
+}
+
+// Final reports whether the order can no longer move
+func (status OrderStatus) Final() bool {
+	return len(orderTransitions[status]) == 0
+}
+
+// Next lists the statuses the order can move to from status, whoever makes the move
+func (status OrderStatus) Next() []OrderStatus {
+	next := make([]OrderStatus, 0, len(orderTransitions[status]))
+	for to := range orderTransitions[status] {
+		next = append(next, to)
+	}
+	slices.Sort(next)
+	return next
+}
+
+// OrderActor is who moves an order: a signed-in user with their roles, or the system
+type OrderActor struct {
+	ID    string
+	Roles []string
+}
+
+// SystemOrderActor moves orders on behalf of payment callbacks and background jobs
+var SystemOrderActor = OrderActor{ID: SystemActor}
+
+// Staff reports whether the actor works for the store
+func (actor OrderActor) Staff() bool {
+	return slices.Contains(actor.Roles, RoleAdmin) || slices.Contains(actor.Roles, RoleStaff)
+}
+
+func (actor OrderActor) party(order *Order) orderParty {
+	var party orderParty
+	if actor.ID == SystemActor {
+		party |= partySystem
+	}
+	if actor.Staff() {
+		party |= partyStaff
+	}
+	if actor.ID != "" && actor.ID == order.UserID {
+		party |= partyCustomer
+	}
+	if slices.Contains(actor.Roles, RoleShipper) && order.Shipper != nil && order.Shipper.ID == actor.ID {
+		party |= partyShipper
+	}
+	return party
+}
+
+// OrderEvent is one step of an order's timeline
+type OrderEvent struct {
+	From    OrderStatus // Empty for the order being placed
+	To      OrderStatus
+	ActorID string
+	Reason  string
+	At      time.Time
+}
+
+type PaymentMethod string
+
+const (
+	PaymentCOD          PaymentMethod = "cod" // Cash on delivery
+	PaymentBankTransfer PaymentMethod = "bank_transfer"
+	PaymentCard         PaymentMethod = "card"
+	PaymentEWallet      PaymentMethod = "e_wallet"
+)
+
+var PaymentMethods = []PaymentMethod{PaymentCOD, PaymentBankTransfer, PaymentCard, PaymentEWallet}
+
+// PaidOnDelivery reports whether the order is confirmed without waiting for a payment
+func (method PaymentMethod) PaidOnDelivery() bool {
+	return method == PaymentCOD
+}
+
+// DeliveryAddress is where and to whom an order is delivered
+type DeliveryAddress struct {
+	RecipientName string
+	Phone         string
+	Address
+	Note string // e.g. "leave it with the building guard"
+}
+
+// ShipperContact is who delivers an order, as shown to the customer
+type ShipperContact struct {
+	ID    string
+	Name  string
+	Phone string
+}
+
+// OrderLine is a product as it was bought: its price is locked at checkout
+type OrderLine struct {
+	ProductID     string
+	Name          string // Default-locale name at checkout
+	Quantity      Quantity
+	UnitPrice     Money // Per PriceUnit
+	PriceUnit     UnitCode
+	Total         Money
+	Discount      Money  // Promotions' share of the total
+	Weight        int64  // Grams
+	ReservationID string // Stock held for the line
+	SubstituteFor string // Box item the line was sent instead of, when it was out of stock
+}
+
+// Paid is what the customer paid for the line once promotions are taken off
+func (line *OrderLine) Paid() Money {
+	return line.Total - line.Discount
+}
+
+// Order is a customer's purchase, placed from their cart at checkout
+type Order struct {
+	ID             string
+	Number         string // Sequential, e.g. "ORD-000042", quoted to the customer
+	UserID         string
+	Status         OrderStatus
+	Lines          []OrderLine
+	Subtotal       Money
+	DeliveryFee    Money
+	Discount       Money              // Off the items and the delivery fee, all promotions together
+	Promotions     []AppliedPromotion // Applied at checkout
+	PointsRedeemed int64              // Loyalty points spent at checkout
+	PointsDiscount Money              // What the points took off
+	Total          Money
+	Weight         int64 // Grams, booked on the delivery slot
+	PaymentMethod  PaymentMethod
+	PaymentDueAt   time.Time // Unpaid orders give their stock back after this; zero when paid on delivery
+	Address        DeliveryAddress
+	ZoneID         string // Delivery zone of the address, which also prices its delivery
+	SlotID         string
+	SlotStart      time.Time
+	SlotEnd        time.Time
+	Shipper        *ShipperContact // Assigned by a dispatcher; nil until then
+	LocationID     string          // Where the order is picked
+	Note           string
+	SubscriptionID string       // Set on boxes ordered for a subscription
+	History        []OrderEvent // Oldest first, starting with the order being placed
+	PlacedAt       time.Time
+	UpdatedAt      time.Time
+}
+
+// Transition moves the order to status if that is a legal move for the actor, recording who did it and why.
+// Cancelling and returning need a reason.
+func (order *Order) Transition(to OrderStatus, actor OrderActor, reason string, now time.Time) error {
+	parties, ok := orderTransitions[order.Status][to]
+	if !ok {
+		return ErrOrderStatus
+	}
+	if actor.party(order)&parties == 0 {
+		return ErrOrderTransitionActor
+	}
+	reason = strings.TrimSpace(reason)
+	if (to == OrderCancelled || to == OrderReturned) && reason == "" {
+		return ErrReasonRequired
+	}
+
+	order.History = append(slices.Clone(order.History), OrderEvent{From: order.Status, To: to, ActorID: actor.ID, Reason: reason, At: now})
+	order.Status = to
+	order.UpdatedAt = now
+	return nil
+}
+
+// EarnBase is what the customer paid for the items in money, which loyalty points are earned on
+func (order *Order) EarnBase() Money {
+	paid := -order.PointsDiscount
+	for _, line := range order.Lines {
+		paid += line.Paid()
+	}
+	return max(paid, 0)
+}
+
+// Contains reports whether any line of the order is the product
+func (order *Order) Contains(productID string) bool {
+	return slices.ContainsFunc(order.Lines, func(line OrderLine) bool { return line.ProductID == productID })
+}
+
+// ReachedAt tells when the order last moved to status
+func (order *Order) ReachedAt(status OrderStatus) (time.Time, bool) {
+	for index := len(order.History) - 1; index >= 0; index-- {
+		if order.History[index].To == status {
+			return order.History[index].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Line finds the order's line of the product
+func (order *Order) Line(productID string) (OrderLine, bool) {
+	index := slices.IndexFunc(order.Lines, func(line OrderLine) bool { return line.ProductID == productID })
+	if index < 0 {
+		return OrderLine{}, false
+	}
+	return order.Lines[index], true
+}
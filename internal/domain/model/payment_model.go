@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPaymentAmount = errors.New("paid amount differs from the amount due")
+	ErrRefundAmount  = errors.New("refund exceeds what is left of the payment")
+)
+
+type PaymentStatus string
+
+const (
+	PaymentPending PaymentStatus = "pending"
+	PaymentPaid    PaymentStatus = "paid"
+	PaymentFailed  PaymentStatus = "failed"
+)
+
+// Payment is one attempt at paying an order. A customer who gives up at the gateway can start another one.
+type Payment struct {
+	ID            string
+	OrderID       string
+	UserID        string
+	Method        PaymentMethod
+	Amount        Money
+	Status        PaymentStatus
+	RedirectURL   string // Gateway page the customer pays on
+	Instructions  string // For payments the customer makes themselves
+	TransactionID string // Provider's id
+	ResultCode    string // Provider's response code
+	SettledBy     string // Staff who confirmed a transfer, else the system
+	Refunded      Money  // Paid back so far, including refunds still being sent
+	RefundDue     bool   // Paid for an order that could not be confirmed any more; staff must pay it back
+	CreatedAt     time.Time
+	SettledAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Settle records the outcome a provider reported. A payment is settled once: repeated callbacks for it change
+// nothing and report false, so a provider retrying its notification is harmless.
+func (payment *Payment) Settle(actorID string, transactionID string, amount Money, success bool, code string, now time.Time) (bool, error) {
+	if payment.Status != PaymentPending {
+		return false, nil
+	}
+	if success && amount != payment.Amount {
+		return false, ErrPaymentAmount
+	}
+
+	payment.Status = PaymentFailed
+	if success {
+		payment.Status = PaymentPaid
+	}
+	payment.TransactionID = transactionID
+	payment.ResultCode = code
+	payment.SettledBy = actorID
+	payment.SettledAt = now
+	payment.UpdatedAt = now
+	return true, nil
+}
+
+// Refundable is what can still be paid back of the payment
+func (payment *Payment) Refundable() Money {
+	if payment.Status != PaymentPaid {
+		return 0
+	}
+	return payment.Amount - payment.Refunded
+}
+
+// Refund books amount as paid back; the payment can never give back more than it took
+func (payment *Payment) Refund(amount Money, now time.Time) error {
+	if amount <= 0 || amount > payment.Refundable() {
+		return ErrRefundAmount
+	}
+	payment.Refunded += amount
+	payment.UpdatedAt = now
+	return nil
+}
+
+// CancelRefund takes back a booked refund the provider did not carry out
+func (payment *Payment) CancelRefund(amount Money, now time.Time) {
+	payment.Refunded = max(payment.Refunded-amount, 0)
+	payment.UpdatedAt = now
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrUnknownNutrient      = errors.New("unknown nutrient")
+	ErrInvalidNutrient      = errors.New("nutrient amount must not be negative")
+	ErrUnknownAllergen      = errors.New("unknown allergen")
+	ErrUnknownCertification = errors.New("unknown certification scheme")
+)
+
+type Nutrient string
+
+const (
+	NutrientEnergy       Nutrient = "energy"
+	NutrientProtein      Nutrient = "protein"
+	NutrientFat          Nutrient = "fat"
+	NutrientCarbohydrate Nutrient = "carbohydrate"
+	NutrientSugar        Nutrient = "sugar"
+	NutrientFiber        Nutrient = "fiber"
+	NutrientSodium       Nutrient = "sodium"
+	NutrientPotassium    Nutrient = "potassium"
+	NutrientVitaminC     Nutrient = "vitamin_c"
+)
+
+// NutrientUnits - the unit every nutrient is measured in, per 100 g of product. The order is the label order.
+var NutrientUnits = []struct {
+	Nutrient Nutrient
+	Unit     string
+}{
+	{NutrientEnergy, "kcal"},
+	{NutrientProtein, "g"},
+	{NutrientFat, "g"},
+	{NutrientCarbohydrate, "g"},
+	{NutrientSugar, "g"},
+	{NutrientFiber, "g"},
+	{NutrientSodium, "mg"},
+	{NutrientPotassium, "mg"},
+	{NutrientVitaminC, "mg"},
+}
+
+// NutritionFacts maps a nutrient to its amount per 100 g, in the nutrient's unit
+type NutritionFacts map[Nutrient]float64
+
+func NutrientUnit(nutrient Nutrient) (string, bool) {
+	for _, entry := range NutrientUnits {
+		if entry.Nutrient == nutrient {
+			return entry.Unit, true
+		}
+	}
+	return "", false
+}
+
+func (facts NutritionFacts) Validate() error {
+	for nutrient, amount := range facts {
+		if _, ok := NutrientUnit(nutrient); !ok {
+			return ErrUnknownNutrient
+		}
+		if amount < 0 {
+			return ErrInvalidNutrient
+		}
+	}
+	return nil
+}
+
+type Allergen string
+
+// Allergens - the declarable allergens relevant to produce and the few packaged items we sell
+var Allergens = []Allergen{"celery", "mustard", "sesame", "soy", "peanut", "tree_nut", "gluten", "sulphite", "lupin"}
+
+type CertificationScheme string
+
+const (
+	CertificationOrganic   CertificationScheme = "organic"
+	CertificationVietGAP   CertificationScheme = "vietgap"
+	CertificationGlobalGAP CertificationScheme = "globalgap"
+)
+
+var CertificationSchemes = []CertificationScheme{CertificationOrganic, CertificationVietGAP, CertificationGlobalGAP}
+
+// Certification is a farming standard the produce is certified for, backed by a certificate document
+type Certification struct {
+	Scheme      CertificationScheme
+	Reference   string    // Certificate number
+	Issuer      string    // Certification body
+	ValidUntil  time.Time // Zero when the certificate does not expire
+	DocumentURL string    // Scan of the certificate
+}
+
+// ValidOn reports whether the certificate is still in force on day
+func (certification Certification) ValidOn(day time.Time) bool {
+	return certification.ValidUntil.IsZero() || !day.After(certification.ValidUntil)
+}
+
+// CertifiedFor reports whether the product holds a certificate for scheme that is valid on day
+func (product *Product) CertifiedFor(scheme CertificationScheme, day time.Time) bool {
+	return slices.ContainsFunc(product.Certifications, func(certification Certification) bool {
+		return certification.Scheme == scheme && certification.ValidOn(day)
+	})
+}
+
+func (product *Product) validateAttributes() error {
+	if err := product.Nutrition.Validate(); err != nil {
+		return err
+	}
+	for _, allergen := range product.Allergens {
+		if !slices.Contains(Allergens, allergen) {
+			return ErrUnknownAllergen
+		}
+	}
+	for _, certification := range product.Certifications {
+		if !slices.Contains(CertificationSchemes, certification.Scheme) {
+			return ErrUnknownCertification
+		}
+	}
+	return nil
+}
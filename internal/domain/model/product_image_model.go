@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+type ImageStatus string
+
+const (
+	ImageProcessing ImageStatus = "processing"
+	ImageReady      ImageStatus = "ready"
+	ImageFailed     ImageStatus = "failed"
+)
+
+type ImageVariantSize struct {
+	Name    string
+	MaxSide int // longest side in pixels
+}
+
+// ImageVariantSizes - variants generated in the background for every uploaded product image
+var ImageVariantSizes = []ImageVariantSize{
+	{Name: "thumbnail", MaxSide: 200},
+	{Name: "medium", MaxSide: 800},
+}
+
+type ImageVariant struct {
+	Name        string
+	Key         string
+	URL         string
+	ContentType string
+	Width       int
+	Height      int
+	Size        int
+}
+
+type ProductImage struct {
+	ID        string
+	ProductID string
+	Status    ImageStatus
+	Original  ImageVariant
+	Variants  []ImageVariant
+	CreatedAt time.Time
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidPrice = errors.New("price must not be negative")
+
+type Product struct {
+	ID          string
+	SKU         string
+	Name        string // Default locale
+	Description string // Default locale
+	CategoryID  string
+	Price       Money    // Price of one PriceUnit
+	PriceUnit   UnitCode // e.g. 45000 per "kg"
+	SaleRule    SaleRule
+	UnitWeight  int64 // Grams in one piece or bunch, for delivery capacity; unused for produce sold by mass
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Translations Translations
+	Origins      []string       // Province slugs the produce comes from, e.g. "lam-dong"
+	Seasons      []SeasonWindow // Empty means available all year
+
+	Nutrition      NutritionFacts // Per 100 g
+	Allergens      []Allergen
+	Certifications []Certification
+
+	Rating RatingSummary // Maintained by the review moderation, not by product updates
+
+	Images   []ProductImage // Loaded by the service, not stored with the product
+	Category *Category      // Loaded by the service, not stored with the product
+}
+
+func (product *Product) Localized(locale string) (string, string) {
+	return product.Translations.Resolve(locale, product.Name, product.Description)
+}
+
+// UnitPrice is the price of a reference quantity, used to compare products sold in different units.
+type UnitPrice struct {
+	Per    Quantity
+	Amount Money
+}
+
+// Weight returns the grams in quantity of the product; pieces and bunches weigh UnitWeight each
+func (product *Product) Weight(quantity Quantity) int64 {
+	unit, err := FindUnit(quantity.Unit)
+	if err == nil && unit.Dimension == DimensionMass {
+		return quantity.Base
+	}
+	return quantity.Base * product.UnitWeight
+}
+
+// LineTotal returns the exact price of a quantity, rounded once to the nearest đồng.
+func (product *Product) LineTotal(quantity Quantity) (Money, error) {
+	priceUnit, err := FindUnit(product.PriceUnit)
+	if err != nil {
+		return 0, err
+	}
+	if quantity.Dimension() != priceUnit.Dimension {
+		return 0, ErrIncompatibleUnits
+	}
+	return product.Price.MulRatio(quantity.Base, priceUnit.Factor), nil
+}
+
+// UnitPrices returns the display prices per 100 g / 1 kg for weighed products, or per piece / bunch otherwise.
+func (product *Product) UnitPrices() []UnitPrice {
+	priceUnit, err := FindUnit(product.PriceUnit)
+	if err != nil {
+		return nil
+	}
+
+	references := referenceQuantities[priceUnit.Dimension]
+	unitPrices := make([]UnitPrice, 0, len(references))
+	for _, reference := range references {
+		amount, _ := product.LineTotal(reference)
+		unitPrices = append(unitPrices, UnitPrice{Per: reference, Amount: amount})
+	}
+	return unitPrices
+}
+
+func (product *Product) Validate() error {
+	if product.Price < 0 {
+		return ErrInvalidPrice
+	}
+	if product.UnitWeight < 0 {
+		return ErrInvalidQuantity
+	}
+	priceUnit, err := FindUnit(product.PriceUnit)
+	if err != nil {
+		return err
+	}
+	if err := product.SaleRule.Validate(); err != nil {
+		return err
+	}
+	saleUnit, _ := FindUnit(product.SaleRule.Unit)
+	if saleUnit.Dimension != priceUnit.Dimension {
+		return ErrIncompatibleUnits
+	}
+	if err := product.validateSeasonality(); err != nil {
+		return err
+	}
+	return product.validateAttributes()
+}
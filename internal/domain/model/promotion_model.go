@@ -0,0 +1,425 @@
+package model
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+/*
+This file prices promotions. ApplyPromotions is pure: the caller hands it the promotions on offer and everything known
+about the basket and the customer, and gets back the discounts with an explanation of every promotion it looked at.
+Logic:
+- Promotions without a code apply on their own; a promotion with a code applies only when the customer entered it.
+- Candidates are tried by priority, highest first, then by id. A promotion that does not combine (Stackable false)
+  applies only when nothing applied before it, and nothing applies after it.
+- Item discounts come off what is left of each line once earlier promotions took theirs, so stacked discounts never
+  exceed the line totals. Thresholds (MinSubtotal) look at the subtotal before any discount.
+- Category-wide discounts are percentage or fixed promotions scoped to categories; first-order coupons are promotions
+  with a code and FirstOrderOnly.
+*/
+
+var ErrInvalidPromotion = errors.New("promotion needs a name, a known kind with a positive discount, and an end after its start")
+
+type PromotionKind string
+
+const (
+	PromotionPercentage   PromotionKind = "percentage"    // Percent off the eligible lines, optionally capped
+	PromotionFixed        PromotionKind = "fixed"         // Amount off the eligible lines together
+	PromotionBuyXGetY     PromotionKind = "buy_x_get_y"   // Of every Buy+Get eligible items, the Get cheapest are Percent off
+	PromotionFreeShipping PromotionKind = "free_shipping" // The delivery fee is waived
+)
+
+// CustomerSegment groups customers for promotion eligibility
+type CustomerSegment string
+
+const (
+	SegmentNew        CustomerSegment = "new"        // No order placed yet, including guests
+	SegmentReturning  CustomerSegment = "returning"  // At least one order placed
+	SegmentSubscriber CustomerSegment = "subscriber" // Has a subscription that is not cancelled
+)
+
+// Promotion is a discount rule with the conditions under which it applies
+type Promotion struct {
+	ID          string
+	Name        string
+	Description string
+	Code        string // Upper case; empty for a promotion applied without one
+	Kind        PromotionKind
+	Percent     int   // Percentage and buy-x-get-y, 1 to 100
+	Amount      Money // Fixed
+	MaxDiscount Money // Cap of a percentage discount; 0 for none
+	Buy         int   // Buy-x-get-y: items to pay for
+	Get         int   // Buy-x-get-y: items discounted with them
+
+	// Scope of item discounts; both empty for every product
+	ProductIDs  []string
+	CategoryIDs []string
+
+	// Eligibility
+	MinSubtotal    Money             // Subtotal the basket needs, e.g. the free-shipping threshold
+	Segments       []CustomerSegment // Empty for every customer
+	StartsAt       time.Time
+	EndsAt         time.Time // Zero for no end
+	FirstOrderOnly bool
+	PerUserLimit   int // Orders a customer may place with the promotion; 0 for no limit
+	UsageLimit     int // Orders everyone together may place with it; 0 for no limit
+
+	Priority  int  // Higher is tried first
+	Stackable bool // Combines with other promotions
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizePromotionCode makes codes case-insensitive
+func NormalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (promotion *Promotion) Validate() error {
+	if strings.TrimSpace(promotion.Name) == "" || strings.ContainsAny(promotion.Code, " \t\n") {
+		return ErrInvalidPromotion
+	}
+	if promotion.MinSubtotal < 0 || promotion.MaxDiscount < 0 || promotion.PerUserLimit < 0 || promotion.UsageLimit < 0 {
+		return ErrInvalidPromotion
+	}
+	if !promotion.EndsAt.IsZero() && !promotion.EndsAt.After(promotion.StartsAt) {
+		return ErrInvalidPromotion
+	}
+	switch promotion.Kind {
+	case PromotionPercentage:
+		if promotion.Percent < 1 || promotion.Percent > 100 {
+			return ErrInvalidPromotion
+		}
+	case PromotionFixed:
+		if promotion.Amount <= 0 {
+			return ErrInvalidPromotion
+		}
+	case PromotionBuyXGetY:
+		if promotion.Buy < 1 || promotion.Get < 1 || promotion.Percent < 1 || promotion.Percent > 100 {
+			return ErrInvalidPromotion
+		}
+	case PromotionFreeShipping:
+		if len(promotion.ProductIDs) > 0 || len(promotion.CategoryIDs) > 0 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+	return nil
+}
+
+// Covers reports whether the promotion's item discount applies to the line
+func (promotion *Promotion) Covers(line PromotionLine) bool {
+	if len(promotion.ProductIDs) == 0 && len(promotion.CategoryIDs) == 0 {
+		return true
+	}
+	return slices.Contains(promotion.ProductIDs, line.ProductID) ||
+		(line.CategoryID != "" && slices.Contains(promotion.CategoryIDs, line.CategoryID))
+}
+
+// PromotionLine is a basket line as promotions see it
+type PromotionLine struct {
+	ProductID  string
+	CategoryID string
+	Quantity   Quantity
+	Total      Money
+}
+
+// PromotionCustomer is what promotions know of who is buying
+type PromotionCustomer struct {
+	UserID   string // Empty for a guest
+	Segments []CustomerSegment
+	Orders   int            // Orders placed and not cancelled
+	Uses     map[string]int // Orders placed with each promotion, by promotion id
+}
+
+// PromotionBasket is everything ApplyPromotions prices
+type PromotionBasket struct {
+	Lines       []PromotionLine
+	DeliveryFee Money // Zero while not known yet, as in the cart
+	Code        string
+	Customer    PromotionCustomer
+	Redeemed    map[string]int // Orders placed with each promotion by everyone, by promotion id
+	At          time.Time
+}
+
+func (basket *PromotionBasket) Subtotal() Money {
+	subtotal := Money(0)
+	for _, line := range basket.Lines {
+		subtotal += line.Total
+	}
+	return subtotal
+}
+
+// PromotionRejection is why a promotion the basket was checked against did not apply
+type PromotionRejection string
+
+const (
+	RejectedNotStarted     PromotionRejection = "not_started"
+	RejectedEnded          PromotionRejection = "ended"
+	RejectedSegment        PromotionRejection = "segment"          // The customer is not in its segments
+	RejectedFirstOrderOnly PromotionRejection = "first_order_only" // The customer already ordered
+	RejectedUserLimit      PromotionRejection = "user_limit"       // The customer used it as often as allowed
+	RejectedUsageLimit     PromotionRejection = "usage_limit"      // Used up by everyone together
+	RejectedBelowMinimum   PromotionRejection = "below_minimum"    // Subtotal below MinSubtotal
+	RejectedNothingToGive  PromotionRejection = "nothing_to_give"  // No eligible item left to discount, or shipping already free
+	RejectedNotCombinable  PromotionRejection = "not_combinable"   // It or a promotion applied before it does not stack
+)
+
+// AppliedPromotion is a promotion that took money off, and how
+type AppliedPromotion struct {
+	PromotionID      string
+	Name             string
+	Code             string
+	Kind             PromotionKind
+	Discount         Money // Off the items
+	FreeShipping     bool
+	ShippingDiscount Money // Off the delivery fee
+	Explanation      Message
+}
+
+type RejectedPromotion struct {
+	PromotionID string
+	Name        string
+	Code        string
+	Reason      PromotionRejection
+}
+
+// PromotionResult is the basket priced with its promotions. LineDiscounts follow the basket's lines.
+type PromotionResult struct {
+	Subtotal         Money
+	Discount         Money // Off the items, all promotions together
+	LineDiscounts    []Money
+	DeliveryFee      Money
+	ShippingDiscount Money
+	FreeShipping     bool
+	Applied          []AppliedPromotion // In the order they applied
+	Rejected         []RejectedPromotion
+}
+
+// Total is what the customer pays once every promotion is taken off
+func (result *PromotionResult) Total() Money {
+	return result.Subtotal - result.Discount + result.DeliveryFee - result.ShippingDiscount
+}
+
+// ApplyPromotions prices the basket with the promotions that apply to it. Inactive promotions, and promotions with a
+// code the basket does not carry, are ignored without an explanation; every other one is either applied or rejected.
+func ApplyPromotions(promotions []Promotion, basket PromotionBasket) PromotionResult {
+	code := NormalizePromotionCode(basket.Code)
+	candidates := slices.DeleteFunc(slices.Clone(promotions), func(promotion Promotion) bool {
+		return !promotion.Active || (promotion.Code != "" && promotion.Code != code)
+	})
+	slices.SortStableFunc(candidates, func(a, b Promotion) int {
+		return cmp.Or(cmp.Compare(b.Priority, a.Priority), cmp.Compare(a.ID, b.ID))
+	})
+
+	result := PromotionResult{
+		Subtotal:      basket.Subtotal(),
+		LineDiscounts: make([]Money, len(basket.Lines)),
+		DeliveryFee:   basket.DeliveryFee,
+	}
+	exclusive := false
+	for _, promotion := range candidates {
+		reason := promotion.eligibility(&basket, result.Subtotal)
+		if reason == "" && (exclusive || (!promotion.Stackable && len(result.Applied) > 0)) {
+			reason = RejectedNotCombinable
+		}
+		if reason == "" {
+			applied, ok := promotion.apply(&basket, &result)
+			if ok {
+				result.Applied = append(result.Applied, applied)
+				exclusive = !promotion.Stackable
+				continue
+			}
+			reason = RejectedNothingToGive
+		}
+		result.Rejected = append(result.Rejected, RejectedPromotion{
+			PromotionID: promotion.ID,
+			Name:        promotion.Name,
+			Code:        promotion.Code,
+			Reason:      reason,
+		})
+	}
+	return result
+}
+
+// eligibility returns why the promotion cannot apply to the basket, or "" when it can
+func (promotion *Promotion) eligibility(basket *PromotionBasket, subtotal Money) PromotionRejection {
+	customer := basket.Customer
+	switch {
+	case basket.At.Before(promotion.StartsAt):
+		return RejectedNotStarted
+	case !promotion.EndsAt.IsZero() && !basket.At.Before(promotion.EndsAt):
+		return RejectedEnded
+	case len(promotion.Segments) > 0 && !slices.ContainsFunc(promotion.Segments, func(segment CustomerSegment) bool {
+		return slices.Contains(customer.Segments, segment)
+	}):
+		return RejectedSegment
+	case promotion.FirstOrderOnly && customer.Orders > 0:
+		return RejectedFirstOrderOnly
+	case promotion.PerUserLimit > 0 && customer.Uses[promotion.ID] >= promotion.PerUserLimit:
+		return RejectedUserLimit
+	case promotion.UsageLimit > 0 && basket.Redeemed[promotion.ID] >= promotion.UsageLimit:
+		return RejectedUsageLimit
+	case subtotal < promotion.MinSubtotal:
+		return RejectedBelowMinimum
+	}
+	return ""
+}
+
+// apply takes the promotion's discount off what earlier promotions left; ok is false when there was nothing to take
+func (promotion *Promotion) apply(basket *PromotionBasket, result *PromotionResult) (AppliedPromotion, bool) {
+	applied := AppliedPromotion{PromotionID: promotion.ID, Name: promotion.Name, Code: promotion.Code, Kind: promotion.Kind}
+	if promotion.Kind == PromotionFreeShipping {
+		if result.FreeShipping {
+			return applied, false
+		}
+		applied.FreeShipping = true
+		applied.ShippingDiscount = result.DeliveryFee - result.ShippingDiscount
+		applied.Explanation = Message{ID: "Promotion.FreeDelivery"}
+		if promotion.MinSubtotal > 0 {
+			applied.Explanation = Message{ID: "Promotion.FreeDeliveryFrom", Data: map[string]any{"MinSubtotal": promotion.MinSubtotal}}
+		}
+		result.FreeShipping = true
+		result.ShippingDiscount += applied.ShippingDiscount
+		return applied, true
+	}
+
+	remaining := make([]Money, len(basket.Lines))
+	for index, line := range basket.Lines {
+		if promotion.Covers(line) {
+			remaining[index] = line.Total - result.LineDiscounts[index]
+		}
+	}
+	var discounts []Money
+	switch promotion.Kind {
+	case PromotionPercentage:
+		discounts = promotion.percentOff(remaining)
+	case PromotionFixed:
+		discounts = allocate(min(promotion.Amount, totalOf(remaining)), remaining)
+	case PromotionBuyXGetY:
+		discounts = promotion.buyXGetY(basket.Lines, remaining)
+	}
+
+	products := 0
+	for index, discount := range discounts {
+		if discount > 0 {
+			result.LineDiscounts[index] += discount
+			applied.Discount += discount
+			products++
+		}
+	}
+	if applied.Discount == 0 {
+		return applied, false
+	}
+	result.Discount += applied.Discount
+	applied.Explanation = promotion.explain(products, applied.Discount)
+	return applied, true
+}
+
+func (promotion *Promotion) percentOff(remaining []Money) []Money {
+	discounts := make([]Money, len(remaining))
+	for index, amount := range remaining {
+		discounts[index] = amount.MulRatio(int64(promotion.Percent), 100)
+	}
+	if total := totalOf(discounts); promotion.MaxDiscount > 0 && total > promotion.MaxDiscount {
+		return allocate(promotion.MaxDiscount, discounts)
+	}
+	return discounts
+}
+
+// buyXGetY lines the eligible items up from the dearest; of every Buy+Get of them, the last Get are discounted.
+// Only items counted in pieces or bunches take part: a weighed product is not a number of items.
+func (promotion *Promotion) buyXGetY(lines []PromotionLine, remaining []Money) []Money {
+	type item struct {
+		line  int
+		price Money
+	}
+	var items []item
+	for index, line := range lines {
+		if remaining[index] <= 0 || line.Quantity.Dimension() == DimensionMass {
+			continue
+		}
+		for range line.Quantity.Base {
+			items = append(items, item{line: index, price: remaining[index].MulRatio(1, line.Quantity.Base)})
+		}
+	}
+	slices.SortStableFunc(items, func(a, b item) int { return cmp.Compare(b.price, a.price) })
+
+	discounts := make([]Money, len(lines))
+	group := promotion.Buy + promotion.Get
+	for start := 0; start+group <= len(items); start += group {
+		for _, free := range items[start+promotion.Buy : start+group] {
+			discounts[free.line] += free.price.MulRatio(int64(promotion.Percent), 100)
+		}
+	}
+	for index := range discounts {
+		discounts[index] = min(discounts[index], remaining[index])
+	}
+	return discounts
+}
+
+// explain says what the promotion took off, as a message of the i18n files; Count is the number of products
+func (promotion *Promotion) explain(products int, discount Money) Message {
+	switch promotion.Kind {
+	case PromotionPercentage:
+		if promotion.MaxDiscount > 0 && discount == promotion.MaxDiscount {
+			return Message{ID: "Promotion.PercentOffCapped", Data: map[string]any{
+				"Percent": promotion.Percent, "Count": products, "MaxDiscount": promotion.MaxDiscount,
+			}}
+		}
+		return Message{ID: "Promotion.PercentOff", Data: map[string]any{"Percent": promotion.Percent, "Count": products}}
+	case PromotionFixed:
+		return Message{ID: "Promotion.AmountOff", Data: map[string]any{"Discount": discount, "Count": products}}
+	case PromotionBuyXGetY:
+		if promotion.Percent == 100 {
+			return Message{ID: "Promotion.BuyGetFree", Data: map[string]any{
+				"Buy": promotion.Buy, "Get": promotion.Get, "Discount": discount,
+			}}
+		}
+		return Message{ID: "Promotion.BuyGetPercentOff", Data: map[string]any{
+			"Buy": promotion.Buy, "Get": promotion.Get, "Percent": promotion.Percent, "Discount": discount,
+		}}
+	}
+	return Message{}
+}
+
+// allocate splits amount over the weights in proportion, the last weighted share taking the rounding
+func allocate(amount Money, weights []Money) []Money {
+	shares := make([]Money, len(weights))
+	total := totalOf(weights)
+	if total <= 0 || amount <= 0 {
+		return shares
+	}
+	last := -1
+	for index, weight := range weights {
+		if weight > 0 {
+			last = index
+		}
+	}
+	left := amount
+	for index, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if index == last {
+			shares[index] = min(left, weight)
+			break
+		}
+		shares[index] = min(amount.MulRatio(int64(weight), int64(total)), weight, left)
+		left -= shares[index]
+	}
+	return shares
+}
+
+func totalOf(amounts []Money) Money {
+	total := Money(0)
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
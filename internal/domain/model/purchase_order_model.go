@@ -0,0 +1,138 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrPurchaseOrderStatus = errors.New("purchase order cannot be changed in its current status")
+	ErrInvalidReceipt      = errors.New("received products must be on the order and within the outstanding quantity")
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderClosed            PurchaseOrderStatus = "closed"
+)
+
+// PurchaseOrderLine - quantities are in base units of the price unit's dimension, the cost is per price unit
+type PurchaseOrderLine struct {
+	ProductID string
+	Unit      UnitCode
+	Ordered   int64
+	Received  int64
+	UnitCost  Money
+}
+
+func (line PurchaseOrderLine) Outstanding() int64 {
+	return max(line.Ordered-line.Received, 0)
+}
+
+// Cost is what the ordered quantity costs
+func (line PurchaseOrderLine) Cost() Money {
+	unit, err := FindUnit(line.Unit)
+	if err != nil {
+		return 0
+	}
+	return line.UnitCost.MulRatio(line.Ordered, unit.Factor)
+}
+
+type GoodsReceiptLine struct {
+	ProductID string
+	Quantity  int64 // Base units
+	LotID     string
+}
+
+// GoodsReceipt is one delivery against a purchase order; each line became a stock lot
+type GoodsReceipt struct {
+	ID         string
+	Lines      []GoodsReceiptLine
+	Note       string
+	ReceivedBy string
+	ReceivedAt time.Time
+}
+
+// PurchaseOrder goes draft → sent → partially received → received → closed. Only drafts can be edited;
+// a partially received order can be closed when the rest will not come.
+type PurchaseOrder struct {
+	ID         string
+	Number     string // Sequential, e.g. "PO-000042", quoted to the supplier
+	SupplierID string
+	LocationID string // Where the goods are delivered
+	Status     PurchaseOrderStatus
+	Lines      []PurchaseOrderLine
+	Receipts   []GoodsReceipt
+	Note       string
+	ExpectedOn time.Time // Delivery date from the supplier's lead time, set when sent
+	CreatedBy  string
+	CreatedAt  time.Time
+	SentAt     time.Time
+	ClosedAt   time.Time
+	UpdatedAt  time.Time
+}
+
+// Open reports whether goods are still expected on the order, drafts included
+func (order *PurchaseOrder) Open() bool {
+	return order.Status == PurchaseOrderDraft || order.Status == PurchaseOrderSent || order.Status == PurchaseOrderPartiallyReceived
+}
+
+func (order *PurchaseOrder) Total() Money {
+	total := Money(0)
+	for _, line := range order.Lines {
+		total += line.Cost()
+	}
+	return total
+}
+
+func (order *PurchaseOrder) Send(expectedOn time.Time, now time.Time) error {
+	if order.Status != PurchaseOrderDraft || len(order.Lines) == 0 {
+		return ErrPurchaseOrderStatus
+	}
+	order.Status = PurchaseOrderSent
+	order.ExpectedOn = expectedOn
+	order.SentAt = now
+	order.UpdatedAt = now
+	return nil
+}
+
+// Receive books a delivery against the outstanding quantities. Nothing changes when a line does not fit.
+func (order *PurchaseOrder) Receive(receipt GoodsReceipt) error {
+	if order.Status != PurchaseOrderSent && order.Status != PurchaseOrderPartiallyReceived {
+		return ErrPurchaseOrderStatus
+	}
+	lines := slices.Clone(order.Lines)
+	for _, received := range receipt.Lines {
+		index := slices.IndexFunc(lines, func(line PurchaseOrderLine) bool {
+			return line.ProductID == received.ProductID
+		})
+		if index < 0 || received.Quantity <= 0 || received.Quantity > lines[index].Outstanding() {
+			return ErrInvalidReceipt
+		}
+		lines[index].Received += received.Quantity
+	}
+
+	order.Lines = lines
+	order.Receipts = append(slices.Clone(order.Receipts), receipt)
+	order.Status = PurchaseOrderReceived
+	if slices.ContainsFunc(lines, func(line PurchaseOrderLine) bool { return line.Outstanding() > 0 }) {
+		order.Status = PurchaseOrderPartiallyReceived
+	}
+	order.UpdatedAt = receipt.ReceivedAt
+	return nil
+}
+
+func (order *PurchaseOrder) Close(now time.Time) error {
+	if order.Status != PurchaseOrderReceived && order.Status != PurchaseOrderPartiallyReceived {
+		return ErrPurchaseOrderStatus
+	}
+	order.Status = PurchaseOrderClosed
+	order.ClosedAt = now
+	order.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidReorderRule = errors.New("reorder point must not be negative and the target level must be above it")
+
+// ReorderRule says when stock of a product at a location runs low and how far to top it up, in base units
+type ReorderRule struct {
+	ProductID    string
+	LocationID   string
+	Unit         UnitCode // Display unit, the product's price unit
+	ReorderPoint int64    // Available stock at or below this is low
+	TargetLevel  int64    // Reorder suggestions bring stock back up to this
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+func (rule *ReorderRule) Validate() error {
+	if rule.ReorderPoint < 0 || rule.TargetLevel <= rule.ReorderPoint {
+		return ErrInvalidReorderRule
+	}
+	return nil
+}
+
+func (rule *ReorderRule) Key() StockKey {
+	return StockKey{ProductID: rule.ProductID, LocationID: rule.LocationID}
+}
+
+func (rule *ReorderRule) IsLow(available int64) bool {
+	return available <= rule.ReorderPoint
+}
+
+// Shortfall is what still has to be ordered to reach the target, counting stock already on its way
+func (rule *ReorderRule) Shortfall(available int64, incoming int64) int64 {
+	return max(rule.TargetLevel-available-incoming, 0)
+}
+
+type LowStockAlertStatus string
+
+const (
+	LowStockOpen     LowStockAlertStatus = "open"
+	LowStockResolved LowStockAlertStatus = "resolved" // Stock went back above the reorder point
+)
+
+// LowStockAlert records that a product ran low at a location and what was suggested to reorder
+type LowStockAlert struct {
+	ID                string
+	ProductID         string
+	LocationID        string
+	Unit              UnitCode
+	Available         int64 // When the alert was raised
+	Incoming          int64 // In transit or outstanding on open purchase orders when the alert was raised
+	ReorderPoint      int64
+	TargetLevel       int64
+	SuggestedQuantity int64
+	SupplierID        string // Empty when no active supplier sells the product
+	PurchaseOrderID   string // The suggested draft purchase order, if one was created
+	Status            LowStockAlertStatus
+	RaisedAt          time.Time
+	ResolvedAt        time.Time
+}
+
+func (alert *LowStockAlert) Resolve(now time.Time) {
+	alert.Status = LowStockResolved
+	alert.ResolvedAt = now
+}
+
+// LowStockCheck is the outcome of one pass over the reorder rules
+type LowStockCheck struct {
+	Raised         []LowStockAlert
+	Resolved       int
+	PurchaseOrders []PurchaseOrder // Suggested drafts, one per supplier and location
+}
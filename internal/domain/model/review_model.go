@@ -0,0 +1,119 @@
+package model
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5 stars")
+	ErrReviewTooLong   = errors.New("review text is too long")
+	ErrTooManyPhotos   = errors.New("review has too many photos")
+	ErrVoteOnOwnReview = errors.New("authors cannot vote on their own review")
+)
+
+const (
+	MaxReviewLength = 2000 // characters
+	MaxReviewPhotos = 5
+)
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+type ReviewPhoto struct {
+	ID          string
+	Key         string
+	URL         string
+	ContentType string
+}
+
+// Review is a customer's rating of a product they received. Only approved reviews are published and counted.
+type Review struct {
+	ID        string
+	ProductID string
+	UserID    string
+	Rating    int // 1-5 stars
+	Text      string
+	Photos    []ReviewPhoto
+	Status    ReviewStatus
+
+	ModeratorID    string
+	ModerationNote string // Shown to the author when the review is rejected
+	ModeratedAt    time.Time
+
+	Votes map[string]bool // User id -> found the review helpful
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (review *Review) Validate() error {
+	if review.Rating < 1 || review.Rating > 5 {
+		return ErrInvalidRating
+	}
+	if utf8.RuneCountInString(review.Text) > MaxReviewLength {
+		return ErrReviewTooLong
+	}
+	if len(review.Photos) > MaxReviewPhotos {
+		return ErrTooManyPhotos
+	}
+	return nil
+}
+
+// Vote records whether userID found the review helpful, replacing their previous vote
+func (review *Review) Vote(userID string, helpful bool) error {
+	if userID == review.UserID {
+		return ErrVoteOnOwnReview
+	}
+	// Copy before writing: reviews are stored by value and must not share the map with the stored row
+	votes := maps.Clone(review.Votes)
+	if votes == nil {
+		votes = map[string]bool{}
+	}
+	votes[userID] = helpful
+	review.Votes = votes
+	return nil
+}
+
+func (review *Review) HelpfulVotes() (helpful int, unhelpful int) {
+	for _, vote := range review.Votes {
+		if vote {
+			helpful++
+		} else {
+			unhelpful++
+		}
+	}
+	return helpful, unhelpful
+}
+
+// RatingSummary is the aggregate of a product's approved reviews, stored on the product for listings
+type RatingSummary struct {
+	Average   float64 // Rounded to one decimal, 0 without reviews
+	Count     int
+	Histogram [5]int // Histogram[0] counts 1-star reviews, Histogram[4] 5-star reviews
+}
+
+func SummarizeRatings(reviews []Review) RatingSummary {
+	summary := RatingSummary{}
+	total := 0
+	for _, review := range reviews {
+		if review.Status != ReviewApproved {
+			continue
+		}
+		summary.Histogram[review.Rating-1]++
+		summary.Count++
+		total += review.Rating
+	}
+	if summary.Count > 0 {
+		summary.Average = math.Round(float64(total)/float64(summary.Count)*10) / 10
+	}
+	return summary
+}
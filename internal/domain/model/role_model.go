@@ -0,0 +1,8 @@
+package model
+
+const (
+	RoleCustomer = "customer"
+	RoleStaff    = "staff"
+	RoleAdmin    = "admin"
+	RoleShipper  = "shipper"
+)
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidSeason = errors.New("season months must be between 1 and 12")
+	ErrInvalidRegion = errors.New("region must be a lowercase province slug")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// SeasonWindow is an inclusive harvest period in months. It may wrap the new year, e.g. November to February.
+type SeasonWindow struct {
+	From    time.Month
+	To      time.Month
+	Regions []string // Province slugs harvesting in this window; empty means every origin of the product
+}
+
+func (window SeasonWindow) Covers(month time.Month) bool {
+	if window.From <= window.To {
+		return month >= window.From && month <= window.To
+	}
+	return month >= window.From || month <= window.To
+}
+
+func (window SeasonWindow) Validate() error {
+	if window.From < time.January || window.From > time.December || window.To < time.January || window.To > time.December {
+		return ErrInvalidSeason
+	}
+	return validateRegions(window.Regions)
+}
+
+// InSeason reports whether the product is harvested in month. Products without season windows are available all year.
+func (product *Product) InSeason(month time.Month) bool {
+	if len(product.Seasons) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(product.Seasons, func(window SeasonWindow) bool {
+		return window.Covers(month)
+	})
+}
+
+// InSeasonFrom reports whether the product is harvested in month in the given province.
+func (product *Product) InSeasonFrom(month time.Month, region string) bool {
+	if len(product.Seasons) == 0 {
+		return slices.Contains(product.Origins, region)
+	}
+	for _, window := range product.Seasons {
+		regions := window.Regions
+		if len(regions) == 0 {
+			regions = product.Origins
+		}
+		if window.Covers(month) && slices.Contains(regions, region) {
+			return true
+		}
+	}
+	return false
+}
+
+func (product *Product) validateSeasonality() error {
+	if err := validateRegions(product.Origins); err != nil {
+		return err
+	}
+	for _, window := range product.Seasons {
+		if err := window.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRegions(regions []string) error {
+	for _, region := range regions {
+		if !slugPattern.MatchString(region) {
+			return ErrInvalidRegion
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidLotDates   = errors.New("best-before date must not be before the harvest date")
+	ErrInsufficientStock = errors.New("not enough stock to allocate the quantity")
+)
+
+type LotStatus string
+
+const (
+	LotActive     LotStatus = "active"
+	LotDepleted   LotStatus = "depleted"
+	LotWrittenOff LotStatus = "written_off"
+)
+
+// StockLot is one delivery of a product from a supplier. Quantities are in base units of Unit's dimension.
+type StockLot struct {
+	ID          string
+	ProductID   string
+	LocationID  string
+	SupplierID  string
+	Code        string   // Lot number printed on the crate
+	Unit        UnitCode // Display unit of the received quantity
+	Received    int64
+	Remaining   int64
+	HarvestedOn time.Time // Calendar dates at midnight in the store zone
+	BestBefore  time.Time
+	Status      LotStatus
+	Reason      string // Why the lot was written off
+	ReceivedAt  time.Time
+	UpdatedAt   time.Time
+}
+
+func (lot *StockLot) Validate() error {
+	if lot.Received <= 0 {
+		return ErrInvalidQuantity
+	}
+	if lot.BestBefore.Before(lot.HarvestedOn) {
+		return ErrInvalidLotDates
+	}
+	return nil
+}
+
+// DaysLeft counts whole days from today to the best-before date; negative once the lot has expired.
+func (lot *StockLot) DaysLeft(today time.Time) int {
+	return int(lot.BestBefore.Sub(today).Hours() / 24)
+}
+
+// Expired reports whether the best-before date has passed. A lot is still sellable on its best-before day.
+func (lot *StockLot) Expired(today time.Time) bool {
+	return lot.DaysLeft(today) < 0
+}
+
+// Pickable - an active lot with stock left that has not expired
+func (lot *StockLot) Pickable(today time.Time) bool {
+	return lot.Status == LotActive && lot.Remaining > 0 && !lot.Expired(today)
+}
+
+type LotAllocation struct {
+	LotID    string
+	Quantity int64
+}
+
+// AllocateFEFO takes quantity from the lots that expire first (first-expired-first-out), skipping lots that
+// cannot be picked today. Lots sharing a best-before date are used in the order they were received.
+func AllocateFEFO(lots []StockLot, quantity int64, today time.Time) ([]LotAllocation, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	candidates := make([]StockLot, 0, len(lots))
+	for _, lot := range lots {
+		if lot.Pickable(today) {
+			candidates = append(candidates, lot)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].BestBefore.Equal(candidates[j].BestBefore) {
+			return candidates[i].BestBefore.Before(candidates[j].BestBefore)
+		}
+		return candidates[i].ReceivedAt.Before(candidates[j].ReceivedAt)
+	})
+
+	allocations := make([]LotAllocation, 0)
+	left := quantity
+	for _, lot := range candidates {
+		if left == 0 {
+			break
+		}
+		take := min(lot.Remaining, left)
+		allocations = append(allocations, LotAllocation{LotID: lot.ID, Quantity: take})
+		left -= take
+	}
+	if left > 0 {
+		return nil, ErrInsufficientStock
+	}
+	return allocations, nil
+}
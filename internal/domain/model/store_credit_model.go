@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Sources a store credit entry can come from
+const (
+	CreditSourceClaim = "claim"
+)
+
+// CreditEntry is one entry of a customer's store credit ledger: positive when credit is granted, negative when spent.
+// The balance is the sum of the entries.
+type CreditEntry struct {
+	ID         string
+	UserID     string
+	Amount     Money
+	Note       string
+	SourceType string
+	SourceID   string
+	ActorID    string
+	CreatedAt  time.Time
+}
+
+// CreditBalance sums a customer's entries
+func CreditBalance(entries []CreditEntry) Money {
+	var balance Money
+	for _, entry := range entries {
+		balance += entry.Amount
+	}
+	return balance
+}
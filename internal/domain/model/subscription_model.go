@@ -0,0 +1,224 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSubscriptionPlan = errors.New("subscription plan needs a name, box contents, a frequency and a delivery day")
+	ErrSubscriptionStatus      = errors.New("subscription cannot do that in its current status")
+	ErrSubscriptionDate        = errors.New("date is not an upcoming delivery of the subscription")
+)
+
+type SubscriptionFrequency string
+
+const (
+	FrequencyWeekly      SubscriptionFrequency = "weekly"
+	FrequencyFortnightly SubscriptionFrequency = "fortnightly"
+)
+
+// Days between two boxes; 0 for an unknown frequency
+func (frequency SubscriptionFrequency) Days() int {
+	switch frequency {
+	case FrequencyWeekly:
+		return 7
+	case FrequencyFortnightly:
+		return 14
+	}
+	return 0
+}
+
+// BoxItem is a product in a subscription box, with the products sent instead when it is out of stock
+type BoxItem struct {
+	ProductID   string
+	Name        string // Default-locale name when the plan was saved
+	Quantity    Quantity
+	Substitutes []string // Product ids tried in order, at the same quantity
+}
+
+// SubscriptionPlan is a box customers subscribe to: its contents, how often it comes and on which day.
+// Boxes are charged at the prices of the day they are ordered.
+type SubscriptionPlan struct {
+	ID          string
+	Name        string
+	Description string
+	Items       []BoxItem
+	Frequency   SubscriptionFrequency
+	DeliveryDay time.Weekday
+	Active      bool // Inactive plans take no new subscribers; existing ones keep getting their boxes
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (plan *SubscriptionPlan) Validate() error {
+	if strings.TrimSpace(plan.Name) == "" || len(plan.Items) == 0 || plan.Frequency.Days() == 0 {
+		return ErrInvalidSubscriptionPlan
+	}
+	if plan.DeliveryDay < time.Sunday || plan.DeliveryDay > time.Saturday {
+		return ErrInvalidSubscriptionPlan
+	}
+	for index, item := range plan.Items {
+		if item.Quantity.Base <= 0 || slices.Contains(item.Substitutes, item.ProductID) {
+			return ErrInvalidSubscriptionPlan
+		}
+		if slices.ContainsFunc(plan.Items[:index], func(other BoxItem) bool { return other.ProductID == item.ProductID }) {
+			return ErrInvalidSubscriptionPlan
+		}
+	}
+	return nil
+}
+
+// FirstDelivery is the plan's first delivery day on or after from, a store date
+func (plan *SubscriptionPlan) FirstDelivery(from time.Time) time.Time {
+	return from.AddDate(0, 0, (int(plan.DeliveryDay)-int(from.Weekday())+7)%7)
+}
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive    SubscriptionStatus = "active"
+	SubscriptionPaused    SubscriptionStatus = "paused"
+	SubscriptionCancelled SubscriptionStatus = "cancelled"
+)
+
+type BoxOutcome string
+
+const (
+	BoxOrdered BoxOutcome = "ordered"
+	BoxSkipped BoxOutcome = "skipped" // Skipped by the customer or paused
+	BoxFailed  BoxOutcome = "failed"  // No order could be placed, e.g. nothing in stock or no slot with room
+)
+
+// BoxRun records what became of one delivery day of a subscription
+type BoxRun struct {
+	SubscriptionID string
+	Date           time.Time // Store date of the delivery
+	Outcome        BoxOutcome
+	OrderID        string
+	Notes          []Message // Substitutions made, or why no box was sent
+	At             time.Time
+}
+
+// Subscription is a customer getting a plan's box delivered on its cycle. The frequency and delivery day are the
+// plan's when they subscribed, so later changes to the plan do not move their deliveries.
+type Subscription struct {
+	ID            string
+	UserID        string
+	PlanID        string
+	PlanName      string
+	Status        SubscriptionStatus
+	Frequency     SubscriptionFrequency
+	DeliveryDay   time.Weekday
+	PaymentMethod PaymentMethod
+	Address       DeliveryAddress
+	Note          string
+	Locale        string      // Language the customer subscribed in, which their notifications are written in
+	NextDelivery  time.Time   // Store date of the next box
+	Skipped       []time.Time // Upcoming delivery days the customer skipped, earliest first
+	PausedUntil   time.Time   // A paused subscription resumes on its own on this store date; zero until resumed
+	History       []BoxRun    // Oldest first
+	CancelReason  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CancelledAt   time.Time
+}
+
+// OnCycle reports whether date is one of the subscription's delivery days from the next one on
+func (subscription *Subscription) OnCycle(date time.Time) bool {
+	days := daysBetween(subscription.NextDelivery, date)
+	return days >= 0 && days%subscription.Frequency.Days() == 0
+}
+
+// Skips reports whether the customer skipped the delivery day
+func (subscription *Subscription) Skips(date time.Time) bool {
+	return slices.ContainsFunc(subscription.Skipped, date.Equal)
+}
+
+// Skip leaves out the box of an upcoming delivery day; skipping a day twice changes nothing
+func (subscription *Subscription) Skip(date time.Time, now time.Time) error {
+	if subscription.Status == SubscriptionCancelled {
+		return ErrSubscriptionStatus
+	}
+	if !subscription.OnCycle(date) {
+		return ErrSubscriptionDate
+	}
+	if !subscription.Skips(date) {
+		subscription.Skipped = append(slices.Clone(subscription.Skipped), date)
+		slices.SortFunc(subscription.Skipped, time.Time.Compare)
+	}
+	subscription.UpdatedAt = now
+	return nil
+}
+
+// Unskip brings back the box of a day the customer skipped
+func (subscription *Subscription) Unskip(date time.Time, now time.Time) error {
+	if subscription.Status == SubscriptionCancelled {
+		return ErrSubscriptionStatus
+	}
+	if !subscription.Skips(date) {
+		return ErrSubscriptionDate
+	}
+	subscription.Skipped = slices.DeleteFunc(slices.Clone(subscription.Skipped), date.Equal)
+	subscription.UpdatedAt = now
+	return nil
+}
+
+// Pause stops boxes until the customer resumes the subscription, or until the store date until when it is set
+func (subscription *Subscription) Pause(until time.Time, today time.Time, now time.Time) error {
+	if subscription.Status != SubscriptionActive {
+		return ErrSubscriptionStatus
+	}
+	if !until.IsZero() && !until.After(today) {
+		return ErrSubscriptionDate
+	}
+	subscription.Status = SubscriptionPaused
+	subscription.PausedUntil = until
+	subscription.UpdatedAt = now
+	return nil
+}
+
+func (subscription *Subscription) Resume(now time.Time) error {
+	if subscription.Status != SubscriptionPaused {
+		return ErrSubscriptionStatus
+	}
+	subscription.Status = SubscriptionActive
+	subscription.PausedUntil = time.Time{}
+	subscription.UpdatedAt = now
+	return nil
+}
+
+// PauseEnded reports whether a subscription paused until a date should resume on today
+func (subscription *Subscription) PauseEnded(today time.Time) bool {
+	return subscription.Status == SubscriptionPaused && !subscription.PausedUntil.IsZero() && !today.Before(subscription.PausedUntil)
+}
+
+func (subscription *Subscription) Cancel(reason string, now time.Time) error {
+	if subscription.Status == SubscriptionCancelled {
+		return ErrSubscriptionStatus
+	}
+	subscription.Status = SubscriptionCancelled
+	subscription.CancelReason = strings.TrimSpace(reason)
+	subscription.CancelledAt = now
+	subscription.UpdatedAt = now
+	return nil
+}
+
+// Record keeps what became of the next delivery day and moves on to the one after it
+func (subscription *Subscription) Record(run BoxRun, now time.Time) {
+	subscription.History = append(slices.Clone(subscription.History), run)
+	subscription.NextDelivery = subscription.NextDelivery.AddDate(0, 0, subscription.Frequency.Days())
+	subscription.Skipped = slices.DeleteFunc(slices.Clone(subscription.Skipped), func(date time.Time) bool {
+		return date.Before(subscription.NextDelivery)
+	})
+	subscription.UpdatedAt = now
+}
+
+// daysBetween counts calendar days from one date to another, whatever the time zone's offsets do in between
+func daysBetween(from time.Time, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
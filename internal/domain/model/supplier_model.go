@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrInvalidSupplier = errors.New("supplier id must be a lowercase slug, the type known and each product listed once at a cost")
+
+type SupplierType string
+
+const (
+	SupplierFarm       SupplierType = "farm"
+	SupplierWholesaler SupplierType = "wholesaler"
+)
+
+var SupplierTypes = []SupplierType{SupplierFarm, SupplierWholesaler}
+
+// MaxLeadTimeDays - a supplier taking longer than this is not one we order fresh produce from
+const MaxLeadTimeDays = 60
+
+type SupplierContact struct {
+	Name  string
+	Role  string // e.g. "owner", "sales", "dispatch"
+	Phone string
+	Email string
+}
+
+// SuppliedProduct is a product a supplier sells to us, at a cost per the product's price unit
+type SuppliedProduct struct {
+	ProductID   string
+	SupplierSKU string // The supplier's own code, printed on its invoices
+	CostPrice   Money
+	Preferred   bool // Reorder suggestions go to a preferred supplier before a cheaper one
+}
+
+// Supplier is a farm or wholesaler we buy produce from
+type Supplier struct {
+	ID           string // Slug chosen by staff, e.g. "da-lat-green-farm"
+	Name         string
+	Type         SupplierType
+	Contacts     []SupplierContact
+	LeadTimeDays int // Days between sending a purchase order and the delivery
+	Products     []SuppliedProduct
+	Note         string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (supplier *Supplier) Validate() error {
+	if !slugPattern.MatchString(supplier.ID) || !slices.Contains(SupplierTypes, supplier.Type) {
+		return ErrInvalidSupplier
+	}
+	if supplier.LeadTimeDays < 0 || supplier.LeadTimeDays > MaxLeadTimeDays {
+		return ErrInvalidSupplier
+	}
+	seen := make(map[string]bool, len(supplier.Products))
+	for _, product := range supplier.Products {
+		if product.CostPrice < 0 || seen[product.ProductID] {
+			return ErrInvalidSupplier
+		}
+		seen[product.ProductID] = true
+	}
+	return nil
+}
+
+// Supplies finds the terms on which the supplier sells a product
+func (supplier *Supplier) Supplies(productID string) (SuppliedProduct, bool) {
+	index := slices.IndexFunc(supplier.Products, func(product SuppliedProduct) bool {
+		return product.ProductID == productID
+	})
+	if index < 0 {
+		return SuppliedProduct{}, false
+	}
+	return supplier.Products[index], true
+}
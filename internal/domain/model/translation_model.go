@@ -0,0 +1,37 @@
+package model
+
+// Translation holds the localized content of a catalog entity for one locale
+type Translation struct {
+	Name        string
+	Description string
+}
+
+// Translations maps a locale ("vi") to its content. The default locale lives in the entity's own fields.
+type Translations map[string]Translation
+
+// Resolve returns the name and description for locale, falling back field by field to the default content
+func (translations Translations) Resolve(locale string, defaultName string, defaultDescription string) (string, string) {
+	name, description := defaultName, defaultDescription
+	if translation, ok := translations[locale]; ok {
+		if translation.Name != "" {
+			name = translation.Name
+		}
+		if translation.Description != "" {
+			description = translation.Description
+		}
+	}
+	return name, description
+}
+
+// Missing lists the fields ("name", "description") that have default content but no translation for locale
+func (translations Translations) Missing(locale string, defaultDescription string) []string {
+	translation := translations[locale]
+	missing := make([]string, 0)
+	if translation.Name == "" {
+		missing = append(missing, "name")
+	}
+	if defaultDescription != "" && translation.Description == "" {
+		missing = append(missing, "description")
+	}
+	return missing
+}
@@ -0,0 +1,218 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+This file defines units of measure and exact quantities.
+Logic:
+- Every unit belongs to a dimension (mass, count, bunch) and is worth Factor base units of that dimension.
+- A Quantity always stores its amount in base units (grams, pieces, bunches) as an integer,
+  and keeps the unit it should be displayed in. 1.25 kg is stored as {Base: 1250, Unit: "kg"}.
+- Conversions only happen inside one dimension. A bunch cannot be converted to grams or pieces.
+*/
+
+var (
+	ErrUnknownUnit               = errors.New("unknown unit of measure")
+	ErrIncompatibleUnits         = errors.New("units belong to different dimensions")
+	ErrInvalidQuantity           = errors.New("invalid quantity")
+	ErrQuantityBelowMinimum      = errors.New("quantity is below the minimum order quantity")
+	ErrQuantityAboveMaximum      = errors.New("quantity is above the maximum order quantity")
+	ErrQuantityNotMultipleOfStep = errors.New("quantity is not a multiple of the step increment")
+)
+
+type UnitCode string
+
+const (
+	UnitGram     UnitCode = "g"
+	UnitKilogram UnitCode = "kg"
+	UnitPiece    UnitCode = "piece"
+	UnitBunch    UnitCode = "bunch"
+)
+
+type Dimension string
+
+const (
+	DimensionMass  Dimension = "mass"
+	DimensionCount Dimension = "count"
+	DimensionBunch Dimension = "bunch"
+)
+
+type UnitOfMeasure struct {
+	Code      UnitCode
+	Dimension Dimension
+	Factor    int64 // Number of base units in one of this unit
+}
+
+var unitsOfMeasure = map[UnitCode]UnitOfMeasure{
+	UnitGram:     {Code: UnitGram, Dimension: DimensionMass, Factor: 1},
+	UnitKilogram: {Code: UnitKilogram, Dimension: DimensionMass, Factor: 1000},
+	UnitPiece:    {Code: UnitPiece, Dimension: DimensionCount, Factor: 1},
+	UnitBunch:    {Code: UnitBunch, Dimension: DimensionBunch, Factor: 1},
+}
+
+// referenceQuantities - quantities used to display comparable unit prices per dimension
+var referenceQuantities = map[Dimension][]Quantity{
+	DimensionMass:  {{Base: 100, Unit: UnitGram}, {Base: 1000, Unit: UnitKilogram}},
+	DimensionCount: {{Base: 1, Unit: UnitPiece}},
+	DimensionBunch: {{Base: 1, Unit: UnitBunch}},
+}
+
+func FindUnit(code UnitCode) (UnitOfMeasure, error) {
+	unit, ok := unitsOfMeasure[code]
+	if !ok {
+		return UnitOfMeasure{}, ErrUnknownUnit
+	}
+	return unit, nil
+}
+
+// Units returns all supported units ordered by dimension then factor.
+func Units() []UnitOfMeasure {
+	units := make([]UnitOfMeasure, 0, len(unitsOfMeasure))
+	for _, unit := range unitsOfMeasure {
+		units = append(units, unit)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].Dimension != units[j].Dimension {
+			return units[i].Dimension < units[j].Dimension
+		}
+		return units[i].Factor < units[j].Factor
+	})
+	return units
+}
+
+// decimals - number of fraction digits a value in this unit may carry (kg → 3, g → 0)
+func (unit UnitOfMeasure) decimals() int {
+	return len(strconv.FormatInt(unit.Factor, 10)) - 1
+}
+
+type Quantity struct {
+	Base int64
+	Unit UnitCode
+}
+
+// ParseQuantity - Usage: ParseQuantity("1.25", UnitKilogram) → {Base: 1250, Unit: "kg"}.
+// The value is parsed as an exact decimal; more fraction digits than the unit allows is an error.
+func ParseQuantity(value string, code UnitCode) (Quantity, error) {
+	unit, err := FindUnit(code)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		return Quantity{}, ErrInvalidQuantity
+	}
+
+	integerPart, fractionPart, _ := strings.Cut(value, ".")
+	if len(fractionPart) > unit.decimals() {
+		return Quantity{}, ErrInvalidQuantity
+	}
+	fractionPart += strings.Repeat("0", unit.decimals()-len(fractionPart))
+	if integerPart == "" {
+		integerPart = "0"
+	}
+
+	base, err := strconv.ParseInt(integerPart+fractionPart, 10, 64)
+	if err != nil {
+		return Quantity{}, ErrInvalidQuantity
+	}
+	return Quantity{Base: base, Unit: code}, nil
+}
+
+func (quantity Quantity) Dimension() Dimension {
+	return unitsOfMeasure[quantity.Unit].Dimension
+}
+
+// Value renders the amount in the display unit without trailing zeros, e.g. "1.25" for 1250 g shown in kg.
+func (quantity Quantity) Value() string {
+	unit, err := FindUnit(quantity.Unit)
+	if err != nil || unit.Factor == 1 {
+		return strconv.FormatInt(quantity.Base, 10)
+	}
+
+	sign := ""
+	base := quantity.Base
+	if base < 0 {
+		sign, base = "-", -base
+	}
+	integerPart := base / unit.Factor
+	fractionPart := fmt.Sprintf("%0*d", unit.decimals(), base%unit.Factor)
+	fractionPart = strings.TrimRight(fractionPart, "0")
+	if fractionPart == "" {
+		return fmt.Sprintf("%s%d", sign, integerPart)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, integerPart, fractionPart)
+}
+
+func (quantity Quantity) String() string {
+	return quantity.Value() + " " + string(quantity.Unit)
+}
+
+// In returns the same amount displayed in another unit of the same dimension.
+func (quantity Quantity) In(code UnitCode) (Quantity, error) {
+	unit, err := FindUnit(code)
+	if err != nil {
+		return Quantity{}, err
+	}
+	if unit.Dimension != quantity.Dimension() {
+		return Quantity{}, ErrIncompatibleUnits
+	}
+	return Quantity{Base: quantity.Base, Unit: code}, nil
+}
+
+// Add sums two quantities of the same dimension, keeping the unit of the receiver.
+func (quantity Quantity) Add(other Quantity) (Quantity, error) {
+	if quantity.Dimension() != other.Dimension() {
+		return Quantity{}, ErrIncompatibleUnits
+	}
+	return Quantity{Base: quantity.Base + other.Base, Unit: quantity.Unit}, nil
+}
+
+// SaleRule describes how a product can be ordered: in which unit, from which minimum and by which step.
+// All amounts are in base units of the unit's dimension; MaxQuantity 0 means no limit.
+type SaleRule struct {
+	Unit        UnitCode
+	MinQuantity int64
+	Step        int64
+	MaxQuantity int64
+}
+
+func (rule SaleRule) Validate() error {
+	if _, err := FindUnit(rule.Unit); err != nil {
+		return err
+	}
+	if rule.MinQuantity <= 0 || rule.Step <= 0 || rule.MaxQuantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if rule.MaxQuantity > 0 && rule.MaxQuantity < rule.MinQuantity {
+		return ErrInvalidQuantity
+	}
+	return nil
+}
+
+// Check verifies that a requested quantity respects the minimum, maximum and step of the rule.
+func (rule SaleRule) Check(quantity Quantity) error {
+	unit, err := FindUnit(rule.Unit)
+	if err != nil {
+		return err
+	}
+	if quantity.Dimension() != unit.Dimension {
+		return ErrIncompatibleUnits
+	}
+	if quantity.Base < rule.MinQuantity {
+		return ErrQuantityBelowMinimum
+	}
+	if rule.MaxQuantity > 0 && quantity.Base > rule.MaxQuantity {
+		return ErrQuantityAboveMaximum
+	}
+	if (quantity.Base-rule.MinQuantity)%rule.Step != 0 {
+		return ErrQuantityNotMultipleOfStep
+	}
+	return nil
+}
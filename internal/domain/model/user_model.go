@@ -1,8 +1,9 @@
 package model
 
 type User struct {
-	ID   string
-	Name string
-	Age  int
-	Sex  bool
+	ID    string
+	Name  string
+	Age   int
+	Sex   bool
+	Roles []string
 }
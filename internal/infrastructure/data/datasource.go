@@ -1 +1,139 @@
 package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+)
+
+/*
+This file defines the in-memory Datasource used by all repositories until a real database is wired in.
+Logic:
+- Every repository stores its rows in a Table registered to the shared Datasource.
+- Single row operations are atomic on their own (see Table.Update).
+- Transaction serializes writes and hands its callback a context carrying the transaction. Writes made with that
+  context, from any goroutine, join the transaction and record how to undo them; Transaction called with it joins
+  too, so transactional services can call each other. Writes made with any other context wait for the transaction
+  to end and commit at once.
+- When the callback returns an error or panics only the rows written through the transaction are put back, so
+  callers get all-or-nothing semantics without copying any table.
+*/
+
+type Datasource struct {
+	transactionMutex sync.Mutex
+}
+
+// transaction is what a transaction's context carries: the undo log its writes fill until it ends
+type transaction struct {
+	datasource *Datasource
+	mutex      sync.Mutex
+	undo       []func()
+	ended      bool
+}
+
+type transactionKey struct{}
+
+func NewDatasource() *Datasource {
+	return &Datasource{}
+}
+
+// Transaction - Usage: datasource.Transaction(ctx, func(ctx context.Context) error { ... }) to run several writes
+// all-or-nothing. Writes must be made with the context the callback gets.
+func (datasource *Datasource) Transaction(ctx context.Context, callback func(ctx context.Context) error) (err error) {
+	if datasource.running(ctx) != nil {
+		return callback(ctx)
+	}
+
+	datasource.transactionMutex.Lock()
+	defer datasource.transactionMutex.Unlock()
+	tx := &transaction{datasource: datasource}
+	rollback := true
+	defer func() {
+		recovered := recover()
+		tx.end(rollback)
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	err = callback(context.WithValue(ctx, transactionKey{}, tx))
+	rollback = err != nil
+	return err
+}
+
+// running returns the transaction of this datasource ctx carries, nil when it carries none or it has ended
+func (datasource *Datasource) running(ctx context.Context) *transaction {
+	tx, ok := ctx.Value(transactionKey{}).(*transaction)
+	if !ok || tx.datasource != datasource {
+		return nil
+	}
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+	if tx.ended {
+		return nil
+	}
+	return tx
+}
+
+// write applies one table write. Within a running transaction apply gets it to remember how to undo the write;
+// otherwise it gets nil and runs once no transaction does.
+func (datasource *Datasource) write(ctx context.Context, apply func(tx *transaction)) {
+	if tx := datasource.running(ctx); tx != nil && tx.apply(apply) {
+		return
+	}
+	datasource.transactionMutex.Lock()
+	defer datasource.transactionMutex.Unlock()
+	apply(nil)
+}
+
+// apply runs a write within the transaction and reports whether it did, which it does not once the transaction ended
+func (tx *transaction) apply(apply func(tx *transaction)) bool {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+	if tx.ended {
+		return false
+	}
+	apply(tx)
+	return true
+}
+
+// remember records how to undo a write; it must be called from within apply
+func (tx *transaction) remember(undo func()) {
+	tx.undo = append(tx.undo, undo)
+}
+
+// end stops the transaction taking writes and, on rollback, undoes the ones it took, the latest first
+func (tx *transaction) end(rollback bool) {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+	tx.ended = true
+	if rollback {
+		for index := len(tx.undo) - 1; index >= 0; index-- {
+			tx.undo[index]()
+		}
+	}
+	tx.undo = nil
+}
+
+func (datasource *Datasource) Name() string { return "Datasource" }
+func (datasource *Datasource) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", datasource.Name()))
+	return nil
+}
+func (datasource *Datasource) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", datasource.Name()))
+	return nil
+}
+
+func NewTransactor(datasource *Datasource) infra_interface.Transactor {
+	return datasource
+}
+
+var DatasourceModule = fx.Options(
+	fx.Provide(NewDatasource),
+	fx.Provide(NewTransactor),
+)
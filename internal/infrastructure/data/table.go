@@ -0,0 +1,149 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+var ErrRowNotFound = errors.New("row not found")
+
+// Table is an in-memory, insertion-ordered collection of rows keyed by K.
+// Rows are stored by value, so callers must not mutate slices or maps of a row they read.
+type Table[K comparable, V any] struct {
+	datasource *Datasource
+	mutex      sync.RWMutex
+	rows       map[K]V
+	keys       []K
+}
+
+func NewTable[K comparable, V any](datasource *Datasource) *Table[K, V] {
+	return &Table[K, V]{datasource: datasource, rows: map[K]V{}}
+}
+
+func (table *Table[K, V]) Get(key K) (V, bool) {
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+	row, ok := table.rows[key]
+	return row, ok
+}
+
+// Put inserts or replaces the row stored under key.
+func (table *Table[K, V]) Put(ctx context.Context, key K, row V) {
+	table.datasource.write(ctx, func(tx *transaction) {
+		table.mutex.Lock()
+		defer table.mutex.Unlock()
+		table.remember(tx, key)
+		if _, exists := table.rows[key]; !exists {
+			table.keys = append(table.keys, key)
+		}
+		table.rows[key] = row
+	})
+}
+
+// Insert stores the row only if key is free and reports whether it did.
+func (table *Table[K, V]) Insert(ctx context.Context, key K, row V) bool {
+	var inserted bool
+	table.datasource.write(ctx, func(tx *transaction) {
+		table.mutex.Lock()
+		defer table.mutex.Unlock()
+		if _, exists := table.rows[key]; exists {
+			return
+		}
+		table.remember(tx, key)
+		table.keys = append(table.keys, key)
+		table.rows[key] = row
+		inserted = true
+	})
+	return inserted
+}
+
+// Update atomically reads, modifies and writes back one row. The row is left untouched when modify fails.
+// modify must not use any table itself.
+func (table *Table[K, V]) Update(ctx context.Context, key K, modify func(row V) (V, error)) (V, error) {
+	var (
+		updated V
+		err     error
+	)
+	table.datasource.write(ctx, func(tx *transaction) {
+		table.mutex.Lock()
+		defer table.mutex.Unlock()
+
+		row, ok := table.rows[key]
+		if !ok {
+			err = ErrRowNotFound
+			return
+		}
+		if updated, err = modify(row); err != nil {
+			updated = row
+			return
+		}
+		table.remember(tx, key)
+		table.rows[key] = updated
+	})
+	return updated, err
+}
+
+func (table *Table[K, V]) Delete(ctx context.Context, key K) bool {
+	var deleted bool
+	table.datasource.write(ctx, func(tx *transaction) {
+		table.mutex.Lock()
+		defer table.mutex.Unlock()
+		if _, exists := table.rows[key]; !exists {
+			return
+		}
+		table.remember(tx, key)
+		delete(table.rows, key)
+		table.keys = slices.DeleteFunc(table.keys, func(k K) bool { return k == key })
+		deleted = true
+	})
+	return deleted
+}
+
+// List returns all rows in insertion order.
+func (table *Table[K, V]) List() []V {
+	return table.Filter(func(V) bool { return true })
+}
+
+// Filter returns the rows matching predicate in insertion order.
+func (table *Table[K, V]) Filter(predicate func(row V) bool) []V {
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+	result := make([]V, 0)
+	for _, key := range table.keys {
+		if row := table.rows[key]; predicate(row) {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+func (table *Table[K, V]) Len() int {
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+	return len(table.rows)
+}
+
+// remember records in tx how to put the row under key back as it is now, should tx roll back. It does nothing
+// outside a transaction. The table must be locked.
+func (table *Table[K, V]) remember(tx *transaction, key K) {
+	if tx == nil {
+		return
+	}
+	row, existed := table.rows[key]
+	index := slices.Index(table.keys, key)
+	tx.remember(func() {
+		table.mutex.Lock()
+		defer table.mutex.Unlock()
+		if !existed {
+			delete(table.rows, key)
+			table.keys = slices.DeleteFunc(table.keys, func(k K) bool { return k == key })
+			return
+		}
+		if _, exists := table.rows[key]; !exists {
+			table.keys = slices.Insert(table.keys, index, key)
+		}
+		table.rows[key] = row
+	})
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+This file defines the in-memory store behind the Idempotency-Key middleware.
+Logic:
+- A key is claimed by the first request sending it and lives for 'idempotency.ttl' from then on.
+- Expired keys are treated as free and swept away periodically.
+- Keys are kept outside the Datasource on purpose: a transaction rolling back must not forget a key.
+*/
+
+const (
+	defaultTTL    = 24 * time.Hour
+	sweepInterval = 10 * time.Minute
+)
+
+type MemoryStore struct {
+	mutex   sync.Mutex
+	records map[string]infra_interface.IdempotencyRecord
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewIdempotencyStore keeps keys for 'idempotency.ttl' (24h when unset)
+func NewIdempotencyStore(scheduler infra_interface.Scheduler) infra_interface.IdempotencyStore {
+	ttl, err := util.ParseDuration(core.Configs.Idempotency.TTL)
+	if err != nil || ttl <= 0 {
+		ttl = defaultTTL
+	}
+	store := NewMemoryStore(ttl, time.Now)
+
+	scheduler.Every("expire-idempotency-keys", sweepInterval, func() error {
+		if expired := store.Sweep(); expired > 0 {
+			zap.L().Debug("Deleted expired idempotency keys", zap.Int("count", expired))
+		}
+		return nil
+	})
+	return store
+}
+
+// NewMemoryStore keeps keys for ttl, reading the time from now
+func NewMemoryStore(ttl time.Duration, now func() time.Time) *MemoryStore {
+	return &MemoryStore{records: make(map[string]infra_interface.IdempotencyRecord), ttl: ttl, now: now}
+}
+
+func (store *MemoryStore) Claim(key string, fingerprint string) (*infra_interface.IdempotencyRecord, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	if record, ok := store.records[key]; ok && now.Before(record.ExpiresAt) {
+		return &record, false
+	}
+	store.records[key] = infra_interface.IdempotencyRecord{Fingerprint: fingerprint, ExpiresAt: now.Add(store.ttl)}
+	return nil, true
+}
+
+func (store *MemoryStore) Complete(key string, response infra_interface.IdempotentResponse) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if record, ok := store.records[key]; ok {
+		record.Response = &response
+		store.records[key] = record
+	}
+}
+
+func (store *MemoryStore) Release(key string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	delete(store.records, key)
+}
+
+// Sweep deletes the expired keys and returns how many there were
+func (store *MemoryStore) Sweep() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	expired := 0
+	for key, record := range store.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(store.records, key)
+			expired++
+		}
+	}
+	return expired
+}
+
+func (store *MemoryStore) Name() string { return "IdempotencyStore" }
+func (store *MemoryStore) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", store.Name()))
+	return nil
+}
+func (store *MemoryStore) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", store.Name()))
+	return nil
+}
+
+var IdempotencyStoreModule = fx.Options(fx.Provide(NewIdempotencyStore))
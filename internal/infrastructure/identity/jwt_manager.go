@@ -54,9 +54,11 @@ func (manager *jwtManager) Sign(isRefresh bool, userID string, roles ...string)
 	var err error
 	if isRefresh {
 		Expiration, err = util.ParseDuration(core.Configs.JWT.RefreshDuration)
-		if err != nil {
-			core.Logger.Fatal("error to parse string to duration", zap.Error(err))
-		}
+	} else {
+		Expiration, err = util.ParseDuration(core.Configs.JWT.AccessDuration)
+	}
+	if err != nil {
+		core.Logger.Fatal("error to parse string to duration", zap.Error(err))
 	}
 	claims := &infra_interface.JWTClaims{
 		UserID: userID,
@@ -75,14 +77,16 @@ func (manager *jwtManager) Sign(isRefresh bool, userID string, roles ...string)
 func (manager *jwtManager) Verify(tokenStr string) (*infra_interface.JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &infra_interface.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return manager.publicKey, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
 	if err != nil {
-		core.Logger.Fatal("invalid token", zap.Error(err))
+		core.Logger.Warn("invalid token", zap.Error(err))
+		return nil, core.Error.Invalid.Token
 	}
 
 	claims, ok := token.Claims.(*infra_interface.JWTClaims)
 	if !ok || !token.Valid {
-		core.Logger.Fatal("invalid claims", zap.Error(err))
+		core.Logger.Warn("invalid claims")
+		return nil, core.Error.Invalid.Token
 	}
 	return claims, nil
 }
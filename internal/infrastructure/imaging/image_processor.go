@@ -0,0 +1,75 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP decoder
+)
+
+const jpegQuality = 85
+
+type imageProcessor struct{}
+
+func NewImageProcessor() infra_interface.ImageProcessor {
+	return &imageProcessor{}
+}
+
+func (processor *imageProcessor) Inspect(content []byte) (*infra_interface.ImageInfo, error) {
+	// DetectContentType only looks at the leading magic bytes, never at the file name or client header
+	contentType := http.DetectContentType(content)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/webp":
+	default:
+		return nil, infra_interface.ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, infra_interface.ErrUnsupportedImage
+	}
+	return &infra_interface.ImageInfo{ContentType: contentType, Width: config.Width, Height: config.Height}, nil
+}
+
+func (processor *imageProcessor) Resize(content []byte, maxSide int) ([]byte, *infra_interface.ImageInfo, error) {
+	source, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, infra_interface.ErrUnsupportedImage
+	}
+
+	width, height := fit(source.Bounds().Dx(), source.Bounds().Dy(), maxSide)
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(target, target.Bounds(), source, source.Bounds(), draw.Over, nil)
+
+	// PNG keeps transparency; JPEG and WebP sources are re-encoded as JPEG (there is no WebP encoder in Go)
+	var buffer bytes.Buffer
+	contentType := "image/jpeg"
+	if format == "png" {
+		contentType = "image/png"
+		err = png.Encode(&buffer, target)
+	} else {
+		err = jpeg.Encode(&buffer, target, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buffer.Bytes(), &infra_interface.ImageInfo{ContentType: contentType, Width: width, Height: height}, nil
+}
+
+// fit returns the dimensions scaled down so that the longest side is at most maxSide (never scales up)
+func fit(width int, height int, maxSide int) (int, int) {
+	longest := max(width, height)
+	if longest <= maxSide || longest == 0 {
+		return width, height
+	}
+	return max(1, width*maxSide/longest), max(1, height*maxSide/longest)
+}
+
+var ImageProcessorModule = fx.Options(fx.Provide(NewImageProcessor))
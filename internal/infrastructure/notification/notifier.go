@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+This file defines how notifications reach people, picked by 'notification.driver':
+- log: writes every notification to the application log, for development.
+- webhook: posts every notification as JSON to 'notification.webhook_url', e.g. a chat bot that relays it to staff.
+*/
+
+// NewNotifier picks the notifier implementation configured by 'notification.driver' (log | webhook).
+func NewNotifier() infra_interface.Notifier {
+	config := core.Configs.Notification
+	switch config.Driver {
+	case "webhook":
+		return NewWebhookNotifier(config.WebhookURL, nil)
+	case "", "log":
+		return &logNotifier{}
+	default:
+		core.Logger.Fatal("unsupported notification driver: " + config.Driver)
+		return nil
+	}
+}
+
+type logNotifier struct{}
+
+func (notifier *logNotifier) Notify(notification infra_interface.Notification) error {
+	zap.L().Info("Notification",
+		zap.Strings("roles", notification.Roles),
+		zap.String("user_id", notification.UserID),
+		zap.String("subject", notification.Subject),
+		zap.String("body", notification.Body),
+	)
+	return nil
+}
+
+func (notifier *logNotifier) Name() string { return "LogNotifier" }
+func (notifier *logNotifier) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", notifier.Name()))
+	return nil
+}
+func (notifier *logNotifier) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", notifier.Name()))
+	return nil
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// WebhookPayload is the JSON body posted for every notification
+type WebhookPayload struct {
+	Roles   []string  `json:"roles,omitempty"`
+	UserID  string    `json:"user_id,omitempty"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// NewWebhookNotifier posts notifications to url; a nil client uses one with a 10 second timeout
+func NewWebhookNotifier(url string, client *http.Client) infra_interface.Notifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &webhookNotifier{url: url, client: client}
+}
+
+func (notifier *webhookNotifier) Notify(notification infra_interface.Notification) error {
+	body, err := json.Marshal(WebhookPayload{
+		Roles:   notification.Roles,
+		UserID:  notification.UserID,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+		SentAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	response, err := notifier.client.Post(notifier.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification webhook answered %s", response.Status)
+	}
+	return nil
+}
+
+func (notifier *webhookNotifier) Name() string { return "WebhookNotifier" }
+func (notifier *webhookNotifier) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", notifier.Name()))
+	return nil
+}
+func (notifier *webhookNotifier) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", notifier.Name()))
+	return nil
+}
+
+var NotifierModule = fx.Options(fx.Provide(NewNotifier))
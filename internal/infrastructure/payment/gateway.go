@@ -0,0 +1,171 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+)
+
+/*
+The gateway speaks a redirect protocol in the style of VNPay and MoMo:
+ 1. Create posts the signed payment form to '{url}/payments'; the gateway answers with the page to send the customer to.
+ 2. Once the customer paid (or gave up), the gateway calls the IPN URL server to server, and sends the customer's
+    browser back to the return URL. Both carry the same signed query parameters:
+    merchant_code, reference, transaction_id, amount, code ("00" for success) and signature.
+
+Refunds are synchronous: Refund posts the signed form merchant_code, reference, transaction_id, amount and reason to
+'{url}/refunds' and the gateway answers with the outcome.
+
+Every message is signed with HMAC-SHA256 over its parameters, sorted by name and URL-encoded as in a query string,
+leaving out the signature itself. The shared secret never leaves the server.
+*/
+
+const (
+	gatewaySuccessCode = "00"
+	signatureParam     = "signature"
+)
+
+type GatewayConfig struct {
+	URL          string
+	MerchantCode string
+	Secret       string
+	ReturnURL    string
+	IPNURL       string
+}
+
+type gatewayProvider struct {
+	config GatewayConfig
+	client *http.Client
+}
+
+// NewGatewayProvider - a nil client uses a default one with a 10 second timeout
+func NewGatewayProvider(config GatewayConfig, client *http.Client) infra_interface.PaymentProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &gatewayProvider{config: config, client: client}
+}
+
+// Sign computes the signature of the parameters, ignoring any signature already among them
+func Sign(secret string, params url.Values) string {
+	unsigned := url.Values{}
+	for key, values := range params {
+		if key != signatureParam {
+			unsigned[key] = values
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type gatewayCreateResponse struct {
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	PayURL        string `json:"pay_url"`
+	TransactionID string `json:"transaction_id"`
+}
+
+func (provider *gatewayProvider) Create(intent infra_interface.PaymentIntent) (*infra_interface.PaymentSession, error) {
+	form := url.Values{
+		"merchant_code": {provider.config.MerchantCode},
+		"reference":     {intent.Reference},
+		"amount":        {strconv.FormatInt(intent.Amount, 10)},
+		"description":   {intent.Description},
+		"channel":       {intent.Method},
+		"client_ip":     {intent.ClientIP},
+		"return_url":    {provider.config.ReturnURL},
+		"ipn_url":       {provider.config.IPNURL},
+	}
+	if !intent.ExpiresAt.IsZero() {
+		form.Set("expires_at", strconv.FormatInt(intent.ExpiresAt.Unix(), 10))
+	}
+	form.Set(signatureParam, Sign(provider.config.Secret, form))
+
+	var created gatewayCreateResponse
+	if err := provider.post("/payments", form, &created); err != nil {
+		return nil, err
+	}
+	if created.Code != gatewaySuccessCode {
+		return nil, fmt.Errorf("payment gateway refused the payment: %s %s", created.Code, created.Message)
+	}
+	return &infra_interface.PaymentSession{RedirectURL: created.PayURL, TransactionID: created.TransactionID}, nil
+}
+
+type gatewayRefundResponse struct {
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	TransactionID string `json:"transaction_id"`
+}
+
+func (provider *gatewayProvider) Refund(refund infra_interface.PaymentRefund) (*infra_interface.RefundResult, error) {
+	form := url.Values{
+		"merchant_code":  {provider.config.MerchantCode},
+		"reference":      {refund.Reference},
+		"transaction_id": {refund.TransactionID},
+		"amount":         {strconv.FormatInt(refund.Amount, 10)},
+		"reason":         {refund.Reason},
+	}
+	form.Set(signatureParam, Sign(provider.config.Secret, form))
+
+	var refunded gatewayRefundResponse
+	if err := provider.post("/refunds", form, &refunded); err != nil {
+		return nil, err
+	}
+	if refunded.Code != gatewaySuccessCode {
+		return nil, fmt.Errorf("payment gateway refused the refund: %s %s", refunded.Code, refunded.Message)
+	}
+	return &infra_interface.RefundResult{TransactionID: refunded.TransactionID, Code: refunded.Code}, nil
+}
+
+// post sends the signed form to the gateway and decodes its JSON answer into output
+func (provider *gatewayProvider) post(path string, form url.Values, output any) error {
+	response, err := provider.client.Post(strings.TrimRight(provider.config.URL, "/")+path,
+		"application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("payment gateway responded %s", response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(output)
+}
+
+func (provider *gatewayProvider) Verify(params url.Values) (*infra_interface.PaymentResult, error) {
+	expected := Sign(provider.config.Secret, params)
+	if !hmac.Equal([]byte(expected), []byte(params.Get(signatureParam))) ||
+		params.Get("merchant_code") != provider.config.MerchantCode {
+		return nil, infra_interface.ErrInvalidPaymentSignature
+	}
+	amount, err := strconv.ParseInt(params.Get("amount"), 10, 64)
+	if err != nil {
+		return nil, infra_interface.ErrInvalidPaymentSignature
+	}
+	return &infra_interface.PaymentResult{
+		Reference:     params.Get("reference"),
+		TransactionID: params.Get("transaction_id"),
+		Amount:        amount,
+		Success:       params.Get("code") == gatewaySuccessCode,
+		Code:          params.Get("code"),
+	}, nil
+}
+
+func (provider *gatewayProvider) Name() string { return "PaymentGateway" }
+func (provider *gatewayProvider) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
+func (provider *gatewayProvider) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
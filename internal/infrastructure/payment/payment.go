@@ -0,0 +1,104 @@
+package payment
+
+import (
+	"fmt"
+	"net/url"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+)
+
+/*
+This file defines who collects each payment method:
+- cod: the shipper collects cash on delivery; there is nothing to redirect to.
+- bank_transfer: the customer transfers to the store's account ('payment.bank_transfer') quoting the order number,
+  and staff confirm the transfer once it shows up on the statement.
+- card, e_wallet: the payment gateway ('payment.gateway'), see gateway.go.
+*/
+
+func NewPaymentProviders() infra_interface.PaymentProviders {
+	config := core.Configs.Payment
+	gateway := NewGatewayProvider(GatewayConfig{
+		URL:          config.Gateway.URL,
+		MerchantCode: config.Gateway.MerchantCode,
+		Secret:       config.Gateway.Secret,
+		ReturnURL:    config.Gateway.ReturnURL,
+		IPNURL:       config.Gateway.IPNURL,
+	}, nil)
+	return infra_interface.PaymentProviders{
+		"cod":           NewCashOnDelivery(),
+		"bank_transfer": NewBankTransfer(config.BankTransfer.BankName, config.BankTransfer.AccountNumber, config.BankTransfer.AccountName),
+		"card":          gateway,
+		"e_wallet":      gateway,
+	}
+}
+
+type cashOnDelivery struct{}
+
+func NewCashOnDelivery() infra_interface.PaymentProvider {
+	return &cashOnDelivery{}
+}
+
+func (provider *cashOnDelivery) Create(intent infra_interface.PaymentIntent) (*infra_interface.PaymentSession, error) {
+	return &infra_interface.PaymentSession{
+		Instructions: fmt.Sprintf("Pay %d VND in cash to the shipper on delivery", intent.Amount),
+	}, nil
+}
+
+func (provider *cashOnDelivery) Verify(url.Values) (*infra_interface.PaymentResult, error) {
+	return nil, infra_interface.ErrPaymentCallbackUnsupported
+}
+
+// Refund - cash was handed to the shipper, so staff give it back in cash or by transfer themselves
+func (provider *cashOnDelivery) Refund(infra_interface.PaymentRefund) (*infra_interface.RefundResult, error) {
+	return nil, infra_interface.ErrRefundUnsupported
+}
+
+func (provider *cashOnDelivery) Name() string { return "CashOnDelivery" }
+func (provider *cashOnDelivery) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
+func (provider *cashOnDelivery) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
+
+type bankTransfer struct {
+	bankName      string
+	accountNumber string
+	accountName   string
+}
+
+func NewBankTransfer(bankName string, accountNumber string, accountName string) infra_interface.PaymentProvider {
+	return &bankTransfer{bankName: bankName, accountNumber: accountNumber, accountName: accountName}
+}
+
+func (provider *bankTransfer) Create(intent infra_interface.PaymentIntent) (*infra_interface.PaymentSession, error) {
+	return &infra_interface.PaymentSession{
+		Instructions: fmt.Sprintf("Transfer %d VND to %s account %s (%s) with the content \"%s\"",
+			intent.Amount, provider.bankName, provider.accountNumber, provider.accountName, intent.Description),
+	}, nil
+}
+
+func (provider *bankTransfer) Verify(url.Values) (*infra_interface.PaymentResult, error) {
+	return nil, infra_interface.ErrPaymentCallbackUnsupported
+}
+
+// Refund - the bank has no API for sending money back; staff transfer it from the store's account by hand
+func (provider *bankTransfer) Refund(infra_interface.PaymentRefund) (*infra_interface.RefundResult, error) {
+	return nil, infra_interface.ErrRefundUnsupported
+}
+
+func (provider *bankTransfer) Name() string { return "BankTransfer" }
+func (provider *bankTransfer) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
+func (provider *bankTransfer) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", provider.Name()))
+	return nil
+}
+
+var PaymentModule = fx.Options(fx.Provide(NewPaymentProviders))
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type CartRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Save(ctx context.Context, cart model.Cart)
+	Delete(ctx context.Context, cart model.Cart)
+	FindByUser(userID string) (*model.Cart, error)
+	FindByToken(token string) (*model.Cart, error)
+	FindAll(filter func(cart model.Cart) bool) []model.Cart
+}
+
+type cartRepository struct {
+	carts *data.Table[string, model.Cart]
+}
+
+func NewCartRepository(datasource *data.Datasource) CartRepository {
+	return &cartRepository{carts: data.NewTable[string, model.Cart](datasource)}
+}
+
+// cartKey - a user has one cart, a guest cart is looked up by its token
+func cartKey(userID string, token string) string {
+	if userID != "" {
+		return "user:" + userID
+	}
+	return "guest:" + token
+}
+
+func (repository *cartRepository) Save(ctx context.Context, cart model.Cart) {
+	repository.carts.Put(ctx, cartKey(cart.UserID, cart.Token), cart)
+}
+
+func (repository *cartRepository) Delete(ctx context.Context, cart model.Cart) {
+	repository.carts.Delete(ctx, cartKey(cart.UserID, cart.Token))
+}
+
+func (repository *cartRepository) FindByUser(userID string) (*model.Cart, error) {
+	cart, ok := repository.carts.Get(cartKey(userID, ""))
+	if !ok {
+		return nil, core.Error.NotFound.Cart
+	}
+	return &cart, nil
+}
+
+func (repository *cartRepository) FindByToken(token string) (*model.Cart, error) {
+	cart, ok := repository.carts.Get(cartKey("", token))
+	if !ok || token == "" {
+		return nil, core.Error.NotFound.Cart
+	}
+	return &cart, nil
+}
+
+func (repository *cartRepository) FindAll(filter func(cart model.Cart) bool) []model.Cart {
+	return repository.carts.Filter(filter)
+}
+
+func (repository *cartRepository) Name() string { return "CartRepository" }
+func (repository *cartRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *cartRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var CartRepositoryModule = fx.Options(fx.Provide(NewCartRepository))
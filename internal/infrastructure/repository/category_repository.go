@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type CategoryRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, category model.Category) error
+	Update(ctx context.Context, category model.Category) error
+	FindByID(id string) (*model.Category, error)
+	FindBySlug(slug string) (*model.Category, error)
+	FindAll() []model.Category
+}
+
+type categoryRepository struct {
+	categories *data.Table[string, model.Category]
+}
+
+func NewCategoryRepository(datasource *data.Datasource) CategoryRepository {
+	return &categoryRepository{categories: data.NewTable[string, model.Category](datasource)}
+}
+
+func (repository *categoryRepository) Create(ctx context.Context, category model.Category) error {
+	if _, err := repository.FindBySlug(category.Slug); err == nil {
+		return core.Error.Conflict.Slug
+	}
+	if !repository.categories.Insert(ctx, category.ID, category) {
+		return core.Error.Conflict.Slug
+	}
+	return nil
+}
+
+func (repository *categoryRepository) Update(ctx context.Context, category model.Category) error {
+	if existing, err := repository.FindBySlug(category.Slug); err == nil && existing.ID != category.ID {
+		return core.Error.Conflict.Slug
+	}
+	_, err := repository.categories.Update(ctx, category.ID, func(model.Category) (model.Category, error) {
+		return category, nil
+	})
+	if err != nil {
+		return core.Error.NotFound.Category
+	}
+	return nil
+}
+
+func (repository *categoryRepository) FindByID(id string) (*model.Category, error) {
+	category, ok := repository.categories.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Category
+	}
+	return &category, nil
+}
+
+func (repository *categoryRepository) FindBySlug(slug string) (*model.Category, error) {
+	categories := repository.categories.Filter(func(category model.Category) bool {
+		return category.Slug == slug
+	})
+	if len(categories) == 0 {
+		return nil, core.Error.NotFound.Category
+	}
+	return &categories[0], nil
+}
+
+func (repository *categoryRepository) FindAll() []model.Category {
+	return repository.categories.List()
+}
+
+func (repository *categoryRepository) Name() string { return "CategoryRepository" }
+func (repository *categoryRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *categoryRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var CategoryRepositoryModule = fx.Options(fx.Provide(NewCategoryRepository))
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type ClaimRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, claim model.Claim)
+	Update(ctx context.Context, id string, modify func(claim *model.Claim) error) (*model.Claim, error)
+	FindByID(id string) (*model.Claim, error)
+	FindAll(filter func(claim model.Claim) bool) []model.Claim
+}
+
+type claimRepository struct {
+	claims *data.Table[string, model.Claim]
+}
+
+func NewClaimRepository(datasource *data.Datasource) ClaimRepository {
+	return &claimRepository{claims: data.NewTable[string, model.Claim](datasource)}
+}
+
+func (repository *claimRepository) Create(ctx context.Context, claim model.Claim) {
+	repository.claims.Put(ctx, claim.ID, claim)
+}
+
+// Update applies modify atomically; the claim is left untouched when modify returns an error.
+func (repository *claimRepository) Update(ctx context.Context, id string, modify func(claim *model.Claim) error) (*model.Claim, error) {
+	claim, err := repository.claims.Update(ctx, id, func(claim model.Claim) (model.Claim, error) {
+		err := modify(&claim)
+		return claim, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Claim
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+func (repository *claimRepository) FindByID(id string) (*model.Claim, error) {
+	claim, ok := repository.claims.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Claim
+	}
+	return &claim, nil
+}
+
+// FindAll returns matching claims, the latest first
+func (repository *claimRepository) FindAll(filter func(claim model.Claim) bool) []model.Claim {
+	claims := repository.claims.Filter(filter)
+	slices.SortFunc(claims, func(a, b model.Claim) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return claims
+}
+
+func (repository *claimRepository) Name() string { return "ClaimRepository" }
+func (repository *claimRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *claimRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var ClaimRepositoryModule = fx.Options(fx.Provide(NewClaimRepository))
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type DeliveryRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	CreateShipper(ctx context.Context, shipper model.Shipper) error
+	UpdateShipper(ctx context.Context, id string, modify func(shipper *model.Shipper) error) (*model.Shipper, error)
+	FindShipper(id string) (*model.Shipper, error)
+	FindShippers(filter func(shipper model.Shipper) bool) []model.Shipper
+
+	// Upsert applies modify to the order's delivery, starting from an empty one when it has none
+	Upsert(ctx context.Context, orderID string, modify func(delivery *model.Delivery) error) (*model.Delivery, error)
+	Update(ctx context.Context, orderID string, modify func(delivery *model.Delivery) error) (*model.Delivery, error)
+	FindByOrder(orderID string) (*model.Delivery, error)
+	FindAll(filter func(delivery model.Delivery) bool) []model.Delivery
+}
+
+type deliveryRepository struct {
+	shippers   *data.Table[string, model.Shipper]
+	deliveries *data.Table[string, model.Delivery]
+}
+
+func NewDeliveryRepository(datasource *data.Datasource) DeliveryRepository {
+	return &deliveryRepository{
+		shippers:   data.NewTable[string, model.Shipper](datasource),
+		deliveries: data.NewTable[string, model.Delivery](datasource),
+	}
+}
+
+func (repository *deliveryRepository) CreateShipper(ctx context.Context, shipper model.Shipper) error {
+	if !repository.shippers.Insert(ctx, shipper.ID, shipper) {
+		return core.Error.Conflict.Shipper
+	}
+	return nil
+}
+
+// UpdateShipper applies modify atomically; the shipper is left untouched when modify returns an error.
+func (repository *deliveryRepository) UpdateShipper(ctx context.Context, id string, modify func(shipper *model.Shipper) error) (*model.Shipper, error) {
+	shipper, err := repository.shippers.Update(ctx, id, func(shipper model.Shipper) (model.Shipper, error) {
+		err := modify(&shipper)
+		return shipper, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Shipper
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &shipper, nil
+}
+
+func (repository *deliveryRepository) FindShipper(id string) (*model.Shipper, error) {
+	shipper, ok := repository.shippers.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Shipper
+	}
+	return &shipper, nil
+}
+
+// FindShippers returns matching shippers by name
+func (repository *deliveryRepository) FindShippers(filter func(shipper model.Shipper) bool) []model.Shipper {
+	shippers := repository.shippers.Filter(filter)
+	slices.SortFunc(shippers, func(a, b model.Shipper) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return shippers
+}
+
+// Upsert expects the caller to hold a transaction, so no one else creates the delivery in between
+func (repository *deliveryRepository) Upsert(ctx context.Context, orderID string, modify func(delivery *model.Delivery) error) (*model.Delivery, error) {
+	if _, ok := repository.deliveries.Get(orderID); ok {
+		return repository.Update(ctx, orderID, modify)
+	}
+	delivery := model.Delivery{OrderID: orderID}
+	if err := modify(&delivery); err != nil {
+		return nil, err
+	}
+	repository.deliveries.Put(ctx, orderID, delivery)
+	return &delivery, nil
+}
+
+// Update applies modify atomically; the delivery is left untouched when modify returns an error.
+func (repository *deliveryRepository) Update(ctx context.Context, orderID string, modify func(delivery *model.Delivery) error) (*model.Delivery, error) {
+	delivery, err := repository.deliveries.Update(ctx, orderID, func(delivery model.Delivery) (model.Delivery, error) {
+		err := modify(&delivery)
+		return delivery, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Delivery
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (repository *deliveryRepository) FindByOrder(orderID string) (*model.Delivery, error) {
+	delivery, ok := repository.deliveries.Get(orderID)
+	if !ok {
+		return nil, core.Error.NotFound.Delivery
+	}
+	return &delivery, nil
+}
+
+// FindAll returns matching deliveries in the order they were assigned
+func (repository *deliveryRepository) FindAll(filter func(delivery model.Delivery) bool) []model.Delivery {
+	deliveries := repository.deliveries.Filter(filter)
+	slices.SortFunc(deliveries, func(a, b model.Delivery) int {
+		return cmp.Or(a.AssignedAt.Compare(b.AssignedAt), cmp.Compare(a.OrderNumber, b.OrderNumber))
+	})
+	return deliveries
+}
+
+func (repository *deliveryRepository) Name() string { return "DeliveryRepository" }
+func (repository *deliveryRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *deliveryRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var DeliveryRepositoryModule = fx.Options(fx.Provide(NewDeliveryRepository))
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type DeliverySlotRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, slot model.DeliverySlot)
+	Insert(ctx context.Context, slot model.DeliverySlot) bool
+	Update(ctx context.Context, id string, modify func(slot *model.DeliverySlot) error) (*model.DeliverySlot, error)
+	FindByID(id string) (*model.DeliverySlot, error)
+	FindAll(filter func(slot model.DeliverySlot) bool) []model.DeliverySlot
+
+	SaveTemplate(ctx context.Context, template model.SlotTemplate)
+	UpdateTemplate(ctx context.Context, id string, modify func(template *model.SlotTemplate) error) (*model.SlotTemplate, error)
+	FindTemplates(filter func(template model.SlotTemplate) bool) []model.SlotTemplate
+
+	InsertHoliday(ctx context.Context, holiday model.Holiday) error
+	DeleteHoliday(ctx context.Context, id string) (*model.Holiday, error)
+	FindHolidays(filter func(holiday model.Holiday) bool) []model.Holiday
+}
+
+type deliverySlotRepository struct {
+	slots     *data.Table[string, model.DeliverySlot]
+	templates *data.Table[string, model.SlotTemplate]
+	holidays  *data.Table[string, model.Holiday]
+}
+
+func NewDeliverySlotRepository(datasource *data.Datasource) DeliverySlotRepository {
+	return &deliverySlotRepository{
+		slots:     data.NewTable[string, model.DeliverySlot](datasource),
+		templates: data.NewTable[string, model.SlotTemplate](datasource),
+		holidays:  data.NewTable[string, model.Holiday](datasource),
+	}
+}
+
+func (repository *deliverySlotRepository) Create(ctx context.Context, slot model.DeliverySlot) {
+	repository.slots.Put(ctx, slot.ID, slot)
+}
+
+// Insert adds the slot unless one with its id exists, reporting whether it did
+func (repository *deliverySlotRepository) Insert(ctx context.Context, slot model.DeliverySlot) bool {
+	return repository.slots.Insert(ctx, slot.ID, slot)
+}
+
+// Update applies modify atomically; the slot is left untouched when modify returns an error.
+func (repository *deliverySlotRepository) Update(ctx context.Context, id string, modify func(slot *model.DeliverySlot) error) (*model.DeliverySlot, error) {
+	slot, err := repository.slots.Update(ctx, id, func(slot model.DeliverySlot) (model.DeliverySlot, error) {
+		err := modify(&slot)
+		return slot, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.DeliverySlot
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (repository *deliverySlotRepository) FindByID(id string) (*model.DeliverySlot, error) {
+	slot, ok := repository.slots.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.DeliverySlot
+	}
+	return &slot, nil
+}
+
+// FindAll returns matching slots, the earliest first
+func (repository *deliverySlotRepository) FindAll(filter func(slot model.DeliverySlot) bool) []model.DeliverySlot {
+	slots := repository.slots.Filter(filter)
+	slices.SortFunc(slots, func(a, b model.DeliverySlot) int {
+		return a.Start.Compare(b.Start)
+	})
+	return slots
+}
+
+func (repository *deliverySlotRepository) SaveTemplate(ctx context.Context, template model.SlotTemplate) {
+	repository.templates.Put(ctx, template.ID, template)
+}
+
+// UpdateTemplate applies modify atomically; the template is left untouched when modify returns an error.
+func (repository *deliverySlotRepository) UpdateTemplate(ctx context.Context, id string, modify func(template *model.SlotTemplate) error) (*model.SlotTemplate, error) {
+	template, err := repository.templates.Update(ctx, id, func(template model.SlotTemplate) (model.SlotTemplate, error) {
+		err := modify(&template)
+		return template, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.SlotTemplate
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindTemplates returns matching templates by zone, then weekday and start
+func (repository *deliverySlotRepository) FindTemplates(filter func(template model.SlotTemplate) bool) []model.SlotTemplate {
+	templates := repository.templates.Filter(filter)
+	slices.SortFunc(templates, func(a, b model.SlotTemplate) int {
+		return cmp.Or(cmp.Compare(a.ZoneID, b.ZoneID), cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.Starts, b.Starts))
+	})
+	return templates
+}
+
+// InsertHoliday adds the holiday unless the same day is already a holiday in its zone
+func (repository *deliverySlotRepository) InsertHoliday(ctx context.Context, holiday model.Holiday) error {
+	if !repository.holidays.Insert(ctx, holidayKey(holiday), holiday) {
+		return core.Error.Conflict.Holiday
+	}
+	return nil
+}
+
+func (repository *deliverySlotRepository) DeleteHoliday(ctx context.Context, id string) (*model.Holiday, error) {
+	for _, holiday := range repository.holidays.Filter(func(holiday model.Holiday) bool { return holiday.ID == id }) {
+		repository.holidays.Delete(ctx, holidayKey(holiday))
+		return &holiday, nil
+	}
+	return nil, core.Error.NotFound.Holiday
+}
+
+// FindHolidays returns matching holidays, the earliest first
+func (repository *deliverySlotRepository) FindHolidays(filter func(holiday model.Holiday) bool) []model.Holiday {
+	holidays := repository.holidays.Filter(filter)
+	slices.SortFunc(holidays, func(a, b model.Holiday) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ZoneID, b.ZoneID))
+	})
+	return holidays
+}
+
+// holidayKey - one holiday per zone and day
+func holidayKey(holiday model.Holiday) string {
+	return holiday.ZoneID + "@" + holiday.Date.Format("2006-01-02")
+}
+
+func (repository *deliverySlotRepository) Name() string { return "DeliverySlotRepository" }
+func (repository *deliverySlotRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *deliverySlotRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var DeliverySlotRepositoryModule = fx.Options(fx.Provide(NewDeliverySlotRepository))
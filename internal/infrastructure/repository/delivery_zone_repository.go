@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type DeliveryZoneRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, zone model.DeliveryZone) error
+	Update(ctx context.Context, id string, modify func(zone *model.DeliveryZone) error) (*model.DeliveryZone, error)
+	FindByID(id string) (*model.DeliveryZone, error)
+	FindAll(filter func(zone model.DeliveryZone) bool) []model.DeliveryZone
+}
+
+type deliveryZoneRepository struct {
+	zones *data.Table[string, model.DeliveryZone]
+}
+
+func NewDeliveryZoneRepository(datasource *data.Datasource) DeliveryZoneRepository {
+	return &deliveryZoneRepository{zones: data.NewTable[string, model.DeliveryZone](datasource)}
+}
+
+func (repository *deliveryZoneRepository) Create(ctx context.Context, zone model.DeliveryZone) error {
+	if !repository.zones.Insert(ctx, zone.ID, zone) {
+		return core.Error.Conflict.DeliveryZone
+	}
+	return nil
+}
+
+// Update applies modify atomically; the zone is left untouched when modify returns an error.
+func (repository *deliveryZoneRepository) Update(ctx context.Context, id string, modify func(zone *model.DeliveryZone) error) (*model.DeliveryZone, error) {
+	zone, err := repository.zones.Update(ctx, id, func(zone model.DeliveryZone) (model.DeliveryZone, error) {
+		err := modify(&zone)
+		return zone, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.DeliveryZone
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+func (repository *deliveryZoneRepository) FindByID(id string) (*model.DeliveryZone, error) {
+	zone, ok := repository.zones.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.DeliveryZone
+	}
+	return &zone, nil
+}
+
+// FindAll returns matching zones ordered by id
+func (repository *deliveryZoneRepository) FindAll(filter func(zone model.DeliveryZone) bool) []model.DeliveryZone {
+	zones := repository.zones.Filter(filter)
+	slices.SortFunc(zones, func(a, b model.DeliveryZone) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return zones
+}
+
+func (repository *deliveryZoneRepository) Name() string { return "DeliveryZoneRepository" }
+func (repository *deliveryZoneRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *deliveryZoneRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var DeliveryZoneRepositoryModule = fx.Options(fx.Provide(NewDeliveryZoneRepository))
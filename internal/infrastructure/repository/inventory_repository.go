@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type InventoryRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	FindLevel(productID string, locationID string) model.StockLevel
+	FindLevels(filter func(level model.StockLevel) bool) []model.StockLevel
+	UpdateLevel(ctx context.Context, productID string, locationID string, modify func(level *model.StockLevel) error) (*model.StockLevel, error)
+
+	SaveReservation(ctx context.Context, reservation model.Reservation)
+	UpdateReservation(ctx context.Context, id string, modify func(reservation *model.Reservation) error) (*model.Reservation, error)
+	FindReservation(id string) (*model.Reservation, error)
+	FindReservations(filter func(reservation model.Reservation) bool) []model.Reservation
+
+	SaveTransfer(ctx context.Context, transfer model.Transfer)
+	UpdateTransfer(ctx context.Context, id string, modify func(transfer *model.Transfer) error) (*model.Transfer, error)
+	FindTransfers(filter func(transfer model.Transfer) bool) []model.Transfer
+
+	AppendMovement(ctx context.Context, movement model.Movement) model.Movement
+	FindMovements(filter func(movement model.Movement) bool) []model.Movement
+
+	SaveStocktake(ctx context.Context, stocktake model.Stocktake)
+	UpdateStocktake(ctx context.Context, id string, modify func(stocktake *model.Stocktake) error) (*model.Stocktake, error)
+	FindStocktake(id string) (*model.Stocktake, error)
+	FindStocktakes(filter func(stocktake model.Stocktake) bool) []model.Stocktake
+}
+
+type inventoryRepository struct {
+	levels       *data.Table[string, model.StockLevel]
+	reservations *data.Table[string, model.Reservation]
+	transfers    *data.Table[string, model.Transfer]
+	movements    *data.Table[int64, model.Movement]
+	stocktakes   *data.Table[string, model.Stocktake]
+}
+
+func NewInventoryRepository(datasource *data.Datasource) InventoryRepository {
+	return &inventoryRepository{
+		levels:       data.NewTable[string, model.StockLevel](datasource),
+		reservations: data.NewTable[string, model.Reservation](datasource),
+		transfers:    data.NewTable[string, model.Transfer](datasource),
+		movements:    data.NewTable[int64, model.Movement](datasource),
+		stocktakes:   data.NewTable[string, model.Stocktake](datasource),
+	}
+}
+
+func levelKey(productID string, locationID string) string {
+	return productID + "@" + locationID
+}
+
+// FindLevel returns the stock of a product at a location, an empty level if it was never stocked there
+func (repository *inventoryRepository) FindLevel(productID string, locationID string) model.StockLevel {
+	level, ok := repository.levels.Get(levelKey(productID, locationID))
+	if !ok {
+		return model.StockLevel{ProductID: productID, LocationID: locationID}
+	}
+	return level
+}
+
+func (repository *inventoryRepository) FindLevels(filter func(level model.StockLevel) bool) []model.StockLevel {
+	return repository.levels.Filter(filter)
+}
+
+// UpdateLevel applies modify atomically, creating the level on first use; nothing changes when modify returns an error.
+func (repository *inventoryRepository) UpdateLevel(ctx context.Context, productID string, locationID string, modify func(level *model.StockLevel) error) (*model.StockLevel, error) {
+	key := levelKey(productID, locationID)
+	repository.levels.Insert(ctx, key, model.StockLevel{ProductID: productID, LocationID: locationID})
+
+	level, err := repository.levels.Update(ctx, key, func(level model.StockLevel) (model.StockLevel, error) {
+		err := modify(&level)
+		return level, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+func (repository *inventoryRepository) SaveReservation(ctx context.Context, reservation model.Reservation) {
+	repository.reservations.Put(ctx, reservation.ID, reservation)
+}
+
+// UpdateReservation applies modify atomically; the reservation is left untouched when modify returns an error.
+func (repository *inventoryRepository) UpdateReservation(ctx context.Context, id string, modify func(reservation *model.Reservation) error) (*model.Reservation, error) {
+	reservation, err := repository.reservations.Update(ctx, id, func(reservation model.Reservation) (model.Reservation, error) {
+		err := modify(&reservation)
+		return reservation, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Reservation
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (repository *inventoryRepository) FindReservation(id string) (*model.Reservation, error) {
+	reservation, ok := repository.reservations.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Reservation
+	}
+	return &reservation, nil
+}
+
+func (repository *inventoryRepository) FindReservations(filter func(reservation model.Reservation) bool) []model.Reservation {
+	return repository.reservations.Filter(filter)
+}
+
+func (repository *inventoryRepository) SaveTransfer(ctx context.Context, transfer model.Transfer) {
+	repository.transfers.Put(ctx, transfer.ID, transfer)
+}
+
+// UpdateTransfer applies modify atomically; the transfer is left untouched when modify returns an error.
+func (repository *inventoryRepository) UpdateTransfer(ctx context.Context, id string, modify func(transfer *model.Transfer) error) (*model.Transfer, error) {
+	transfer, err := repository.transfers.Update(ctx, id, func(transfer model.Transfer) (model.Transfer, error) {
+		err := modify(&transfer)
+		return transfer, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Transfer
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (repository *inventoryRepository) FindTransfers(filter func(transfer model.Transfer) bool) []model.Transfer {
+	return repository.transfers.Filter(filter)
+}
+
+// AppendMovement adds a movement at the end of the ledger and returns it with its sequence number.
+// Callers hold a transaction, which keeps sequence numbers gapless.
+func (repository *inventoryRepository) AppendMovement(ctx context.Context, movement model.Movement) model.Movement {
+	movement.Sequence = int64(repository.movements.Len()) + 1
+	repository.movements.Put(ctx, movement.Sequence, movement)
+	return movement
+}
+
+// FindMovements returns the matching movements in ledger order
+func (repository *inventoryRepository) FindMovements(filter func(movement model.Movement) bool) []model.Movement {
+	movements := repository.movements.Filter(filter)
+	slices.SortFunc(movements, func(a, b model.Movement) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+	return movements
+}
+
+func (repository *inventoryRepository) SaveStocktake(ctx context.Context, stocktake model.Stocktake) {
+	repository.stocktakes.Put(ctx, stocktake.ID, stocktake)
+}
+
+// UpdateStocktake applies modify atomically; the stocktake is left untouched when modify returns an error.
+func (repository *inventoryRepository) UpdateStocktake(ctx context.Context, id string, modify func(stocktake *model.Stocktake) error) (*model.Stocktake, error) {
+	stocktake, err := repository.stocktakes.Update(ctx, id, func(stocktake model.Stocktake) (model.Stocktake, error) {
+		err := modify(&stocktake)
+		return stocktake, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Stocktake
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stocktake, nil
+}
+
+func (repository *inventoryRepository) FindStocktake(id string) (*model.Stocktake, error) {
+	stocktake, ok := repository.stocktakes.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Stocktake
+	}
+	return &stocktake, nil
+}
+
+func (repository *inventoryRepository) FindStocktakes(filter func(stocktake model.Stocktake) bool) []model.Stocktake {
+	return repository.stocktakes.Filter(filter)
+}
+
+func (repository *inventoryRepository) Name() string { return "InventoryRepository" }
+func (repository *inventoryRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *inventoryRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var InventoryRepositoryModule = fx.Options(fx.Provide(NewInventoryRepository))
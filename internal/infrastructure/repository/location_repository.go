@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type LocationRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, location model.Location) error
+	Update(ctx context.Context, location model.Location) error
+	FindByID(id string) (*model.Location, error)
+	FindAll() []model.Location
+}
+
+type locationRepository struct {
+	locations *data.Table[string, model.Location]
+}
+
+// NewLocationRepository starts with the central warehouse, which stock defaults to when no location is given
+func NewLocationRepository(datasource *data.Datasource) LocationRepository {
+	repository := &locationRepository{locations: data.NewTable[string, model.Location](datasource)}
+	now := time.Now()
+	repository.locations.Put(context.Background(), model.DefaultLocationID, model.Location{
+		ID:        model.DefaultLocationID,
+		Name:      "Central warehouse",
+		Type:      model.LocationWarehouse,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	return repository
+}
+
+func (repository *locationRepository) Create(ctx context.Context, location model.Location) error {
+	if !repository.locations.Insert(ctx, location.ID, location) {
+		return core.Error.Conflict.Location
+	}
+	return nil
+}
+
+func (repository *locationRepository) Update(ctx context.Context, location model.Location) error {
+	_, err := repository.locations.Update(ctx, location.ID, func(model.Location) (model.Location, error) {
+		return location, nil
+	})
+	if err != nil {
+		return core.Error.NotFound.Location
+	}
+	return nil
+}
+
+func (repository *locationRepository) FindByID(id string) (*model.Location, error) {
+	location, ok := repository.locations.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Location
+	}
+	return &location, nil
+}
+
+func (repository *locationRepository) FindAll() []model.Location {
+	return repository.locations.List()
+}
+
+func (repository *locationRepository) Name() string { return "LocationRepository" }
+func (repository *locationRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *locationRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var LocationRepositoryModule = fx.Options(fx.Provide(NewLocationRepository))
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type LoyaltyRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Append(ctx context.Context, entry model.LoyaltyEntry)
+	FindByUser(userID string) []model.LoyaltyEntry
+	FindByOrder(orderID string) []model.LoyaltyEntry
+	FindAll(filter func(entry model.LoyaltyEntry) bool) []model.LoyaltyEntry
+}
+
+// loyaltyRepository keeps the append-only loyalty points ledger
+type loyaltyRepository struct {
+	entries *data.Table[string, model.LoyaltyEntry]
+}
+
+func NewLoyaltyRepository(datasource *data.Datasource) LoyaltyRepository {
+	return &loyaltyRepository{entries: data.NewTable[string, model.LoyaltyEntry](datasource)}
+}
+
+func (repository *loyaltyRepository) Append(ctx context.Context, entry model.LoyaltyEntry) {
+	repository.entries.Put(ctx, entry.ID, entry)
+}
+
+// FindByUser returns the user's entries, the earliest first
+func (repository *loyaltyRepository) FindByUser(userID string) []model.LoyaltyEntry {
+	return repository.entries.Filter(func(entry model.LoyaltyEntry) bool { return entry.UserID == userID })
+}
+
+// FindByOrder returns the entries an order made, the earliest first
+func (repository *loyaltyRepository) FindByOrder(orderID string) []model.LoyaltyEntry {
+	return repository.entries.Filter(func(entry model.LoyaltyEntry) bool { return entry.OrderID == orderID })
+}
+
+// FindAll returns matching entries, the earliest first
+func (repository *loyaltyRepository) FindAll(filter func(entry model.LoyaltyEntry) bool) []model.LoyaltyEntry {
+	return repository.entries.Filter(filter)
+}
+
+func (repository *loyaltyRepository) Name() string { return "LoyaltyRepository" }
+func (repository *loyaltyRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *loyaltyRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var LoyaltyRepositoryModule = fx.Options(fx.Provide(NewLoyaltyRepository))
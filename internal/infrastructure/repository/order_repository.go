@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type OrderRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, order model.Order) model.Order
+	Update(ctx context.Context, id string, modify func(order *model.Order) error) (*model.Order, error)
+	FindByID(id string) (*model.Order, error)
+	FindAll(filter func(order model.Order) bool) []model.Order
+}
+
+type orderRepository struct {
+	orders *data.Table[string, model.Order]
+}
+
+func NewOrderRepository(datasource *data.Datasource) OrderRepository {
+	return &orderRepository{orders: data.NewTable[string, model.Order](datasource)}
+}
+
+// Create numbers the order after the ones before it. Callers hold a transaction, which keeps numbers unique.
+func (repository *orderRepository) Create(ctx context.Context, order model.Order) model.Order {
+	order.Number = fmt.Sprintf("ORD-%06d", repository.orders.Len()+1)
+	repository.orders.Put(ctx, order.ID, order)
+	return order
+}
+
+// Update applies modify atomically; the order is left untouched when modify returns an error.
+func (repository *orderRepository) Update(ctx context.Context, id string, modify func(order *model.Order) error) (*model.Order, error) {
+	order, err := repository.orders.Update(ctx, id, func(order model.Order) (model.Order, error) {
+		err := modify(&order)
+		return order, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Order
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (repository *orderRepository) FindByID(id string) (*model.Order, error) {
+	order, ok := repository.orders.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Order
+	}
+	return &order, nil
+}
+
+// FindAll returns matching orders, the latest first
+func (repository *orderRepository) FindAll(filter func(order model.Order) bool) []model.Order {
+	orders := repository.orders.Filter(filter)
+	slices.SortFunc(orders, func(a, b model.Order) int {
+		return b.PlacedAt.Compare(a.PlacedAt)
+	})
+	return orders
+}
+
+func (repository *orderRepository) Name() string { return "OrderRepository" }
+func (repository *orderRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *orderRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var OrderRepositoryModule = fx.Options(fx.Provide(NewOrderRepository))
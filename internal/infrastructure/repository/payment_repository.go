@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type PaymentRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, payment model.Payment)
+	Update(ctx context.Context, id string, modify func(payment *model.Payment) error) (*model.Payment, error)
+	FindByID(id string) (*model.Payment, error)
+	FindAll(filter func(payment model.Payment) bool) []model.Payment
+}
+
+type paymentRepository struct {
+	payments *data.Table[string, model.Payment]
+}
+
+func NewPaymentRepository(datasource *data.Datasource) PaymentRepository {
+	return &paymentRepository{payments: data.NewTable[string, model.Payment](datasource)}
+}
+
+func (repository *paymentRepository) Create(ctx context.Context, payment model.Payment) {
+	repository.payments.Put(ctx, payment.ID, payment)
+}
+
+// Update applies modify atomically; the payment is left untouched when modify returns an error.
+func (repository *paymentRepository) Update(ctx context.Context, id string, modify func(payment *model.Payment) error) (*model.Payment, error) {
+	payment, err := repository.payments.Update(ctx, id, func(payment model.Payment) (model.Payment, error) {
+		err := modify(&payment)
+		return payment, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Payment
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (repository *paymentRepository) FindByID(id string) (*model.Payment, error) {
+	payment, ok := repository.payments.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Payment
+	}
+	return &payment, nil
+}
+
+// FindAll returns matching payments, the earliest first
+func (repository *paymentRepository) FindAll(filter func(payment model.Payment) bool) []model.Payment {
+	payments := repository.payments.Filter(filter)
+	slices.SortFunc(payments, func(a, b model.Payment) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return payments
+}
+
+func (repository *paymentRepository) Name() string { return "PaymentRepository" }
+func (repository *paymentRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *paymentRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var PaymentRepositoryModule = fx.Options(fx.Provide(NewPaymentRepository))
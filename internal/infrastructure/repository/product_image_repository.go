@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type ProductImageRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Save(ctx context.Context, image model.ProductImage)
+	Update(ctx context.Context, id string, modify func(image *model.ProductImage)) (*model.ProductImage, error)
+	Delete(ctx context.Context, id string) error
+	FindByID(id string) (*model.ProductImage, error)
+	FindByProduct(productID string) []model.ProductImage
+}
+
+type productImageRepository struct {
+	images *data.Table[string, model.ProductImage]
+}
+
+func NewProductImageRepository(datasource *data.Datasource) ProductImageRepository {
+	return &productImageRepository{images: data.NewTable[string, model.ProductImage](datasource)}
+}
+
+func (repository *productImageRepository) Save(ctx context.Context, image model.ProductImage) {
+	repository.images.Put(ctx, image.ID, image)
+}
+
+func (repository *productImageRepository) Update(ctx context.Context, id string, modify func(image *model.ProductImage)) (*model.ProductImage, error) {
+	image, err := repository.images.Update(ctx, id, func(image model.ProductImage) (model.ProductImage, error) {
+		modify(&image)
+		return image, nil
+	})
+	if err != nil {
+		return nil, core.Error.NotFound.Image
+	}
+	return &image, nil
+}
+
+func (repository *productImageRepository) Delete(ctx context.Context, id string) error {
+	if !repository.images.Delete(ctx, id) {
+		return core.Error.NotFound.Image
+	}
+	return nil
+}
+
+func (repository *productImageRepository) FindByID(id string) (*model.ProductImage, error) {
+	image, ok := repository.images.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Image
+	}
+	return &image, nil
+}
+
+func (repository *productImageRepository) FindByProduct(productID string) []model.ProductImage {
+	images := repository.images.Filter(func(image model.ProductImage) bool {
+		return image.ProductID == productID
+	})
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].CreatedAt.Before(images[j].CreatedAt)
+	})
+	return images
+}
+
+func (repository *productImageRepository) Name() string { return "ProductImageRepository" }
+func (repository *productImageRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *productImageRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var ProductImageRepositoryModule = fx.Options(fx.Provide(NewProductImageRepository))
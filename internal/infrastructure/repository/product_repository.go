@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type ProductRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, product model.Product) error
+	Update(ctx context.Context, id string, modify func(product *model.Product) error) (*model.Product, error)
+	FindByID(id string) (*model.Product, error)
+	FindBySKU(sku string) (*model.Product, error)
+	FindAll() []model.Product
+}
+
+type productRepository struct {
+	products *data.Table[string, model.Product]
+}
+
+func NewProductRepository(datasource *data.Datasource) ProductRepository {
+	return &productRepository{products: data.NewTable[string, model.Product](datasource)}
+}
+
+func (repository *productRepository) Create(ctx context.Context, product model.Product) error {
+	product.Images = nil
+	if _, err := repository.FindBySKU(product.SKU); err == nil {
+		return core.Error.Conflict.SKU
+	}
+	if !repository.products.Insert(ctx, product.ID, product) {
+		return core.Error.Conflict.SKU
+	}
+	return nil
+}
+
+// Update applies modify to the stored product and writes it back, keeping SKUs unique; the product is left
+// untouched when modify returns an error. It expects the caller to hold a transaction, so no one else writes the
+// product or takes its SKU in between. modify may read other repositories.
+func (repository *productRepository) Update(ctx context.Context, id string, modify func(product *model.Product) error) (*model.Product, error) {
+	product, err := repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := modify(product); err != nil {
+		return nil, err
+	}
+	product.ID = id
+	product.Images = nil
+	if existing, err := repository.FindBySKU(product.SKU); err == nil && existing.ID != id {
+		return nil, core.Error.Conflict.SKU
+	}
+	_, err = repository.products.Update(ctx, id, func(model.Product) (model.Product, error) {
+		return *product, nil
+	})
+	if err != nil {
+		return nil, core.Error.NotFound.Product
+	}
+	return product, nil
+}
+
+func (repository *productRepository) FindByID(id string) (*model.Product, error) {
+	product, ok := repository.products.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Product
+	}
+	return &product, nil
+}
+
+func (repository *productRepository) FindBySKU(sku string) (*model.Product, error) {
+	products := repository.products.Filter(func(product model.Product) bool {
+		return product.SKU == sku
+	})
+	if len(products) == 0 {
+		return nil, core.Error.NotFound.Product
+	}
+	return &products[0], nil
+}
+
+func (repository *productRepository) FindAll() []model.Product {
+	return repository.products.List()
+}
+
+func (repository *productRepository) Name() string { return "ProductRepository" }
+func (repository *productRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *productRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var ProductRepositoryModule = fx.Options(fx.Provide(NewProductRepository))
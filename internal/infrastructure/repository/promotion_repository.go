@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type PromotionRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, promotion model.Promotion)
+	Update(ctx context.Context, id string, modify func(promotion *model.Promotion) error) (*model.Promotion, error)
+	FindByID(id string) (*model.Promotion, error)
+	FindByCode(code string) (*model.Promotion, error)
+	FindAll(filter func(promotion model.Promotion) bool) []model.Promotion
+}
+
+type promotionRepository struct {
+	promotions *data.Table[string, model.Promotion]
+}
+
+func NewPromotionRepository(datasource *data.Datasource) PromotionRepository {
+	return &promotionRepository{promotions: data.NewTable[string, model.Promotion](datasource)}
+}
+
+func (repository *promotionRepository) Create(ctx context.Context, promotion model.Promotion) {
+	repository.promotions.Put(ctx, promotion.ID, promotion)
+}
+
+// Update applies modify atomically; the promotion is left untouched when modify returns an error.
+func (repository *promotionRepository) Update(ctx context.Context, id string, modify func(promotion *model.Promotion) error) (*model.Promotion, error) {
+	promotion, err := repository.promotions.Update(ctx, id, func(promotion model.Promotion) (model.Promotion, error) {
+		err := modify(&promotion)
+		return promotion, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Promotion
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (repository *promotionRepository) FindByID(id string) (*model.Promotion, error) {
+	promotion, ok := repository.promotions.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Promotion
+	}
+	return &promotion, nil
+}
+
+func (repository *promotionRepository) FindByCode(code string) (*model.Promotion, error) {
+	promotions := repository.promotions.Filter(func(promotion model.Promotion) bool {
+		return promotion.Code != "" && promotion.Code == code
+	})
+	if len(promotions) == 0 {
+		return nil, core.Error.NotFound.Promotion
+	}
+	return &promotions[0], nil
+}
+
+// FindAll returns matching promotions, the highest priority first
+func (repository *promotionRepository) FindAll(filter func(promotion model.Promotion) bool) []model.Promotion {
+	promotions := repository.promotions.Filter(filter)
+	slices.SortFunc(promotions, func(a, b model.Promotion) int {
+		return cmp.Or(cmp.Compare(b.Priority, a.Priority), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return promotions
+}
+
+func (repository *promotionRepository) Name() string { return "PromotionRepository" }
+func (repository *promotionRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *promotionRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var PromotionRepositoryModule = fx.Options(fx.Provide(NewPromotionRepository))
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type PurchaseOrderRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, order model.PurchaseOrder) model.PurchaseOrder
+	Update(ctx context.Context, id string, modify func(order *model.PurchaseOrder) error) (*model.PurchaseOrder, error)
+	FindByID(id string) (*model.PurchaseOrder, error)
+	FindAll(filter func(order model.PurchaseOrder) bool) []model.PurchaseOrder
+}
+
+type purchaseOrderRepository struct {
+	orders *data.Table[string, model.PurchaseOrder]
+}
+
+func NewPurchaseOrderRepository(datasource *data.Datasource) PurchaseOrderRepository {
+	return &purchaseOrderRepository{orders: data.NewTable[string, model.PurchaseOrder](datasource)}
+}
+
+// Create numbers the order after the ones before it. Callers hold a transaction, which keeps numbers unique.
+func (repository *purchaseOrderRepository) Create(ctx context.Context, order model.PurchaseOrder) model.PurchaseOrder {
+	order.Number = fmt.Sprintf("PO-%06d", repository.orders.Len()+1)
+	repository.orders.Put(ctx, order.ID, order)
+	return order
+}
+
+// Update applies modify atomically; the order is left untouched when modify returns an error.
+func (repository *purchaseOrderRepository) Update(ctx context.Context, id string, modify func(order *model.PurchaseOrder) error) (*model.PurchaseOrder, error) {
+	order, err := repository.orders.Update(ctx, id, func(order model.PurchaseOrder) (model.PurchaseOrder, error) {
+		err := modify(&order)
+		return order, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.PurchaseOrder
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (repository *purchaseOrderRepository) FindByID(id string) (*model.PurchaseOrder, error) {
+	order, ok := repository.orders.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.PurchaseOrder
+	}
+	return &order, nil
+}
+
+func (repository *purchaseOrderRepository) FindAll(filter func(order model.PurchaseOrder) bool) []model.PurchaseOrder {
+	return repository.orders.Filter(filter)
+}
+
+func (repository *purchaseOrderRepository) Name() string { return "PurchaseOrderRepository" }
+func (repository *purchaseOrderRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *purchaseOrderRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var PurchaseOrderRepositoryModule = fx.Options(fx.Provide(NewPurchaseOrderRepository))
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type ReorderRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	SaveRule(ctx context.Context, rule model.ReorderRule)
+	DeleteRule(ctx context.Context, productID string, locationID string) error
+	FindRules(filter func(rule model.ReorderRule) bool) []model.ReorderRule
+
+	SaveAlert(ctx context.Context, alert model.LowStockAlert)
+	UpdateAlert(ctx context.Context, id string, modify func(alert *model.LowStockAlert) error) (*model.LowStockAlert, error)
+	FindAlerts(filter func(alert model.LowStockAlert) bool) []model.LowStockAlert
+}
+
+type reorderRepository struct {
+	rules  *data.Table[model.StockKey, model.ReorderRule]
+	alerts *data.Table[string, model.LowStockAlert]
+}
+
+func NewReorderRepository(datasource *data.Datasource) ReorderRepository {
+	return &reorderRepository{
+		rules:  data.NewTable[model.StockKey, model.ReorderRule](datasource),
+		alerts: data.NewTable[string, model.LowStockAlert](datasource),
+	}
+}
+
+// SaveRule creates or replaces the rule for the product at the location
+func (repository *reorderRepository) SaveRule(ctx context.Context, rule model.ReorderRule) {
+	repository.rules.Put(ctx, rule.Key(), rule)
+}
+
+func (repository *reorderRepository) DeleteRule(ctx context.Context, productID string, locationID string) error {
+	if !repository.rules.Delete(ctx, model.StockKey{ProductID: productID, LocationID: locationID}) {
+		return core.Error.NotFound.ReorderRule
+	}
+	return nil
+}
+
+// FindRules returns matching rules ordered by product, then location
+func (repository *reorderRepository) FindRules(filter func(rule model.ReorderRule) bool) []model.ReorderRule {
+	rules := repository.rules.Filter(filter)
+	slices.SortFunc(rules, func(a, b model.ReorderRule) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LocationID, b.LocationID))
+	})
+	return rules
+}
+
+func (repository *reorderRepository) SaveAlert(ctx context.Context, alert model.LowStockAlert) {
+	repository.alerts.Put(ctx, alert.ID, alert)
+}
+
+func (repository *reorderRepository) UpdateAlert(ctx context.Context, id string, modify func(alert *model.LowStockAlert) error) (*model.LowStockAlert, error) {
+	alert, err := repository.alerts.Update(ctx, id, func(alert model.LowStockAlert) (model.LowStockAlert, error) {
+		err := modify(&alert)
+		return alert, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.LowStockAlert
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// FindAlerts returns matching alerts, newest first
+func (repository *reorderRepository) FindAlerts(filter func(alert model.LowStockAlert) bool) []model.LowStockAlert {
+	alerts := repository.alerts.Filter(filter)
+	slices.SortFunc(alerts, func(a, b model.LowStockAlert) int {
+		return cmp.Or(b.RaisedAt.Compare(a.RaisedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return alerts
+}
+
+func (repository *reorderRepository) Name() string { return "ReorderRepository" }
+func (repository *reorderRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *reorderRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var ReorderRepositoryModule = fx.Options(fx.Provide(NewReorderRepository))
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type ReviewRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, review model.Review) error
+	Update(ctx context.Context, id string, modify func(review *model.Review) error) (*model.Review, error)
+	FindByID(id string) (*model.Review, error)
+	FindByProduct(productID string) []model.Review
+	FindAll() []model.Review
+}
+
+type reviewRepository struct {
+	reviews *data.Table[string, model.Review]
+}
+
+func NewReviewRepository(datasource *data.Datasource) ReviewRepository {
+	return &reviewRepository{reviews: data.NewTable[string, model.Review](datasource)}
+}
+
+// Create rejects a second review of the same product by the same user. Callers hold a transaction, which keeps the
+// check and the insert together.
+func (repository *reviewRepository) Create(ctx context.Context, review model.Review) error {
+	existing := repository.reviews.Filter(func(other model.Review) bool {
+		return other.ProductID == review.ProductID && other.UserID == review.UserID
+	})
+	if len(existing) > 0 || !repository.reviews.Insert(ctx, review.ID, review) {
+		return core.Error.Conflict.Review
+	}
+	return nil
+}
+
+// Update applies modify atomically; the review is left untouched when modify returns an error.
+func (repository *reviewRepository) Update(ctx context.Context, id string, modify func(review *model.Review) error) (*model.Review, error) {
+	review, err := repository.reviews.Update(ctx, id, func(review model.Review) (model.Review, error) {
+		err := modify(&review)
+		return review, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Review
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (repository *reviewRepository) FindByID(id string) (*model.Review, error) {
+	review, ok := repository.reviews.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Review
+	}
+	return &review, nil
+}
+
+func (repository *reviewRepository) FindByProduct(productID string) []model.Review {
+	return repository.reviews.Filter(func(review model.Review) bool {
+		return review.ProductID == productID
+	})
+}
+
+func (repository *reviewRepository) FindAll() []model.Review {
+	return repository.reviews.List()
+}
+
+func (repository *reviewRepository) Name() string { return "ReviewRepository" }
+func (repository *reviewRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *reviewRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var ReviewRepositoryModule = fx.Options(fx.Provide(NewReviewRepository))
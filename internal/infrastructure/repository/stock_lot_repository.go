@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type StockLotRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Save(ctx context.Context, lot model.StockLot)
+	Update(ctx context.Context, id string, modify func(lot *model.StockLot) error) (*model.StockLot, error)
+	FindByID(id string) (*model.StockLot, error)
+	FindByProduct(productID string) []model.StockLot
+	FindAll() []model.StockLot
+}
+
+type stockLotRepository struct {
+	lots *data.Table[string, model.StockLot]
+}
+
+func NewStockLotRepository(datasource *data.Datasource) StockLotRepository {
+	return &stockLotRepository{lots: data.NewTable[string, model.StockLot](datasource)}
+}
+
+func (repository *stockLotRepository) Save(ctx context.Context, lot model.StockLot) {
+	repository.lots.Put(ctx, lot.ID, lot)
+}
+
+// Update applies modify atomically; the lot is left untouched when modify returns an error.
+func (repository *stockLotRepository) Update(ctx context.Context, id string, modify func(lot *model.StockLot) error) (*model.StockLot, error) {
+	lot, err := repository.lots.Update(ctx, id, func(lot model.StockLot) (model.StockLot, error) {
+		err := modify(&lot)
+		return lot, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Lot
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+func (repository *stockLotRepository) FindByID(id string) (*model.StockLot, error) {
+	lot, ok := repository.lots.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Lot
+	}
+	return &lot, nil
+}
+
+func (repository *stockLotRepository) FindByProduct(productID string) []model.StockLot {
+	return repository.lots.Filter(func(lot model.StockLot) bool {
+		return lot.ProductID == productID
+	})
+}
+
+func (repository *stockLotRepository) FindAll() []model.StockLot {
+	return repository.lots.List()
+}
+
+func (repository *stockLotRepository) Name() string { return "StockLotRepository" }
+func (repository *stockLotRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *stockLotRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var StockLotRepositoryModule = fx.Options(fx.Provide(NewStockLotRepository))
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type StoreCreditRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Append(ctx context.Context, entry model.CreditEntry)
+	FindByUser(userID string) []model.CreditEntry
+}
+
+// storeCreditRepository keeps the append-only store credit ledger
+type storeCreditRepository struct {
+	entries *data.Table[string, model.CreditEntry]
+}
+
+func NewStoreCreditRepository(datasource *data.Datasource) StoreCreditRepository {
+	return &storeCreditRepository{entries: data.NewTable[string, model.CreditEntry](datasource)}
+}
+
+func (repository *storeCreditRepository) Append(ctx context.Context, entry model.CreditEntry) {
+	repository.entries.Put(ctx, entry.ID, entry)
+}
+
+// FindByUser returns the user's entries, the earliest first
+func (repository *storeCreditRepository) FindByUser(userID string) []model.CreditEntry {
+	return repository.entries.Filter(func(entry model.CreditEntry) bool { return entry.UserID == userID })
+}
+
+func (repository *storeCreditRepository) Name() string { return "StoreCreditRepository" }
+func (repository *storeCreditRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *storeCreditRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var StoreCreditRepositoryModule = fx.Options(fx.Provide(NewStoreCreditRepository))
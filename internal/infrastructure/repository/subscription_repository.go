@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type SubscriptionRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	CreatePlan(ctx context.Context, plan model.SubscriptionPlan)
+	UpdatePlan(ctx context.Context, id string, modify func(plan *model.SubscriptionPlan) error) (*model.SubscriptionPlan, error)
+	FindPlan(id string) (*model.SubscriptionPlan, error)
+	FindPlans(filter func(plan model.SubscriptionPlan) bool) []model.SubscriptionPlan
+
+	Create(ctx context.Context, subscription model.Subscription)
+	Update(ctx context.Context, id string, modify func(subscription *model.Subscription) error) (*model.Subscription, error)
+	FindByID(id string) (*model.Subscription, error)
+	FindAll(filter func(subscription model.Subscription) bool) []model.Subscription
+}
+
+type subscriptionRepository struct {
+	plans         *data.Table[string, model.SubscriptionPlan]
+	subscriptions *data.Table[string, model.Subscription]
+}
+
+func NewSubscriptionRepository(datasource *data.Datasource) SubscriptionRepository {
+	return &subscriptionRepository{
+		plans:         data.NewTable[string, model.SubscriptionPlan](datasource),
+		subscriptions: data.NewTable[string, model.Subscription](datasource),
+	}
+}
+
+func (repository *subscriptionRepository) CreatePlan(ctx context.Context, plan model.SubscriptionPlan) {
+	repository.plans.Put(ctx, plan.ID, plan)
+}
+
+// UpdatePlan applies modify atomically; the plan is left untouched when modify returns an error.
+func (repository *subscriptionRepository) UpdatePlan(ctx context.Context, id string, modify func(plan *model.SubscriptionPlan) error) (*model.SubscriptionPlan, error) {
+	plan, err := repository.plans.Update(ctx, id, func(plan model.SubscriptionPlan) (model.SubscriptionPlan, error) {
+		err := modify(&plan)
+		return plan, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.SubscriptionPlan
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (repository *subscriptionRepository) FindPlan(id string) (*model.SubscriptionPlan, error) {
+	plan, ok := repository.plans.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.SubscriptionPlan
+	}
+	return &plan, nil
+}
+
+// FindPlans returns matching plans by name
+func (repository *subscriptionRepository) FindPlans(filter func(plan model.SubscriptionPlan) bool) []model.SubscriptionPlan {
+	plans := repository.plans.Filter(filter)
+	slices.SortFunc(plans, func(a, b model.SubscriptionPlan) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return plans
+}
+
+func (repository *subscriptionRepository) Create(ctx context.Context, subscription model.Subscription) {
+	repository.subscriptions.Put(ctx, subscription.ID, subscription)
+}
+
+// Update applies modify atomically; the subscription is left untouched when modify returns an error.
+func (repository *subscriptionRepository) Update(ctx context.Context, id string, modify func(subscription *model.Subscription) error) (*model.Subscription, error) {
+	subscription, err := repository.subscriptions.Update(ctx, id, func(subscription model.Subscription) (model.Subscription, error) {
+		err := modify(&subscription)
+		return subscription, err
+	})
+	if err == data.ErrRowNotFound {
+		return nil, core.Error.NotFound.Subscription
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (repository *subscriptionRepository) FindByID(id string) (*model.Subscription, error) {
+	subscription, ok := repository.subscriptions.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Subscription
+	}
+	return &subscription, nil
+}
+
+// FindAll returns matching subscriptions, the earliest first
+func (repository *subscriptionRepository) FindAll(filter func(subscription model.Subscription) bool) []model.Subscription {
+	subscriptions := repository.subscriptions.Filter(filter)
+	slices.SortFunc(subscriptions, func(a, b model.Subscription) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return subscriptions
+}
+
+func (repository *subscriptionRepository) Name() string { return "SubscriptionRepository" }
+func (repository *subscriptionRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *subscriptionRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var SubscriptionRepositoryModule = fx.Options(fx.Provide(NewSubscriptionRepository))
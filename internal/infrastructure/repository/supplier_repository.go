@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"go.uber.org/fx"
+)
+
+type SupplierRepository interface {
+	Name() string
+	Start() error
+	Stop() error
+
+	Create(ctx context.Context, supplier model.Supplier) error
+	Update(ctx context.Context, supplier model.Supplier) error
+	FindByID(id string) (*model.Supplier, error)
+	FindAll() []model.Supplier
+}
+
+type supplierRepository struct {
+	suppliers *data.Table[string, model.Supplier]
+}
+
+func NewSupplierRepository(datasource *data.Datasource) SupplierRepository {
+	return &supplierRepository{suppliers: data.NewTable[string, model.Supplier](datasource)}
+}
+
+func (repository *supplierRepository) Create(ctx context.Context, supplier model.Supplier) error {
+	if !repository.suppliers.Insert(ctx, supplier.ID, supplier) {
+		return core.Error.Conflict.Supplier
+	}
+	return nil
+}
+
+func (repository *supplierRepository) Update(ctx context.Context, supplier model.Supplier) error {
+	_, err := repository.suppliers.Update(ctx, supplier.ID, func(model.Supplier) (model.Supplier, error) {
+		return supplier, nil
+	})
+	if err != nil {
+		return core.Error.NotFound.Supplier
+	}
+	return nil
+}
+
+func (repository *supplierRepository) FindByID(id string) (*model.Supplier, error) {
+	supplier, ok := repository.suppliers.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.Supplier
+	}
+	return &supplier, nil
+}
+
+func (repository *supplierRepository) FindAll() []model.Supplier {
+	return repository.suppliers.List()
+}
+
+func (repository *supplierRepository) Name() string { return "SupplierRepository" }
+func (repository *supplierRepository) Start() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+func (repository *supplierRepository) Stop() error {
+	core.Logger.Debug(fmt.Sprintf("%s initialized", repository.Name()))
+	return nil
+}
+
+var SupplierRepositoryModule = fx.Options(fx.Provide(NewSupplierRepository))
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
 
 	"go.uber.org/fx"
 )
@@ -11,13 +14,29 @@ type UserRepository interface {
 	Name() string
 	Start() error
 	Stop() error
+
+	Save(ctx context.Context, user model.User)
+	FindByID(id string) (*model.User, error)
 }
 
 type userRepository struct {
+	users *data.Table[string, model.User]
+}
+
+func NewUserRepository(datasource *data.Datasource) UserRepository {
+	return &userRepository{users: data.NewTable[string, model.User](datasource)}
+}
+
+func (repository *userRepository) Save(ctx context.Context, user model.User) {
+	repository.users.Put(ctx, user.ID, user)
 }
 
-func NewUserRepository() UserRepository {
-	return &userRepository{}
+func (repository *userRepository) FindByID(id string) (*model.User, error) {
+	user, ok := repository.users.Get(id)
+	if !ok {
+		return nil, core.Error.NotFound.User
+	}
+	return &user, nil
 }
 
 func (repository *userRepository) Name() string { return "UserRepository" }
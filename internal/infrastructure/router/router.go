@@ -82,8 +82,8 @@ func initGinEngine() *gin.Engine {
 	// Register all middlewares
 	engine.Use(
 		middleware.Locale(util.DefaultLocale),
-		middleware.HttpContext(),
 		middleware.TraceID(),
+		middleware.HttpContext(),
 		middleware.ErrorHandler(),
 	)
 
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+This file defines an in-process scheduler for periodic jobs (e.g. expiring stock reservations).
+Logic:
+- Jobs are registered with Every, usually from a service constructor, and start ticking on Start.
+- Each job runs on its own goroutine, so a slow job never delays the others or overlaps itself.
+- A failing or panicking run is logged and the job keeps its schedule.
+- Stop cancels every job and waits for running ones to return.
+*/
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func() error
+}
+
+type scheduler struct {
+	mutex   sync.Mutex
+	jobs    []job
+	started bool
+	stop    chan struct{}
+	wait    sync.WaitGroup
+}
+
+func NewScheduler() infra_interface.Scheduler {
+	return &scheduler{stop: make(chan struct{})}
+}
+
+func (scheduler *scheduler) Every(name string, interval time.Duration, run func() error) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	registered := job{name: name, interval: interval, run: run}
+	scheduler.jobs = append(scheduler.jobs, registered)
+	if scheduler.started {
+		scheduler.launch(registered)
+	}
+}
+
+func (scheduler *scheduler) launch(job job) {
+	scheduler.wait.Add(1)
+	go func() {
+		defer scheduler.wait.Done()
+		ticker := time.NewTicker(job.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-scheduler.stop:
+				return
+			case <-ticker.C:
+				execute(job)
+			}
+		}
+	}()
+}
+
+func execute(job job) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			zap.L().Error("Scheduled job panicked", zap.String("job", job.name), zap.Any("panic", recovered))
+		}
+	}()
+
+	if err := job.run(); err != nil {
+		zap.L().Warn("Scheduled job failed", zap.String("job", job.name), zap.Error(err))
+	}
+}
+
+func (scheduler *scheduler) Name() string { return "Scheduler" }
+func (scheduler *scheduler) Start() error {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if !scheduler.started {
+		scheduler.started = true
+		for _, job := range scheduler.jobs {
+			scheduler.launch(job)
+		}
+	}
+	zap.L().Debug(fmt.Sprintf("%s initialized", scheduler.Name()), zap.Int("jobs", len(scheduler.jobs)))
+	return nil
+}
+func (scheduler *scheduler) Stop() error {
+	scheduler.mutex.Lock()
+	if scheduler.started {
+		scheduler.started = false
+		close(scheduler.stop)
+	}
+	scheduler.mutex.Unlock()
+
+	scheduler.wait.Wait()
+	zap.L().Debug(fmt.Sprintf("%s stopped", scheduler.Name()))
+	return nil
+}
+
+func RegisterScheduler(lifecycle fx.Lifecycle, scheduler infra_interface.Scheduler) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context context.Context) error {
+			return scheduler.Start()
+		},
+		OnStop: func(context context.Context) error {
+			return scheduler.Stop()
+		},
+	})
+}
+
+var SchedulerModule = fx.Options(
+	fx.Provide(NewScheduler),
+	fx.Invoke(RegisterScheduler),
+)
@@ -0,0 +1,146 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/fx"
+)
+
+/*
+This file reads and writes the CSV and XLSX sheets used for bulk catalog transfer.
+Logic:
+- Every cell is handled as a string; callers parse values so they can report errors per column.
+- CSV is written with a UTF-8 byte order mark so spreadsheet apps keep Vietnamese text intact,
+  and the mark is stripped again when reading.
+- XLSX is written through excelize's stream writer, which keeps memory flat for large catalogs.
+*/
+
+const xlsxSheet = "Sheet1"
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type spreadsheet struct{}
+
+func NewSpreadsheet() infra_interface.Spreadsheet {
+	return &spreadsheet{}
+}
+
+func (sheet *spreadsheet) Read(format infra_interface.SpreadsheetFormat, reader io.Reader) ([][]string, error) {
+	switch format {
+	case infra_interface.SpreadsheetCSV:
+		return readCSV(reader)
+	case infra_interface.SpreadsheetXLSX:
+		return readXLSX(reader)
+	default:
+		return nil, infra_interface.ErrUnsupportedSpreadsheet
+	}
+}
+
+func (sheet *spreadsheet) NewWriter(format infra_interface.SpreadsheetFormat, writer io.Writer) (infra_interface.RowWriter, error) {
+	switch format {
+	case infra_interface.SpreadsheetCSV:
+		if _, err := writer.Write(utf8BOM); err != nil {
+			return nil, err
+		}
+		return &csvWriter{writer: csv.NewWriter(writer)}, nil
+	case infra_interface.SpreadsheetXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(xlsxSheet)
+		if err != nil {
+			return nil, err
+		}
+		return &xlsxWriter{file: file, stream: stream, target: writer}, nil
+	default:
+		return nil, infra_interface.ErrUnsupportedSpreadsheet
+	}
+}
+
+func (sheet *spreadsheet) ContentType(format infra_interface.SpreadsheetFormat) string {
+	if format == infra_interface.SpreadsheetXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func readCSV(reader io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(reader)
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	csvReader := csv.NewReader(buffered)
+	csvReader.FieldsPerRecord = -1 // short rows are padded by the caller
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, infra_interface.ErrUnreadableSpreadsheet
+	}
+	return rows, nil
+}
+
+func readXLSX(reader io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, infra_interface.ErrUnreadableSpreadsheet
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, infra_interface.ErrUnreadableSpreadsheet
+	}
+	rows, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, infra_interface.ErrUnreadableSpreadsheet
+	}
+	return rows, nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (writer *csvWriter) Write(row []string) error {
+	return writer.writer.Write(row)
+}
+
+func (writer *csvWriter) Close() error {
+	writer.writer.Flush()
+	return writer.writer.Error()
+}
+
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	target io.Writer
+	rows   int
+}
+
+func (writer *xlsxWriter) Write(row []string) error {
+	writer.rows++
+	cell, err := excelize.CoordinatesToCellName(1, writer.rows)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(row))
+	for i, value := range row {
+		values[i] = value
+	}
+	return writer.stream.SetRow(cell, values)
+}
+
+func (writer *xlsxWriter) Close() error {
+	defer writer.file.Close()
+	if err := writer.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := writer.file.WriteTo(writer.target)
+	return err
+}
+
+var SpreadsheetModule = fx.Options(fx.Provide(NewSpreadsheet))
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/util"
+
+	"go.uber.org/zap"
+)
+
+type localStorage struct {
+	root      string
+	publicURL string
+}
+
+// NewLocalStorage stores objects as files under root (relative roots are resolved from the project root).
+func NewLocalStorage(root string, publicURL string) infra_interface.FileStorage {
+	if !filepath.IsAbs(root) {
+		root = util.GetConfigPathFromGoMod(root)
+	}
+	return &localStorage{root: root, publicURL: publicURL}
+}
+
+func (storage *localStorage) Put(key string, _ string, content []byte) error {
+	filePath, err := storage.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	return os.WriteFile(filePath, content, 0o644)
+}
+
+func (storage *localStorage) Get(key string) ([]byte, string, error) {
+	filePath, err := storage.filePath(key)
+	if err != nil {
+		return nil, "", err
+	}
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", infra_interface.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return content, http.DetectContentType(content), nil
+}
+
+func (storage *localStorage) Delete(key string) error {
+	filePath, err := storage.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (storage *localStorage) URL(key string) string {
+	return joinURL(storage.publicURL, key)
+}
+
+func (storage *localStorage) filePath(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(storage.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (storage *localStorage) Name() string { return "LocalStorage" }
+func (storage *localStorage) Start() error {
+	zap.L().Debug(fmt.Sprintf("%s initialized", storage.Name()), zap.String("root", storage.root))
+	return nil
+}
+func (storage *localStorage) Stop() error {
+	zap.L().Debug(fmt.Sprintf("%s initialized", storage.Name()))
+	return nil
+}
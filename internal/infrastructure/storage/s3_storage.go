@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/zap"
+)
+
+/*
+This file implements FileStorage on top of any S3-compatible object store (AWS S3, MinIO, R2, ...).
+Requests are signed with AWS Signature Version 4 using only the standard library, so tests can run
+against a local stand-in built on httptest.
+*/
+
+type S3Options struct {
+	Endpoint     string // e.g. https://s3.ap-southeast-1.amazonaws.com or http://localhost:9000
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool   // http://endpoint/bucket/key instead of http://bucket.endpoint/key
+	PublicURL    string // base URL the stored objects are served from; defaults to the bucket URL
+}
+
+type s3Storage struct {
+	options S3Options
+	client  *http.Client
+	now     func() time.Time
+}
+
+func NewS3Storage(options S3Options, client *http.Client) infra_interface.FileStorage {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &s3Storage{options: options, client: client, now: time.Now}
+}
+
+func (storage *s3Storage) Put(key string, contentType string, content []byte) error {
+	response, err := storage.do(http.MethodPut, key, contentType, content)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return storage.responseError(http.MethodPut, key, response)
+	}
+	return nil
+}
+
+func (storage *s3Storage) Get(key string) ([]byte, string, error) {
+	response, err := storage.do(http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, "", infra_interface.ErrObjectNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", storage.responseError(http.MethodGet, key, response)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, response.Header.Get("Content-Type"), nil
+}
+
+func (storage *s3Storage) Delete(key string) error {
+	response, err := storage.do(http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// S3 answers 204 for deletes, including deletes of missing keys
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return storage.responseError(http.MethodDelete, key, response)
+	}
+	return nil
+}
+
+func (storage *s3Storage) URL(key string) string {
+	if storage.options.PublicURL != "" {
+		return joinURL(storage.options.PublicURL, key)
+	}
+	objectURL, err := storage.objectURL(key)
+	if err != nil {
+		return ""
+	}
+	return objectURL.String()
+}
+
+func (storage *s3Storage) do(method string, key string, contentType string, content []byte) (*http.Response, error) {
+	objectURL, err := storage.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(method, objectURL.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	storage.sign(request, content)
+
+	return storage.client.Do(request)
+}
+
+func (storage *s3Storage) objectURL(key string) (*url.URL, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := url.Parse(storage.options.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse s3 endpoint: %w", err)
+	}
+
+	if storage.options.UsePathStyle {
+		endpoint.Path = "/" + storage.options.Bucket + "/" + cleaned
+	} else {
+		endpoint.Host = storage.options.Bucket + "." + endpoint.Host
+		endpoint.Path = "/" + cleaned
+	}
+	// Send exactly the encoding that is signed
+	endpoint.RawPath = canonicalURI(endpoint.Path)
+	return endpoint, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to request
+func (storage *s3Storage) sign(request *http.Request, payload []byte) {
+	now := storage.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaderNames := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if request.Header.Get("Content-Type") != "" {
+		signedHeaderNames = append(signedHeaderNames, "content-type")
+	}
+	sort.Strings(signedHeaderNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaderNames {
+		value := request.Header.Get(name)
+		if name == "host" {
+			value = request.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(signedHeaderNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI(request.URL.Path),
+		request.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + storage.options.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+storage.options.SecretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, storage.options.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		storage.options.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func (storage *s3Storage) responseError(method string, key string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("s3 %s %s failed with status %d: %s", method, key, response.StatusCode, string(body))
+}
+
+// canonicalURI URI-encodes a path as required by SigV4: only A-Z a-z 0-9 - . _ ~ and '/' are kept as is
+func canonicalURI(path string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(path) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '.', b == '_', b == '~', b == '/':
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
+
+func (storage *s3Storage) Name() string { return "S3Storage" }
+func (storage *s3Storage) Start() error {
+	zap.L().Debug(fmt.Sprintf("%s initialized", storage.Name()), zap.String("bucket", storage.options.Bucket))
+	return nil
+}
+func (storage *s3Storage) Stop() error {
+	zap.L().Debug(fmt.Sprintf("%s initialized", storage.Name()))
+	return nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+)
+
+var ErrInvalidKey = errors.New("invalid object key")
+
+// NewFileStorage picks the storage implementation configured by 'storage.driver' (local | s3).
+func NewFileStorage() infra_interface.FileStorage {
+	config := core.Configs.Storage
+	switch config.Driver {
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:     config.S3.Endpoint,
+			Region:       config.S3.Region,
+			Bucket:       config.S3.Bucket,
+			AccessKey:    config.S3.AccessKey,
+			SecretKey:    config.S3.SecretKey,
+			UsePathStyle: config.S3.UsePathStyle,
+			PublicURL:    config.PublicURL,
+		}, nil)
+	case "", "local":
+		return NewLocalStorage(config.Local.Root, config.PublicURL)
+	default:
+		core.Logger.Fatal("unsupported storage driver: " + config.Driver)
+		return nil
+	}
+}
+
+// cleanKey rejects keys that could escape the storage root, e.g. "../secrets/keypair/private.pem"
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+func joinURL(base string, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
+
+var StorageModule = fx.Options(fx.Provide(NewFileStorage))
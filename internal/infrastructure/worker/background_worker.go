@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+/*
+This file defines a small in-process worker pool for background tasks (e.g. thumbnail generation).
+Logic:
+- Tasks are pushed to a buffered queue and consumed by a fixed number of goroutines.
+- A failing or panicking task is logged and never stops the pool.
+- Stop closes the queue and waits for queued tasks to finish.
+*/
+
+type task struct {
+	name string
+	run  func() error
+}
+
+type backgroundWorker struct {
+	size    int
+	queue   chan task
+	mutex   sync.RWMutex
+	stopped bool
+	wait    sync.WaitGroup
+}
+
+func NewBackgroundWorker() infra_interface.BackgroundWorker {
+	size := core.Configs.Worker.Size
+	if size <= 0 {
+		size = 1
+	}
+	queueSize := core.Configs.Worker.QueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	return NewBackgroundWorkerWithSize(size, queueSize)
+}
+
+func NewBackgroundWorkerWithSize(size int, queueSize int) infra_interface.BackgroundWorker {
+	return &backgroundWorker{size: size, queue: make(chan task, queueSize)}
+}
+
+func (worker *backgroundWorker) Enqueue(name string, run func() error) bool {
+	worker.mutex.RLock()
+	defer worker.mutex.RUnlock()
+	if worker.stopped {
+		return false
+	}
+
+	select {
+	case worker.queue <- task{name: name, run: run}:
+		return true
+	default:
+		zap.L().Warn("Background queue is full", zap.String("task", name))
+		return false
+	}
+}
+
+func (worker *backgroundWorker) consume() {
+	defer worker.wait.Done()
+	for task := range worker.queue {
+		worker.execute(task)
+	}
+}
+
+func (worker *backgroundWorker) execute(task task) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			zap.L().Error("Background task panicked", zap.String("task", task.name), zap.Any("panic", recovered))
+		}
+	}()
+
+	if err := task.run(); err != nil {
+		zap.L().Warn("Background task failed", zap.String("task", task.name), zap.Error(err))
+	}
+}
+
+func (worker *backgroundWorker) Name() string { return "BackgroundWorker" }
+func (worker *backgroundWorker) Start() error {
+	for range worker.size {
+		worker.wait.Add(1)
+		go worker.consume()
+	}
+	zap.L().Debug(fmt.Sprintf("%s initialized", worker.Name()), zap.Int("size", worker.size))
+	return nil
+}
+func (worker *backgroundWorker) Stop() error {
+	worker.mutex.Lock()
+	if !worker.stopped {
+		worker.stopped = true
+		close(worker.queue)
+	}
+	worker.mutex.Unlock()
+
+	worker.wait.Wait()
+	zap.L().Debug(fmt.Sprintf("%s stopped", worker.Name()))
+	return nil
+}
+
+func RegisterBackgroundWorker(lifecycle fx.Lifecycle, worker infra_interface.BackgroundWorker) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context context.Context) error {
+			return worker.Start()
+		},
+		OnStop: func(context context.Context) error {
+			return worker.Stop()
+		},
+	})
+}
+
+var BackgroundWorkerModule = fx.Options(
+	fx.Provide(NewBackgroundWorker),
+	fx.Invoke(RegisterBackgroundWorker),
+)
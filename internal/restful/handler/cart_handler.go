@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type CartHandler struct {
+	service service.CartService
+}
+
+func NewCartHandler(cartService service.CartService) *CartHandler {
+	return &CartHandler{service: cartService}
+}
+
+// Cart godoc
+// @Summary View the cart
+// @Description The signed-in user's cart, or the guest cart of the X-Cart-Token header, re-checked against current prices and stock
+// @Tags cart
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /cart [get]
+func (handler *CartHandler) Cart(context *core.HttpContext) {
+	cart, err := handler.service.Find(cartOwner(context))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// AddLine godoc
+// @Summary Add to the cart
+// @Description Add a quantity of a product. A guest without a cart gets one; its token is in the response.
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Param line body dto.CartLineRequest true "Product and quantity"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /cart/line [post]
+func (handler *CartHandler) AddLine(context *core.HttpContext) {
+	var request dto.CartLineRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	cart, err := handler.service.AddLine(cartOwner(context), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// UpdateLine godoc
+// @Summary Change a cart quantity
+// @Description Replace the quantity of a product in the cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Param productId path string true "product id"
+// @Param line body dto.UpdateCartLineRequest true "New quantity"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /cart/line/{productId} [put]
+func (handler *CartHandler) UpdateLine(context *core.HttpContext) {
+	var request dto.UpdateCartLineRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	cart, err := handler.service.UpdateLine(cartOwner(context), context.Gin.Param("productId"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// RemoveLine godoc
+// @Summary Remove from the cart
+// @Description Remove a product from the cart
+// @Tags cart
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Param productId path string true "product id"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /cart/line/{productId} [delete]
+func (handler *CartHandler) RemoveLine(context *core.HttpContext) {
+	cart, err := handler.service.RemoveLine(cartOwner(context), context.Gin.Param("productId"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// ApplyCoupon godoc
+// @Summary Enter a promotion code
+// @Description Enter a promotion code in the cart, replacing any code entered before. The cart explains whether it applies.
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Param coupon body dto.CouponRequest true "Promotion code"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /cart/coupon [put]
+func (handler *CartHandler) ApplyCoupon(context *core.HttpContext) {
+	var request dto.CouponRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	cart, err := handler.service.ApplyCoupon(cartOwner(context), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// RemoveCoupon godoc
+// @Summary Remove the promotion code
+// @Description Take the promotion code out of the cart
+// @Tags cart
+// @Produce json
+// @Security BearerAuth
+// @Param X-Cart-Token header string false "guest cart token"
+// @Success 200 {object} dto.HttpResponse[dto.CartResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /cart/coupon [delete]
+func (handler *CartHandler) RemoveCoupon(context *core.HttpContext) {
+	cart, err := handler.service.RemoveCoupon(cartOwner(context))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CartResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCartResponse(cart, context.Locale()),
+	})
+}
+
+// cartOwner - a signed-in user's cart wins over any guest token sent along
+func cartOwner(context *core.HttpContext) model.CartOwner {
+	if claims := context.Claims(); claims != nil {
+		return model.CartOwner{UserID: claims.UserID}
+	}
+	return model.CartOwner{Token: context.Gin.GetHeader(util.CartTokenHeader)}
+}
+
+var CartHandlerModule = fx.Options(fx.Provide(NewCartHandler))
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type CatalogHandler struct {
+	service service.CatalogService
+}
+
+func NewCatalogHandler(catalogService service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{service: catalogService}
+}
+
+// Import godoc
+// @Summary Import the catalog from a sheet
+// @Description Create or update products from a CSV or XLSX sheet keyed by SKU. Every row is validated and
+// @Description errors are reported per row in the request language. Nothing is written unless every row is valid.
+// @Tags catalog
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX sheet"
+// @Param dry_run query bool false "validate and preview without writing"
+// @Success 200 {object} dto.HttpResponse[dto.ImportReport]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 413 {object} dto.HttpResponse[any]
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Failure 422 {object} dto.HttpResponse[dto.ImportReport]
+// @Router /catalog/import [post]
+func (handler *CatalogHandler) Import(context *core.HttpContext) {
+	var query dto.CatalogImportQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+	file, err := context.Gin.FormFile("file")
+	if err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	report, err := handler.service.Import(file, query.DryRun, context.Locale())
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Failed > 0 && !report.DryRun {
+		status = http.StatusUnprocessableEntity
+	}
+	context.JSON(status, dto.HttpResponse[dto.ImportReport]{
+		HttpStatus: status,
+		Data:       *report,
+	})
+}
+
+// Export godoc
+// @Summary Export the catalog to a sheet
+// @Description Stream every product as a CSV or XLSX sheet in the layout accepted by the import
+// @Tags catalog
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "csv (default) or xlsx"
+// @Success 200 {file} binary
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Router /catalog/export [get]
+func (handler *CatalogHandler) Export(context *core.HttpContext) {
+	var query dto.CatalogExportQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+	if query.Format == "" {
+		query.Format = "csv"
+	}
+
+	contentType, err := handler.service.ExportContentType(query.Format)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	fileName := fmt.Sprintf("catalog-%s.%s", time.Now().Format("20060102"), query.Format)
+	context.Gin.Header("Content-Type", contentType)
+	context.Gin.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	context.Gin.Status(http.StatusOK)
+
+	if err := handler.service.Export(query.Format, context.Gin.Writer); err != nil {
+		// The body is already streaming, so the client gets a truncated file instead of an error response
+		context.Logger.Error("Catalog export interrupted", zap.Error(err))
+	}
+}
+
+var CatalogHandlerModule = fx.Options(fx.Provide(NewCatalogHandler))
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type CategoryHandler struct {
+	service service.CategoryService
+}
+
+func NewCategoryHandler(categoryService service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{service: categoryService}
+}
+
+// List godoc
+// @Summary List categories
+// @Description List categories in the language resolved from Accept-Language
+// @Tags categories
+// @Produce json
+// @Param Accept-Language header string false "e.g. vi, en-US"
+// @Success 200 {object} dto.HttpResponse[[]dto.CategoryResponse]
+// @Router /category [get]
+func (handler *CategoryHandler) List(context *core.HttpContext) {
+	categories := make([]dto.CategoryResponse, 0)
+	for _, category := range handler.service.FindAll() {
+		categories = append(categories, dto.ToCategoryResponse(&category, context.Locale()))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.CategoryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       categories,
+	})
+}
+
+// Details godoc
+// @Summary Category details
+// @Description Get a category by id in the language resolved from Accept-Language
+// @Tags categories
+// @Produce json
+// @Param id path string true "category id"
+// @Success 200 {object} dto.HttpResponse[dto.CategoryResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /category/{id} [get]
+func (handler *CategoryHandler) Details(context *core.HttpContext) {
+	category, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CategoryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCategoryResponse(category, context.Locale()),
+	})
+}
+
+// Create godoc
+// @Summary Create a category
+// @Description Create a category with its default content and optional translations
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body dto.CategoryRequest true "Category"
+// @Success 201 {object} dto.HttpResponse[dto.CategoryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /category [post]
+func (handler *CategoryHandler) Create(context *core.HttpContext) {
+	var request dto.CategoryRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	category, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.CategoryResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToCategoryResponse(category, context.Locale()),
+	})
+}
+
+// Update godoc
+// @Summary Update a category
+// @Description Replace the content and translations of a category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "category id"
+// @Param category body dto.CategoryRequest true "Category"
+// @Success 200 {object} dto.HttpResponse[dto.CategoryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /category/{id} [put]
+func (handler *CategoryHandler) Update(context *core.HttpContext) {
+	var request dto.CategoryRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	category, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CategoryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCategoryResponse(category, context.Locale()),
+	})
+}
+
+// Translations godoc
+// @Summary Category translations
+// @Description Get the default content and every translation of a category
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "category id"
+// @Success 200 {object} dto.HttpResponse[dto.TranslationsResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /category/{id}/translations [get]
+func (handler *CategoryHandler) Translations(context *core.HttpContext) {
+	category, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.TranslationsResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToTranslationsResponse(util.DefaultLocale, category.Name, category.Description, category.Translations),
+	})
+}
+
+var CategoryHandlerModule = fx.Options(fx.Provide(NewCategoryHandler))
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type CheckoutHandler struct {
+	service service.CheckoutService
+}
+
+func NewCheckoutHandler(checkoutService service.CheckoutService) *CheckoutHandler {
+	return &CheckoutHandler{service: checkoutService}
+}
+
+// Checkout godoc
+// @Summary Place an order
+// @Description Place the cart as an order at the prices last shown, holding its stock and booking the delivery slot.
+// @Description Fails without placing anything when a product is out of stock, a price changed or the slot is full.
+// @Tags checkout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param checkout body dto.CheckoutRequest true "Delivery address, slot and payment method"
+// @Param Idempotency-Key header string false "retries with the same key get the first response back"
+// @Success 201 {object} dto.HttpResponse[dto.OrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 401 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Failure 422 {object} dto.HttpResponse[any]
+// @Router /checkout [post]
+func (handler *CheckoutHandler) Checkout(context *core.HttpContext) {
+	var request dto.CheckoutRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	order, err := handler.service.Checkout(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.OrderResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToOrderResponse(order, context.Locale()),
+	})
+}
+
+var CheckoutHandlerModule = fx.Options(fx.Provide(NewCheckoutHandler))
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+
+	"go.uber.org/fx"
+)
+
+type ClaimHandler struct {
+	service service.ClaimService
+}
+
+func NewClaimHandler(claimService service.ClaimService) *ClaimHandler {
+	return &ClaimHandler{service: claimService}
+}
+
+// Create godoc
+// @Summary Claim my order
+// @Description Claim bruised, spoiled, missing or wrong produce of a delivered order, line by line, within the claim
+// @Description window after delivery. Add photos of the damage afterwards.
+// @Tags claim
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param claim body dto.ClaimRequest true "Claimed lines and what went wrong"
+// @Success 201 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /order/{id}/claim [post]
+func (handler *ClaimHandler) Create(context *core.HttpContext) {
+	var request dto.ClaimRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claim, err := handler.service.Create(context.Claims().UserID, context.Gin.Param("id"), request, context.Locale())
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// AddPhoto godoc
+// @Summary Add a photo to my claim
+// @Description Attach a JPEG, PNG or WebP photo of the damage to a claim still waiting for a decision
+// @Tags claim
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Param file formData file true "photo"
+// @Success 200 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Failure 413 {object} dto.HttpResponse[any]
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Router /claim/{id}/photo [post]
+func (handler *ClaimHandler) AddPhoto(context *core.HttpContext) {
+	file, err := context.Gin.FormFile("file")
+	if err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claim, err := handler.service.AddPhoto(context.Claims().UserID, context.Gin.Param("id"), file)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// Mine godoc
+// @Summary My claims
+// @Description List the caller's claims, the latest first
+// @Tags claim
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, refunding, approved or rejected"
+// @Param order_id query string false "order id"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.ClaimResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /claim [get]
+func (handler *ClaimHandler) Mine(context *core.HttpContext) {
+	var query dto.ClaimQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.ClaimResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimPage(handler.service.FindMine(context.Claims().UserID, query)),
+	})
+}
+
+// MineDetails godoc
+// @Summary My claim
+// @Description Get one of the caller's claims with its decision
+// @Tags claim
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Success 200 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /claim/{id} [get]
+func (handler *ClaimHandler) MineDetails(context *core.HttpContext) {
+	claim, err := handler.service.FindMineByID(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// List godoc
+// @Summary List claims
+// @Description List all claims, the latest first
+// @Tags claim
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, refunding, approved or rejected"
+// @Param order_id query string false "order id"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.ClaimResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /claim/manage [get]
+func (handler *ClaimHandler) List(context *core.HttpContext) {
+	var query dto.ClaimQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.ClaimResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimPage(handler.service.FindAll(query)),
+	})
+}
+
+// Details godoc
+// @Summary Claim details
+// @Description Get any claim by id
+// @Tags claim
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Success 200 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /claim/manage/{id} [get]
+func (handler *ClaimHandler) Details(context *core.HttpContext) {
+	claim, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// Approve godoc
+// @Summary Approve a claim
+// @Description Pay a claim back in full, or in part when an amount is given: refunded to the order's card or
+// @Description e-wallet payment, or added to the customer's store credit. The claimed goods are recorded as returned
+// @Description and written off, unless they are restocked.
+// @Tags claim
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Param approval body dto.ApproveClaimRequest true "How the claim is paid back"
+// @Success 200 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Failure 502 {object} dto.HttpResponse[any]
+// @Router /claim/manage/{id}/approve [post]
+func (handler *ClaimHandler) Approve(context *core.HttpContext) {
+	var request dto.ApproveClaimRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claim, err := handler.service.Approve(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// Reject godoc
+// @Summary Reject a claim
+// @Description Turn a claim down; the note tells the customer why
+// @Tags claim
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Param rejection body dto.RejectClaimRequest true "Why the claim is rejected"
+// @Success 200 {object} dto.HttpResponse[dto.ClaimResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /claim/manage/{id}/reject [post]
+func (handler *ClaimHandler) Reject(context *core.HttpContext) {
+	var request dto.RejectClaimRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claim, err := handler.service.Reject(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ClaimResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToClaimResponse(claim),
+	})
+}
+
+// Photo godoc
+// @Summary Claim photo
+// @Description Serve a photo of a claim to the customer who made it or to staff
+// @Tags claim
+// @Produce image/jpeg,image/png,image/webp
+// @Security BearerAuth
+// @Param id path string true "claim id"
+// @Param photoId path string true "photo id"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /claim/{id}/photo/{photoId} [get]
+func (handler *ClaimHandler) Photo(context *core.HttpContext) {
+	claims := context.Claims()
+	actor := model.OrderActor{ID: claims.UserID, Roles: claims.Roles}
+	content, contentType, err := handler.service.Photo(actor, context.Gin.Param("id"), context.Gin.Param("photoId"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	servePrivate(context, content, contentType)
+}
+
+var ClaimHandlerModule = fx.Options(fx.Provide(NewClaimHandler))
@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+
+	"go.uber.org/fx"
+)
+
+type DeliveryHandler struct {
+	service service.DeliveryService
+}
+
+func NewDeliveryHandler(deliveryService service.DeliveryService) *DeliveryHandler {
+	return &DeliveryHandler{service: deliveryService}
+}
+
+// Shippers godoc
+// @Summary List shippers
+// @Description List the registered shippers, active or not
+// @Tags dispatch
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.ShipperResponse]
+// @Router /dispatch/shipper [get]
+func (handler *DeliveryHandler) Shippers(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.ShipperResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToShipperResponses(handler.service.FindShippers()),
+	})
+}
+
+// CreateShipper godoc
+// @Summary Register a shipper
+// @Description Register a user with the shipper role, with the name and phone customers will see
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param shipper body dto.ShipperRequest true "Shipper"
+// @Success 201 {object} dto.HttpResponse[dto.ShipperResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /dispatch/shipper [post]
+func (handler *DeliveryHandler) CreateShipper(context *core.HttpContext) {
+	var request dto.ShipperRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	shipper, err := handler.service.CreateShipper(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.ShipperResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToShipperResponse(shipper),
+	})
+}
+
+// UpdateShipper godoc
+// @Summary Update a shipper
+// @Description Change a shipper's name or phone, or stop assigning them orders
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "shipper user id"
+// @Param shipper body dto.ShipperRequest true "Shipper"
+// @Success 200 {object} dto.HttpResponse[dto.ShipperResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /dispatch/shipper/{id} [put]
+func (handler *DeliveryHandler) UpdateShipper(context *core.HttpContext) {
+	var request dto.ShipperRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	shipper, err := handler.service.UpdateShipper(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ShipperResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToShipperResponse(shipper),
+	})
+}
+
+// Assign godoc
+// @Summary Assign an order to a shipper
+// @Description Put a confirmed order that is not yet on its way on a shipper's run sheet, or hand it to another
+// @Description shipper before it is picked up. The customer sees the shipper's name and phone from then on.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param assignment body dto.AssignDeliveryRequest true "Shipper"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /dispatch/order/{id}/assign [post]
+func (handler *DeliveryHandler) Assign(context *core.HttpContext) {
+	var request dto.AssignDeliveryRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	delivery, err := handler.service.Assign(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// Details godoc
+// @Summary Delivery of an order
+// @Description Get who delivers an order, how far they got, the proof of delivery and the cash collected
+// @Tags dispatch
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /dispatch/order/{id} [get]
+func (handler *DeliveryHandler) Details(context *core.HttpContext) {
+	delivery, err := handler.service.FindByOrder(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// CashReport godoc
+// @Summary Daily cash report
+// @Description Per shipper, the deliveries finished on a store date, the cash collected on delivery, what was handed
+// @Description over and what the shipper still holds
+// @Tags dispatch
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "store date, default today" example(2026-10-20)
+// @Success 200 {object} dto.HttpResponse[[]dto.CashReportResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /dispatch/cash-report [get]
+func (handler *DeliveryHandler) CashReport(context *core.HttpContext) {
+	var query dto.CashReportQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	reports, err := handler.service.CashReport(query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.CashReportResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCashReportResponses(reports),
+	})
+}
+
+// Remit godoc
+// @Summary Record cash handed over
+// @Description Record a shipper handing over the cash collected on a store date. The amount must be all they still
+// @Description hold for that day.
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "shipper user id"
+// @Param remittance body dto.RemitCashRequest true "Date and amount"
+// @Success 200 {object} dto.HttpResponse[dto.CashReportResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /dispatch/cash-report/{id}/remit [post]
+func (handler *DeliveryHandler) Remit(context *core.HttpContext) {
+	var request dto.RemitCashRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	report, err := handler.service.Remit(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.CashReportResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToCashReportResponse(report),
+	})
+}
+
+// RunSheet godoc
+// @Summary My run sheet
+// @Description List the caller's deliveries with slots on a store date, in slot order, with the addresses and the
+// @Description cash to collect
+// @Tags shipper
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "store date, default today" example(2026-10-20)
+// @Success 200 {object} dto.HttpResponse[[]dto.RunSheetStopResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /shipper/run-sheet [get]
+func (handler *DeliveryHandler) RunSheet(context *core.HttpContext) {
+	var query dto.RunSheetQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	stops, err := handler.service.RunSheet(context.Claims().UserID, query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.RunSheetStopResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToRunSheetStopResponses(stops),
+	})
+}
+
+// PickUp godoc
+// @Summary Pick up an order
+// @Description Take a packed order from the store: it goes out for delivery and the customer is told who brings it
+// @Tags shipper
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /shipper/delivery/{id}/pickup [post]
+func (handler *DeliveryHandler) PickUp(context *core.HttpContext) {
+	delivery, err := handler.service.PickUp(shipperActor(context), context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// AddProof godoc
+// @Summary Add proof of delivery
+// @Description Attach a JPEG, PNG or WebP photo of the handed-over order, or an image of the recipient's signature,
+// @Description to a delivery on its way
+// @Tags shipper
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param file formData file true "photo or signature"
+// @Param kind formData string false "photo (default) or signature"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Failure 413 {object} dto.HttpResponse[any]
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Router /shipper/delivery/{id}/proof [post]
+func (handler *DeliveryHandler) AddProof(context *core.HttpContext) {
+	file, err := context.Gin.FormFile("file")
+	if err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	delivery, err := handler.service.AddProof(shipperActor(context), context.Gin.Param("id"), context.Gin.PostForm("kind"), file)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// Deliver godoc
+// @Summary Mark an order delivered
+// @Description Hand the order over once it has proof of delivery. Cash-on-delivery orders report the cash collected,
+// @Description which must be the order total.
+// @Tags shipper
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param delivery body dto.DeliverRequest true "Cash collected"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /shipper/delivery/{id}/deliver [post]
+func (handler *DeliveryHandler) Deliver(context *core.HttpContext) {
+	var request dto.DeliverRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	delivery, err := handler.service.Deliver(shipperActor(context), context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// Fail godoc
+// @Summary Mark a delivery failed
+// @Description Record why the order could not be handed over; it is returned and the goods come back to the store
+// @Tags shipper
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param failure body dto.FailDeliveryRequest true "Reason"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /shipper/delivery/{id}/fail [post]
+func (handler *DeliveryHandler) Fail(context *core.HttpContext) {
+	var request dto.FailDeliveryRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	delivery, err := handler.service.Fail(shipperActor(context), context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// Proof godoc
+// @Summary Proof of delivery
+// @Description Serve a photo or signature taken at the door to the customer of the order, its shipper or staff
+// @Tags shipper
+// @Produce image/jpeg,image/png,image/webp
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param proofId path string true "proof id"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /delivery/{id}/proof/{proofId} [get]
+func (handler *DeliveryHandler) Proof(context *core.HttpContext) {
+	content, contentType, err := handler.service.Proof(shipperActor(context), context.Gin.Param("id"), context.Gin.Param("proofId"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	servePrivate(context, content, contentType)
+}
+
+func shipperActor(context *core.HttpContext) model.OrderActor {
+	claims := context.Claims()
+	return model.OrderActor{ID: claims.UserID, Roles: claims.Roles}
+}
+
+var DeliveryHandlerModule = fx.Options(fx.Provide(NewDeliveryHandler))
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type DeliverySlotHandler struct {
+	service service.DeliverySlotService
+}
+
+func NewDeliverySlotHandler(deliverySlotService service.DeliverySlotService) *DeliverySlotHandler {
+	return &DeliverySlotHandler{service: deliverySlotService}
+}
+
+// Available godoc
+// @Summary Available delivery slots
+// @Description List the delivery slots between two store dates that can still be booked at checkout. Given an address,
+// @Description only the slots delivering to its zone are listed; an address outside every zone is rejected.
+// @Tags delivery-slot
+// @Produce json
+// @Param from query string false "first store date, default today" example(2026-10-20)
+// @Param to query string false "last store date, default a week after from" example(2026-10-26)
+// @Param province query string false "province of the delivery address"
+// @Param district query string false "district of the delivery address"
+// @Param ward query string false "ward of the delivery address"
+// @Param latitude query number false "latitude the address was geocoded at"
+// @Param longitude query number false "longitude the address was geocoded at"
+// @Param weight query integer false "grams of the order the slot must still take, e.g. the cart's" example(2500)
+// @Success 200 {object} dto.HttpResponse[[]dto.DeliverySlotResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /delivery-slot [get]
+func (handler *DeliverySlotHandler) Available(context *core.HttpContext) {
+	var query dto.DeliverySlotQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	slots, err := handler.service.FindAvailable(query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.DeliverySlotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliverySlotResponses(slots),
+	})
+}
+
+// Create godoc
+// @Summary Create a delivery slot
+// @Description Open a delivery window taking a limited number or weight of orders, in one zone or all of them
+// @Tags delivery-slot
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slot body dto.DeliverySlotRequest true "Delivery slot"
+// @Success 201 {object} dto.HttpResponse[dto.DeliverySlotResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /delivery-slot [post]
+func (handler *DeliverySlotHandler) Create(context *core.HttpContext) {
+	var request dto.DeliverySlotRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	slot, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.DeliverySlotResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToDeliverySlotResponse(slot),
+	})
+}
+
+// Update godoc
+// @Summary Update a delivery slot
+// @Description Reschedule or resize a delivery slot, or close it to new bookings. Booked orders move with the slot;
+// @Description a booked slot cannot be narrowed to another zone.
+// @Tags delivery-slot
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "delivery slot id"
+// @Param slot body dto.DeliverySlotRequest true "Delivery slot"
+// @Success 200 {object} dto.HttpResponse[dto.DeliverySlotResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /delivery-slot/{id} [put]
+func (handler *DeliverySlotHandler) Update(context *core.HttpContext) {
+	var request dto.DeliverySlotRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	slot, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliverySlotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliverySlotResponse(slot),
+	})
+}
+
+// Templates godoc
+// @Summary List slot templates
+// @Description List the weekly templates delivery slots are generated from
+// @Tags delivery-slot
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.SlotTemplateResponse]
+// @Router /delivery-slot/template [get]
+func (handler *DeliverySlotHandler) Templates(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.SlotTemplateResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSlotTemplateResponses(handler.service.FindTemplates()),
+	})
+}
+
+// CreateTemplate godoc
+// @Summary Create a slot template
+// @Description Open a delivery slot every week on a weekday, in one zone or all of them. Its slots within the horizon
+// @Description are opened at once.
+// @Tags delivery-slot
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template body dto.SlotTemplateRequest true "Slot template"
+// @Success 201 {object} dto.HttpResponse[dto.SlotTemplateResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /delivery-slot/template [post]
+func (handler *DeliverySlotHandler) CreateTemplate(context *core.HttpContext) {
+	var request dto.SlotTemplateRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	template, err := handler.service.CreateTemplate(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.SlotTemplateResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToSlotTemplateResponse(template),
+	})
+}
+
+// UpdateTemplate godoc
+// @Summary Update a slot template
+// @Description Change the slots generated from now on, or stop generating them. Slots already open are left as they are.
+// @Tags delivery-slot
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "slot template id"
+// @Param template body dto.SlotTemplateRequest true "Slot template"
+// @Success 200 {object} dto.HttpResponse[dto.SlotTemplateResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /delivery-slot/template/{id} [put]
+func (handler *DeliverySlotHandler) UpdateTemplate(context *core.HttpContext) {
+	var request dto.SlotTemplateRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	template, err := handler.service.UpdateTemplate(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SlotTemplateResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSlotTemplateResponse(template),
+	})
+}
+
+// Holidays godoc
+// @Summary List holidays
+// @Description List the holidays from today on
+// @Tags delivery-slot
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.HolidayResponse]
+// @Router /delivery-slot/holiday [get]
+func (handler *DeliverySlotHandler) Holidays(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.HolidayResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToHolidayResponses(handler.service.FindHolidays()),
+	})
+}
+
+// CreateHoliday godoc
+// @Summary Close a day for delivery
+// @Description Close every slot of a store date, in one zone or all of them. Orders already booked keep their slot.
+// @Tags delivery-slot
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param holiday body dto.HolidayRequest true "Holiday"
+// @Success 201 {object} dto.HttpResponse[dto.HolidayResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /delivery-slot/holiday [post]
+func (handler *DeliverySlotHandler) CreateHoliday(context *core.HttpContext) {
+	var request dto.HolidayRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	holiday, err := handler.service.CreateHoliday(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.HolidayResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToHolidayResponse(holiday),
+	})
+}
+
+// DeleteHoliday godoc
+// @Summary Remove a holiday
+// @Description Open the slots the holiday closed again
+// @Tags delivery-slot
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "holiday id"
+// @Success 200 {object} dto.HttpResponse[dto.HolidayResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /delivery-slot/holiday/{id} [delete]
+func (handler *DeliverySlotHandler) DeleteHoliday(context *core.HttpContext) {
+	holiday, err := handler.service.DeleteHoliday(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.HolidayResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToHolidayResponse(holiday),
+	})
+}
+
+var DeliverySlotHandlerModule = fx.Options(fx.Provide(NewDeliverySlotHandler))
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type DeliveryZoneHandler struct {
+	service service.DeliveryZoneService
+}
+
+func NewDeliveryZoneHandler(deliveryZoneService service.DeliveryZoneService) *DeliveryZoneHandler {
+	return &DeliveryZoneHandler{service: deliveryZoneService}
+}
+
+// Quote godoc
+// @Summary Quote the delivery fee
+// @Description Price delivering an order of a subtotal and weight to an address, the same way checkout does: the
+// @Description zone's base fee, per-km surcharge from the store when the address is geocoded, per-kg surcharge, and
+// @Description free shipping from the zone's threshold.
+// @Tags delivery-zone
+// @Accept json
+// @Produce json
+// @Param quote body dto.DeliveryQuoteRequest true "Address and order"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryQuoteResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /delivery-zone/quote [post]
+func (handler *DeliveryZoneHandler) Quote(context *core.HttpContext) {
+	var request dto.DeliveryQuoteRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	zone, quote, err := handler.service.Quote(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryQuoteResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryQuoteResponse(zone, quote),
+	})
+}
+
+// List godoc
+// @Summary List delivery zones
+// @Description List the areas the store delivers to, active or not
+// @Tags delivery-zone
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.DeliveryZoneResponse]
+// @Router /delivery-zone [get]
+func (handler *DeliveryZoneHandler) List(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.DeliveryZoneResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryZoneResponses(handler.service.FindAll()),
+	})
+}
+
+// Details godoc
+// @Summary Delivery zone details
+// @Description Get a delivery zone by id
+// @Tags delivery-zone
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "delivery zone id"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryZoneResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /delivery-zone/{id} [get]
+func (handler *DeliveryZoneHandler) Details(context *core.HttpContext) {
+	zone, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryZoneResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryZoneResponse(zone),
+	})
+}
+
+// Create godoc
+// @Summary Create a delivery zone
+// @Description Add an area the store delivers to, as whole districts, some of their wards or a polygon, with its fee rule
+// @Tags delivery-zone
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param zone body dto.DeliveryZoneRequest true "Delivery zone"
+// @Success 201 {object} dto.HttpResponse[dto.DeliveryZoneResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /delivery-zone [post]
+func (handler *DeliveryZoneHandler) Create(context *core.HttpContext) {
+	var request dto.DeliveryZoneRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	zone, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.DeliveryZoneResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToDeliveryZoneResponse(zone),
+	})
+}
+
+// Update godoc
+// @Summary Update a delivery zone
+// @Description Rename or redraw a delivery zone, or stop delivering to it
+// @Tags delivery-zone
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "delivery zone id"
+// @Param zone body dto.DeliveryZoneRequest true "Delivery zone"
+// @Success 200 {object} dto.HttpResponse[dto.DeliveryZoneResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /delivery-zone/{id} [put]
+func (handler *DeliveryZoneHandler) Update(context *core.HttpContext) {
+	var request dto.DeliveryZoneRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	zone, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.DeliveryZoneResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToDeliveryZoneResponse(zone),
+	})
+}
+
+var DeliveryZoneHandlerModule = fx.Options(fx.Provide(NewDeliveryZoneHandler))
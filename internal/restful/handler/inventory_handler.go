@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type InventoryHandler struct {
+	service service.InventoryService
+}
+
+func NewInventoryHandler(inventoryService service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: inventoryService}
+}
+
+// Levels godoc
+// @Summary Stock levels
+// @Description List on-hand, reserved and available stock per product and location
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Param location_id query string false "location id"
+// @Success 200 {object} dto.HttpResponse[[]dto.StockLevelResponse]
+// @Router /inventory [get]
+func (handler *InventoryHandler) Levels(context *core.HttpContext) {
+	var query dto.StockLevelQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	levels := make([]dto.StockLevelResponse, 0)
+	for _, level := range handler.service.FindLevels(query) {
+		levels = append(levels, dto.ToStockLevelResponse(level))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.StockLevelResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       levels,
+	})
+}
+
+// Adjust godoc
+// @Summary Adjust stock on hand
+// @Description Add or remove stock at a location with a reason code (recount, damaged, found or correction), recorded in the ledger; reserved stock cannot be removed
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param adjustment body dto.AdjustStockRequest true "Adjustment"
+// @Success 200 {object} dto.HttpResponse[dto.StockLevelResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/adjust [post]
+func (handler *InventoryHandler) Adjust(context *core.HttpContext) {
+	var request dto.AdjustStockRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	level, err := handler.service.Adjust(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.StockLevelResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToStockLevelResponse(*level),
+	})
+}
+
+// Reserve godoc
+// @Summary Reserve stock
+// @Description Hold stock of several products for a reference until it is committed, released or expires; all items or none
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param reservation body dto.ReserveRequest true "Reservation"
+// @Success 201 {object} dto.HttpResponse[[]dto.ReservationResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/reservation [post]
+func (handler *InventoryHandler) Reserve(context *core.HttpContext) {
+	var request dto.ReserveRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	reservations, err := handler.service.Reserve(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[[]dto.ReservationResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToReservationResponses(reservations),
+	})
+}
+
+// Reservations godoc
+// @Summary List stock reservations
+// @Description List reservations by reference and status
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param reference query string false "reference, e.g. a checkout id"
+// @Param status query string false "active, committed, released or expired"
+// @Success 200 {object} dto.HttpResponse[[]dto.ReservationResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/reservation [get]
+func (handler *InventoryHandler) Reservations(context *core.HttpContext) {
+	var query dto.ReservationQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.ReservationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReservationResponses(handler.service.FindReservations(query)),
+	})
+}
+
+// Release godoc
+// @Summary Release a reservation
+// @Description Give the held stock back
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "reservation id"
+// @Success 200 {object} dto.HttpResponse[dto.ReservationResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/reservation/{id}/release [post]
+func (handler *InventoryHandler) Release(context *core.HttpContext) {
+	reservation, err := handler.service.Release(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReservationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReservationResponse(*reservation),
+	})
+}
+
+// Commit godoc
+// @Summary Commit a reservation
+// @Description Turn the held stock into a sale, removing it from stock on hand
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "reservation id"
+// @Success 200 {object} dto.HttpResponse[dto.ReservationResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/reservation/{id}/commit [post]
+func (handler *InventoryHandler) Commit(context *core.HttpContext) {
+	reservation, err := handler.service.Commit(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReservationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReservationResponse(*reservation),
+	})
+}
+
+// Availability godoc
+// @Summary Product availability by location
+// @Description Show how much of a product each active location can sell now, what is on its way there and whether it is open
+// @Tags inventory
+// @Produce json
+// @Param id path string true "product id"
+// @Success 200 {object} dto.HttpResponse[dto.ProductAvailabilityResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/availability [get]
+func (handler *InventoryHandler) Availability(context *core.HttpContext) {
+	availability, err := handler.service.Availability(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ProductAvailabilityResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       *availability,
+	})
+}
+
+// Transfer godoc
+// @Summary Transfer stock
+// @Description Send stock from one location to another; it stays in transit until the destination receives it
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param transfer body dto.TransferRequest true "Transfer"
+// @Success 201 {object} dto.HttpResponse[dto.TransferResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/transfer [post]
+func (handler *InventoryHandler) Transfer(context *core.HttpContext) {
+	var request dto.TransferRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	transfer, err := handler.service.Transfer(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.TransferResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToTransferResponse(*transfer),
+	})
+}
+
+// Transfers godoc
+// @Summary List stock transfers
+// @Description List transfers by product, location and status
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Param location_id query string false "sending or receiving location id"
+// @Param status query string false "in_transit, received or cancelled"
+// @Success 200 {object} dto.HttpResponse[[]dto.TransferResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/transfer [get]
+func (handler *InventoryHandler) Transfers(context *core.HttpContext) {
+	var query dto.TransferQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.TransferResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToTransferResponses(handler.service.FindTransfers(query)),
+	})
+}
+
+// ReceiveTransfer godoc
+// @Summary Receive a transfer
+// @Description Put the transferred stock on hand at the destination
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "transfer id"
+// @Success 200 {object} dto.HttpResponse[dto.TransferResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/transfer/{id}/receive [post]
+func (handler *InventoryHandler) ReceiveTransfer(context *core.HttpContext) {
+	transfer, err := handler.service.ReceiveTransfer(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.TransferResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToTransferResponse(*transfer),
+	})
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a transfer
+// @Description Put the transferred stock back on hand at the source
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "transfer id"
+// @Success 200 {object} dto.HttpResponse[dto.TransferResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/transfer/{id}/cancel [post]
+func (handler *InventoryHandler) CancelTransfer(context *core.HttpContext) {
+	transfer, err := handler.service.CancelTransfer(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.TransferResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToTransferResponse(*transfer),
+	})
+}
+
+var InventoryHandlerModule = fx.Options(fx.Provide(NewInventoryHandler))
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type LocationHandler struct {
+	service service.LocationService
+}
+
+func NewLocationHandler(locationService service.LocationService) *LocationHandler {
+	return &LocationHandler{service: locationService}
+}
+
+// List godoc
+// @Summary List locations
+// @Description List warehouses, stores and dark stores with their address and opening hours
+// @Tags location
+// @Produce json
+// @Param include_inactive query bool false "also list closed-down locations"
+// @Success 200 {object} dto.HttpResponse[[]dto.LocationResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /location [get]
+func (handler *LocationHandler) List(context *core.HttpContext) {
+	var query dto.LocationQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	now := util.StoreNow()
+	locations := make([]dto.LocationResponse, 0)
+	for _, location := range handler.service.FindAll(query) {
+		locations = append(locations, dto.ToLocationResponse(&location, now))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.LocationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       locations,
+	})
+}
+
+// Details godoc
+// @Summary Location details
+// @Description Get a location by id
+// @Tags location
+// @Produce json
+// @Param id path string true "location id"
+// @Success 200 {object} dto.HttpResponse[dto.LocationResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /location/{id} [get]
+func (handler *LocationHandler) Details(context *core.HttpContext) {
+	location, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LocationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLocationResponse(location, util.StoreNow()),
+	})
+}
+
+// Create godoc
+// @Summary Create a location
+// @Description Open a new warehouse, store or dark store
+// @Tags location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param location body dto.LocationRequest true "Location"
+// @Success 201 {object} dto.HttpResponse[dto.LocationResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /location [post]
+func (handler *LocationHandler) Create(context *core.HttpContext) {
+	var request dto.LocationRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	location, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.LocationResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToLocationResponse(location, util.StoreNow()),
+	})
+}
+
+// Update godoc
+// @Summary Update a location
+// @Description Change a location's details and opening hours, or deactivate it
+// @Tags location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "location id"
+// @Param location body dto.LocationRequest true "Location"
+// @Success 200 {object} dto.HttpResponse[dto.LocationResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /location/{id} [put]
+func (handler *LocationHandler) Update(context *core.HttpContext) {
+	var request dto.LocationRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	location, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LocationResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLocationResponse(location, util.StoreNow()),
+	})
+}
+
+var LocationHandlerModule = fx.Options(fx.Provide(NewLocationHandler))
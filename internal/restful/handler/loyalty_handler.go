@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type LoyaltyHandler struct {
+	service service.LoyaltyService
+}
+
+func NewLoyaltyHandler(loyaltyService service.LoyaltyService) *LoyaltyHandler {
+	return &LoyaltyHandler{service: loyaltyService}
+}
+
+// Mine godoc
+// @Summary My loyalty points
+// @Description The caller's points balance, tier and what it takes to reach the next tier
+// @Tags loyalty
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[dto.LoyaltyAccountResponse]
+// @Failure 401 {object} dto.HttpResponse[any]
+// @Router /loyalty [get]
+func (handler *LoyaltyHandler) Mine(context *core.HttpContext) {
+	account := handler.service.FindAccount(context.Claims().UserID)
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LoyaltyAccountResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLoyaltyAccountResponse(account, handler.service.Program()),
+	})
+}
+
+// MyHistory godoc
+// @Summary My loyalty points history
+// @Description Page through the points the caller earned, spent, lost to expiry or was given, the latest first
+// @Tags loyalty
+// @Produce json
+// @Security BearerAuth
+// @Param query query dto.LoyaltyHistoryQuery false "Filters"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.LoyaltyEntryResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 401 {object} dto.HttpResponse[any]
+// @Router /loyalty/history [get]
+func (handler *LoyaltyHandler) MyHistory(context *core.HttpContext) {
+	var query dto.LoyaltyHistoryQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.LoyaltyEntryResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLoyaltyEntryPage(handler.service.FindHistory(context.Claims().UserID, query), context.Locale()),
+	})
+}
+
+// Account godoc
+// @Summary A customer's loyalty points
+// @Description The customer's points balance and tier
+// @Tags loyalty
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "user id"
+// @Success 200 {object} dto.HttpResponse[dto.LoyaltyAccountResponse]
+// @Router /loyalty/manage/{userId} [get]
+func (handler *LoyaltyHandler) Account(context *core.HttpContext) {
+	account := handler.service.FindAccount(context.Gin.Param("userId"))
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LoyaltyAccountResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLoyaltyAccountResponse(account, handler.service.Program()),
+	})
+}
+
+// History godoc
+// @Summary A customer's loyalty points history
+// @Description Page through the customer's points entries, the latest first
+// @Tags loyalty
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "user id"
+// @Param query query dto.LoyaltyHistoryQuery false "Filters"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.LoyaltyEntryResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /loyalty/manage/{userId}/history [get]
+func (handler *LoyaltyHandler) History(context *core.HttpContext) {
+	var query dto.LoyaltyHistoryQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.LoyaltyEntryResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLoyaltyEntryPage(handler.service.FindHistory(context.Gin.Param("userId"), query), context.Locale()),
+	})
+}
+
+// Adjust godoc
+// @Summary Adjust a customer's loyalty points
+// @Description Give the customer points, or take them away with negative points; a note saying why is required
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "user id"
+// @Param adjustment body dto.LoyaltyAdjustRequest true "Adjustment"
+// @Success 201 {object} dto.HttpResponse[dto.LoyaltyEntryResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /loyalty/manage/{userId}/adjust [post]
+func (handler *LoyaltyHandler) Adjust(context *core.HttpContext) {
+	var request dto.LoyaltyAdjustRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	entry, err := handler.service.Adjust(context.Claims().UserID, context.Gin.Param("userId"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.LoyaltyEntryResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToLoyaltyEntryResponse(entry, context.Locale()),
+	})
+}
+
+// Run godoc
+// @Summary Book loyalty points
+// @Description Run the scheduled job now: earn points on delivered orders, give back points spent on cancelled orders and expire old points
+// @Tags loyalty
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[dto.LoyaltyRunResponse]
+// @Router /loyalty/manage/run [post]
+func (handler *LoyaltyHandler) Run(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LoyaltyRunResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLoyaltyRunResponse(handler.service.Run()),
+	})
+}
+
+var LoyaltyHandlerModule = fx.Options(fx.Provide(NewLoyaltyHandler))
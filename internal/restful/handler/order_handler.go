@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+
+	"go.uber.org/fx"
+)
+
+type OrderHandler struct {
+	service service.OrderService
+}
+
+func NewOrderHandler(orderService service.OrderService) *OrderHandler {
+	return &OrderHandler{service: orderService}
+}
+
+// Mine godoc
+// @Summary My orders
+// @Description List the caller's orders, the latest first
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "order status"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.OrderResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /order [get]
+func (handler *OrderHandler) Mine(context *core.HttpContext) {
+	var query dto.OrderQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.OrderResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderPage(handler.service.FindMine(context.Claims().UserID, query), context.Locale()),
+	})
+}
+
+// MineDetails godoc
+// @Summary My order
+// @Description Get one of the caller's orders
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[dto.OrderResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /order/{id} [get]
+func (handler *OrderHandler) MineDetails(context *core.HttpContext) {
+	order, err := handler.service.FindMineByID(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.OrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderResponse(order, context.Locale()),
+	})
+}
+
+// MineTimeline godoc
+// @Summary My order's timeline
+// @Description Every status the caller's order went through, with who moved it, when and why
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[[]dto.OrderEventResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /order/{id}/timeline [get]
+func (handler *OrderHandler) MineTimeline(context *core.HttpContext) {
+	order, err := handler.service.FindMineByID(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.OrderEventResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderEventResponses(order.History),
+	})
+}
+
+// Cancel godoc
+// @Summary Cancel my order
+// @Description Cancel an order the store has not started picking yet; its stock and delivery slot are given back
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param cancel body dto.CancelOrderRequest true "Reason"
+// @Success 200 {object} dto.HttpResponse[dto.OrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 403 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /order/{id}/cancel [post]
+func (handler *OrderHandler) Cancel(context *core.HttpContext) {
+	var request dto.CancelOrderRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	order, err := handler.service.Cancel(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.OrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderResponse(order, context.Locale()),
+	})
+}
+
+// List godoc
+// @Summary List orders
+// @Description List all orders, the latest first
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "order status"
+// @Param user_id query string false "customer id"
+// @Param slot_id query string false "delivery slot id"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.OrderResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /order/manage [get]
+func (handler *OrderHandler) List(context *core.HttpContext) {
+	var query dto.StaffOrderQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.OrderResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderPage(handler.service.FindAll(query), context.Locale()),
+	})
+}
+
+// Details godoc
+// @Summary Order details
+// @Description Get any order by id
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[dto.OrderResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /order/manage/{id} [get]
+func (handler *OrderHandler) Details(context *core.HttpContext) {
+	order, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.OrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderResponse(order, context.Locale()),
+	})
+}
+
+// Timeline godoc
+// @Summary Order timeline
+// @Description Every status the order went through, with who moved it, when and why
+// @Tags order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[[]dto.OrderEventResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /order/manage/{id}/timeline [get]
+func (handler *OrderHandler) Timeline(context *core.HttpContext) {
+	order, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.OrderEventResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderEventResponses(order.History),
+	})
+}
+
+// Transition godoc
+// @Summary Move an order
+// @Description Move an order to the next status of the workflow: confirmed, picking, packed, out_for_delivery,
+// @Description delivered, cancelled or returned. Only legal moves are accepted; cancelling and returning need a reason.
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param transition body dto.OrderTransitionRequest true "Status and reason"
+// @Success 200 {object} dto.HttpResponse[dto.OrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 403 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /order/manage/{id}/transition [post]
+func (handler *OrderHandler) Transition(context *core.HttpContext) {
+	var request dto.OrderTransitionRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claims := context.Claims()
+	actor := model.OrderActor{ID: claims.UserID, Roles: claims.Roles}
+	order, err := handler.service.Transition(actor, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.OrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToOrderResponse(order, context.Locale()),
+	})
+}
+
+var OrderHandlerModule = fx.Options(fx.Provide(NewOrderHandler))
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+
+	"go.uber.org/fx"
+)
+
+type PaymentHandler struct {
+	service service.PaymentService
+}
+
+func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: paymentService}
+}
+
+// Pay godoc
+// @Summary Pay my order
+// @Description Start paying an order with its payment method. Card and e-wallet payments return the gateway page
+// @Description to redirect to; bank transfers and cash on delivery return instructions.
+// @Tags payment
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Param Idempotency-Key header string false "retries with the same key get the first response back"
+// @Success 201 {object} dto.HttpResponse[dto.PaymentResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Failure 422 {object} dto.HttpResponse[any]
+// @Failure 502 {object} dto.HttpResponse[any]
+// @Router /order/{id}/payment [post]
+func (handler *PaymentHandler) Pay(context *core.HttpContext) {
+	payment, err := handler.service.Pay(context.Claims().UserID, context.Gin.Param("id"), context.Gin.ClientIP())
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.PaymentResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToPaymentResponse(payment),
+	})
+}
+
+// Payments godoc
+// @Summary My order's payments
+// @Description List the payment attempts of one of the caller's orders
+// @Tags payment
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "order id"
+// @Success 200 {object} dto.HttpResponse[[]dto.PaymentResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /order/{id}/payment [get]
+func (handler *PaymentHandler) Payments(context *core.HttpContext) {
+	payments, err := handler.service.FindByOrder(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.PaymentResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPaymentResponses(payments),
+	})
+}
+
+// Return godoc
+// @Summary Payment return URL
+// @Description Where the gateway sends the customer back after paying; reports the payment's outcome
+// @Tags payment
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[dto.PaymentResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /payment/callback/return [get]
+func (handler *PaymentHandler) Return(context *core.HttpContext) {
+	payment, err := handler.service.HandleCallback(context.Gin.Request.URL.Query())
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PaymentResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPaymentResponse(payment),
+	})
+}
+
+// IPN godoc
+// @Summary Payment notification
+// @Description Server-to-server notification of a payment's outcome from the gateway. Always answers 200; the code
+// @Description tells the gateway whether to retry: 00 confirmed (also for a repeat), 01 unknown payment,
+// @Description 04 wrong amount, 97 bad signature, 99 other failure.
+// @Tags payment
+// @Produce json
+// @Success 200 {object} dto.PaymentIPNResponse
+// @Router /payment/callback/ipn [get]
+func (handler *PaymentHandler) IPN(context *core.HttpContext) {
+	_, err := handler.service.HandleCallback(context.Gin.Request.URL.Query())
+
+	response := dto.PaymentIPNResponse{Code: "00", Message: "Confirmed"}
+	switch err {
+	case nil:
+	case core.Error.NotFound.Payment:
+		response = dto.PaymentIPNResponse{Code: "01", Message: "Payment not found"}
+	case core.Error.Conflict.PaymentAmount:
+		response = dto.PaymentIPNResponse{Code: "04", Message: "Invalid amount"}
+	case core.Error.Invalid.PaymentSignature:
+		response = dto.PaymentIPNResponse{Code: "97", Message: "Invalid signature"}
+	default:
+		response = dto.PaymentIPNResponse{Code: "99", Message: "Unknown error"}
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// ConfirmTransfer godoc
+// @Summary Confirm a bank transfer
+// @Description Record a bank transfer found on the statement as paid, which confirms its order
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "payment id"
+// @Param transfer body dto.ConfirmTransferRequest true "Statement reference"
+// @Success 200 {object} dto.HttpResponse[dto.PaymentResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /payment/manage/{id}/confirm [post]
+func (handler *PaymentHandler) ConfirmTransfer(context *core.HttpContext) {
+	var request dto.ConfirmTransferRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	claims := context.Claims()
+	actor := model.OrderActor{ID: claims.UserID, Roles: claims.Roles}
+	payment, err := handler.service.ConfirmTransfer(actor, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PaymentResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPaymentResponse(payment),
+	})
+}
+
+var PaymentHandlerModule = fx.Options(fx.Provide(NewPaymentHandler))
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type ProductHandler struct {
+	service service.ProductService
+}
+
+func NewProductHandler(productService service.ProductService) *ProductHandler {
+	return &ProductHandler{service: productService}
+}
+
+// List godoc
+// @Summary List products
+// @Description List catalog products with their computed unit prices
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Param include_inactive query bool false "include inactive products"
+// @Param in_season_only query bool false "hide produce that is out of season this month"
+// @Param q query string false "search text, accents ignored"
+// @Param certification query []string false "organic, vietgap or globalgap; any of them" collectionFormat(multi)
+// @Param exclude_allergen query []string false "allergens the product must be free from" collectionFormat(multi)
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.ProductResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /product [get]
+func (handler *ProductHandler) List(context *core.HttpContext) {
+	var query dto.ProductQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.ProductResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       toProductPage(handler.service.FindAll(query), context.Locale()),
+	})
+}
+
+// InSeason godoc
+// @Summary Produce in season
+// @Description List active products harvested in a month, optionally only from one province, for the "in season now" section
+// @Tags products
+// @Produce json
+// @Param month query int false "month 1-12, defaults to the current month"
+// @Param province query string false "province slug, e.g. lam-dong"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.ProductResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /product/in-season [get]
+func (handler *ProductHandler) InSeason(context *core.HttpContext) {
+	var query dto.SeasonalQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.ProductResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       toProductPage(handler.service.FindInSeason(query), context.Locale()),
+	})
+}
+
+// Details godoc
+// @Summary Product details
+// @Description Get details of a product by id
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Success 200 {object} dto.HttpResponse[dto.ProductResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id} [get]
+func (handler *ProductHandler) Details(context *core.HttpContext) {
+	product, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ProductResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToProductResponse(product, context.Locale()),
+	})
+}
+
+// Create godoc
+// @Summary Create a product
+// @Description Create a catalog product with its price unit and sale rule (minimum quantity, step increment)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body dto.ProductRequest true "Product"
+// @Success 201 {object} dto.HttpResponse[dto.ProductResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /product [post]
+func (handler *ProductHandler) Create(context *core.HttpContext) {
+	var request dto.ProductRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	product, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.ProductResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToProductResponse(product, context.Locale()),
+	})
+}
+
+// Update godoc
+// @Summary Update a product
+// @Description Replace the details of a product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param product body dto.ProductRequest true "Product"
+// @Success 200 {object} dto.HttpResponse[dto.ProductResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id} [put]
+func (handler *ProductHandler) Update(context *core.HttpContext) {
+	var request dto.ProductRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	product, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ProductResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToProductResponse(product, context.Locale()),
+	})
+}
+
+// Translations godoc
+// @Summary Product translations
+// @Description Get the default content and every translation of a product
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "product id"
+// @Success 200 {object} dto.HttpResponse[dto.TranslationsResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/translations [get]
+func (handler *ProductHandler) Translations(context *core.HttpContext) {
+	product, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.TranslationsResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToTranslationsResponse(util.DefaultLocale, product.Name, product.Description, product.Translations),
+	})
+}
+
+// Quote godoc
+// @Summary Price a quantity
+// @Description Compute the exact total for a quantity of a product, checking its minimum and step increment
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param quote body dto.PriceQuoteRequest true "Quantity"
+// @Success 200 {object} dto.HttpResponse[dto.PriceQuoteResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/quote [post]
+func (handler *ProductHandler) Quote(context *core.HttpContext) {
+	var request dto.PriceQuoteRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	quote, err := handler.service.Quote(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PriceQuoteResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       *quote,
+	})
+}
+
+// Attributes godoc
+// @Summary Product attributes
+// @Description List nutrients, allergens and certification schemes with their labels in the request language
+// @Tags products
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[dto.AttributesResponse]
+// @Router /product/attributes [get]
+func (handler *ProductHandler) Attributes(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.AttributesResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToAttributesResponse(context.Locale()),
+	})
+}
+
+// Units godoc
+// @Summary Units of measure
+// @Description List supported units of measure and their conversion factor to the base unit
+// @Tags products
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[[]dto.UnitResponse]
+// @Router /product/units [get]
+func (handler *ProductHandler) Units(context *core.HttpContext) {
+	units := make([]dto.UnitResponse, 0)
+	for _, unit := range handler.service.Units() {
+		units = append(units, dto.ToUnitResponse(unit))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.UnitResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       units,
+	})
+}
+
+func toProductPage(page dto.Page[model.Product], locale string) dto.Page[dto.ProductResponse] {
+	items := make([]dto.ProductResponse, 0, len(page.Items))
+	for _, product := range page.Items {
+		items = append(items, dto.ToProductResponse(&product, locale))
+	}
+	return dto.Page[dto.ProductResponse]{
+		Page:  page.Page,
+		Size:  page.Size,
+		Total: page.Total,
+		Items: items,
+	}
+}
+
+var ProductHandlerModule = fx.Options(fx.Provide(NewProductHandler))
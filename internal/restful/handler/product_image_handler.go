@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type ProductImageHandler struct {
+	service service.ProductImageService
+}
+
+func NewProductImageHandler(productImageService service.ProductImageService) *ProductImageHandler {
+	return &ProductImageHandler{service: productImageService}
+}
+
+// Upload godoc
+// @Summary Upload a product image
+// @Description Upload a JPEG, PNG or WebP image (checked by magic bytes). Thumbnails are generated in the background.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "product id"
+// @Param file formData file true "image file"
+// @Success 202 {object} dto.HttpResponse[dto.ImageDto]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 413 {object} dto.HttpResponse[any]
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Router /product/{id}/image [post]
+func (handler *ProductImageHandler) Upload(context *core.HttpContext) {
+	file, err := context.Gin.FormFile("file")
+	if err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	image, err := handler.service.Upload(context.Gin.Param("id"), file)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusAccepted, dto.HttpResponse[dto.ImageDto]{
+		HttpStatus: http.StatusAccepted,
+		Data:       dto.ToImageDto(*image),
+	})
+}
+
+// List godoc
+// @Summary List product images
+// @Description List the images of a product with the URLs of every stored variant
+// @Tags products
+// @Produce json
+// @Param id path string true "product id"
+// @Success 200 {object} dto.HttpResponse[[]dto.ImageDto]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/image [get]
+func (handler *ProductImageHandler) List(context *core.HttpContext) {
+	images, err := handler.service.FindByProduct(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.ImageDto]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToImageDtos(images),
+	})
+}
+
+// Delete godoc
+// @Summary Delete a product image
+// @Description Delete a product image and all of its stored variants
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "product id"
+// @Param imageId path string true "image id"
+// @Success 200 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/image/{imageId} [delete]
+func (handler *ProductImageHandler) Delete(context *core.HttpContext) {
+	if err := handler.service.Delete(context.Gin.Param("id"), context.Gin.Param("imageId")); err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[any]{
+		HttpStatus: http.StatusOK,
+	})
+}
+
+// Media godoc
+// @Summary Serve a stored file
+// @Description Serve a stored product image variant by its storage key
+// @Tags media
+// @Produce image/jpeg,image/png,image/webp
+// @Param key path string true "storage key"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /media/{key} [get]
+func (handler *ProductImageHandler) Media(context *core.HttpContext) {
+	key := strings.TrimPrefix(context.Gin.Param("key"), "/")
+	content, contentType, err := handler.service.Media(key)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	// Keys are unique per upload, so variants never change once written
+	context.Gin.Header("Cache-Control", "public, max-age=31536000, immutable")
+	context.Gin.Data(http.StatusOK, contentType, content)
+}
+
+// servePrivate sends a file only some users may see: the browser may keep it, shared caches must not
+func servePrivate(context *core.HttpContext, content []byte, contentType string) {
+	context.Gin.Header("Cache-Control", "private, max-age=86400")
+	context.Gin.Data(http.StatusOK, contentType, content)
+}
+
+var ProductImageHandlerModule = fx.Options(fx.Provide(NewProductImageHandler))
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type PromotionHandler struct {
+	service service.PromotionService
+}
+
+func NewPromotionHandler(promotionService service.PromotionService) *PromotionHandler {
+	return &PromotionHandler{service: promotionService}
+}
+
+// Current godoc
+// @Summary Current promotions
+// @Description List the promotions running now that apply without a code
+// @Tags promotion
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[[]dto.PromotionResponse]
+// @Router /promotion [get]
+func (handler *PromotionHandler) Current(context *core.HttpContext) {
+	promotions := handler.service.FindCurrent()
+	responses := make([]dto.PromotionResponse, 0, len(promotions))
+	for _, promotion := range promotions {
+		responses = append(responses, dto.ToPromotionResponse(&promotion))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.PromotionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       responses,
+	})
+}
+
+// List godoc
+// @Summary List promotions
+// @Description Page through every promotion, the highest priority first
+// @Tags promotion
+// @Produce json
+// @Security BearerAuth
+// @Param query query dto.PromotionQuery false "Filters"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.PromotionResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /promotion/manage [get]
+func (handler *PromotionHandler) List(context *core.HttpContext) {
+	var query dto.PromotionQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.PromotionResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPromotionPage(handler.service.FindAll(query)),
+	})
+}
+
+// Details godoc
+// @Summary Promotion details
+// @Description Get a promotion by id
+// @Tags promotion
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "promotion id"
+// @Success 200 {object} dto.HttpResponse[dto.PromotionResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /promotion/manage/{id} [get]
+func (handler *PromotionHandler) Details(context *core.HttpContext) {
+	promotion, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PromotionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPromotionResponse(promotion),
+	})
+}
+
+// Create godoc
+// @Summary Create a promotion
+// @Description Define a discount with its eligibility, priority and whether it combines with others
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param promotion body dto.PromotionRequest true "Promotion"
+// @Success 201 {object} dto.HttpResponse[dto.PromotionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /promotion/manage [post]
+func (handler *PromotionHandler) Create(context *core.HttpContext) {
+	var request dto.PromotionRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	promotion, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.PromotionResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToPromotionResponse(promotion),
+	})
+}
+
+// Update godoc
+// @Summary Update a promotion
+// @Description Change or end a promotion; orders already placed keep their discounts
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "promotion id"
+// @Param promotion body dto.PromotionRequest true "Promotion"
+// @Success 200 {object} dto.HttpResponse[dto.PromotionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /promotion/manage/{id} [put]
+func (handler *PromotionHandler) Update(context *core.HttpContext) {
+	var request dto.PromotionRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	promotion, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PromotionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPromotionResponse(promotion),
+	})
+}
+
+var PromotionHandlerModule = fx.Options(fx.Provide(NewPromotionHandler))
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type PurchaseOrderHandler struct {
+	service service.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(purchaseOrderService service.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: purchaseOrderService}
+}
+
+// Create godoc
+// @Summary Draft a purchase order
+// @Description Draft an order to a supplier; unit costs default to the supplier's cost prices
+// @Tags purchase-order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body dto.PurchaseOrderRequest true "Purchase order"
+// @Success 201 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /purchase-order [post]
+func (handler *PurchaseOrderHandler) Create(context *core.HttpContext) {
+	var request dto.PurchaseOrderRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	order, err := handler.service.Create(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+// List godoc
+// @Summary List purchase orders
+// @Description Page through purchase orders, the latest first
+// @Tags purchase-order
+// @Produce json
+// @Security BearerAuth
+// @Param supplier_id query string false "supplier id"
+// @Param status query string false "draft, sent, partially_received, received or closed"
+// @Param page query int false "page, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.PurchaseOrderResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /purchase-order [get]
+func (handler *PurchaseOrderHandler) List(context *core.HttpContext) {
+	var query dto.PurchaseOrderQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.PurchaseOrderResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderPage(handler.service.FindAll(query)),
+	})
+}
+
+// Details godoc
+// @Summary Purchase order details
+// @Description Get a purchase order with its lines and goods receipts
+// @Tags purchase-order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /purchase-order/{id} [get]
+func (handler *PurchaseOrderHandler) Details(context *core.HttpContext) {
+	order, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+// Update godoc
+// @Summary Update a draft purchase order
+// @Description Replace the supplier, destination and lines; only drafts can be changed
+// @Tags purchase-order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "purchase order id"
+// @Param order body dto.PurchaseOrderRequest true "Purchase order"
+// @Success 200 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /purchase-order/{id} [put]
+func (handler *PurchaseOrderHandler) Update(context *core.HttpContext) {
+	var request dto.PurchaseOrderRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	order, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+// Send godoc
+// @Summary Send a purchase order
+// @Description Mark a draft as sent to the supplier; the delivery is expected after the supplier's lead time
+// @Tags purchase-order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /purchase-order/{id}/send [post]
+func (handler *PurchaseOrderHandler) Send(context *core.HttpContext) {
+	order, err := handler.service.Send(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+// Receive godoc
+// @Summary Receive goods
+// @Description Book a delivery against a sent order; each line becomes a stock lot and a receipt in the stock ledger
+// @Tags purchase-order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "purchase order id"
+// @Param receipt body dto.GoodsReceiptRequest true "Goods receipt"
+// @Success 200 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /purchase-order/{id}/receipt [post]
+func (handler *PurchaseOrderHandler) Receive(context *core.HttpContext) {
+	var request dto.GoodsReceiptRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	order, err := handler.service.Receive(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+// Close godoc
+// @Summary Close a purchase order
+// @Description Finish a received order, or a partially received one whose rest will not come
+// @Tags purchase-order
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} dto.HttpResponse[dto.PurchaseOrderResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /purchase-order/{id}/close [post]
+func (handler *PurchaseOrderHandler) Close(context *core.HttpContext) {
+	order, err := handler.service.Close(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PurchaseOrderResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToPurchaseOrderResponse(order),
+	})
+}
+
+var PurchaseOrderHandlerModule = fx.Options(fx.Provide(NewPurchaseOrderHandler))
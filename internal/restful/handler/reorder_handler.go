@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type ReorderHandler struct {
+	service service.ReorderService
+}
+
+func NewReorderHandler(reorderService service.ReorderService) *ReorderHandler {
+	return &ReorderHandler{service: reorderService}
+}
+
+// Rules godoc
+// @Summary List reorder rules
+// @Description List reorder points and target levels per product and location
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Param location_id query string false "location id"
+// @Success 200 {object} dto.HttpResponse[[]dto.ReorderRuleResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/reorder-rule [get]
+func (handler *ReorderHandler) Rules(context *core.HttpContext) {
+	var query dto.ReorderRuleQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.ReorderRuleResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReorderRuleResponses(handler.service.FindRules(query)),
+	})
+}
+
+// SetRule godoc
+// @Summary Set a reorder rule
+// @Description Create or replace the reorder point and target level of a product at a location
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.ReorderRuleRequest true "Reorder rule"
+// @Success 200 {object} dto.HttpResponse[dto.ReorderRuleResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /inventory/reorder-rule [put]
+func (handler *ReorderHandler) SetRule(context *core.HttpContext) {
+	var request dto.ReorderRuleRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	rule, err := handler.service.SetRule(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReorderRuleResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReorderRuleResponse(*rule),
+	})
+}
+
+// DeleteRule godoc
+// @Summary Delete a reorder rule
+// @Description Stop watching a product at a location; earlier alerts stay in the history
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string true "product id"
+// @Param location_id query string false "location id, defaults to the central warehouse"
+// @Success 200 {object} dto.HttpResponse[any]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /inventory/reorder-rule [delete]
+func (handler *ReorderHandler) DeleteRule(context *core.HttpContext) {
+	var query dto.DeleteReorderRuleQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	if err := handler.service.DeleteRule(query.ProductID, query.LocationID); err != nil {
+		context.Gin.Error(err)
+		return
+	}
+	context.JSON(http.StatusOK, dto.HttpResponse[any]{
+		HttpStatus: http.StatusOK,
+	})
+}
+
+// Alerts godoc
+// @Summary Low-stock alert history
+// @Description Page through low-stock alerts with their suggested reorders, the latest first
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Param location_id query string false "location id"
+// @Param status query string false "open or resolved"
+// @Param page query int false "page, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.LowStockAlertResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/low-stock-alert [get]
+func (handler *ReorderHandler) Alerts(context *core.HttpContext) {
+	var query dto.LowStockAlertQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.LowStockAlertResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLowStockAlertPage(handler.service.FindAlerts(query)),
+	})
+}
+
+// CheckStock godoc
+// @Summary Check stock now
+// @Description Run the scheduled low-stock check at once: raise alerts, draft suggested purchase orders and notify staff
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[dto.LowStockCheckResponse]
+// @Router /inventory/low-stock-alert/check [post]
+func (handler *ReorderHandler) CheckStock(context *core.HttpContext) {
+	check, err := handler.service.CheckStock()
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LowStockCheckResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLowStockCheckResponse(*check),
+	})
+}
+
+var ReorderHandlerModule = fx.Options(fx.Provide(NewReorderHandler))
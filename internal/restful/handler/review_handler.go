@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+
+	"go.uber.org/fx"
+)
+
+type ReviewHandler struct {
+	service service.ReviewService
+}
+
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{service: reviewService}
+}
+
+// Create godoc
+// @Summary Review a product
+// @Description Rate a product from a delivered order. The review is published once staff approve it; one review per product.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param review body dto.ReviewRequest true "Review"
+// @Success 201 {object} dto.HttpResponse[dto.ReviewResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 403 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /review [post]
+func (handler *ReviewHandler) Create(context *core.HttpContext) {
+	var request dto.ReviewRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	review, err := handler.service.Create(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.ReviewResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToReviewResponse(review),
+	})
+}
+
+// AddPhoto godoc
+// @Summary Add a photo to a review
+// @Description Attach a JPEG, PNG or WebP photo to your review; a published review goes back to moderation
+// @Tags reviews
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Param file formData file true "photo"
+// @Success 200 {object} dto.HttpResponse[dto.ReviewResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 403 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 413 {object} dto.HttpResponse[any]
+// @Failure 415 {object} dto.HttpResponse[any]
+// @Router /review/{id}/photo [post]
+func (handler *ReviewHandler) AddPhoto(context *core.HttpContext) {
+	file, err := context.Gin.FormFile("file")
+	if err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	review, err := handler.service.AddPhoto(context.Claims().UserID, context.Gin.Param("id"), file)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReviewResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReviewResponse(review),
+	})
+}
+
+// ProductReviews godoc
+// @Summary Product reviews
+// @Description List the published reviews of a product with its average rating and star histogram
+// @Tags reviews
+// @Produce json
+// @Param id path string true "product id"
+// @Param rating query int false "only reviews with this many stars"
+// @Param sort query string false "recent (default) or helpful"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.ProductReviewsResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /product/{id}/review [get]
+func (handler *ReviewHandler) ProductReviews(context *core.HttpContext) {
+	var query dto.ReviewQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	page, summary, err := handler.service.FindByProduct(context.Gin.Param("id"), query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ProductReviewsResponse]{
+		HttpStatus: http.StatusOK,
+		Data: dto.ProductReviewsResponse{
+			Summary: dto.ToRatingSummaryDto(summary),
+			Reviews: dto.ToReviewPage(page),
+		},
+	})
+}
+
+// Mine godoc
+// @Summary My reviews
+// @Description List your reviews in every moderation status, newest first
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.ReviewResponse]
+// @Router /review/mine [get]
+func (handler *ReviewHandler) Mine(context *core.HttpContext) {
+	reviews := make([]dto.ReviewResponse, 0)
+	for _, review := range handler.service.FindByUser(context.Claims().UserID) {
+		reviews = append(reviews, dto.ToReviewResponse(&review))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.ReviewResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       reviews,
+	})
+}
+
+// Vote godoc
+// @Summary Vote on a review
+// @Description Mark a published review as helpful or not; voting again replaces your previous vote
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Param vote body dto.VoteRequest true "Vote"
+// @Success 200 {object} dto.HttpResponse[dto.ReviewResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /review/{id}/vote [post]
+func (handler *ReviewHandler) Vote(context *core.HttpContext) {
+	var request dto.VoteRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	review, err := handler.service.Vote(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReviewResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReviewResponse(review),
+	})
+}
+
+// Moderation godoc
+// @Summary Review moderation queue
+// @Description List reviews by moderation status, oldest first
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending (default), approved or rejected"
+// @Param product_id query string false "product id"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.ReviewResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /review/moderation [get]
+func (handler *ReviewHandler) Moderation(context *core.HttpContext) {
+	var query dto.ModerationQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.ReviewResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReviewPage(handler.service.FindForModeration(query)),
+	})
+}
+
+// Moderate godoc
+// @Summary Approve or reject a review
+// @Description Publish or reject a review; the product's rating summary is updated accordingly
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Param decision body dto.ModerationRequest true "Decision"
+// @Success 200 {object} dto.HttpResponse[dto.ReviewResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /review/{id}/moderate [post]
+func (handler *ReviewHandler) Moderate(context *core.HttpContext) {
+	var request dto.ModerationRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	review, err := handler.service.Moderate(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.ReviewResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToReviewResponse(review),
+	})
+}
+
+// Photo godoc
+// @Summary Review photo
+// @Description Serve a photo of a published review; photos of reviews awaiting moderation are only served to their author and staff
+// @Tags reviews
+// @Produce image/jpeg,image/png,image/webp
+// @Param id path string true "review id"
+// @Param photoId path string true "photo id"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /review/{id}/photo/{photoId} [get]
+func (handler *ReviewHandler) Photo(context *core.HttpContext) {
+	var actor model.OrderActor
+	if claims := context.Claims(); claims != nil {
+		actor = model.OrderActor{ID: claims.UserID, Roles: claims.Roles}
+	}
+	content, contentType, err := handler.service.Photo(actor, context.Gin.Param("id"), context.Gin.Param("photoId"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	servePrivate(context, content, contentType)
+}
+
+var ReviewHandlerModule = fx.Options(fx.Provide(NewReviewHandler))
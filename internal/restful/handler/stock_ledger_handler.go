@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type StockLedgerHandler struct {
+	service service.StockLedgerService
+}
+
+func NewStockLedgerHandler(stockLedgerService service.StockLedgerService) *StockLedgerHandler {
+	return &StockLedgerHandler{service: stockLedgerService}
+}
+
+// Movements godoc
+// @Summary Stock movement ledger
+// @Description Page through stock movements, newest first
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Param location_id query string false "location id"
+// @Param type query string false "receipt, sale, return, adjustment, spoilage or transfer"
+// @Param source_type query string false "source document type, e.g. lot, reservation, transfer or stocktake"
+// @Param source_id query string false "source document id"
+// @Param actor_id query string false "user who caused the movement"
+// @Param from query string false "first day, YYYY-MM-DD"
+// @Param to query string false "last day, YYYY-MM-DD"
+// @Param page query int false "page, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.MovementResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/ledger [get]
+func (handler *StockLedgerHandler) Movements(context *core.HttpContext) {
+	var query dto.MovementQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	page, err := handler.service.FindMovements(query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.MovementResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToMovementPage(page),
+	})
+}
+
+// Rebuild godoc
+// @Summary Rebuild stock on hand
+// @Description Replay the ledger and correct every stock level whose on-hand quantity disagrees with it
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[dto.RebuildResponse]
+// @Router /inventory/ledger/rebuild [post]
+func (handler *StockLedgerHandler) Rebuild(context *core.HttpContext) {
+	response, err := handler.service.Rebuild(context.Claims().UserID)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.RebuildResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       *response,
+	})
+}
+
+// CreateStocktake godoc
+// @Summary Record a stocktake
+// @Description Record counted stock at a location against what the ledger expects; nothing changes until it is applied
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param stocktake body dto.StocktakeRequest true "Counts"
+// @Success 201 {object} dto.HttpResponse[dto.StocktakeResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /inventory/stocktake [post]
+func (handler *StockLedgerHandler) CreateStocktake(context *core.HttpContext) {
+	var request dto.StocktakeRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	stocktake, err := handler.service.CreateStocktake(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.StocktakeResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToStocktakeResponse(*stocktake),
+	})
+}
+
+// Stocktakes godoc
+// @Summary List stocktakes
+// @Description List stocktakes with their variances, the latest first
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param location_id query string false "location id"
+// @Param status query string false "counted or applied"
+// @Success 200 {object} dto.HttpResponse[[]dto.StocktakeResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /inventory/stocktake [get]
+func (handler *StockLedgerHandler) Stocktakes(context *core.HttpContext) {
+	var query dto.StocktakeQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.StocktakeResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToStocktakeResponses(handler.service.FindStocktakes(query)),
+	})
+}
+
+// Stocktake godoc
+// @Summary Stocktake details
+// @Description Get a stocktake with its counted-versus-expected variances
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "stocktake id"
+// @Success 200 {object} dto.HttpResponse[dto.StocktakeResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /inventory/stocktake/{id} [get]
+func (handler *StockLedgerHandler) Stocktake(context *core.HttpContext) {
+	stocktake, err := handler.service.FindStocktake(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.StocktakeResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToStocktakeResponse(*stocktake),
+	})
+}
+
+// ApplyStocktake godoc
+// @Summary Apply a stocktake
+// @Description Post the stocktake's variances to the ledger as adjustments
+// @Tags inventory
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "stocktake id"
+// @Success 200 {object} dto.HttpResponse[dto.StocktakeResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /inventory/stocktake/{id}/apply [post]
+func (handler *StockLedgerHandler) ApplyStocktake(context *core.HttpContext) {
+	stocktake, err := handler.service.ApplyStocktake(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.StocktakeResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToStocktakeResponse(*stocktake),
+	})
+}
+
+var StockLedgerHandlerModule = fx.Options(fx.Provide(NewStockLedgerHandler))
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"go.uber.org/fx"
+)
+
+type StockLotHandler struct {
+	service service.StockLotService
+}
+
+func NewStockLotHandler(stockLotService service.StockLotService) *StockLotHandler {
+	return &StockLotHandler{service: stockLotService}
+}
+
+// Receive godoc
+// @Summary Receive a stock lot
+// @Description Record a delivered lot of a product with its harvest and best-before dates
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param lot body dto.LotRequest true "Lot"
+// @Success 201 {object} dto.HttpResponse[dto.LotResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /lot [post]
+func (handler *StockLotHandler) Receive(context *core.HttpContext) {
+	var request dto.LotRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	lot, err := handler.service.Receive(context.Claims().UserID, request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.LotResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToLotResponse(lot, util.StoreToday()),
+	})
+}
+
+// List godoc
+// @Summary List stock lots
+// @Description List stock lots ordered by best-before date, optionally for one product
+// @Tags lots
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "product id"
+// @Success 200 {object} dto.HttpResponse[[]dto.LotResponse]
+// @Router /lot [get]
+func (handler *StockLotHandler) List(context *core.HttpContext) {
+	var query dto.LotQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.LotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       toLotResponses(handler.service.FindAll(query)),
+	})
+}
+
+// Expiring godoc
+// @Summary Lots expiring soon
+// @Description List lots with stock left whose best-before date is within N days, expired ones included, so staff can mark them down or write them off
+// @Tags lots
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "days ahead, default 3"
+// @Success 200 {object} dto.HttpResponse[[]dto.LotResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /lot/expiring [get]
+func (handler *StockLotHandler) Expiring(context *core.HttpContext) {
+	var query dto.ExpiringLotQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.LotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       toLotResponses(handler.service.FindExpiring(query.Days)),
+	})
+}
+
+// Details godoc
+// @Summary Stock lot details
+// @Tags lots
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "lot id"
+// @Success 200 {object} dto.HttpResponse[dto.LotResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /lot/{id} [get]
+func (handler *StockLotHandler) Details(context *core.HttpContext) {
+	lot, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLotResponse(lot, util.StoreToday()),
+	})
+}
+
+// WriteOff godoc
+// @Summary Write off a stock lot
+// @Description Remove the remaining stock of a lot, e.g. because it spoiled
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "lot id"
+// @Param writeOff body dto.WriteOffRequest true "Reason"
+// @Success 200 {object} dto.HttpResponse[dto.LotResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /lot/{id}/write-off [post]
+func (handler *StockLotHandler) WriteOff(context *core.HttpContext) {
+	var request dto.WriteOffRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	lot, err := handler.service.WriteOff(context.Claims().UserID, context.Gin.Param("id"), request.Reason)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.LotResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToLotResponse(lot, util.StoreToday()),
+	})
+}
+
+// Pick godoc
+// @Summary Pick stock
+// @Description Take a quantity of a product out of its lots, first-expired-first-out
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pick body dto.PickRequest true "Product and quantity"
+// @Success 200 {object} dto.HttpResponse[dto.PickResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /lot/pick [post]
+func (handler *StockLotHandler) Pick(context *core.HttpContext) {
+	var request dto.PickRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	pick, err := handler.service.Pick(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.PickResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       *pick,
+	})
+}
+
+func toLotResponses(lots []model.StockLot) []dto.LotResponse {
+	today := util.StoreToday()
+	responses := make([]dto.LotResponse, 0, len(lots))
+	for _, lot := range lots {
+		responses = append(responses, dto.ToLotResponse(&lot, today))
+	}
+	return responses
+}
+
+var StockLotHandlerModule = fx.Options(fx.Provide(NewStockLotHandler))
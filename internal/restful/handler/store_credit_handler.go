@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type StoreCreditHandler struct {
+	service service.StoreCreditService
+}
+
+func NewStoreCreditHandler(storeCreditService service.StoreCreditService) *StoreCreditHandler {
+	return &StoreCreditHandler{service: storeCreditService}
+}
+
+// Mine godoc
+// @Summary My store credit
+// @Description The caller's store credit balance with its entries, the latest first
+// @Tags store-credit
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[dto.StoreCreditResponse]
+// @Failure 401 {object} dto.HttpResponse[any]
+// @Router /store-credit [get]
+func (handler *StoreCreditHandler) Mine(context *core.HttpContext) {
+	balance, entries := handler.service.FindMine(context.Claims().UserID)
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.StoreCreditResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToStoreCreditResponse(balance, entries),
+	})
+}
+
+var StoreCreditHandlerModule = fx.Options(fx.Provide(NewStoreCreditHandler))
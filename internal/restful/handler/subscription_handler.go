@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type SubscriptionHandler struct {
+	service service.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService service.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{service: subscriptionService}
+}
+
+// Plans godoc
+// @Summary List subscription plans
+// @Description List the veg boxes customers can subscribe to, with what is in them and when they come
+// @Tags subscription
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[[]dto.SubscriptionPlanResponse]
+// @Router /subscription-plan [get]
+func (handler *SubscriptionHandler) Plans(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.SubscriptionPlanResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionPlanResponses(handler.service.FindPlans(true)),
+	})
+}
+
+// Subscribe godoc
+// @Summary Subscribe to a plan
+// @Description Get the plan's box delivered on its cycle, from its first delivery day on or after the start date
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription body dto.SubscribeRequest true "Subscription"
+// @Success 201 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription [post]
+func (handler *SubscriptionHandler) Subscribe(context *core.HttpContext) {
+	var request dto.SubscribeRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	subscription, err := handler.service.Subscribe(context.Claims().UserID, request, context.Locale())
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Mine godoc
+// @Summary My subscriptions
+// @Description List the caller's subscriptions, cancelled ones included
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.SubscriptionResponse]
+// @Router /subscription [get]
+func (handler *SubscriptionHandler) Mine(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponses(handler.service.FindMine(context.Claims().UserID), context.Locale()),
+	})
+}
+
+// MineDetails godoc
+// @Summary My subscription
+// @Description Get one of the caller's subscriptions with what became of its past delivery days
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription/{id} [get]
+func (handler *SubscriptionHandler) MineDetails(context *core.HttpContext) {
+	subscription, err := handler.service.FindMineByID(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Pause godoc
+// @Summary Pause a subscription
+// @Description Stop boxes until the subscription is resumed, or until a date
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Param pause body dto.PauseSubscriptionRequest true "Pause, {} until resumed"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /subscription/{id}/pause [post]
+func (handler *SubscriptionHandler) Pause(context *core.HttpContext) {
+	var request dto.PauseSubscriptionRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	subscription, err := handler.service.Pause(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Resume godoc
+// @Summary Resume a subscription
+// @Description Start boxes again from the next delivery day that has not been ordered yet
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /subscription/{id}/resume [post]
+func (handler *SubscriptionHandler) Resume(context *core.HttpContext) {
+	subscription, err := handler.service.Resume(context.Claims().UserID, context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Skip godoc
+// @Summary Skip a delivery
+// @Description Leave out the box of an upcoming delivery day that has not been ordered yet
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Param skip body dto.SkipDeliveryRequest true "Delivery day"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /subscription/{id}/skip [post]
+func (handler *SubscriptionHandler) Skip(context *core.HttpContext) {
+	var request dto.SkipDeliveryRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	subscription, err := handler.service.Skip(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Unskip godoc
+// @Summary Undo a skipped delivery
+// @Description Get the box of a skipped delivery day after all
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Param date path string true "skipped delivery day" example(2026-10-30)
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription/{id}/skip/{date} [delete]
+func (handler *SubscriptionHandler) Unskip(context *core.HttpContext) {
+	subscription, err := handler.service.Unskip(context.Claims().UserID, context.Gin.Param("id"), context.Gin.Param("date"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Cancel godoc
+// @Summary Cancel a subscription
+// @Description End the subscription; boxes already ordered are still delivered unless their orders are cancelled
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Param cancel body dto.CancelSubscriptionRequest true "Reason"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /subscription/{id}/cancel [post]
+func (handler *SubscriptionHandler) Cancel(context *core.HttpContext) {
+	var request dto.CancelSubscriptionRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	subscription, err := handler.service.Cancel(context.Claims().UserID, context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// AllPlans godoc
+// @Summary List all subscription plans
+// @Description List every plan, including those no longer taking subscribers
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.SubscriptionPlanResponse]
+// @Router /subscription/manage/plan [get]
+func (handler *SubscriptionHandler) AllPlans(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.SubscriptionPlanResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionPlanResponses(handler.service.FindPlans(false)),
+	})
+}
+
+// CreatePlan godoc
+// @Summary Create a subscription plan
+// @Description Define a box: its products with substitutes for each, how often it comes and on which weekday
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param plan body dto.SubscriptionPlanRequest true "Plan"
+// @Success 201 {object} dto.HttpResponse[dto.SubscriptionPlanResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription/manage/plan [post]
+func (handler *SubscriptionHandler) CreatePlan(context *core.HttpContext) {
+	var request dto.SubscriptionPlanRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	plan, err := handler.service.CreatePlan(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.SubscriptionPlanResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToSubscriptionPlanResponse(plan),
+	})
+}
+
+// UpdatePlan godoc
+// @Summary Update a subscription plan
+// @Description Change what later boxes contain, or stop taking subscribers; subscribers keep their delivery day
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "plan id"
+// @Param plan body dto.SubscriptionPlanRequest true "Plan"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionPlanResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription/manage/plan/{id} [put]
+func (handler *SubscriptionHandler) UpdatePlan(context *core.HttpContext) {
+	var request dto.SubscriptionPlanRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	plan, err := handler.service.UpdatePlan(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionPlanResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionPlanResponse(plan),
+	})
+}
+
+// List godoc
+// @Summary List subscriptions
+// @Description Page through every customer's subscriptions, by status or plan
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Param query query dto.SubscriptionQuery false "Filters"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.SubscriptionResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /subscription/manage [get]
+func (handler *SubscriptionHandler) List(context *core.HttpContext) {
+	var query dto.SubscriptionQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.SubscriptionResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionPage(handler.service.FindAll(query), context.Locale()),
+	})
+}
+
+// Details godoc
+// @Summary Subscription details
+// @Description Get any subscription by id
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "subscription id"
+// @Success 200 {object} dto.HttpResponse[dto.SubscriptionResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /subscription/manage/{id} [get]
+func (handler *SubscriptionHandler) Details(context *core.HttpContext) {
+	subscription, err := handler.service.FindByID(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SubscriptionResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSubscriptionResponse(subscription, context.Locale()),
+	})
+}
+
+// Run godoc
+// @Summary Order due subscription boxes
+// @Description Run the scheduled job now: order every box whose delivery day is within the lead time
+// @Tags subscription
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.HttpResponse[[]dto.BoxRunResponse]
+// @Router /subscription/manage/run [post]
+func (handler *SubscriptionHandler) Run(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.BoxRunResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToBoxRunResponses(handler.service.Generate(), context.Locale()),
+	})
+}
+
+var SubscriptionHandlerModule = fx.Options(fx.Provide(NewSubscriptionHandler))
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type SupplierHandler struct {
+	service service.SupplierService
+}
+
+func NewSupplierHandler(supplierService service.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: supplierService}
+}
+
+// List godoc
+// @Summary List suppliers
+// @Description List farms and wholesalers with their contacts, lead times and the products they supply
+// @Tags supplier
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query string false "only suppliers of this product"
+// @Param include_inactive query bool false "also list suppliers we no longer buy from"
+// @Success 200 {object} dto.HttpResponse[[]dto.SupplierResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /supplier [get]
+func (handler *SupplierHandler) List(context *core.HttpContext) {
+	var query dto.SupplierQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	suppliers := make([]dto.SupplierResponse, 0)
+	for _, supplier := range handler.service.FindAll(query) {
+		suppliers = append(suppliers, dto.ToSupplierResponse(&supplier))
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[[]dto.SupplierResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       suppliers,
+	})
+}
+
+// Details godoc
+// @Summary Supplier details
+// @Description Get a supplier by id
+// @Tags supplier
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "supplier id"
+// @Success 200 {object} dto.HttpResponse[dto.SupplierResponse]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /supplier/{id} [get]
+func (handler *SupplierHandler) Details(context *core.HttpContext) {
+	supplier, err := handler.service.FindById(context.Gin.Param("id"))
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SupplierResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSupplierResponse(supplier),
+	})
+}
+
+// Create godoc
+// @Summary Create a supplier
+// @Description Add a farm or wholesaler with the products it supplies at cost price
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param supplier body dto.SupplierRequest true "Supplier"
+// @Success 201 {object} dto.HttpResponse[dto.SupplierResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Failure 409 {object} dto.HttpResponse[any]
+// @Router /supplier [post]
+func (handler *SupplierHandler) Create(context *core.HttpContext) {
+	var request dto.SupplierRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	supplier, err := handler.service.Create(request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, dto.HttpResponse[dto.SupplierResponse]{
+		HttpStatus: http.StatusCreated,
+		Data:       dto.ToSupplierResponse(supplier),
+	})
+}
+
+// Update godoc
+// @Summary Update a supplier
+// @Description Change a supplier's details, contacts, lead time or products, or deactivate it
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "supplier id"
+// @Param supplier body dto.SupplierRequest true "Supplier"
+// @Success 200 {object} dto.HttpResponse[dto.SupplierResponse]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Failure 404 {object} dto.HttpResponse[any]
+// @Router /supplier/{id} [put]
+func (handler *SupplierHandler) Update(context *core.HttpContext) {
+	var request dto.SupplierRequest
+	if err := context.Gin.ShouldBindJSON(&request); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	supplier, err := handler.service.Update(context.Gin.Param("id"), request)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.SupplierResponse]{
+		HttpStatus: http.StatusOK,
+		Data:       dto.ToSupplierResponse(supplier),
+	})
+}
+
+var SupplierHandlerModule = fx.Options(fx.Provide(NewSupplierHandler))
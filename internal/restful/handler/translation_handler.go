@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+
+	"go.uber.org/fx"
+)
+
+type TranslationHandler struct {
+	service service.TranslationService
+}
+
+func NewTranslationHandler(translationService service.TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: translationService}
+}
+
+// Locales godoc
+// @Summary Supported locales
+// @Description List the locales the API can answer in
+// @Tags translations
+// @Produce json
+// @Success 200 {object} dto.HttpResponse[[]string]
+// @Router /translation/locales [get]
+func (handler *TranslationHandler) Locales(context *core.HttpContext) {
+	context.JSON(http.StatusOK, dto.HttpResponse[[]string]{
+		HttpStatus: http.StatusOK,
+		Data:       handler.service.Locales(),
+	})
+}
+
+// Missing godoc
+// @Summary Missing translations
+// @Description List products and categories whose name or description has no translation
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param locale query string false "only this locale, e.g. vi"
+// @Param page query int false "page number, starting at 1"
+// @Param size query int false "page size"
+// @Success 200 {object} dto.HttpResponse[dto.Page[dto.MissingTranslationResponse]]
+// @Failure 400 {object} dto.HttpResponse[any]
+// @Router /translation/missing [get]
+func (handler *TranslationHandler) Missing(context *core.HttpContext) {
+	var query dto.MissingTranslationQuery
+	if err := context.Gin.ShouldBindQuery(&query); err != nil {
+		context.Gin.Error(core.Error.Invalid.Request)
+		return
+	}
+
+	page, err := handler.service.FindMissing(query)
+	if err != nil {
+		context.Gin.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, dto.HttpResponse[dto.Page[dto.MissingTranslationResponse]]{
+		HttpStatus: http.StatusOK,
+		Data:       page,
+	})
+}
+
+var TranslationHandlerModule = fx.Options(fx.Provide(NewTranslationHandler))
@@ -5,11 +5,13 @@ import (
 	"veg-store-backend/util"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 /*
 This middleware injects the application context into each Gin request context.
-It provides access to shared resources like configuration, logger, and localizer.
+It provides access to shared resources like configuration, logger, and localizer. It runs after TraceID, so the
+request's logger carries its trace_id.
 */
 
 func HttpContext() gin.HandlerFunc {
@@ -17,6 +19,7 @@ func HttpContext() gin.HandlerFunc {
 		httpContext := &core.HttpContext{
 			Translator: core.Translator,
 			Gin:        ginContext,
+			Logger:     zap.L().With(zap.String("trace_id", util.GetTraceId(ginContext))),
 		}
 
 		ginContext.Set(util.AppContextKey, httpContext)
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authentication verifies the "Authorization: Bearer <token>" header and stores the JWT claims in context
+func Authentication(jwtManager infra_interface.JWTManager) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := verifyBearer(jwtManager, ginContext)
+		if !ok {
+			_ = ginContext.Error(core.Error.Auth.Unauthenticated)
+			ginContext.Abort()
+			return
+		}
+
+		ginContext.Set(util.ClaimsContextKey, claims)
+		ginContext.Next()
+	}
+}
+
+// OptionalAuthentication stores the JWT claims when a valid bearer token is sent, and lets anonymous requests through
+func OptionalAuthentication(jwtManager infra_interface.JWTManager) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if claims, ok := verifyBearer(jwtManager, ginContext); ok {
+			ginContext.Set(util.ClaimsContextKey, claims)
+		}
+		ginContext.Next()
+	}
+}
+
+// RequireRoles must be registered after Authentication; the request passes if the user has any of the roles
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		value, exists := ginContext.Get(util.ClaimsContextKey)
+		claims, ok := value.(*infra_interface.JWTClaims)
+		if !exists || !ok {
+			_ = ginContext.Error(core.Error.Auth.Unauthenticated)
+			ginContext.Abort()
+			return
+		}
+
+		for _, role := range claims.Roles {
+			if slices.Contains(roles, role) {
+				ginContext.Next()
+				return
+			}
+		}
+
+		_ = ginContext.Error(core.Error.Auth.Forbidden)
+		ginContext.Abort()
+	}
+}
+
+func verifyBearer(jwtManager infra_interface.JWTManager, ginContext *gin.Context) (*infra_interface.JWTClaims, bool) {
+	header := ginContext.GetHeader("Authorization")
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(token) == "" {
+		return nil, false
+	}
+
+	claims, err := jwtManager.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, false
+	}
+	return claims, true
+}
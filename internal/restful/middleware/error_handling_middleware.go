@@ -61,14 +61,24 @@ func ErrorHandler() gin.HandlerFunc {
 
 func mapErrorCodeToStatus(code string) int {
 	switch {
+	case code == "invalid/image-too-large", code == "invalid/spreadsheet-too-large":
+		return http.StatusRequestEntityTooLarge
+	case code == "invalid/idempotency-key-reused":
+		return http.StatusUnprocessableEntity
+	case code == "invalid/image-type", code == "invalid/spreadsheet-format":
+		return http.StatusUnsupportedMediaType
 	case strings.HasPrefix(code, "invalid/"):
 		return http.StatusBadRequest
 	case strings.HasPrefix(code, "auth/unauthenticated"):
 		return http.StatusUnauthorized
-	case strings.HasPrefix(code, "auth/forbidden"):
+	case strings.HasPrefix(code, "auth/forbidden"), code == "auth/purchase-required", code == "auth/order-transition":
 		return http.StatusForbidden
 	case strings.HasPrefix(code, "not_found/"):
 		return http.StatusNotFound
+	case strings.HasPrefix(code, "conflict/"):
+		return http.StatusConflict
+	case strings.HasPrefix(code, "unavailable/"):
+		return http.StatusBadGateway
 	default:
 		return http.StatusInternalServerError
 	}
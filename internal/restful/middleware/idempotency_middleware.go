@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+This middleware makes POST endpoints safe to retry when the client sends an "Idempotency-Key" header.
+Logic:
+- Keys belong to the signed-in user (or are shared by anonymous callers), so two users never collide.
+- The first request with a key is fingerprinted (method, path, body) and runs; its response is kept.
+- A retry with the same key and fingerprint gets the kept response back, marked "Idempotent-Replayed: true".
+- A retry with the same key but a different fingerprint is rejected; so is one arriving while the first still runs.
+- Failed requests (errors or 5xx) are not kept: the key is released so the retry runs again.
+Requests without the header pass through untouched.
+*/
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	anonymousIdempotencyScope = "anonymous"
+)
+
+// Idempotent must be registered after Authentication on the routes it protects
+func Idempotent(store infra_interface.IdempotencyStore) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if _, sent := ginContext.Request.Header[IdempotencyKeyHeader]; !sent {
+			ginContext.Next()
+			return
+		}
+		key := strings.TrimSpace(ginContext.GetHeader(IdempotencyKeyHeader))
+		if key == "" || len(key) > maxIdempotencyKeyLength {
+			_ = ginContext.Error(core.Error.Invalid.IdempotencyKey)
+			ginContext.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(ginContext.Request.Body)
+		if err != nil {
+			_ = ginContext.Error(core.Error.Invalid.Request)
+			ginContext.Abort()
+			return
+		}
+		ginContext.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key = idempotencyScope(ginContext) + ":" + key
+		fingerprint := requestFingerprint(ginContext.Request, body)
+		record, claimed := store.Claim(key, fingerprint)
+		if !claimed {
+			switch {
+			case record.Fingerprint != fingerprint:
+				_ = ginContext.Error(core.Error.Invalid.IdempotencyKeyReused)
+			case record.Response == nil:
+				_ = ginContext.Error(core.Error.Conflict.IdempotencyInProgress)
+			default:
+				ginContext.Header(IdempotentReplayedHeader, "true")
+				ginContext.Data(record.Response.Status, record.Response.ContentType, record.Response.Body)
+			}
+			ginContext.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: ginContext.Writer}
+		ginContext.Writer = writer
+		completed := false
+		defer func() {
+			ginContext.Writer = writer.ResponseWriter
+			if !completed {
+				store.Release(key) // Also when the handler panicked
+			}
+		}()
+
+		ginContext.Next()
+
+		if len(ginContext.Errors) == 0 && writer.Written() && writer.Status() < http.StatusInternalServerError {
+			store.Complete(key, infra_interface.IdempotentResponse{
+				Status:      writer.Status(),
+				ContentType: writer.Header().Get("Content-Type"),
+				Body:        writer.body.Bytes(),
+			})
+			completed = true
+		}
+	}
+}
+
+func idempotencyScope(ginContext *gin.Context) string {
+	if value, exists := ginContext.Get(util.ClaimsContextKey); exists {
+		if claims, ok := value.(*infra_interface.JWTClaims); ok {
+			return claims.UserID
+		}
+	}
+	return anonymousIdempotencyScope
+}
+
+func requestFingerprint(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter copies the response body as it is written so it can be kept for replays
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (writer *recordingWriter) Write(data []byte) (int, error) {
+	writer.body.Write(data)
+	return writer.ResponseWriter.Write(data)
+}
+
+func (writer *recordingWriter) WriteString(data string) (int, error) {
+	writer.body.WriteString(data)
+	return writer.ResponseWriter.WriteString(data)
+}
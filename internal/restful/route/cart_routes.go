@@ -0,0 +1,51 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CartRoutes struct {
+	*Route[*handler.CartHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewCartRoutes(cartHandler *handler.CartHandler, router *router.Router, jwtManager infra_interface.JWTManager) *CartRoutes {
+	return &CartRoutes{
+		Route: &Route[*handler.CartHandler]{
+			Handler: cartHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *CartRoutes) Setup() {
+	// Guests and signed-in users alike
+	cart := routes.Router.Engine.Group(routes.Router.ApiPath+"/cart", middleware.OptionalAuthentication(routes.jwtManager))
+	{
+		cart.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Cart(core.GetHttpContext(ginContext))
+		})
+		cart.POST("/line", func(ginContext *gin.Context) {
+			routes.Handler.AddLine(core.GetHttpContext(ginContext))
+		})
+		cart.PUT("/line/:productId", func(ginContext *gin.Context) {
+			routes.Handler.UpdateLine(core.GetHttpContext(ginContext))
+		})
+		cart.DELETE("/line/:productId", func(ginContext *gin.Context) {
+			routes.Handler.RemoveLine(core.GetHttpContext(ginContext))
+		})
+		cart.PUT("/coupon", func(ginContext *gin.Context) {
+			routes.Handler.ApplyCoupon(core.GetHttpContext(ginContext))
+		})
+		cart.DELETE("/coupon", func(ginContext *gin.Context) {
+			routes.Handler.RemoveCoupon(core.GetHttpContext(ginContext))
+		})
+	}
+}
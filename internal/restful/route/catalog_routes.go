@@ -0,0 +1,42 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogRoutes struct {
+	*Route[*handler.CatalogHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewCatalogRoutes(catalogHandler *handler.CatalogHandler, router *router.Router, jwtManager infra_interface.JWTManager) *CatalogRoutes {
+	return &CatalogRoutes{
+		Route: &Route[*handler.CatalogHandler]{
+			Handler: catalogHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *CatalogRoutes) Setup() {
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/catalog",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		admin.POST("/import", func(ginContext *gin.Context) {
+			routes.Handler.Import(core.GetHttpContext(ginContext))
+		})
+		admin.GET("/export", func(ginContext *gin.Context) {
+			routes.Handler.Export(core.GetHttpContext(ginContext))
+		})
+	}
+}
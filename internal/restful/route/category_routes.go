@@ -0,0 +1,55 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryRoutes struct {
+	*Route[*handler.CategoryHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewCategoryRoutes(categoryHandler *handler.CategoryHandler, router *router.Router, jwtManager infra_interface.JWTManager) *CategoryRoutes {
+	return &CategoryRoutes{
+		Route: &Route[*handler.CategoryHandler]{
+			Handler: categoryHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *CategoryRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/category")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		api.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/category",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		admin.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		admin.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+		admin.GET("/:id/translations", func(ginContext *gin.Context) {
+			routes.Handler.Translations(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckoutRoutes struct {
+	*Route[*handler.CheckoutHandler]
+	jwtManager  infra_interface.JWTManager
+	idempotency infra_interface.IdempotencyStore
+}
+
+func NewCheckoutRoutes(checkoutHandler *handler.CheckoutHandler, router *router.Router, jwtManager infra_interface.JWTManager, idempotency infra_interface.IdempotencyStore) *CheckoutRoutes {
+	return &CheckoutRoutes{
+		Route: &Route[*handler.CheckoutHandler]{
+			Handler: checkoutHandler,
+			Router:  router,
+		},
+		jwtManager:  jwtManager,
+		idempotency: idempotency,
+	}
+}
+
+func (routes *CheckoutRoutes) Setup() {
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/checkout",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.POST("", middleware.Idempotent(routes.idempotency), func(ginContext *gin.Context) {
+			routes.Handler.Checkout(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClaimRoutes struct {
+	*Route[*handler.ClaimHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewClaimRoutes(claimHandler *handler.ClaimHandler, router *router.Router, jwtManager infra_interface.JWTManager) *ClaimRoutes {
+	return &ClaimRoutes{
+		Route: &Route[*handler.ClaimHandler]{
+			Handler: claimHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *ClaimRoutes) Setup() {
+	order := routes.Router.Engine.Group(routes.Router.ApiPath+"/order",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		order.POST("/:id/claim", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+	}
+
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/claim",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.MineDetails(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/photo", func(ginContext *gin.Context) {
+			routes.Handler.AddPhoto(core.GetHttpContext(ginContext))
+		})
+		// Served to the customer and to staff; the service checks which
+		customer.GET("/:id/photo/:photoId", func(ginContext *gin.Context) {
+			routes.Handler.Photo(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/claim/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/approve", func(ginContext *gin.Context) {
+			routes.Handler.Approve(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/reject", func(ginContext *gin.Context) {
+			routes.Handler.Reject(core.GetHttpContext(ginContext))
+		})
+	}
+}
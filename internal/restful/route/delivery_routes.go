@@ -0,0 +1,89 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliveryRoutes struct {
+	*Route[*handler.DeliveryHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewDeliveryRoutes(deliveryHandler *handler.DeliveryHandler, router *router.Router, jwtManager infra_interface.JWTManager) *DeliveryRoutes {
+	return &DeliveryRoutes{
+		Route: &Route[*handler.DeliveryHandler]{
+			Handler: deliveryHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *DeliveryRoutes) Setup() {
+	dispatch := routes.Router.Engine.Group(routes.Router.ApiPath+"/dispatch",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		dispatch.GET("/shipper", func(ginContext *gin.Context) {
+			routes.Handler.Shippers(core.GetHttpContext(ginContext))
+		})
+		dispatch.POST("/shipper", func(ginContext *gin.Context) {
+			routes.Handler.CreateShipper(core.GetHttpContext(ginContext))
+		})
+		dispatch.PUT("/shipper/:id", func(ginContext *gin.Context) {
+			routes.Handler.UpdateShipper(core.GetHttpContext(ginContext))
+		})
+		dispatch.GET("/order/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		dispatch.POST("/order/:id/assign", func(ginContext *gin.Context) {
+			routes.Handler.Assign(core.GetHttpContext(ginContext))
+		})
+		dispatch.GET("/cash-report", func(ginContext *gin.Context) {
+			routes.Handler.CashReport(core.GetHttpContext(ginContext))
+		})
+		dispatch.POST("/cash-report/:id/remit", func(ginContext *gin.Context) {
+			routes.Handler.Remit(core.GetHttpContext(ginContext))
+		})
+	}
+
+	// Proofs are served to the customer of the order, its shipper and staff; the service checks which
+	proofs := routes.Router.Engine.Group(routes.Router.ApiPath+"/delivery",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		proofs.GET("/:id/proof/:proofId", func(ginContext *gin.Context) {
+			routes.Handler.Proof(core.GetHttpContext(ginContext))
+		})
+	}
+
+	shipper := routes.Router.Engine.Group(routes.Router.ApiPath+"/shipper",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleShipper),
+	)
+	{
+		shipper.GET("/run-sheet", func(ginContext *gin.Context) {
+			routes.Handler.RunSheet(core.GetHttpContext(ginContext))
+		})
+		shipper.POST("/delivery/:id/pickup", func(ginContext *gin.Context) {
+			routes.Handler.PickUp(core.GetHttpContext(ginContext))
+		})
+		shipper.POST("/delivery/:id/proof", func(ginContext *gin.Context) {
+			routes.Handler.AddProof(core.GetHttpContext(ginContext))
+		})
+		shipper.POST("/delivery/:id/deliver", func(ginContext *gin.Context) {
+			routes.Handler.Deliver(core.GetHttpContext(ginContext))
+		})
+		shipper.POST("/delivery/:id/fail", func(ginContext *gin.Context) {
+			routes.Handler.Fail(core.GetHttpContext(ginContext))
+		})
+	}
+}
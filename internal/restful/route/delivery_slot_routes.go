@@ -0,0 +1,67 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliverySlotRoutes struct {
+	*Route[*handler.DeliverySlotHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewDeliverySlotRoutes(deliverySlotHandler *handler.DeliverySlotHandler, router *router.Router, jwtManager infra_interface.JWTManager) *DeliverySlotRoutes {
+	return &DeliverySlotRoutes{
+		Route: &Route[*handler.DeliverySlotHandler]{
+			Handler: deliverySlotHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *DeliverySlotRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/delivery-slot")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Available(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/delivery-slot",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/template", func(ginContext *gin.Context) {
+			routes.Handler.Templates(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/template", func(ginContext *gin.Context) {
+			routes.Handler.CreateTemplate(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/template/:id", func(ginContext *gin.Context) {
+			routes.Handler.UpdateTemplate(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/holiday", func(ginContext *gin.Context) {
+			routes.Handler.Holidays(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/holiday", func(ginContext *gin.Context) {
+			routes.Handler.CreateHoliday(core.GetHttpContext(ginContext))
+		})
+		staff.DELETE("/holiday/:id", func(ginContext *gin.Context) {
+			routes.Handler.DeleteHoliday(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliveryZoneRoutes struct {
+	*Route[*handler.DeliveryZoneHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewDeliveryZoneRoutes(deliveryZoneHandler *handler.DeliveryZoneHandler, router *router.Router, jwtManager infra_interface.JWTManager) *DeliveryZoneRoutes {
+	return &DeliveryZoneRoutes{
+		Route: &Route[*handler.DeliveryZoneHandler]{
+			Handler: deliveryZoneHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *DeliveryZoneRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/delivery-zone")
+	{
+		api.POST("/quote", func(ginContext *gin.Context) {
+			routes.Handler.Quote(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/delivery-zone",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InventoryRoutes struct {
+	*Route[*handler.InventoryHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewInventoryRoutes(inventoryHandler *handler.InventoryHandler, router *router.Router, jwtManager infra_interface.JWTManager) *InventoryRoutes {
+	return &InventoryRoutes{
+		Route: &Route[*handler.InventoryHandler]{
+			Handler: inventoryHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *InventoryRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/product")
+	{
+		api.GET("/:id/availability", func(ginContext *gin.Context) {
+			routes.Handler.Availability(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/inventory",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Levels(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/adjust", func(ginContext *gin.Context) {
+			routes.Handler.Adjust(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/reservation", func(ginContext *gin.Context) {
+			routes.Handler.Reservations(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/reservation", func(ginContext *gin.Context) {
+			routes.Handler.Reserve(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/reservation/:id/release", func(ginContext *gin.Context) {
+			routes.Handler.Release(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/reservation/:id/commit", func(ginContext *gin.Context) {
+			routes.Handler.Commit(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/transfer", func(ginContext *gin.Context) {
+			routes.Handler.Transfers(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/transfer", func(ginContext *gin.Context) {
+			routes.Handler.Transfer(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/transfer/:id/receive", func(ginContext *gin.Context) {
+			routes.Handler.ReceiveTransfer(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/transfer/:id/cancel", func(ginContext *gin.Context) {
+			routes.Handler.CancelTransfer(core.GetHttpContext(ginContext))
+		})
+	}
+}
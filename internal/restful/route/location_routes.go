@@ -0,0 +1,52 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationRoutes struct {
+	*Route[*handler.LocationHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewLocationRoutes(locationHandler *handler.LocationHandler, router *router.Router, jwtManager infra_interface.JWTManager) *LocationRoutes {
+	return &LocationRoutes{
+		Route: &Route[*handler.LocationHandler]{
+			Handler: locationHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *LocationRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/location")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		api.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/location",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin),
+	)
+	{
+		admin.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		admin.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+	}
+}
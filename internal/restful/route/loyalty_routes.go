@@ -0,0 +1,67 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoyaltyRoutes struct {
+	*Route[*handler.LoyaltyHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewLoyaltyRoutes(loyaltyHandler *handler.LoyaltyHandler, router *router.Router, jwtManager infra_interface.JWTManager) *LoyaltyRoutes {
+	return &LoyaltyRoutes{
+		Route: &Route[*handler.LoyaltyHandler]{
+			Handler: loyaltyHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *LoyaltyRoutes) Setup() {
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/loyalty",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/history", func(ginContext *gin.Context) {
+			routes.Handler.MyHistory(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/loyalty/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.POST("/run", func(ginContext *gin.Context) {
+			routes.Handler.Run(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:userId", func(ginContext *gin.Context) {
+			routes.Handler.Account(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:userId/history", func(ginContext *gin.Context) {
+			routes.Handler.History(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/loyalty/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin),
+	)
+	{
+		admin.POST("/:userId/adjust", func(ginContext *gin.Context) {
+			routes.Handler.Adjust(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderRoutes struct {
+	*Route[*handler.OrderHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewOrderRoutes(orderHandler *handler.OrderHandler, router *router.Router, jwtManager infra_interface.JWTManager) *OrderRoutes {
+	return &OrderRoutes{
+		Route: &Route[*handler.OrderHandler]{
+			Handler: orderHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *OrderRoutes) Setup() {
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/order",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.MineDetails(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/:id/timeline", func(ginContext *gin.Context) {
+			routes.Handler.MineTimeline(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/cancel", func(ginContext *gin.Context) {
+			routes.Handler.Cancel(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/order/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id/timeline", func(ginContext *gin.Context) {
+			routes.Handler.Timeline(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/transition", func(ginContext *gin.Context) {
+			routes.Handler.Transition(core.GetHttpContext(ginContext))
+		})
+	}
+}
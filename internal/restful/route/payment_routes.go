@@ -0,0 +1,64 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentRoutes struct {
+	*Route[*handler.PaymentHandler]
+	jwtManager  infra_interface.JWTManager
+	idempotency infra_interface.IdempotencyStore
+}
+
+func NewPaymentRoutes(paymentHandler *handler.PaymentHandler, router *router.Router, jwtManager infra_interface.JWTManager, idempotency infra_interface.IdempotencyStore) *PaymentRoutes {
+	return &PaymentRoutes{
+		Route: &Route[*handler.PaymentHandler]{
+			Handler: paymentHandler,
+			Router:  router,
+		},
+		jwtManager:  jwtManager,
+		idempotency: idempotency,
+	}
+}
+
+func (routes *PaymentRoutes) Setup() {
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/order",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.POST("/:id/payment", middleware.Idempotent(routes.idempotency), func(ginContext *gin.Context) {
+			routes.Handler.Pay(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/:id/payment", func(ginContext *gin.Context) {
+			routes.Handler.Payments(core.GetHttpContext(ginContext))
+		})
+	}
+
+	// Called by the payment gateway and the customer's browser on its way back; trusted by signature only
+	callback := routes.Router.Engine.Group(routes.Router.ApiPath + "/payment/callback")
+	{
+		callback.GET("/return", func(ginContext *gin.Context) {
+			routes.Handler.Return(core.GetHttpContext(ginContext))
+		})
+		callback.GET("/ipn", func(ginContext *gin.Context) {
+			routes.Handler.IPN(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/payment/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.POST("/:id/confirm", func(ginContext *gin.Context) {
+			routes.Handler.ConfirmTransfer(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductImageRoutes struct {
+	*Route[*handler.ProductImageHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewProductImageRoutes(productImageHandler *handler.ProductImageHandler, router *router.Router, jwtManager infra_interface.JWTManager) *ProductImageRoutes {
+	return &ProductImageRoutes{
+		Route: &Route[*handler.ProductImageHandler]{
+			Handler: productImageHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *ProductImageRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/product")
+	{
+		api.GET("/:id/image", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/product",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		admin.POST("/:id/image", func(ginContext *gin.Context) {
+			routes.Handler.Upload(core.GetHttpContext(ginContext))
+		})
+		admin.DELETE("/:id/image/:imageId", func(ginContext *gin.Context) {
+			routes.Handler.Delete(core.GetHttpContext(ginContext))
+		})
+	}
+
+	// Product images only; other stored files are served by the endpoints of what they belong to
+	media := routes.Router.Engine.Group(routes.Router.ApiPath + "/media")
+	{
+		media.GET("/*key", func(ginContext *gin.Context) {
+			routes.Handler.Media(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductRoutes struct {
+	*Route[*handler.ProductHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewProductRoutes(productHandler *handler.ProductHandler, router *router.Router, jwtManager infra_interface.JWTManager) *ProductRoutes {
+	return &ProductRoutes{
+		Route: &Route[*handler.ProductHandler]{
+			Handler: productHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *ProductRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/product")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		api.GET("/units", func(ginContext *gin.Context) {
+			routes.Handler.Units(core.GetHttpContext(ginContext))
+		})
+		api.GET("/attributes", func(ginContext *gin.Context) {
+			routes.Handler.Attributes(core.GetHttpContext(ginContext))
+		})
+		api.GET("/in-season", func(ginContext *gin.Context) {
+			routes.Handler.InSeason(core.GetHttpContext(ginContext))
+		})
+		api.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		api.POST("/:id/quote", func(ginContext *gin.Context) {
+			routes.Handler.Quote(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/product",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		admin.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		admin.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+		admin.GET("/:id/translations", func(ginContext *gin.Context) {
+			routes.Handler.Translations(core.GetHttpContext(ginContext))
+		})
+	}
+}
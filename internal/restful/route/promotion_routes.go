@@ -0,0 +1,55 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionRoutes struct {
+	*Route[*handler.PromotionHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewPromotionRoutes(promotionHandler *handler.PromotionHandler, router *router.Router, jwtManager infra_interface.JWTManager) *PromotionRoutes {
+	return &PromotionRoutes{
+		Route: &Route[*handler.PromotionHandler]{
+			Handler: promotionHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *PromotionRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/promotion")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Current(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/promotion/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderRoutes struct {
+	*Route[*handler.PurchaseOrderHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewPurchaseOrderRoutes(purchaseOrderHandler *handler.PurchaseOrderHandler, router *router.Router, jwtManager infra_interface.JWTManager) *PurchaseOrderRoutes {
+	return &PurchaseOrderRoutes{
+		Route: &Route[*handler.PurchaseOrderHandler]{
+			Handler: purchaseOrderHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *PurchaseOrderRoutes) Setup() {
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/purchase-order",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/send", func(ginContext *gin.Context) {
+			routes.Handler.Send(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/receipt", func(ginContext *gin.Context) {
+			routes.Handler.Receive(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/close", func(ginContext *gin.Context) {
+			routes.Handler.Close(core.GetHttpContext(ginContext))
+		})
+	}
+}
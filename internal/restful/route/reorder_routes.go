@@ -0,0 +1,51 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReorderRoutes struct {
+	*Route[*handler.ReorderHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewReorderRoutes(reorderHandler *handler.ReorderHandler, router *router.Router, jwtManager infra_interface.JWTManager) *ReorderRoutes {
+	return &ReorderRoutes{
+		Route: &Route[*handler.ReorderHandler]{
+			Handler: reorderHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *ReorderRoutes) Setup() {
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/inventory",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("/reorder-rule", func(ginContext *gin.Context) {
+			routes.Handler.Rules(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/reorder-rule", func(ginContext *gin.Context) {
+			routes.Handler.SetRule(core.GetHttpContext(ginContext))
+		})
+		staff.DELETE("/reorder-rule", func(ginContext *gin.Context) {
+			routes.Handler.DeleteRule(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/low-stock-alert", func(ginContext *gin.Context) {
+			routes.Handler.Alerts(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/low-stock-alert/check", func(ginContext *gin.Context) {
+			routes.Handler.CheckStock(core.GetHttpContext(ginContext))
+		})
+	}
+}
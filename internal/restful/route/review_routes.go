@@ -0,0 +1,76 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewRoutes struct {
+	*Route[*handler.ReviewHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewReviewRoutes(reviewHandler *handler.ReviewHandler, router *router.Router, jwtManager infra_interface.JWTManager) *ReviewRoutes {
+	return &ReviewRoutes{
+		Route: &Route[*handler.ReviewHandler]{
+			Handler: reviewHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *ReviewRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/product")
+	{
+		api.GET("/:id/review", func(ginContext *gin.Context) {
+			routes.Handler.ProductReviews(core.GetHttpContext(ginContext))
+		})
+	}
+
+	photos := routes.Router.Engine.Group(routes.Router.ApiPath+"/review",
+		middleware.OptionalAuthentication(routes.jwtManager),
+	)
+	{
+		photos.GET("/:id/photo/:photoId", func(ginContext *gin.Context) {
+			routes.Handler.Photo(core.GetHttpContext(ginContext))
+		})
+	}
+
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/review",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/mine", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/photo", func(ginContext *gin.Context) {
+			routes.Handler.AddPhoto(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/vote", func(ginContext *gin.Context) {
+			routes.Handler.Vote(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/review",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("/moderation", func(ginContext *gin.Context) {
+			routes.Handler.Moderation(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/moderate", func(ginContext *gin.Context) {
+			routes.Handler.Moderate(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -17,9 +17,61 @@ type Routes interface {
 	Setup()
 }
 
-func NewRoutesCollection(userRoutes *UserRoutes) RoutesCollection {
+func NewRoutesCollection(
+	userRoutes *UserRoutes,
+	productRoutes *ProductRoutes,
+	productImageRoutes *ProductImageRoutes,
+	categoryRoutes *CategoryRoutes,
+	translationRoutes *TranslationRoutes,
+	catalogRoutes *CatalogRoutes,
+	stockLotRoutes *StockLotRoutes,
+	reviewRoutes *ReviewRoutes,
+	inventoryRoutes *InventoryRoutes,
+	locationRoutes *LocationRoutes,
+	stockLedgerRoutes *StockLedgerRoutes,
+	supplierRoutes *SupplierRoutes,
+	purchaseOrderRoutes *PurchaseOrderRoutes,
+	reorderRoutes *ReorderRoutes,
+	cartRoutes *CartRoutes,
+	deliverySlotRoutes *DeliverySlotRoutes,
+	checkoutRoutes *CheckoutRoutes,
+	orderRoutes *OrderRoutes,
+	paymentRoutes *PaymentRoutes,
+	claimRoutes *ClaimRoutes,
+	storeCreditRoutes *StoreCreditRoutes,
+	deliveryZoneRoutes *DeliveryZoneRoutes,
+	deliveryRoutes *DeliveryRoutes,
+	subscriptionRoutes *SubscriptionRoutes,
+	promotionRoutes *PromotionRoutes,
+	loyaltyRoutes *LoyaltyRoutes,
+) RoutesCollection {
 	return RoutesCollection{
 		userRoutes,
+		productRoutes,
+		productImageRoutes,
+		categoryRoutes,
+		translationRoutes,
+		catalogRoutes,
+		stockLotRoutes,
+		reviewRoutes,
+		inventoryRoutes,
+		locationRoutes,
+		stockLedgerRoutes,
+		supplierRoutes,
+		purchaseOrderRoutes,
+		reorderRoutes,
+		cartRoutes,
+		deliverySlotRoutes,
+		checkoutRoutes,
+		orderRoutes,
+		paymentRoutes,
+		claimRoutes,
+		storeCreditRoutes,
+		deliveryZoneRoutes,
+		deliveryRoutes,
+		subscriptionRoutes,
+		promotionRoutes,
+		loyaltyRoutes,
 	}
 }
 
@@ -31,5 +83,30 @@ func (routesCollection RoutesCollection) Setup() {
 
 var RoutesModule = fx.Options(
 	fx.Provide(NewUserRoutes),
+	fx.Provide(NewProductRoutes),
+	fx.Provide(NewProductImageRoutes),
+	fx.Provide(NewCategoryRoutes),
+	fx.Provide(NewTranslationRoutes),
+	fx.Provide(NewCatalogRoutes),
+	fx.Provide(NewStockLotRoutes),
+	fx.Provide(NewReviewRoutes),
+	fx.Provide(NewInventoryRoutes),
+	fx.Provide(NewLocationRoutes),
+	fx.Provide(NewStockLedgerRoutes),
+	fx.Provide(NewSupplierRoutes),
+	fx.Provide(NewPurchaseOrderRoutes),
+	fx.Provide(NewReorderRoutes),
+	fx.Provide(NewCartRoutes),
+	fx.Provide(NewDeliverySlotRoutes),
+	fx.Provide(NewCheckoutRoutes),
+	fx.Provide(NewOrderRoutes),
+	fx.Provide(NewPaymentRoutes),
+	fx.Provide(NewClaimRoutes),
+	fx.Provide(NewStoreCreditRoutes),
+	fx.Provide(NewDeliveryZoneRoutes),
+	fx.Provide(NewDeliveryRoutes),
+	fx.Provide(NewSubscriptionRoutes),
+	fx.Provide(NewPromotionRoutes),
+	fx.Provide(NewLoyaltyRoutes),
 	fx.Provide(NewRoutesCollection),
 )
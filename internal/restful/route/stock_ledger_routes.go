@@ -0,0 +1,61 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockLedgerRoutes struct {
+	*Route[*handler.StockLedgerHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewStockLedgerRoutes(stockLedgerHandler *handler.StockLedgerHandler, router *router.Router, jwtManager infra_interface.JWTManager) *StockLedgerRoutes {
+	return &StockLedgerRoutes{
+		Route: &Route[*handler.StockLedgerHandler]{
+			Handler: stockLedgerHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *StockLedgerRoutes) Setup() {
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/inventory",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("/ledger", func(ginContext *gin.Context) {
+			routes.Handler.Movements(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/stocktake", func(ginContext *gin.Context) {
+			routes.Handler.Stocktakes(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/stocktake", func(ginContext *gin.Context) {
+			routes.Handler.CreateStocktake(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/stocktake/:id", func(ginContext *gin.Context) {
+			routes.Handler.Stocktake(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/stocktake/:id/apply", func(ginContext *gin.Context) {
+			routes.Handler.ApplyStocktake(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/inventory",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin),
+	)
+	{
+		admin.POST("/ledger/rebuild", func(ginContext *gin.Context) {
+			routes.Handler.Rebuild(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockLotRoutes struct {
+	*Route[*handler.StockLotHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewStockLotRoutes(stockLotHandler *handler.StockLotHandler, router *router.Router, jwtManager infra_interface.JWTManager) *StockLotRoutes {
+	return &StockLotRoutes{
+		Route: &Route[*handler.StockLotHandler]{
+			Handler: stockLotHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *StockLotRoutes) Setup() {
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/lot",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Receive(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/expiring", func(ginContext *gin.Context) {
+			routes.Handler.Expiring(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/pick", func(ginContext *gin.Context) {
+			routes.Handler.Pick(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/:id/write-off", func(ginContext *gin.Context) {
+			routes.Handler.WriteOff(core.GetHttpContext(ginContext))
+		})
+	}
+}
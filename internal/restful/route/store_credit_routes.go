@@ -0,0 +1,37 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StoreCreditRoutes struct {
+	*Route[*handler.StoreCreditHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewStoreCreditRoutes(storeCreditHandler *handler.StoreCreditHandler, router *router.Router, jwtManager infra_interface.JWTManager) *StoreCreditRoutes {
+	return &StoreCreditRoutes{
+		Route: &Route[*handler.StoreCreditHandler]{
+			Handler: storeCreditHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *StoreCreditRoutes) Setup() {
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/store-credit",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+	}
+}
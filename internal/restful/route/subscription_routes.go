@@ -0,0 +1,91 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubscriptionRoutes struct {
+	*Route[*handler.SubscriptionHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewSubscriptionRoutes(subscriptionHandler *handler.SubscriptionHandler, router *router.Router, jwtManager infra_interface.JWTManager) *SubscriptionRoutes {
+	return &SubscriptionRoutes{
+		Route: &Route[*handler.SubscriptionHandler]{
+			Handler: subscriptionHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *SubscriptionRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/subscription-plan")
+	{
+		api.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Plans(core.GetHttpContext(ginContext))
+		})
+	}
+
+	customer := routes.Router.Engine.Group(routes.Router.ApiPath+"/subscription",
+		middleware.Authentication(routes.jwtManager),
+	)
+	{
+		customer.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Subscribe(core.GetHttpContext(ginContext))
+		})
+		customer.GET("", func(ginContext *gin.Context) {
+			routes.Handler.Mine(core.GetHttpContext(ginContext))
+		})
+		customer.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.MineDetails(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/pause", func(ginContext *gin.Context) {
+			routes.Handler.Pause(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/resume", func(ginContext *gin.Context) {
+			routes.Handler.Resume(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/skip", func(ginContext *gin.Context) {
+			routes.Handler.Skip(core.GetHttpContext(ginContext))
+		})
+		customer.DELETE("/:id/skip/:date", func(ginContext *gin.Context) {
+			routes.Handler.Unskip(core.GetHttpContext(ginContext))
+		})
+		customer.POST("/:id/cancel", func(ginContext *gin.Context) {
+			routes.Handler.Cancel(core.GetHttpContext(ginContext))
+		})
+	}
+
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/subscription/manage",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/plan", func(ginContext *gin.Context) {
+			routes.Handler.AllPlans(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/plan", func(ginContext *gin.Context) {
+			routes.Handler.CreatePlan(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/plan/:id", func(ginContext *gin.Context) {
+			routes.Handler.UpdatePlan(core.GetHttpContext(ginContext))
+		})
+		staff.POST("/run", func(ginContext *gin.Context) {
+			routes.Handler.Run(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+	}
+}
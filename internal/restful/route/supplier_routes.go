@@ -0,0 +1,48 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierRoutes struct {
+	*Route[*handler.SupplierHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewSupplierRoutes(supplierHandler *handler.SupplierHandler, router *router.Router, jwtManager infra_interface.JWTManager) *SupplierRoutes {
+	return &SupplierRoutes{
+		Route: &Route[*handler.SupplierHandler]{
+			Handler: supplierHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *SupplierRoutes) Setup() {
+	staff := routes.Router.Engine.Group(routes.Router.ApiPath+"/supplier",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		staff.GET("", func(ginContext *gin.Context) {
+			routes.Handler.List(core.GetHttpContext(ginContext))
+		})
+		staff.POST("", func(ginContext *gin.Context) {
+			routes.Handler.Create(core.GetHttpContext(ginContext))
+		})
+		staff.GET("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Details(core.GetHttpContext(ginContext))
+		})
+		staff.PUT("/:id", func(ginContext *gin.Context) {
+			routes.Handler.Update(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/handler"
+	"veg-store-backend/internal/restful/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationRoutes struct {
+	*Route[*handler.TranslationHandler]
+	jwtManager infra_interface.JWTManager
+}
+
+func NewTranslationRoutes(translationHandler *handler.TranslationHandler, router *router.Router, jwtManager infra_interface.JWTManager) *TranslationRoutes {
+	return &TranslationRoutes{
+		Route: &Route[*handler.TranslationHandler]{
+			Handler: translationHandler,
+			Router:  router,
+		},
+		jwtManager: jwtManager,
+	}
+}
+
+func (routes *TranslationRoutes) Setup() {
+	api := routes.Router.Engine.Group(routes.Router.ApiPath + "/translation")
+	{
+		api.GET("/locales", func(ginContext *gin.Context) {
+			routes.Handler.Locales(core.GetHttpContext(ginContext))
+		})
+	}
+
+	admin := routes.Router.Engine.Group(routes.Router.ApiPath+"/translation",
+		middleware.Authentication(routes.jwtManager),
+		middleware.RequireRoles(model.RoleAdmin, model.RoleStaff),
+	)
+	{
+		admin.GET("/missing", func(ginContext *gin.Context) {
+			routes.Handler.Missing(core.GetHttpContext(ginContext))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func kilograms(base int64) model.Quantity {
+	return model.Quantity{Base: base, Unit: model.UnitKilogram}
+}
+
+func testCartPutAndRemove(test *testing.T) {
+	added := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	cart := model.Cart{Lines: []model.CartLine{{ProductID: "cabbage", Quantity: kilograms(500), AddedAt: added}}}
+	original := cart.Lines
+
+	cart.Put(model.CartLine{ProductID: "cabbage", Quantity: kilograms(1500), AddedAt: added.Add(time.Hour)})
+	assert.Len(test, cart.Lines, 1)
+	assert.Equal(test, int64(1500), cart.Lines[0].Quantity.Base)
+	assert.Equal(test, added, cart.Lines[0].AddedAt)
+	assert.Equal(test, int64(500), original[0].Quantity.Base) // rows are values: the stored slice is not touched
+
+	assert.False(test, cart.Remove("lettuce"))
+	assert.True(test, cart.Remove("cabbage"))
+	assert.Empty(test, cart.Lines)
+}
+
+func testCartMerge(test *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	cart := model.Cart{UserID: "user-1", Lines: []model.CartLine{
+		{ProductID: "cabbage", Quantity: kilograms(1000)},
+		{ProductID: "lettuce", Quantity: model.Quantity{Base: 2, Unit: model.UnitPiece}},
+	}}
+	guest := model.Cart{Token: "guest", Lines: []model.CartLine{
+		{ProductID: "cabbage", Quantity: model.Quantity{Base: 500, Unit: model.UnitGram}},
+		{ProductID: "lettuce", Quantity: kilograms(300)}, // measured differently: the user's line wins
+		{ProductID: "tomato", Quantity: kilograms(750)},
+	}}
+
+	cart.Merge(guest, now)
+	assert.Len(test, cart.Lines, 3)
+	cabbage, _ := cart.Line("cabbage")
+	assert.Equal(test, kilograms(1500), cabbage.Quantity)
+	lettuce, _ := cart.Line("lettuce")
+	assert.Equal(test, int64(2), lettuce.Quantity.Base)
+	tomato, _ := cart.Line("tomato")
+	assert.Equal(test, now, tomato.UpdatedAt)
+	assert.Equal(test, now, cart.UpdatedAt)
+}
+
+func testPricedCartReady(test *testing.T) {
+	cart := model.PricedCart{Lines: []model.PricedCartLine{
+		{Total: 14000, Issues: []model.CartIssue{model.CartIssuePriceChanged}},
+		{Total: 30000},
+	}}
+	assert.True(test, cart.Ready())
+	assert.Equal(test, model.Money(44000), cart.Subtotal())
+
+	cart.Lines[1].Issues = []model.CartIssue{model.CartIssueInsufficientStock}
+	assert.False(test, cart.Ready())
+	assert.False(test, (&model.PricedCart{}).Ready())
+}
+
+func TestCartModel(test *testing.T) {
+	test.Run("TestCartPutAndRemove", testCartPutAndRemove)
+	test.Run("TestCartMerge", testCartMerge)
+	test.Run("TestPricedCartReady", testPricedCartReady)
+}
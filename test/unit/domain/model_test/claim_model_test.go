@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// deliveredOrder delivered 1.5 kg of tomatoes for 45,000 VND and 3 lettuces for 45,000 VND at deliveredAt
+func deliveredOrder(deliveredAt time.Time) *model.Order {
+	return &model.Order{
+		Status: model.OrderDelivered,
+		Lines: []model.OrderLine{
+			{ProductID: "tomato", Name: "Tomato", Quantity: model.Quantity{Base: 1500, Unit: model.UnitKilogram}, Total: 45000},
+			{ProductID: "lettuce", Name: "Lettuce", Quantity: model.Quantity{Base: 3, Unit: model.UnitPiece}, Total: 45000},
+		},
+		History: []model.OrderEvent{{To: model.OrderConfirmed}, {From: model.OrderOutForDelivery, To: model.OrderDelivered, At: deliveredAt}},
+	}
+}
+
+func testNewClaimLine_pricesPartOfLine(test *testing.T) {
+	order := deliveredOrder(time.Now())
+
+	line, err := model.NewClaimLine(order, "tomato", model.Quantity{Base: 500, Unit: model.UnitGram}, model.ClaimBruised, 0)
+	assert.NoError(test, err)
+	assert.Equal(test, model.ClaimLine{
+		ProductID: "tomato",
+		Name:      "Tomato",
+		Quantity:  model.Quantity{Base: 500, Unit: model.UnitKilogram},
+		Reason:    model.ClaimBruised,
+		Amount:    15000,
+	}, line)
+
+	_, err = model.NewClaimLine(order, "tomato", model.Quantity{Base: 1100, Unit: model.UnitKilogram}, model.ClaimBruised, 500)
+	assert.ErrorIs(test, err, model.ErrClaimLines)
+	_, err = model.NewClaimLine(order, "lettuce", model.Quantity{Base: 1, Unit: model.UnitKilogram}, model.ClaimMissing, 0)
+	assert.Error(test, err)
+	_, err = model.NewClaimLine(order, "cabbage", model.Quantity{Base: 1, Unit: model.UnitPiece}, model.ClaimMissing, 0)
+	assert.ErrorIs(test, err, model.ErrClaimLines)
+
+	claims := []model.Claim{
+		{Status: model.ClaimApproved, Lines: []model.ClaimLine{line}},
+		{Status: model.ClaimRejected, Lines: []model.ClaimLine{line}},
+	}
+	assert.Equal(test, int64(500), model.ClaimedQuantity(claims, "tomato"))
+}
+
+func testClaimDecision_followsStatus(test *testing.T) {
+	now := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	claim := model.Claim{Status: model.ClaimPending, Claimed: 15000}
+
+	assert.ErrorIs(test, claim.StartRefund("staff-1", 20000, now), model.ErrRefundAmount)
+	assert.ErrorIs(test, claim.Approve("staff-1", model.ResolutionRefund, 15000, model.DispositionWriteOff, "", now), model.ErrClaimStatus)
+	assert.NoError(test, claim.StartRefund("staff-1", 10000, now))
+	assert.ErrorIs(test, claim.Reject("staff-2", "Too late", now), model.ErrClaimStatus)
+	claim.CancelRefund(now)
+	assert.Equal(test, model.ClaimPending, claim.Status)
+	assert.Zero(test, claim.Amount)
+
+	assert.NoError(test, claim.StartRefund("staff-1", 10000, now))
+	assert.NoError(test, claim.Approve("staff-1", model.ResolutionRefund, 10000, model.DispositionRestock, " Sorry ", now))
+	assert.Equal(test, model.ClaimApproved, claim.Status)
+	assert.Equal(test, "Sorry", claim.DecisionNote)
+
+	rejected := model.Claim{Status: model.ClaimPending}
+	assert.ErrorIs(test, rejected.Reject("staff-1", " ", now), model.ErrReasonRequired)
+	assert.NoError(test, rejected.Reject("staff-1", "Looks fresh on the photos", now))
+	assert.False(test, rejected.Open())
+}
+
+func testCheckClaimable_withinWindow(test *testing.T) {
+	now := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+
+	assert.NoError(test, model.CheckClaimable(deliveredOrder(now.Add(-47*time.Hour)), 48*time.Hour, now))
+	assert.ErrorIs(test, model.CheckClaimable(deliveredOrder(now.Add(-48*time.Hour)), 48*time.Hour, now), model.ErrClaimWindow)
+
+	order := deliveredOrder(now)
+	order.Status = model.OrderOutForDelivery
+	assert.ErrorIs(test, model.CheckClaimable(order, 48*time.Hour, now), model.ErrOrderStatus)
+}
+
+func TestClaimModel(test *testing.T) {
+	test.Run("TestNewClaimLine_pricesPartOfLine", testNewClaimLine_pricesPartOfLine)
+	test.Run("TestClaimDecision_followsStatus", testClaimDecision_followsStatus)
+	test.Run("TestCheckClaimable_withinWindow", testCheckClaimable_withinWindow)
+}
@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDelivery_needsProofAndExactCash(test *testing.T) {
+	now := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	delivery := model.Delivery{OrderID: "order-1", CODDue: 56000}
+
+	assert.NoError(test, delivery.Assign("shipper-1", "dispatcher-1", now))
+	assert.NoError(test, delivery.Assign("shipper-2", "dispatcher-1", now))
+	assert.ErrorIs(test, delivery.AddProof(model.DeliveryProof{Kind: model.ProofPhoto}, now), model.ErrDeliveryStatus)
+	assert.NoError(test, delivery.PickUp(now))
+	assert.ErrorIs(test, delivery.Assign("shipper-1", "dispatcher-1", now), model.ErrDeliveryStatus)
+
+	assert.ErrorIs(test, delivery.Deliver(56000, now), model.ErrProofRequired)
+	for range model.MaxDeliveryProofs {
+		assert.NoError(test, delivery.AddProof(model.DeliveryProof{Kind: model.ProofPhoto}, now))
+	}
+	assert.ErrorIs(test, delivery.AddProof(model.DeliveryProof{Kind: model.ProofSignature}, now), model.ErrTooManyDeliveryProofs)
+	assert.ErrorIs(test, delivery.Deliver(50000, now), model.ErrPaymentAmount)
+	assert.NoError(test, delivery.Deliver(56000, now))
+	assert.Equal(test, model.Money(56000), delivery.Outstanding())
+	assert.ErrorIs(test, delivery.Fail("Nobody home", now), model.ErrDeliveryStatus)
+
+	refused := model.Delivery{Status: model.DeliveryPickedUp}
+	assert.ErrorIs(test, refused.Fail(" ", now), model.ErrReasonRequired)
+	assert.NoError(test, refused.Fail("Nobody home", now))
+	assert.Equal(test, "Nobody home", refused.FailureReason)
+}
+
+func testNewCashReport_sumsTheDay(test *testing.T) {
+	report := model.NewCashReport(model.Shipper{ID: "shipper-1"}, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), []model.Delivery{
+		{Status: model.DeliveryDelivered, Collected: 56000, RemittedAt: time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)},
+		{Status: model.DeliveryDelivered, Collected: 28000},
+		{Status: model.DeliveryDelivered},
+		{Status: model.DeliveryFailed, FailureReason: "Refused"},
+	})
+
+	assert.Equal(test, 3, report.Delivered)
+	assert.Equal(test, 1, report.Failed)
+	assert.Equal(test, model.Money(84000), report.Collected)
+	assert.Equal(test, model.Money(56000), report.Remitted)
+	assert.Equal(test, model.Money(28000), report.Outstanding)
+}
+
+func testOrderTransition_letsAssignedShipperDeliver(test *testing.T) {
+	now := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	shipper := model.OrderActor{ID: "shipper-1", Roles: []string{model.RoleShipper}}
+	other := model.OrderActor{ID: "shipper-2", Roles: []string{model.RoleShipper}}
+	order := model.Order{UserID: "user-1", Status: model.OrderPacked, Shipper: &model.ShipperContact{ID: "shipper-1"}}
+
+	assert.ErrorIs(test, order.Transition(model.OrderOutForDelivery, other, "", now), model.ErrOrderTransitionActor)
+	assert.ErrorIs(test, order.Transition(model.OrderCancelled, shipper, "Rain", now), model.ErrOrderTransitionActor)
+	assert.NoError(test, order.Transition(model.OrderOutForDelivery, shipper, "", now))
+	assert.NoError(test, order.Transition(model.OrderDelivered, shipper, "", now))
+
+	// Shippers cannot move orders that are still being picked
+	order = model.Order{UserID: "user-1", Status: model.OrderConfirmed, Shipper: &model.ShipperContact{ID: "shipper-1"}}
+	assert.ErrorIs(test, order.Transition(model.OrderPicking, shipper, "", now), model.ErrOrderTransitionActor)
+}
+
+func TestDeliveryModel(test *testing.T) {
+	test.Run("TestDelivery_needsProofAndExactCash", testDelivery_needsProofAndExactCash)
+	test.Run("TestNewCashReport_sumsTheDay", testNewCashReport_sumsTheDay)
+	test.Run("TestOrderTransition_letsAssignedShipperDeliver", testOrderTransition_letsAssignedShipperDeliver)
+}
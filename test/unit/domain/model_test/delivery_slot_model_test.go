@@ -0,0 +1,109 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeliverySlotValidate(test *testing.T) {
+	start := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	slot := model.DeliverySlot{Start: start, End: start.Add(2 * time.Hour), Capacity: 1}
+	assert.NoError(test, slot.Validate())
+
+	slot.Capacity = 0
+	assert.ErrorIs(test, slot.Validate(), model.ErrInvalidDeliverySlot)
+	slot.WeightCapacity = 100000
+	assert.NoError(test, slot.Validate())
+	slot.CutoffAt = start.Add(time.Minute)
+	assert.ErrorIs(test, slot.Validate(), model.ErrInvalidDeliverySlot)
+	slot = model.DeliverySlot{Start: start, End: start, Capacity: 1}
+	assert.ErrorIs(test, slot.Validate(), model.ErrInvalidDeliverySlot)
+}
+
+func testDeliverySlotBooking(test *testing.T) {
+	start := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	slot := model.DeliverySlot{Start: start, End: start.Add(2 * time.Hour), Capacity: 2, Active: true}
+
+	assert.NoError(test, slot.Book(1500, now))
+	assert.NoError(test, slot.Book(500, now))
+	remaining, limited := slot.Remaining()
+	assert.Equal(test, 0, remaining)
+	assert.True(test, limited)
+	assert.ErrorIs(test, slot.Book(0, now), model.ErrSlotFull)
+
+	slot.Release(500, now)
+	remaining, _ = slot.Remaining()
+	assert.Equal(test, 1, remaining)
+	assert.Equal(test, int64(1500), slot.BookedWeight)
+	assert.ErrorIs(test, slot.Book(0, start), model.ErrSlotClosed) // too late once the slot has started
+	slot.Active = false
+	assert.ErrorIs(test, slot.Book(0, now), model.ErrSlotClosed)
+
+	slot.Release(1500, now)
+	slot.Release(0, now)
+	assert.Equal(test, 0, slot.Booked)
+	assert.Equal(test, int64(0), slot.BookedWeight)
+}
+
+func testDeliverySlotBooking_byWeightUntilCutoff(test *testing.T) {
+	start := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	slot := model.DeliverySlot{Start: start, End: start.Add(2 * time.Hour), CutoffAt: start.Add(-10 * time.Hour), WeightCapacity: 10000, Active: true}
+	now := start.Add(-12 * time.Hour)
+
+	_, limited := slot.Remaining()
+	assert.False(test, limited)
+	assert.NoError(test, slot.Book(7000, now))
+	assert.ErrorIs(test, slot.Book(3001, now), model.ErrSlotFull)
+	assert.NoError(test, slot.Book(3000, now))
+	assert.True(test, slot.Fits(0))
+	assert.False(test, slot.Fits(1))
+
+	slot.Release(3000, now)
+	assert.ErrorIs(test, slot.Book(1000, slot.CutoffAt), model.ErrSlotClosed)
+	slot.HolidayID = "tet"
+	assert.False(test, slot.Open(now))
+}
+
+func testSlotTemplate_opensSlotInStoreZone(test *testing.T) {
+	template := model.SlotTemplate{ID: "inner-morning", ZoneID: "inner-city", Weekday: time.Tuesday, Starts: 8 * 60, Ends: 10 * 60, Cutoff: 600, Capacity: 20}
+	assert.NoError(test, template.Validate())
+
+	date := time.Date(2026, 10, 20, 0, 0, 0, 0, util.StoreLocation)
+	slot := template.SlotOn(date, time.Now())
+	assert.Equal(test, "inner-morning-20261020", slot.ID)
+	assert.Equal(test, time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC), slot.Start.UTC()) // 08:00 in Ho Chi Minh City
+	assert.Equal(test, time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC), slot.CutoffAt.UTC())
+	assert.Equal(test, "inner-city", slot.ZoneID)
+
+	template.Ends = template.Starts
+	assert.ErrorIs(test, template.Validate(), model.ErrInvalidSlotTemplate)
+	template = model.SlotTemplate{Weekday: time.Monday, Starts: 0, Ends: 60}
+	assert.ErrorIs(test, template.Validate(), model.ErrInvalidSlotTemplate)
+}
+
+func testHoliday_closesDayInZone(test *testing.T) {
+	holiday := model.Holiday{Date: time.Date(2026, 4, 30, 0, 0, 0, 0, util.StoreLocation), ZoneID: "inner-city"}
+	// 23:30 on 29 April in UTC is already 30 April in Ho Chi Minh City
+	slot := model.DeliverySlot{ZoneID: "inner-city", Start: time.Date(2026, 4, 29, 23, 30, 0, 0, time.UTC)}
+	assert.True(test, holiday.Closes(&slot))
+
+	slot.ZoneID = ""
+	assert.False(test, holiday.Closes(&slot))
+	holiday.ZoneID = ""
+	assert.True(test, holiday.Closes(&slot))
+	slot.Start = time.Date(2026, 4, 30, 18, 0, 0, 0, time.UTC)
+	assert.False(test, holiday.Closes(&slot))
+}
+
+func TestDeliverySlotModel(test *testing.T) {
+	test.Run("TestDeliverySlotValidate", testDeliverySlotValidate)
+	test.Run("TestDeliverySlotBooking", testDeliverySlotBooking)
+	test.Run("TestDeliverySlotBooking_byWeightUntilCutoff", testDeliverySlotBooking_byWeightUntilCutoff)
+	test.Run("TestSlotTemplate_opensSlotInStoreZone", testSlotTemplate_opensSlotInStoreZone)
+	test.Run("TestHoliday_closesDayInZone", testHoliday_closesDayInZone)
+}
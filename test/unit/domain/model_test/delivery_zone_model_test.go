@@ -0,0 +1,98 @@
+package model_test
+
+import (
+	"testing"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeliveryZone_coversDistrictsAndWards(test *testing.T) {
+	zone := model.DeliveryZone{
+		ID:   "inner-city",
+		Name: "Inner city",
+		Areas: []model.ZoneArea{
+			{Province: "Hồ Chí Minh", District: "Quận 1"},
+			{Province: "Hồ Chí Minh", District: "Quận 3", Wards: []string{"Phường Võ Thị Sáu"}},
+		},
+	}
+	assert.NoError(test, zone.Validate())
+
+	assert.True(test, zone.Covers(model.Address{Province: "ho chi minh", District: "QUẬN  1", Ward: "Bến Nghé"}, util.FoldText))
+	assert.True(test, zone.Covers(model.Address{Province: "Hồ Chí Minh", District: "Quận 3", Ward: "phuong vo thi sau"}, util.FoldText))
+	assert.False(test, zone.Covers(model.Address{Province: "Hồ Chí Minh", District: "Quận 3", Ward: "Phường 9"}, util.FoldText))
+	assert.False(test, zone.Covers(model.Address{Province: "Hà Nội", District: "Quận 1"}, util.FoldText))
+
+	zone.ID = "Inner City"
+	assert.ErrorIs(test, zone.Validate(), model.ErrInvalidDeliveryZone)
+	zone = model.DeliveryZone{ID: "suburbs", Name: "Suburbs", Areas: []model.ZoneArea{{Province: "Hồ Chí Minh"}}}
+	assert.ErrorIs(test, zone.Validate(), model.ErrInvalidDeliveryZone)
+}
+
+func testDeliveryZone_containsPointsInPolygon(test *testing.T) {
+	// An L-shaped zone: the top-right corner of the square is cut out
+	zone := model.DeliveryZone{
+		ID:   "l-shape",
+		Name: "L shape",
+		Polygon: []model.GeoPoint{
+			{Latitude: 10.70, Longitude: 106.60}, {Latitude: 10.70, Longitude: 106.80}, {Latitude: 10.75, Longitude: 106.80},
+			{Latitude: 10.75, Longitude: 106.70}, {Latitude: 10.80, Longitude: 106.70}, {Latitude: 10.80, Longitude: 106.60},
+		},
+	}
+	assert.NoError(test, zone.Validate())
+
+	assert.True(test, zone.Contains(model.GeoPoint{Latitude: 10.72, Longitude: 106.75}))
+	assert.True(test, zone.Contains(model.GeoPoint{Latitude: 10.78, Longitude: 106.65}))
+	assert.False(test, zone.Contains(model.GeoPoint{Latitude: 10.78, Longitude: 106.75}))
+	assert.False(test, zone.Contains(model.GeoPoint{Latitude: 10.85, Longitude: 106.65}))
+
+	// Addresses are matched by their point, whatever their names say
+	inside := model.Address{District: "Somewhere", Point: &model.GeoPoint{Latitude: 10.72, Longitude: 106.65}}
+	assert.True(test, zone.Covers(inside, util.FoldText))
+	assert.False(test, zone.Covers(model.Address{District: "Somewhere"}, util.FoldText))
+
+	zone.Polygon = zone.Polygon[:2]
+	assert.ErrorIs(test, zone.Validate(), model.ErrInvalidDeliveryZone)
+	zone.Polygon = []model.GeoPoint{{Latitude: 91}, {Latitude: 10}, {Longitude: 10}}
+	assert.ErrorIs(test, zone.Validate(), model.ErrInvalidDeliveryZone)
+}
+
+func testDeliveryFeeRule_quotesSurchargesAndFreeShipping(test *testing.T) {
+	rule := model.DeliveryFeeRule{BaseFee: 15000, IncludedDistance: 3000, PerKm: 3000, IncludedWeight: 5000, PerKg: 2000, FreeFrom: 300000}
+	assert.NoError(test, rule.Validate())
+
+	quote := rule.Quote(120000, 4000, 2500)
+	assert.Equal(test, model.Money(15000), quote.Fee)
+
+	// Every started kilometer and kilogram past what is included is charged
+	quote = rule.Quote(120000, 5001, 5200)
+	assert.Equal(test, model.Money(9000), quote.DistanceSurcharge)
+	assert.Equal(test, model.Money(2000), quote.WeightSurcharge)
+	assert.Equal(test, model.Money(26000), quote.Fee)
+
+	quote = rule.Quote(300000, 5001, 5200)
+	assert.True(test, quote.FreeShipping)
+	assert.Equal(test, model.Money(0), quote.Fee)
+	assert.Equal(test, model.Money(26000), quote.BaseFee+quote.DistanceSurcharge+quote.WeightSurcharge)
+
+	rule.FreeFrom = 0
+	assert.False(test, rule.Quote(10000000, 0, 0).FreeShipping)
+	rule.PerKg = -1
+	assert.ErrorIs(test, rule.Validate(), model.ErrInvalidDeliveryFee)
+}
+
+func testGeoPoint_distance(test *testing.T) {
+	benThanh := model.GeoPoint{Latitude: 10.7725, Longitude: 106.6980}
+	notreDame := model.GeoPoint{Latitude: 10.7798, Longitude: 106.6990}
+	assert.InDelta(test, 818, benThanh.DistanceTo(notreDame), 10)
+	assert.Zero(test, benThanh.DistanceTo(benThanh))
+	assert.False(test, model.GeoPoint{Latitude: 10, Longitude: 181}.Valid())
+}
+
+func TestDeliveryZoneModel(test *testing.T) {
+	test.Run("TestDeliveryZone_coversDistrictsAndWards", testDeliveryZone_coversDistrictsAndWards)
+	test.Run("TestDeliveryZone_containsPointsInPolygon", testDeliveryZone_containsPointsInPolygon)
+	test.Run("TestDeliveryFeeRule_quotesSurchargesAndFreeShipping", testDeliveryFeeRule_quotesSurchargesAndFreeShipping)
+	test.Run("TestGeoPoint_distance", testGeoPoint_distance)
+}
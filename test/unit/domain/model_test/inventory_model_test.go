@@ -0,0 +1,38 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStockLevel_reserveRelease(test *testing.T) {
+	level := model.StockLevel{OnHand: 10}
+
+	assert.NoError(test, level.Reserve(4))
+	assert.Equal(test, int64(6), level.Available())
+	assert.ErrorIs(test, level.Reserve(7), model.ErrInsufficientStock)
+
+	level.Release(3)
+	assert.Equal(test, int64(9), level.Available())
+	assert.ErrorIs(test, level.Adjust(-11), model.ErrInsufficientStock)
+}
+
+func testReservation_closeOnlyOnce(test *testing.T) {
+	now := time.Now()
+	reservation := model.Reservation{Status: model.ReservationActive, ExpiresAt: now.Add(time.Minute)}
+
+	assert.False(test, reservation.Overdue(now))
+	assert.True(test, reservation.Overdue(now.Add(time.Minute)))
+
+	assert.NoError(test, reservation.Close(model.ReservationCommitted, now))
+	assert.ErrorIs(test, reservation.Close(model.ReservationReleased, now), model.ErrReservationClosed)
+	assert.False(test, reservation.Overdue(now.Add(time.Hour)))
+}
+
+func TestInventoryModel(test *testing.T) {
+	test.Run("TestStockLevel_reserveRelease", testStockLevel_reserveRelease)
+	test.Run("TestReservation_closeOnlyOnce", testReservation_closeOnlyOnce)
+}
@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testOpenAt_followsWeekdayHours(test *testing.T) {
+	store := model.Location{
+		ID:   "store-district-1",
+		Type: model.LocationStore,
+		Hours: []model.OpeningHours{
+			{Weekday: time.Monday, Opens: 7 * 60, Closes: 21*60 + 30},
+		},
+	}
+	// 2026-10-19 is a Monday
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2026, time.October, 19, hour, minute, 0, 0, util.StoreLocation)
+	}
+
+	assert.False(test, store.OpenAt(at(6, 59)))
+	assert.True(test, store.OpenAt(at(7, 0)))
+	assert.True(test, store.OpenAt(at(21, 29)))
+	assert.False(test, store.OpenAt(at(21, 30)))
+	assert.False(test, store.OpenAt(at(12, 0).AddDate(0, 0, 1)))
+}
+
+func testLocationValidate(test *testing.T) {
+	valid := model.Location{ID: "dark-store-thu-duc", Type: model.LocationDarkStore}
+	assert.NoError(test, valid.Validate())
+
+	badID := model.Location{ID: "Dark Store", Type: model.LocationDarkStore}
+	assert.ErrorIs(test, badID.Validate(), model.ErrInvalidLocation)
+
+	badType := model.Location{ID: "kiosk", Type: "kiosk"}
+	assert.ErrorIs(test, badType.Validate(), model.ErrInvalidLocation)
+
+	closesFirst := model.Location{
+		ID:    "store-district-3",
+		Type:  model.LocationStore,
+		Hours: []model.OpeningHours{{Weekday: time.Sunday, Opens: 20 * 60, Closes: 8 * 60}},
+	}
+	assert.ErrorIs(test, closesFirst.Validate(), model.ErrInvalidOpeningHours)
+}
+
+func testTransferClose_onlyOnce(test *testing.T) {
+	transfer := model.Transfer{Status: model.TransferInTransit}
+	now := time.Now()
+
+	assert.NoError(test, transfer.Close(model.TransferReceived, now))
+	assert.Equal(test, now, transfer.ReceivedAt)
+	assert.ErrorIs(test, transfer.Close(model.TransferCancelled, now), model.ErrTransferClosed)
+}
+
+func TestLocationModel(test *testing.T) {
+	test.Run("TestOpenAt_followsWeekdayHours", testOpenAt_followsWeekdayHours)
+	test.Run("TestLocationValidate", testLocationValidate)
+	test.Run("TestTransferClose_onlyOnce", testTransferClose_onlyOnce)
+}
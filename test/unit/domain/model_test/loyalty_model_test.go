@@ -0,0 +1,104 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var loyaltyTime = time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+
+func loyaltyProgram() model.LoyaltyProgram {
+	return model.LoyaltyProgram{
+		SpendPerPoint:    10000,
+		PointValue:       100,
+		MaxRedeemPercent: 50,
+		PointsTTL:        365 * 24 * time.Hour,
+		TierWindow:       365 * 24 * time.Hour,
+		Tiers: []model.TierRule{
+			{Tier: model.TierMember, EarnPercent: 100},
+			{Tier: model.TierSilver, MinPoints: 1000, EarnPercent: 125},
+			{Tier: model.TierGold, MinPoints: 5000, EarnPercent: 150},
+		},
+	}
+}
+
+func loyaltyEntry(kind model.LoyaltyEntryKind, points int64, createdAt time.Time, expiresAt time.Time) model.LoyaltyEntry {
+	return model.LoyaltyEntry{Kind: kind, Points: points, CreatedAt: createdAt, ExpiresAt: expiresAt}
+}
+
+func testLoyaltyProgram_tiersByPointsEarnedInWindow(test *testing.T) {
+	program := loyaltyProgram()
+	entries := []model.LoyaltyEntry{
+		loyaltyEntry(model.LoyaltyEarn, 4000, loyaltyTime.AddDate(-2, 0, 0), time.Time{}), // outside the window
+		loyaltyEntry(model.LoyaltyEarn, 800, loyaltyTime.AddDate(0, -1, 0), time.Time{}),
+		loyaltyEntry(model.LoyaltyAdjust, 500, loyaltyTime.AddDate(0, 0, -1), time.Time{}), // not earned on an order
+		loyaltyEntry(model.LoyaltyEarn, 300, loyaltyTime.AddDate(0, 0, -1), time.Time{}),
+	}
+
+	account := program.Account("user-1", entries, loyaltyTime)
+	assert.Equal(test, int64(5600), account.Balance)
+	assert.Equal(test, model.Money(560000), account.Worth)
+	assert.Equal(test, int64(1100), account.TierPoints)
+	assert.Equal(test, model.TierSilver, account.Tier.Tier)
+	assert.Equal(test, model.TierGold, account.Next.Tier)
+
+	tier, next := program.Tier(999)
+	assert.Equal(test, model.TierMember, tier.Tier)
+	assert.Equal(test, model.TierSilver, next.Tier)
+	tier, next = program.Tier(5000)
+	assert.Equal(test, model.TierGold, tier.Tier)
+	assert.Nil(test, next)
+}
+
+func testLoyaltyProgram_earnsByTierRoundingDown(test *testing.T) {
+	program := loyaltyProgram()
+	member, _ := program.Tier(0)
+	gold, _ := program.Tier(5000)
+
+	assert.Equal(test, int64(12), program.Earn(129000, member))
+	assert.Equal(test, int64(19), program.Earn(129000, gold))
+	assert.Equal(test, int64(0), program.Earn(9999, member))
+	assert.Equal(test, int64(0), program.Earn(-5000, gold))
+}
+
+func testLoyaltyProgram_redeemsUpToShareOfTotal(test *testing.T) {
+	program := loyaltyProgram()
+
+	value, err := program.Redeem(200, 300, 40000)
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(20000), value)
+
+	_, err = program.Redeem(201, 300, 40000)
+	assert.ErrorIs(test, err, model.ErrRedeemLimit)
+	_, err = program.Redeem(301, 300, 100000)
+	assert.ErrorIs(test, err, model.ErrInsufficientPoints)
+}
+
+func testExpiredPoints_takesFromEarliestExpiryFirst(test *testing.T) {
+	entries := []model.LoyaltyEntry{
+		loyaltyEntry(model.LoyaltyEarn, 100, loyaltyTime.AddDate(-1, 0, -10), loyaltyTime.AddDate(0, 0, -10)),
+		loyaltyEntry(model.LoyaltyAdjust, 50, loyaltyTime.AddDate(0, -6, 0), loyaltyTime.AddDate(0, 6, 0)),
+		loyaltyEntry(model.LoyaltyEarn, 80, loyaltyTime.AddDate(-1, 0, -5), loyaltyTime.AddDate(0, 0, -5)),
+		loyaltyEntry(model.LoyaltyRedeem, -120, loyaltyTime.AddDate(0, -2, 0), time.Time{}),
+	}
+
+	// The 120 spent came out of the first lot and 20 of the second, leaving 60 of it expired
+	assert.Equal(test, int64(60), model.ExpiredPoints(entries, loyaltyTime))
+	assert.Equal(test, int64(0), model.ExpiredPoints(entries, loyaltyTime.AddDate(0, 0, -11)))
+
+	// Once recorded, expired points are not counted again
+	entries = append(entries, loyaltyEntry(model.LoyaltyExpire, -60, loyaltyTime, time.Time{}))
+	assert.Equal(test, int64(0), model.ExpiredPoints(entries, loyaltyTime))
+	assert.Equal(test, int64(50), model.LoyaltyBalance(entries))
+	assert.Equal(test, int64(50), model.ExpiredPoints(entries, loyaltyTime.AddDate(0, 7, 0)))
+}
+
+func TestLoyaltyModel(test *testing.T) {
+	test.Run("TestLoyaltyProgram_tiersByPointsEarnedInWindow", testLoyaltyProgram_tiersByPointsEarnedInWindow)
+	test.Run("TestLoyaltyProgram_earnsByTierRoundingDown", testLoyaltyProgram_earnsByTierRoundingDown)
+	test.Run("TestLoyaltyProgram_redeemsUpToShareOfTotal", testLoyaltyProgram_redeemsUpToShareOfTotal)
+	test.Run("TestExpiredPoints_takesFromEarliestExpiryFirst", testExpiredPoints_takesFromEarliestExpiryFirst)
+}
@@ -0,0 +1,39 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSumMovements_perProductAndLocation(test *testing.T) {
+	sums := model.SumMovements([]model.Movement{
+		{ProductID: "lettuce", LocationID: "central-warehouse", Quantity: 10},
+		{ProductID: "lettuce", LocationID: "central-warehouse", Quantity: -4},
+		{ProductID: "lettuce", LocationID: "store-district-1", Quantity: 4},
+	})
+
+	assert.Equal(test, int64(6), sums[model.StockKey{ProductID: "lettuce", LocationID: "central-warehouse"}])
+	assert.Equal(test, int64(4), sums[model.StockKey{ProductID: "lettuce", LocationID: "store-district-1"}])
+	assert.Len(test, sums, 2)
+}
+
+func testStocktakeApply_onlyOnce(test *testing.T) {
+	stocktake := model.Stocktake{
+		Status: model.StocktakeCounted,
+		Lines:  []model.StocktakeLine{{ProductID: "lettuce", Expected: 10, Counted: 12}},
+	}
+	now := time.Now()
+
+	assert.Equal(test, int64(2), stocktake.Lines[0].Variance())
+	assert.NoError(test, stocktake.Apply("manager-1", now))
+	assert.Equal(test, "manager-1", stocktake.AppliedBy)
+	assert.ErrorIs(test, stocktake.Apply("manager-1", now), model.ErrStocktakeClosed)
+}
+
+func TestMovementModel(test *testing.T) {
+	test.Run("TestSumMovements_perProductAndLocation", testSumMovements_perProductAndLocation)
+	test.Run("TestStocktakeApply_onlyOnce", testStocktakeApply_onlyOnce)
+}
@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testOrderTransition_followsWorkflow(test *testing.T) {
+	now := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	staff := model.OrderActor{ID: "staff-1", Roles: []string{model.RoleStaff}}
+	order := model.Order{UserID: "user-1", Status: model.OrderConfirmed}
+
+	for _, status := range []model.OrderStatus{model.OrderPicking, model.OrderPacked, model.OrderOutForDelivery, model.OrderDelivered} {
+		assert.NoError(test, order.Transition(status, staff, "", now))
+	}
+	assert.Equal(test, model.OrderDelivered, order.Status)
+	assert.Len(test, order.History, 4)
+	assert.Equal(test, model.OrderEvent{From: model.OrderOutForDelivery, To: model.OrderDelivered, ActorID: "staff-1", At: now}, order.History[3])
+
+	// No skipping steps, no going back, nothing after the end
+	order = model.Order{UserID: "user-1", Status: model.OrderConfirmed}
+	assert.ErrorIs(test, order.Transition(model.OrderDelivered, staff, "", now), model.ErrOrderStatus)
+	order.Status = model.OrderPacked
+	assert.ErrorIs(test, order.Transition(model.OrderPicking, staff, "", now), model.ErrOrderStatus)
+	order.Status = model.OrderCancelled
+	assert.True(test, order.Status.Final())
+	assert.ErrorIs(test, order.Transition(model.OrderConfirmed, staff, "", now), model.ErrOrderStatus)
+	assert.Empty(test, order.History)
+}
+
+func testOrderTransition_checksActor(test *testing.T) {
+	now := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	customer := model.OrderActor{ID: "user-1", Roles: []string{model.RoleCustomer}}
+	stranger := model.OrderActor{ID: "user-2", Roles: []string{model.RoleCustomer}}
+
+	order := model.Order{UserID: "user-1", Status: model.OrderPendingPayment}
+	assert.ErrorIs(test, order.Transition(model.OrderConfirmed, customer, "", now), model.ErrOrderTransitionActor)
+	assert.NoError(test, order.Transition(model.OrderConfirmed, model.SystemOrderActor, "", now))
+
+	assert.ErrorIs(test, order.Transition(model.OrderCancelled, stranger, "Changed my mind", now), model.ErrOrderTransitionActor)
+	assert.ErrorIs(test, order.Transition(model.OrderCancelled, customer, "  ", now), model.ErrReasonRequired)
+	assert.NoError(test, order.Transition(model.OrderCancelled, customer, "Changed my mind", now))
+	assert.Equal(test, "Changed my mind", order.History[1].Reason)
+
+	// Once picking has started, only staff can call the order off
+	order = model.Order{UserID: "user-1", Status: model.OrderPicking}
+	assert.ErrorIs(test, order.Transition(model.OrderCancelled, customer, "Too late?", now), model.ErrOrderTransitionActor)
+}
+
+func TestOrderModel(test *testing.T) {
+	test.Run("TestOrderTransition_followsWorkflow", testOrderTransition_followsWorkflow)
+	test.Run("TestOrderTransition_checksActor", testOrderTransition_checksActor)
+}
@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCertifiedFor_ignoresExpiredCertificate(test *testing.T) {
+	product := model.Product{Certifications: []model.Certification{
+		{Scheme: model.CertificationVietGAP, ValidUntil: time.Date(2025, time.June, 30, 0, 0, 0, 0, util.StoreLocation)},
+		{Scheme: model.CertificationOrganic},
+	}}
+
+	assert.True(test, product.CertifiedFor(model.CertificationVietGAP, time.Date(2025, time.June, 30, 0, 0, 0, 0, util.StoreLocation)))
+	assert.False(test, product.CertifiedFor(model.CertificationVietGAP, time.Date(2025, time.July, 1, 0, 0, 0, 0, util.StoreLocation)))
+	assert.True(test, product.CertifiedFor(model.CertificationOrganic, time.Date(2030, time.January, 1, 0, 0, 0, 0, util.StoreLocation)))
+	assert.False(test, product.CertifiedFor(model.CertificationGlobalGAP, time.Date(2025, time.January, 1, 0, 0, 0, 0, util.StoreLocation)))
+}
+
+func testValidate_rejectsBadAttributes(test *testing.T) {
+	product := model.Product{
+		Price:     10000,
+		PriceUnit: model.UnitKilogram,
+		SaleRule:  model.SaleRule{Unit: model.UnitKilogram, MinQuantity: 500, Step: 500},
+	}
+
+	product.Nutrition = model.NutritionFacts{"caffeine": 1}
+	assert.ErrorIs(test, product.Validate(), model.ErrUnknownNutrient)
+
+	product.Nutrition = model.NutritionFacts{model.NutrientProtein: -1}
+	assert.ErrorIs(test, product.Validate(), model.ErrInvalidNutrient)
+
+	product.Nutrition = model.NutritionFacts{model.NutrientEnergy: 25, model.NutrientVitaminC: 36.6}
+	product.Allergens = []model.Allergen{"shellfish"}
+	assert.ErrorIs(test, product.Validate(), model.ErrUnknownAllergen)
+
+	product.Allergens = []model.Allergen{"celery"}
+	product.Certifications = []model.Certification{{Scheme: "fairtrade"}}
+	assert.ErrorIs(test, product.Validate(), model.ErrUnknownCertification)
+
+	product.Certifications = []model.Certification{{Scheme: model.CertificationGlobalGAP, Reference: "GGN-4063061"}}
+	assert.NoError(test, product.Validate())
+}
+
+func testFoldText_ignoresAccents(test *testing.T) {
+	assert.Equal(test, "rau muong da lat", util.FoldText("Rau Muống Đà Lạt"))
+}
+
+func TestProductAttributeModel(test *testing.T) {
+	test.Run("TestCertifiedFor_ignoresExpiredCertificate", testCertifiedFor_ignoresExpiredCertificate)
+	test.Run("TestValidate_rejectsBadAttributes", testValidate_rejectsBadAttributes)
+	test.Run("TestFoldText_ignoresAccents", testFoldText_ignoresAccents)
+}
@@ -0,0 +1,214 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionTime = time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+
+// promotionBasket holds 3 lettuces (45000), 1.5 kg of cabbage (42000) and 2 bunches of basil (20000)
+func promotionBasket() model.PromotionBasket {
+	return model.PromotionBasket{
+		Lines: []model.PromotionLine{
+			{ProductID: "lettuce", CategoryID: "leafy", Quantity: model.Quantity{Base: 3, Unit: model.UnitPiece}, Total: 45000},
+			{ProductID: "cabbage", CategoryID: "brassica", Quantity: model.Quantity{Base: 1500, Unit: model.UnitKilogram}, Total: 42000},
+			{ProductID: "basil", CategoryID: "herbs", Quantity: model.Quantity{Base: 2, Unit: model.UnitBunch}, Total: 20000},
+		},
+		Customer: model.PromotionCustomer{Segments: []model.CustomerSegment{model.SegmentNew}},
+		At:       promotionTime,
+	}
+}
+
+func promotion(id string, kind model.PromotionKind) model.Promotion {
+	return model.Promotion{ID: id, Name: id, Kind: kind, Percent: 100, Stackable: true, Active: true}
+}
+
+func testPromotion_validates(test *testing.T) {
+	valid := promotion("autumn", model.PromotionPercentage)
+	valid.Percent = 10
+	assert.NoError(test, valid.Validate())
+
+	for _, modify := range []func(promotion *model.Promotion){
+		func(promotion *model.Promotion) { promotion.Name = " " },
+		func(promotion *model.Promotion) { promotion.Kind = "mystery" },
+		func(promotion *model.Promotion) { promotion.Percent = 0 },
+		func(promotion *model.Promotion) { promotion.Percent = 101 },
+		func(promotion *model.Promotion) { promotion.Kind, promotion.Amount = model.PromotionFixed, 0 },
+		func(promotion *model.Promotion) {
+			promotion.Kind, promotion.Buy, promotion.Get = model.PromotionBuyXGetY, 2, 0
+		},
+		func(promotion *model.Promotion) {
+			promotion.Kind, promotion.CategoryIDs = model.PromotionFreeShipping, []string{"leafy"}
+		},
+		func(promotion *model.Promotion) { promotion.StartsAt, promotion.EndsAt = promotionTime, promotionTime },
+		func(promotion *model.Promotion) { promotion.PerUserLimit = -1 },
+		func(promotion *model.Promotion) { promotion.Code = "WELCOME 10" },
+	} {
+		invalid := valid
+		modify(&invalid)
+		assert.ErrorIs(test, invalid.Validate(), model.ErrInvalidPromotion)
+	}
+	assert.Equal(test, "WELCOME10", model.NormalizePromotionCode(" welcome10 "))
+}
+
+func testApplyPromotions_percentageOffCategoriesWithCap(test *testing.T) {
+	greens := promotion("greens", model.PromotionPercentage)
+	greens.Percent = 20
+	greens.CategoryIDs = []string{"leafy", "herbs"}
+
+	result := model.ApplyPromotions([]model.Promotion{greens}, promotionBasket())
+	assert.Equal(test, []model.Money{9000, 0, 4000}, result.LineDiscounts)
+	assert.Equal(test, model.Message{ID: "Promotion.PercentOff", Data: map[string]any{"Percent": 20, "Count": 2}}, result.Applied[0].Explanation)
+
+	// Capped, the cap is shared out in proportion to the uncapped discounts
+	greens.MaxDiscount = 10000
+	result = model.ApplyPromotions([]model.Promotion{greens}, promotionBasket())
+	assert.Equal(test, []model.Money{6923, 0, 3077}, result.LineDiscounts)
+	assert.Equal(test, model.Money(10000), result.Discount)
+	assert.Equal(test, model.Message{ID: "Promotion.PercentOffCapped", Data: map[string]any{
+		"Percent": 20, "Count": 2, "MaxDiscount": model.Money(10000),
+	}}, result.Applied[0].Explanation)
+	assert.Equal(test, model.Money(97000), result.Total())
+}
+
+func testApplyPromotions_fixedNeverExceedsEligibleLines(test *testing.T) {
+	cabbage := promotion("cabbage", model.PromotionFixed)
+	cabbage.Amount = 50000
+	cabbage.ProductIDs = []string{"cabbage"}
+
+	result := model.ApplyPromotions([]model.Promotion{cabbage}, promotionBasket())
+	assert.Equal(test, []model.Money{0, 42000, 0}, result.LineDiscounts)
+	assert.Equal(test, model.Message{ID: "Promotion.AmountOff", Data: map[string]any{"Discount": model.Money(42000), "Count": 1}}, result.Applied[0].Explanation)
+
+	cabbage.ProductIDs = []string{"carrot"}
+	result = model.ApplyPromotions([]model.Promotion{cabbage}, promotionBasket())
+	assert.Empty(test, result.Applied)
+	assert.Equal(test, model.RejectedNothingToGive, result.Rejected[0].Reason)
+}
+
+func testApplyPromotions_buyXGetYDiscountsCheapestCountedItems(test *testing.T) {
+	// 15000, 15000, 15000 | 10000, 10000: the first three make a group and its cheapest lettuce is free.
+	// The cabbage is weighed, so it is not counted.
+	threeForTwo := promotion("three-for-two", model.PromotionBuyXGetY)
+	threeForTwo.Buy, threeForTwo.Get = 2, 1
+
+	result := model.ApplyPromotions([]model.Promotion{threeForTwo}, promotionBasket())
+	assert.Equal(test, []model.Money{15000, 0, 0}, result.LineDiscounts)
+	assert.Equal(test, model.Message{ID: "Promotion.BuyGetFree", Data: map[string]any{
+		"Buy": 2, "Get": 1, "Discount": model.Money(15000),
+	}}, result.Applied[0].Explanation)
+
+	// 15000, 15000 | 15000, 10000 | 10000: half off the second item of each pair
+	secondHalfPrice := promotion("second-half-price", model.PromotionBuyXGetY)
+	secondHalfPrice.Buy, secondHalfPrice.Get, secondHalfPrice.Percent = 1, 1, 50
+	result = model.ApplyPromotions([]model.Promotion{secondHalfPrice}, promotionBasket())
+	assert.Equal(test, []model.Money{7500, 0, 5000}, result.LineDiscounts)
+	assert.Equal(test, model.Message{ID: "Promotion.BuyGetPercentOff", Data: map[string]any{
+		"Buy": 1, "Get": 1, "Percent": 50, "Discount": model.Money(12500),
+	}}, result.Applied[0].Explanation)
+}
+
+func testApplyPromotions_freeShippingAboveThreshold(test *testing.T) {
+	freeDelivery := promotion("free-delivery", model.PromotionFreeShipping)
+	freeDelivery.MinSubtotal = 100000
+	basket := promotionBasket()
+	basket.DeliveryFee = 20000
+
+	result := model.ApplyPromotions([]model.Promotion{freeDelivery}, basket)
+	assert.True(test, result.FreeShipping)
+	assert.Equal(test, model.Money(20000), result.ShippingDiscount)
+	assert.Equal(test, model.Money(107000), result.Total())
+	assert.Equal(test, model.Message{ID: "Promotion.FreeDeliveryFrom", Data: map[string]any{
+		"MinSubtotal": model.Money(100000),
+	}}, result.Applied[0].Explanation)
+
+	// Only one waives the fee, and the threshold looks at the subtotal
+	second := promotion("second", model.PromotionFreeShipping)
+	result = model.ApplyPromotions([]model.Promotion{second, freeDelivery}, basket)
+	assert.Equal(test, model.Money(20000), result.ShippingDiscount)
+	assert.Equal(test, []model.RejectedPromotion{{PromotionID: "second", Name: "second", Reason: model.RejectedNothingToGive}}, result.Rejected)
+
+	freeDelivery.MinSubtotal = 200000
+	result = model.ApplyPromotions([]model.Promotion{freeDelivery}, basket)
+	assert.False(test, result.FreeShipping)
+	assert.Equal(test, model.RejectedBelowMinimum, result.Rejected[0].Reason)
+}
+
+func testApplyPromotions_stacksByPriority(test *testing.T) {
+	welcome := promotion("welcome", model.PromotionFixed)
+	welcome.Code, welcome.Amount, welcome.FirstOrderOnly, welcome.Priority = "WELCOME", 20000, true, 10
+	autumn := promotion("autumn", model.PromotionPercentage)
+	autumn.Percent, autumn.Priority = 10, 5
+	flash := promotion("flash", model.PromotionPercentage)
+	flash.Percent, flash.Priority, flash.Stackable = 50, 1, false
+	basket := promotionBasket()
+	basket.Code = "welcome"
+
+	// The coupon goes first; the autumn sale takes 10% of what it left; the flash sale does not combine
+	result := model.ApplyPromotions([]model.Promotion{flash, autumn, welcome}, basket)
+	assert.Equal(test, []string{"welcome", "autumn"}, []string{result.Applied[0].PromotionID, result.Applied[1].PromotionID})
+	assert.Equal(test, model.Money(20000), result.Applied[0].Discount)
+	assert.Equal(test, model.Money(8700), result.Applied[1].Discount)
+	assert.Equal(test, []model.Money{8411 + 3659, 7850 + 3415, 3739 + 1626}, result.LineDiscounts)
+	assert.Equal(test, model.RejectedNotCombinable, result.Rejected[0].Reason)
+
+	// First the flash sale applies alone
+	flash.Priority = 20
+	result = model.ApplyPromotions([]model.Promotion{flash, autumn, welcome}, basket)
+	assert.Len(test, result.Applied, 1)
+	assert.Equal(test, model.Money(53500), result.Discount)
+	assert.Equal(test, model.RejectedNotCombinable, result.Rejected[0].Reason)
+	assert.Equal(test, model.RejectedNotCombinable, result.Rejected[1].Reason)
+
+	// Without its code, or for a customer who ordered before, the coupon does not apply
+	basket.Code = ""
+	result = model.ApplyPromotions([]model.Promotion{autumn, welcome}, basket)
+	assert.Len(test, result.Applied, 1)
+	assert.Empty(test, result.Rejected)
+	basket.Code = "WELCOME"
+	basket.Customer.Orders = 1
+	result = model.ApplyPromotions([]model.Promotion{autumn, welcome}, basket)
+	assert.Equal(test, model.RejectedFirstOrderOnly, result.Rejected[0].Reason)
+}
+
+func testApplyPromotions_explainsIneligibility(test *testing.T) {
+	notStarted := promotion("a-not-started", model.PromotionFreeShipping)
+	notStarted.StartsAt = promotionTime.Add(time.Hour)
+	ended := promotion("b-ended", model.PromotionFreeShipping)
+	ended.EndsAt = promotionTime
+	subscribers := promotion("c-subscribers", model.PromotionFreeShipping)
+	subscribers.Segments = []model.CustomerSegment{model.SegmentSubscriber}
+	oncePerCustomer := promotion("d-once-per-customer", model.PromotionFreeShipping)
+	oncePerCustomer.PerUserLimit = 1
+	firstHundred := promotion("e-first-hundred", model.PromotionFreeShipping)
+	firstHundred.UsageLimit = 100
+	inactive := promotion("f-inactive", model.PromotionFreeShipping)
+	inactive.Active = false
+
+	basket := promotionBasket()
+	basket.Customer.Uses = map[string]int{"d-once-per-customer": 1}
+	basket.Redeemed = map[string]int{"d-once-per-customer": 1, "e-first-hundred": 100}
+	result := model.ApplyPromotions([]model.Promotion{inactive, firstHundred, oncePerCustomer, subscribers, ended, notStarted}, basket)
+	assert.Empty(test, result.Applied)
+	reasons := make([]model.PromotionRejection, 0, len(result.Rejected))
+	for _, rejected := range result.Rejected {
+		reasons = append(reasons, rejected.Reason)
+	}
+	assert.Equal(test, []model.PromotionRejection{
+		model.RejectedNotStarted, model.RejectedEnded, model.RejectedSegment, model.RejectedUserLimit, model.RejectedUsageLimit,
+	}, reasons)
+}
+
+func TestPromotionModel(test *testing.T) {
+	test.Run("TestPromotion_validates", testPromotion_validates)
+	test.Run("TestApplyPromotions_percentageOffCategoriesWithCap", testApplyPromotions_percentageOffCategoriesWithCap)
+	test.Run("TestApplyPromotions_fixedNeverExceedsEligibleLines", testApplyPromotions_fixedNeverExceedsEligibleLines)
+	test.Run("TestApplyPromotions_buyXGetYDiscountsCheapestCountedItems", testApplyPromotions_buyXGetYDiscountsCheapestCountedItems)
+	test.Run("TestApplyPromotions_freeShippingAboveThreshold", testApplyPromotions_freeShippingAboveThreshold)
+	test.Run("TestApplyPromotions_stacksByPriority", testApplyPromotions_stacksByPriority)
+	test.Run("TestApplyPromotions_explainsIneligibility", testApplyPromotions_explainsIneligibility)
+}
@@ -0,0 +1,59 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSupplierValidate(test *testing.T) {
+	supplier := model.Supplier{
+		ID:       "cho-dau-moi-wholesale",
+		Type:     model.SupplierWholesaler,
+		Products: []model.SuppliedProduct{{ProductID: "cabbage", CostPrice: 12000}},
+	}
+	assert.NoError(test, supplier.Validate())
+	terms, ok := supplier.Supplies("cabbage")
+	assert.True(test, ok)
+	assert.Equal(test, model.Money(12000), terms.CostPrice)
+
+	supplier.Products = append(supplier.Products, model.SuppliedProduct{ProductID: "cabbage", CostPrice: 11000})
+	assert.ErrorIs(test, supplier.Validate(), model.ErrInvalidSupplier)
+
+	farAway := model.Supplier{ID: "far-away", Type: model.SupplierFarm, LeadTimeDays: model.MaxLeadTimeDays + 1}
+	assert.ErrorIs(test, farAway.Validate(), model.ErrInvalidSupplier)
+}
+
+func testPurchaseOrderReceive_allOrNothing(test *testing.T) {
+	order := model.PurchaseOrder{
+		Status: model.PurchaseOrderSent,
+		Lines: []model.PurchaseOrderLine{
+			{ProductID: "cabbage", Unit: model.UnitKilogram, Ordered: 10000, UnitCost: 12000},
+			{ProductID: "lettuce", Unit: model.UnitPiece, Ordered: 20, UnitCost: 5000},
+		},
+	}
+	assert.Equal(test, model.Money(220000), order.Total())
+
+	err := order.Receive(model.GoodsReceipt{Lines: []model.GoodsReceiptLine{
+		{ProductID: "cabbage", Quantity: 10000},
+		{ProductID: "lettuce", Quantity: 21},
+	}})
+	assert.ErrorIs(test, err, model.ErrInvalidReceipt)
+	assert.Equal(test, int64(0), order.Lines[0].Received)
+	assert.Empty(test, order.Receipts)
+
+	assert.NoError(test, order.Receive(model.GoodsReceipt{Lines: []model.GoodsReceiptLine{{ProductID: "cabbage", Quantity: 10000}}}))
+	assert.Equal(test, model.PurchaseOrderPartiallyReceived, order.Status)
+
+	// The lettuces will not come: close short
+	assert.NoError(test, order.Close(time.Now()))
+	assert.Equal(test, model.PurchaseOrderClosed, order.Status)
+	assert.ErrorIs(test, order.Receive(model.GoodsReceipt{}), model.ErrPurchaseOrderStatus)
+}
+
+func TestPurchaseOrderModel(test *testing.T) {
+	test.Run("TestSupplierValidate", testSupplierValidate)
+	test.Run("TestPurchaseOrderReceive_allOrNothing", testPurchaseOrderReceive_allOrNothing)
+}
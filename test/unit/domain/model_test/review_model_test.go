@@ -0,0 +1,52 @@
+package model_test
+
+import (
+	"testing"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSummarizeRatings_countsApprovedOnly(test *testing.T) {
+	reviews := []model.Review{
+		{Rating: 5, Status: model.ReviewApproved},
+		{Rating: 4, Status: model.ReviewApproved},
+		{Rating: 4, Status: model.ReviewApproved},
+		{Rating: 1, Status: model.ReviewPending},
+		{Rating: 1, Status: model.ReviewRejected},
+	}
+
+	summary := model.SummarizeRatings(reviews)
+	assert.Equal(test, 3, summary.Count)
+	assert.Equal(test, 4.3, summary.Average)
+	assert.Equal(test, [5]int{0, 0, 0, 2, 1}, summary.Histogram)
+	assert.Equal(test, model.RatingSummary{}, model.SummarizeRatings(nil))
+}
+
+func testVote_replacesPreviousVote(test *testing.T) {
+	review := model.Review{UserID: "author"}
+
+	assert.NoError(test, review.Vote("u1", true))
+	assert.NoError(test, review.Vote("u2", true))
+	assert.NoError(test, review.Vote("u1", false))
+	helpful, unhelpful := review.HelpfulVotes()
+	assert.Equal(test, 1, helpful)
+	assert.Equal(test, 1, unhelpful)
+
+	assert.ErrorIs(test, review.Vote("author", true), model.ErrVoteOnOwnReview)
+}
+
+func testValidate_rejectsBadRating(test *testing.T) {
+	review := model.Review{Rating: 6}
+	assert.ErrorIs(test, review.Validate(), model.ErrInvalidRating)
+
+	review.Rating = 3
+	review.Photos = make([]model.ReviewPhoto, model.MaxReviewPhotos+1)
+	assert.ErrorIs(test, review.Validate(), model.ErrTooManyPhotos)
+}
+
+func TestReviewModel(test *testing.T) {
+	test.Run("TestSummarizeRatings_countsApprovedOnly", testSummarizeRatings_countsApprovedOnly)
+	test.Run("TestVote_replacesPreviousVote", testVote_replacesPreviousVote)
+	test.Run("TestValidate_rejectsBadRating", testValidate_rejectsBadRating)
+}
@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSeasonWindow_wrapsNewYear(test *testing.T) {
+	winter := model.SeasonWindow{From: time.November, To: time.February}
+
+	assert.True(test, winter.Covers(time.December))
+	assert.True(test, winter.Covers(time.January))
+	assert.False(test, winter.Covers(time.June))
+}
+
+func testInSeason_withoutWindows_allYear(test *testing.T) {
+	product := model.Product{Origins: []string{"lam-dong"}}
+
+	assert.True(test, product.InSeason(time.July))
+	assert.True(test, product.InSeasonFrom(time.July, "lam-dong"))
+	assert.False(test, product.InSeasonFrom(time.July, "ha-noi"))
+}
+
+func testInSeasonFrom_usesWindowRegions(test *testing.T) {
+	cabbage := model.Product{
+		Origins: []string{"lam-dong", "ha-noi"},
+		Seasons: []model.SeasonWindow{
+			{From: time.January, To: time.December, Regions: []string{"lam-dong"}},
+			{From: time.November, To: time.February, Regions: []string{"ha-noi"}},
+		},
+	}
+
+	assert.True(test, cabbage.InSeasonFrom(time.July, "lam-dong"))
+	assert.False(test, cabbage.InSeasonFrom(time.July, "ha-noi"))
+	assert.True(test, cabbage.InSeasonFrom(time.December, "ha-noi"))
+}
+
+func testValidate_rejectsBadSeasonality(test *testing.T) {
+	product := model.Product{
+		Price:     10000,
+		PriceUnit: model.UnitKilogram,
+		SaleRule:  model.SaleRule{Unit: model.UnitKilogram, MinQuantity: 500, Step: 500},
+	}
+
+	product.Seasons = []model.SeasonWindow{{From: 0, To: 13}}
+	assert.ErrorIs(test, product.Validate(), model.ErrInvalidSeason)
+
+	product.Seasons = nil
+	product.Origins = []string{"Lâm Đồng"}
+	assert.ErrorIs(test, product.Validate(), model.ErrInvalidRegion)
+}
+
+func TestSeasonModel(test *testing.T) {
+	test.Run("TestSeasonWindow_wrapsNewYear", testSeasonWindow_wrapsNewYear)
+	test.Run("TestInSeason_withoutWindows_allYear", testInSeason_withoutWindows_allYear)
+	test.Run("TestInSeasonFrom_usesWindowRegions", testInSeasonFrom_usesWindowRegions)
+	test.Run("TestValidate_rejectsBadSeasonality", testValidate_rejectsBadSeasonality)
+}
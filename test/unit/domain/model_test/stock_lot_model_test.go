@@ -0,0 +1,54 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var today = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+func lot(id string, remaining int64, bestBeforeInDays int) model.StockLot {
+	return model.StockLot{
+		ID:         id,
+		Remaining:  remaining,
+		Status:     model.LotActive,
+		BestBefore: today.AddDate(0, 0, bestBeforeInDays),
+	}
+}
+
+func testAllocateFEFO_earliestExpiryFirst(test *testing.T) {
+	lots := []model.StockLot{lot("late", 1000, 5), lot("early", 300, 1), lot("expired", 900, -1)}
+
+	allocations, err := model.AllocateFEFO(lots, 800, today)
+	assert.NoError(test, err)
+	assert.Equal(test, []model.LotAllocation{
+		{LotID: "early", Quantity: 300},
+		{LotID: "late", Quantity: 500},
+	}, allocations)
+}
+
+func testAllocateFEFO_insufficient_fail(test *testing.T) {
+	writtenOff := lot("written-off", 5000, 3)
+	writtenOff.Status = model.LotWrittenOff
+	lots := []model.StockLot{lot("a", 300, 0), writtenOff}
+
+	_, err := model.AllocateFEFO(lots, 301, today)
+	assert.ErrorIs(test, err, model.ErrInsufficientStock)
+}
+
+func testStockLot_expiresAfterBestBeforeDay(test *testing.T) {
+	bestBeforeToday := lot("a", 1, 0)
+	assert.False(test, bestBeforeToday.Expired(today))
+	assert.True(test, bestBeforeToday.Expired(today.AddDate(0, 0, 1)))
+	inTwoDays := lot("b", 1, 2)
+	assert.Equal(test, 2, inTwoDays.DaysLeft(today))
+}
+
+func TestStockLotModel(test *testing.T) {
+	test.Run("TestAllocateFEFO_earliestExpiryFirst", testAllocateFEFO_earliestExpiryFirst)
+	test.Run("TestAllocateFEFO_insufficient_fail", testAllocateFEFO_insufficient_fail)
+	test.Run("TestStockLot_expiresAfterBestBeforeDay", testStockLot_expiresAfterBestBeforeDay)
+}
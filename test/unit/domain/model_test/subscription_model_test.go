@@ -0,0 +1,82 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSubscriptionPlan_validatesBox(test *testing.T) {
+	kilo, _ := model.ParseQuantity("1", model.UnitKilogram)
+	plan := model.SubscriptionPlan{
+		Name:        "Family box",
+		Items:       []model.BoxItem{{ProductID: "cabbage", Quantity: kilo, Substitutes: []string{"bok-choy"}}},
+		Frequency:   model.FrequencyWeekly,
+		DeliveryDay: time.Friday,
+	}
+	assert.NoError(test, plan.Validate())
+	// Tuesday 20/10/2026 to the Friday of the same week, and a Friday to itself
+	assert.Equal(test, time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC), plan.FirstDelivery(time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(test, time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC), plan.FirstDelivery(time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)))
+
+	plan.Items = append(plan.Items, model.BoxItem{ProductID: "cabbage", Quantity: kilo})
+	assert.ErrorIs(test, plan.Validate(), model.ErrInvalidSubscriptionPlan)
+	plan.Items = []model.BoxItem{{ProductID: "cabbage", Quantity: kilo, Substitutes: []string{"cabbage"}}}
+	assert.ErrorIs(test, plan.Validate(), model.ErrInvalidSubscriptionPlan)
+	plan.Items = []model.BoxItem{{ProductID: "cabbage", Quantity: kilo}}
+	plan.Frequency = "monthly"
+	assert.ErrorIs(test, plan.Validate(), model.ErrInvalidSubscriptionPlan)
+}
+
+func testSubscription_skipsDaysOnItsCycle(test *testing.T) {
+	now := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	first := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+	subscription := model.Subscription{Status: model.SubscriptionActive, Frequency: model.FrequencyFortnightly, NextDelivery: first}
+
+	assert.ErrorIs(test, subscription.Skip(first.AddDate(0, 0, 7), now), model.ErrSubscriptionDate)
+	assert.ErrorIs(test, subscription.Skip(first.AddDate(0, 0, -14), now), model.ErrSubscriptionDate)
+	assert.NoError(test, subscription.Skip(first.AddDate(0, 0, 28), now))
+	assert.NoError(test, subscription.Skip(first, now))
+	assert.NoError(test, subscription.Skip(first, now))
+	assert.Equal(test, []time.Time{first, first.AddDate(0, 0, 28)}, subscription.Skipped)
+
+	assert.NoError(test, subscription.Unskip(first.AddDate(0, 0, 28), now))
+	assert.ErrorIs(test, subscription.Unskip(first.AddDate(0, 0, 28), now), model.ErrSubscriptionDate)
+	assert.NoError(test, subscription.Skip(first.AddDate(0, 0, 14), now))
+
+	// Recording a day moves to the next one and forgets skips already passed
+	subscription.Record(model.BoxRun{Date: first, Outcome: model.BoxSkipped}, now)
+	assert.Equal(test, first.AddDate(0, 0, 14), subscription.NextDelivery)
+	assert.Equal(test, []time.Time{first.AddDate(0, 0, 14)}, subscription.Skipped)
+	assert.Len(test, subscription.History, 1)
+}
+
+func testSubscription_pausesAndCancels(test *testing.T) {
+	now := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	subscription := model.Subscription{Status: model.SubscriptionActive, Frequency: model.FrequencyWeekly, NextDelivery: today.AddDate(0, 0, 3)}
+
+	assert.ErrorIs(test, subscription.Pause(today, today, now), model.ErrSubscriptionDate)
+	assert.ErrorIs(test, subscription.Resume(now), model.ErrSubscriptionStatus)
+	assert.NoError(test, subscription.Pause(today.AddDate(0, 0, 14), today, now))
+	assert.ErrorIs(test, subscription.Pause(time.Time{}, today, now), model.ErrSubscriptionStatus)
+	assert.False(test, subscription.PauseEnded(today.AddDate(0, 0, 13)))
+	assert.True(test, subscription.PauseEnded(today.AddDate(0, 0, 14)))
+	assert.NoError(test, subscription.Resume(now))
+	assert.True(test, subscription.PausedUntil.IsZero())
+
+	assert.NoError(test, subscription.Pause(time.Time{}, today, now))
+	assert.False(test, subscription.PauseEnded(today.AddDate(1, 0, 0)))
+	assert.NoError(test, subscription.Cancel(" Moving abroad ", now))
+	assert.Equal(test, "Moving abroad", subscription.CancelReason)
+	assert.ErrorIs(test, subscription.Cancel("", now), model.ErrSubscriptionStatus)
+	assert.ErrorIs(test, subscription.Skip(subscription.NextDelivery, now), model.ErrSubscriptionStatus)
+}
+
+func TestSubscriptionModel(test *testing.T) {
+	test.Run("TestSubscriptionPlan_validatesBox", testSubscriptionPlan_validatesBox)
+	test.Run("TestSubscription_skipsDaysOnItsCycle", testSubscription_skipsDaysOnItsCycle)
+	test.Run("TestSubscription_pausesAndCancels", testSubscription_pausesAndCancels)
+}
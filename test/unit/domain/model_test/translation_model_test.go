@@ -0,0 +1,32 @@
+package model_test
+
+import (
+	"testing"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testResolve_fallsBackPerField(test *testing.T) {
+	translations := model.Translations{"vi": {Name: "Rau muống"}}
+
+	name, description := translations.Resolve("vi", "Water spinach", "Fresh from Da Lat")
+	assert.Equal(test, "Rau muống", name)
+	assert.Equal(test, "Fresh from Da Lat", description)
+
+	name, _ = translations.Resolve("fr", "Water spinach", "")
+	assert.Equal(test, "Water spinach", name)
+}
+
+func testMissing_skipsEmptyDefaults(test *testing.T) {
+	translations := model.Translations{"vi": {Name: "Cà rốt"}}
+
+	assert.Empty(test, translations.Missing("vi", ""))
+	assert.Equal(test, []string{"description"}, translations.Missing("vi", "Sweet carrots"))
+	assert.Equal(test, []string{"name", "description"}, translations.Missing("ko", "Sweet carrots"))
+}
+
+func TestTranslationModel(test *testing.T) {
+	test.Run("TestResolve_fallsBackPerField", testResolve_fallsBackPerField)
+	test.Run("TestMissing_skipsEmptyDefaults", testMissing_skipsEmptyDefaults)
+}
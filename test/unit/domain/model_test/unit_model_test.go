@@ -0,0 +1,73 @@
+package model_test
+
+import (
+	"testing"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testParseQuantity_success(test *testing.T) {
+	quantity, err := model.ParseQuantity("1.25", model.UnitKilogram)
+	assert.NoError(test, err)
+	assert.Equal(test, int64(1250), quantity.Base)
+	assert.Equal(test, "1.25 kg", quantity.String())
+
+	inGrams, err := quantity.In(model.UnitGram)
+	assert.NoError(test, err)
+	assert.Equal(test, "1250 g", inGrams.String())
+}
+
+func testParseQuantity_tooPrecise_fail(test *testing.T) {
+	_, err := model.ParseQuantity("1.2505", model.UnitKilogram)
+	assert.ErrorIs(test, err, model.ErrInvalidQuantity)
+
+	_, err = model.ParseQuantity("0.5", model.UnitPiece)
+	assert.ErrorIs(test, err, model.ErrInvalidQuantity)
+}
+
+func testConvert_acrossDimensions_fail(test *testing.T) {
+	quantity, _ := model.ParseQuantity("2", model.UnitBunch)
+	_, err := quantity.In(model.UnitGram)
+	assert.ErrorIs(test, err, model.ErrIncompatibleUnits)
+}
+
+func testSaleRule_stepIncrement(test *testing.T) {
+	rule := model.SaleRule{Unit: model.UnitKilogram, MinQuantity: 500, Step: 250}
+
+	valid, _ := model.ParseQuantity("0.75", model.UnitKilogram)
+	assert.NoError(test, rule.Check(valid))
+
+	offStep, _ := model.ParseQuantity("0.6", model.UnitKilogram)
+	assert.ErrorIs(test, rule.Check(offStep), model.ErrQuantityNotMultipleOfStep)
+
+	tooSmall, _ := model.ParseQuantity("250", model.UnitGram)
+	assert.ErrorIs(test, rule.Check(tooSmall), model.ErrQuantityBelowMinimum)
+}
+
+func testLineTotal_isExact(test *testing.T) {
+	product := model.Product{
+		Price:     33333,
+		PriceUnit: model.UnitKilogram,
+		SaleRule:  model.SaleRule{Unit: model.UnitGram, MinQuantity: 250, Step: 250},
+	}
+
+	// 0.75 kg * 33,333 đ/kg = 24,999.75 đ → 25,000 đ
+	quantity, _ := model.ParseQuantity("750", model.UnitGram)
+	total, err := product.LineTotal(quantity)
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(25000), total)
+
+	unitPrices := product.UnitPrices()
+	assert.Len(test, unitPrices, 2)
+	assert.Equal(test, "100 g", unitPrices[0].Per.String())
+	assert.Equal(test, model.Money(3333), unitPrices[0].Amount)
+}
+
+func TestUnitModel(test *testing.T) {
+	test.Run("TestParseQuantity_success", testParseQuantity_success)
+	test.Run("TestParseQuantity_tooPrecise_fail", testParseQuantity_tooPrecise_fail)
+	test.Run("TestConvert_acrossDimensions_fail", testConvert_acrossDimensions_fail)
+	test.Run("TestSaleRule_stepIncrement", testSaleRule_stepIncrement)
+	test.Run("TestLineTotal_isExact", testLineTotal_isExact)
+}
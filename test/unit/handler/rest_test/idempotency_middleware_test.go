@@ -0,0 +1,155 @@
+package rest_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/idempotency"
+	"veg-store-backend/internal/infrastructure/router"
+	"veg-store-backend/internal/restful/middleware"
+	"veg-store-backend/util"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderPlaced struct {
+	Number int    `json:"number"`
+	Note   string `json:"note"`
+}
+
+type IdempotencyTest struct {
+	*HandlerTest[gin.HandlerFunc, *idempotency.MemoryStore]
+	mutex   sync.Mutex
+	placed  int
+	now     time.Time
+	started chan struct{} // A "slow" checkout signals here, then waits for finish
+	finish  chan struct{}
+}
+
+// setupIdempotencyTest protects a fake checkout that numbers every order it places, and fails on an "out of stock" note
+func setupIdempotencyTest() *IdempotencyTest {
+	test := &IdempotencyTest{now: time.Now(), started: make(chan struct{}), finish: make(chan struct{})}
+	store := idempotency.NewMemoryStore(time.Hour, func() time.Time { return test.now })
+
+	place := func(ginContext *gin.Context) {
+		var request orderPlaced
+		_ = ginContext.ShouldBindJSON(&request)
+		if request.Note == "out of stock" {
+			_ = ginContext.Error(core.Error.Conflict.OutOfStock)
+			return
+		}
+		if request.Note == "slow" {
+			test.started <- struct{}{}
+			<-test.finish
+		}
+		test.mutex.Lock()
+		test.placed++
+		placed := orderPlaced{Number: test.placed, Note: request.Note}
+		test.mutex.Unlock()
+		ginContext.JSON(http.StatusCreated, dto.HttpResponse[orderPlaced]{HttpStatus: http.StatusCreated, Data: placed})
+	}
+
+	mockRouter := router.NewRouter()
+	mockRouter.Engine.POST(mockRouter.ApiPath+"/checkout",
+		func(ginContext *gin.Context) {
+			if userID := ginContext.GetHeader("X-Test-User"); userID != "" {
+				ginContext.Set(util.ClaimsContextKey, &infra_interface.JWTClaims{UserID: userID})
+			}
+		},
+		middleware.Idempotent(store),
+		place,
+	)
+	test.HandlerTest = NewHandlerTest[gin.HandlerFunc, *idempotency.MemoryStore](mockRouter.Engine, place, store)
+	return test
+}
+
+func keyed(userID string, key string) map[string]string {
+	return map[string]string{"X-Test-User": userID, middleware.IdempotencyKeyHeader: key}
+}
+
+func (testHandler *IdempotencyTest) checkout(test *testing.T, body orderPlaced, headers map[string]string) (int, orderPlaced, string) {
+	recorder := testHandler.Post(test, AppURI("/checkout"), body, headers)
+	var response dto.HttpResponse[orderPlaced]
+	testHandler.DecodeResponse(test, recorder, &response)
+	return recorder.Code, response.Data, recorder.Header().Get(middleware.IdempotentReplayedHeader)
+}
+
+func testIdempotent_replaysRetries(test *testing.T) {
+	testHandler := setupIdempotencyTest()
+	body := orderPlaced{Note: "leave at the door"}
+
+	status, first, replayed := testHandler.checkout(test, body, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusCreated, status)
+	assert.Empty(test, replayed)
+
+	status, retried, replayed := testHandler.checkout(test, body, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusCreated, status)
+	assert.Equal(test, first, retried)
+	assert.Equal(test, "true", replayed)
+	assert.Equal(test, 1, testHandler.placed)
+
+	// Keys are the user's own, and requests without one are not deduplicated
+	_, other, _ := testHandler.checkout(test, body, keyed("user-2", "key-1"))
+	assert.Equal(test, 2, other.Number)
+	testHandler.checkout(test, body, map[string]string{"X-Test-User": "user-1"})
+	testHandler.checkout(test, body, map[string]string{"X-Test-User": "user-1"})
+	assert.Equal(test, 4, testHandler.placed)
+}
+
+func testIdempotent_rejectsReusedKey(test *testing.T) {
+	testHandler := setupIdempotencyTest()
+	testHandler.checkout(test, orderPlaced{Note: "first"}, keyed("user-1", "key-1"))
+
+	status, _, _ := testHandler.checkout(test, orderPlaced{Note: "second"}, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusUnprocessableEntity, status)
+	status, _, _ = testHandler.checkout(test, orderPlaced{}, keyed("user-1", " "))
+	assert.Equal(test, http.StatusBadRequest, status)
+	assert.Equal(test, 1, testHandler.placed)
+
+	// Until the key expires
+	testHandler.now = testHandler.now.Add(time.Hour)
+	status, placed, _ := testHandler.checkout(test, orderPlaced{Note: "second"}, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusCreated, status)
+	assert.Equal(test, 2, placed.Number)
+	assert.Equal(test, 0, testHandler.MockService.Sweep())
+	testHandler.now = testHandler.now.Add(time.Hour)
+	assert.Equal(test, 1, testHandler.MockService.Sweep())
+}
+
+func testIdempotent_retriesFailures(test *testing.T) {
+	testHandler := setupIdempotencyTest()
+	body := orderPlaced{Note: "out of stock"}
+
+	status, _, _ := testHandler.checkout(test, body, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusConflict, status)
+	status, _, replayed := testHandler.checkout(test, body, keyed("user-1", "key-1"))
+	assert.Equal(test, http.StatusConflict, status)
+	assert.Empty(test, replayed)
+
+	// A request still running holds its key
+	done := make(chan int)
+	go func() {
+		status, _, _ := testHandler.checkout(test, orderPlaced{Note: "slow"}, keyed("user-1", "key-2"))
+		done <- status
+	}()
+	<-testHandler.started
+	status, _, _ = testHandler.checkout(test, orderPlaced{Note: "slow"}, keyed("user-1", "key-2"))
+	assert.Equal(test, http.StatusConflict, status)
+	close(testHandler.finish)
+	assert.Equal(test, http.StatusCreated, <-done)
+	assert.Equal(test, 1, testHandler.placed)
+}
+
+func TestIdempotencyMiddleware(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestIdempotent_replaysRetries", testIdempotent_replaysRetries)
+	test.Run("TestIdempotent_rejectsReusedKey", testIdempotent_rejectsReusedKey)
+	test.Run("TestIdempotent_retriesFailures", testIdempotent_retriesFailures)
+}
@@ -0,0 +1,139 @@
+package data_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"veg-store-backend/internal/infrastructure/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errFailed = errors.New("failed")
+
+func testTransaction_rollsBackOnlyItsOwnWrites(test *testing.T) {
+	datasource := data.NewDatasource()
+	table := data.NewTable[string, int](datasource)
+	table.Put(context.Background(), "a", 1)
+	table.Put(context.Background(), "b", 2)
+	table.Put(context.Background(), "c", 3)
+
+	err := datasource.Transaction(context.Background(), func(ctx context.Context) error {
+		table.Put(ctx, "a", 10)
+		table.Delete(ctx, "b")
+		table.Put(ctx, "d", 4)
+		_, _ = table.Update(ctx, "c", func(row int) (int, error) { return row * 10, nil })
+		table.Put(ctx, "a", 100)
+		return errFailed
+	})
+	assert.ErrorIs(test, err, errFailed)
+	assert.Equal(test, []int{1, 2, 3}, table.List())
+
+	assert.NoError(test, datasource.Transaction(context.Background(), func(ctx context.Context) error {
+		table.Delete(ctx, "a")
+		return nil
+	}))
+	assert.Equal(test, []int{2, 3}, table.List())
+}
+
+func testTransaction_keepsConcurrentWritesOutsideIt(test *testing.T) {
+	datasource := data.NewDatasource()
+	orders := data.NewTable[string, int](datasource)
+	carts := data.NewTable[string, int](datasource)
+
+	started, release, written := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	failed := make(chan error)
+	go func() {
+		failed <- datasource.Transaction(context.Background(), func(ctx context.Context) error {
+			orders.Put(ctx, "order-1", 1)
+			close(started)
+			<-release
+			return errFailed
+		})
+	}()
+
+	<-started
+	go func() {
+		carts.Put(context.Background(), "cart-1", 1)
+		close(written)
+	}()
+	// Give the write time to reach the table while the transaction is still running
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.ErrorIs(test, <-failed, errFailed)
+	<-written
+
+	_, ok := orders.Get("order-1")
+	assert.False(test, ok)
+	row, ok := carts.Get("cart-1")
+	assert.True(test, ok)
+	assert.Equal(test, 1, row)
+}
+
+func testTransaction_joinsTransactionOfItsContext(test *testing.T) {
+	datasource := data.NewDatasource()
+	orders := data.NewTable[string, int](datasource)
+	ledger := data.NewTable[string, int](datasource)
+
+	err := datasource.Transaction(context.Background(), func(ctx context.Context) error {
+		orders.Put(ctx, "order-1", 1)
+		assert.NoError(test, datasource.Transaction(ctx, func(ctx context.Context) error {
+			ledger.Put(ctx, "entry-1", 1)
+			return nil
+		}))
+		return errFailed
+	})
+	assert.ErrorIs(test, err, errFailed)
+	assert.Empty(test, orders.List())
+	assert.Empty(test, ledger.List())
+}
+
+func testTransaction_joinsWritesOfGoroutinesItStarts(test *testing.T) {
+	datasource := data.NewDatasource()
+	table := data.NewTable[string, int](datasource)
+
+	err := datasource.Transaction(context.Background(), func(ctx context.Context) error {
+		var group sync.WaitGroup
+		for _, key := range []string{"a", "b", "c"} {
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				table.Put(ctx, key, 1)
+			}()
+		}
+		group.Wait()
+		assert.Equal(test, 3, table.Len())
+		return errFailed
+	})
+	assert.ErrorIs(test, err, errFailed)
+	assert.Empty(test, table.List())
+}
+
+func testTransaction_rollsBackWhenCallbackPanics(test *testing.T) {
+	datasource := data.NewDatasource()
+	table := data.NewTable[string, int](datasource)
+	table.Put(context.Background(), "a", 1)
+
+	assert.PanicsWithValue(test, "boom", func() {
+		_ = datasource.Transaction(context.Background(), func(ctx context.Context) error {
+			table.Put(ctx, "a", 10)
+			table.Put(ctx, "b", 2)
+			panic("boom")
+		})
+	})
+	assert.Equal(test, []int{1}, table.List())
+
+	// The panic released the datasource
+	table.Put(context.Background(), "c", 3)
+	assert.Equal(test, []int{1, 3}, table.List())
+}
+
+func TestDatasource(test *testing.T) {
+	test.Run("TestTransaction_rollsBackOnlyItsOwnWrites", testTransaction_rollsBackOnlyItsOwnWrites)
+	test.Run("TestTransaction_keepsConcurrentWritesOutsideIt", testTransaction_keepsConcurrentWritesOutsideIt)
+	test.Run("TestTransaction_joinsTransactionOfItsContext", testTransaction_joinsTransactionOfItsContext)
+	test.Run("TestTransaction_joinsWritesOfGoroutinesItStarts", testTransaction_joinsWritesOfGoroutinesItStarts)
+	test.Run("TestTransaction_rollsBackWhenCallbackPanics", testTransaction_rollsBackWhenCallbackPanics)
+}
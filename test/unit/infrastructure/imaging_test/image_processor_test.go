@@ -0,0 +1,53 @@
+package imaging_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/imaging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pngBytes(test *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.RGBA{G: 200, A: 255})
+	var buffer bytes.Buffer
+	assert.NoError(test, png.Encode(&buffer, img))
+	return buffer.Bytes()
+}
+
+func testInspect_byMagicBytes(test *testing.T) {
+	processor := imaging.NewImageProcessor()
+
+	info, err := processor.Inspect(pngBytes(test, 40, 20))
+	assert.NoError(test, err)
+	assert.Equal(test, "image/png", info.ContentType)
+	assert.Equal(test, 40, info.Width)
+
+	// A script pretending to be an image is rejected whatever its file name says
+	_, err = processor.Inspect([]byte("<?php echo 'hello'; ?>"))
+	assert.ErrorIs(test, err, infra_interface.ErrUnsupportedImage)
+}
+
+func testResize_keepsAspectRatio(test *testing.T) {
+	processor := imaging.NewImageProcessor()
+
+	resized, info, err := processor.Resize(pngBytes(test, 1000, 500), 200)
+	assert.NoError(test, err)
+	assert.Equal(test, 200, info.Width)
+	assert.Equal(test, 100, info.Height)
+	assert.Equal(test, "image/png", info.ContentType)
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(resized))
+	assert.NoError(test, err)
+	assert.Equal(test, 200, config.Width)
+}
+
+func TestImageProcessor(test *testing.T) {
+	test.Run("TestInspect_byMagicBytes", testInspect_byMagicBytes)
+	test.Run("TestResize_keepsAspectRatio", testResize_keepsAspectRatio)
+}
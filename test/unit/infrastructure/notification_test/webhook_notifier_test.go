@@ -0,0 +1,46 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/notification"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testNotify_postsPayload(test *testing.T) {
+	var received notification.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(test, http.MethodPost, request.Method)
+		assert.Equal(test, "application/json", request.Header.Get("Content-Type"))
+		assert.NoError(test, json.NewDecoder(request.Body).Decode(&received))
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := notification.NewWebhookNotifier(server.URL, server.Client())
+	err := notifier.Notify(infra_interface.Notification{Roles: []string{"staff"}, Subject: "Low stock", Body: "cabbage"})
+	assert.NoError(test, err)
+	assert.Equal(test, []string{"staff"}, received.Roles)
+	assert.Equal(test, "Low stock", received.Subject)
+	assert.Equal(test, "cabbage", received.Body)
+	assert.False(test, received.SentAt.IsZero())
+}
+
+func testNotify_failsOnErrorStatus(test *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := notification.NewWebhookNotifier(server.URL, server.Client())
+	assert.Error(test, notifier.Notify(infra_interface.Notification{Subject: "Low stock"}))
+}
+
+func TestWebhookNotifier(test *testing.T) {
+	test.Run("TestNotify_postsPayload", testNotify_postsPayload)
+	test.Run("TestNotify_failsOnErrorStatus", testNotify_failsOnErrorStatus)
+}
@@ -0,0 +1,124 @@
+package payment_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/payment"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "test-secret"
+
+func gatewayConfig(serverURL string) payment.GatewayConfig {
+	return payment.GatewayConfig{
+		URL:          serverURL,
+		MerchantCode: "VEGSTORE",
+		Secret:       secret,
+		ReturnURL:    "https://shop.example/return",
+		IPNURL:       "https://api.example/ipn",
+	}
+}
+
+func testCreate_postsSignedForm(test *testing.T) {
+	var received url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(test, http.MethodPost, request.Method)
+		assert.Equal(test, "/payments", request.URL.Path)
+		assert.NoError(test, request.ParseForm())
+		received = request.PostForm
+		_ = json.NewEncoder(writer).Encode(map[string]string{
+			"code":           "00",
+			"pay_url":        "https://gateway.example/pay/abc",
+			"transaction_id": "GW-1",
+		})
+	}))
+	defer server.Close()
+
+	provider := payment.NewGatewayProvider(gatewayConfig(server.URL), server.Client())
+	session, err := provider.Create(infra_interface.PaymentIntent{Reference: "payment-1", Method: "card", Amount: 82500, Description: "ORD-000001"})
+	assert.NoError(test, err)
+	assert.Equal(test, "https://gateway.example/pay/abc", session.RedirectURL)
+	assert.Equal(test, "GW-1", session.TransactionID)
+
+	assert.Equal(test, "82500", received.Get("amount"))
+	assert.Equal(test, "https://api.example/ipn", received.Get("ipn_url"))
+	assert.Equal(test, payment.Sign(secret, received), received.Get("signature"))
+	assert.Empty(test, received.Get("expires_at"))
+}
+
+func testCreate_failsWhenRefused(test *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_ = json.NewEncoder(writer).Encode(map[string]string{"code": "24", "message": "merchant locked"})
+	}))
+	defer server.Close()
+
+	provider := payment.NewGatewayProvider(gatewayConfig(server.URL), server.Client())
+	_, err := provider.Create(infra_interface.PaymentIntent{Reference: "payment-1", Amount: 82500})
+	assert.ErrorContains(test, err, "merchant locked")
+}
+
+func testVerify_checksSignature(test *testing.T) {
+	provider := payment.NewGatewayProvider(gatewayConfig("http://unused"), nil)
+	params := url.Values{
+		"merchant_code":  {"VEGSTORE"},
+		"reference":      {"payment-1"},
+		"transaction_id": {"GW-1"},
+		"amount":         {"82500"},
+		"code":           {"00"},
+	}
+	params.Set("signature", payment.Sign(secret, params))
+
+	result, err := provider.Verify(params)
+	assert.NoError(test, err)
+	assert.Equal(test, infra_interface.PaymentResult{Reference: "payment-1", TransactionID: "GW-1", Amount: 82500, Success: true, Code: "00"}, *result)
+
+	params.Set("amount", "1000")
+	_, err = provider.Verify(params)
+	assert.ErrorIs(test, err, infra_interface.ErrInvalidPaymentSignature)
+
+	params.Set("amount", "82500")
+	params.Set("signature", payment.Sign("someone-elses-secret", params))
+	_, err = provider.Verify(params)
+	assert.ErrorIs(test, err, infra_interface.ErrInvalidPaymentSignature)
+}
+
+func testRefund_postsSignedForm(test *testing.T) {
+	var received url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(test, "/refunds", request.URL.Path)
+		assert.NoError(test, request.ParseForm())
+		received = request.PostForm
+		code := "00"
+		if received.Get("amount") == "999999" {
+			code = "94"
+		}
+		_ = json.NewEncoder(writer).Encode(map[string]string{"code": code, "message": "exceeds paid amount", "transaction_id": "RF-1"})
+	}))
+	defer server.Close()
+
+	provider := payment.NewGatewayProvider(gatewayConfig(server.URL), server.Client())
+	result, err := provider.Refund(infra_interface.PaymentRefund{Reference: "claim-1", TransactionID: "GW-1", Amount: 14000, Reason: "Claim on order ORD-000001"})
+	assert.NoError(test, err)
+	assert.Equal(test, "RF-1", result.TransactionID)
+	assert.Equal(test, "GW-1", received.Get("transaction_id"))
+	assert.Equal(test, "14000", received.Get("amount"))
+	assert.Equal(test, payment.Sign(secret, received), received.Get("signature"))
+
+	_, err = provider.Refund(infra_interface.PaymentRefund{Reference: "claim-2", TransactionID: "GW-1", Amount: 999999})
+	assert.ErrorContains(test, err, "exceeds paid amount")
+
+	_, err = payment.NewCashOnDelivery().Refund(infra_interface.PaymentRefund{Reference: "claim-3", Amount: 1000})
+	assert.ErrorIs(test, err, infra_interface.ErrRefundUnsupported)
+}
+
+func TestGatewayProvider(test *testing.T) {
+	test.Run("TestCreate_postsSignedForm", testCreate_postsSignedForm)
+	test.Run("TestCreate_failsWhenRefused", testCreate_failsWhenRefused)
+	test.Run("TestVerify_checksSignature", testVerify_checksSignature)
+	test.Run("TestRefund_postsSignedForm", testRefund_postsSignedForm)
+}
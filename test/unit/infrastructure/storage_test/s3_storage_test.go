@@ -0,0 +1,117 @@
+package storage_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is a local stand-in for an S3-compatible bucket: it keeps objects in memory
+// and rejects requests that are not SigV4 signed or whose payload hash does not match.
+type fakeS3 struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (s3 *fakeS3) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	authorization := request.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=test-key/") ||
+		!strings.Contains(authorization, "/ap-southeast-1/s3/aws4_request") {
+		writer.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	body, _ := io.ReadAll(request.Body)
+	sum := sha256.Sum256(body)
+	if request.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s3.mutex.Lock()
+	defer s3.mutex.Unlock()
+	switch request.Method {
+	case http.MethodPut:
+		s3.objects[request.URL.Path] = body
+		s3.types[request.URL.Path] = request.Header.Get("Content-Type")
+		writer.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s3.objects[request.URL.Path]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writer.Header().Set("Content-Type", s3.types[request.URL.Path])
+		_, _ = writer.Write(object)
+	case http.MethodDelete:
+		delete(s3.objects, request.URL.Path)
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newS3Storage(server *httptest.Server) infra_interface.FileStorage {
+	return storage.NewS3Storage(storage.S3Options{
+		Endpoint:     server.URL,
+		Region:       "ap-southeast-1",
+		Bucket:       "veg-store",
+		AccessKey:    "test-key",
+		SecretKey:    "test-secret",
+		UsePathStyle: true,
+	}, server.Client())
+}
+
+func testPutGetDelete_success(test *testing.T) {
+	bucket := newFakeS3()
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+	s3Storage := newS3Storage(server)
+
+	err := s3Storage.Put("products/1/2/original.png", "image/png", []byte("png-bytes"))
+	assert.NoError(test, err)
+	assert.Contains(test, bucket.objects, "/veg-store/products/1/2/original.png")
+
+	content, contentType, err := s3Storage.Get("products/1/2/original.png")
+	assert.NoError(test, err)
+	assert.Equal(test, "png-bytes", string(content))
+	assert.Equal(test, "image/png", contentType)
+
+	assert.NoError(test, s3Storage.Delete("products/1/2/original.png"))
+	_, _, err = s3Storage.Get("products/1/2/original.png")
+	assert.ErrorIs(test, err, infra_interface.ErrObjectNotFound)
+}
+
+func testURL_usesBucketPath(test *testing.T) {
+	server := httptest.NewServer(newFakeS3())
+	defer server.Close()
+
+	url := newS3Storage(server).URL("products/1/2/thumbnail.jpg")
+	assert.Equal(test, server.URL+"/veg-store/products/1/2/thumbnail.jpg", url)
+}
+
+func testPut_keyOutsideBucket_fail(test *testing.T) {
+	server := httptest.NewServer(newFakeS3())
+	defer server.Close()
+
+	err := newS3Storage(server).Put("../other-bucket/secret", "text/plain", []byte("x"))
+	assert.ErrorIs(test, err, storage.ErrInvalidKey)
+}
+
+func TestS3Storage(test *testing.T) {
+	test.Run("TestPutGetDelete_success", testPutGetDelete_success)
+	test.Run("TestURL_usesBucketPath", testURL_usesBucketPath)
+	test.Run("TestPut_keyOutsideBucket_fail", testPut_keyOutsideBucket_fail)
+}
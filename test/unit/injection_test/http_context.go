@@ -4,6 +4,7 @@ import (
 	"veg-store-backend/injection/core"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 func MockHttpContext(
@@ -12,5 +13,6 @@ func MockHttpContext(
 	return &core.HttpContext{
 		Translator: core.Translator,
 		Gin:        ginCtx,
+		Logger:     zap.L(),
 	}
 }
@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cartFixture struct {
+	*store
+	service    service.CartService
+	carts      repository.CartRepository
+	promotions service.PromotionService
+}
+
+func setupCartService(test *testing.T) *cartFixture {
+	fixture := &cartFixture{store: newGreengrocer()}
+	fixture.stock(test, "lettuce", 5)
+	fixture.stock(test, "cabbage", 3000)
+
+	fixture.carts = repository.NewCartRepository(fixture.datasource)
+	fixture.promotions = service.NewPromotionService(
+		repository.NewPromotionRepository(fixture.datasource),
+		fixture.orders,
+		repository.NewSubscriptionRepository(fixture.datasource),
+		fixture.transactor,
+	)
+	fixture.service = service.NewCartService(
+		fixture.carts,
+		fixture.products,
+		fixture.inventory,
+		fixture.promotions,
+		fixture.transactor,
+		scheduler.NewScheduler(),
+	)
+	return fixture
+}
+
+func (fixture *cartFixture) reprice(productID string, price model.Money, active bool) {
+	_, _ = fixture.products.Update(context.Background(), productID, func(product *model.Product) error {
+		product.Price = price
+		product.Active = active
+		return nil
+	})
+}
+
+func cartLine(productID string, value string, unit string) dto.CartLineRequest {
+	return dto.CartLineRequest{ProductID: productID, Quantity: dto.QuantityDto{Value: value, Unit: unit}}
+}
+
+func testGuestCart_addressedByToken(test *testing.T) {
+	fixture := setupCartService(test)
+
+	cart, err := fixture.service.AddLine(model.CartOwner{}, cartLine("cabbage", "0.5", "kg"))
+	assert.NoError(test, err)
+	token := cart.Cart.Token
+	assert.NotEmpty(test, token)
+	guest := model.CartOwner{Token: token}
+
+	cart, err = fixture.service.AddLine(guest, cartLine("cabbage", "500", "g"))
+	assert.NoError(test, err)
+	assert.Len(test, cart.Lines, 1)
+	assert.Equal(test, int64(1000), cart.Lines[0].Quantity.Base)
+	assert.Equal(test, model.Money(28000), cart.Subtotal())
+
+	_, err = fixture.service.AddLine(guest, cartLine("cabbage", "2.25", "kg"))
+	assert.Equal(test, core.Error.Conflict.InsufficientStock, err)
+	_, err = fixture.service.AddLine(guest, cartLine("cabbage", "0.1", "kg"))
+	assert.Equal(test, core.Error.Invalid.QuantityStep, err)
+	fixture.reprice("lettuce", 15000, false)
+	_, err = fixture.service.AddLine(guest, cartLine("lettuce", "1", "piece"))
+	assert.Equal(test, core.Error.Conflict.ProductUnavailable, err)
+
+	_, err = fixture.service.Find(model.CartOwner{Token: "forged"})
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+	_, err = fixture.service.AddLine(model.CartOwner{Token: "forged"}, cartLine("cabbage", "1", "kg"))
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+	empty, err := fixture.service.Find(model.CartOwner{})
+	assert.NoError(test, err)
+	assert.Empty(test, empty.Lines)
+}
+
+func testUpdateAndRemoveLine(test *testing.T) {
+	fixture := setupCartService(test)
+	user := model.CartOwner{UserID: "user-1"}
+	_, err := fixture.service.RemoveLine(user, "lettuce")
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+	_, _ = fixture.service.AddLine(user, cartLine("lettuce", "2", "piece"))
+
+	cart, err := fixture.service.UpdateLine(user, "lettuce", dto.UpdateCartLineRequest{Quantity: dto.QuantityDto{Value: "4", Unit: "piece"}})
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(60000), cart.Subtotal())
+	assert.Empty(test, cart.Cart.Token)
+
+	_, err = fixture.service.UpdateLine(user, "cabbage", dto.UpdateCartLineRequest{Quantity: dto.QuantityDto{Value: "1", Unit: "kg"}})
+	assert.Equal(test, core.Error.NotFound.CartLine, err)
+	_, err = fixture.service.UpdateLine(user, "lettuce", dto.UpdateCartLineRequest{Quantity: dto.QuantityDto{Value: "11", Unit: "piece"}})
+	assert.Equal(test, core.Error.Invalid.QuantityAboveMaximum, err)
+
+	cart, err = fixture.service.RemoveLine(user, "lettuce")
+	assert.NoError(test, err)
+	assert.Empty(test, cart.Lines)
+	assert.False(test, cart.Ready())
+}
+
+func testFind_revalidatesPricesAndStock(test *testing.T) {
+	fixture := setupCartService(test)
+	user := model.CartOwner{UserID: "user-1"}
+	_, _ = fixture.service.AddLine(user, cartLine("lettuce", "4", "piece"))
+	_, _ = fixture.service.AddLine(user, cartLine("cabbage", "1", "kg"))
+
+	fixture.reprice("cabbage", 32000, true)
+	fixture.stock(test, "lettuce", 3)
+	cart, err := fixture.service.Find(user)
+	assert.NoError(test, err)
+	assert.Equal(test, []model.CartIssue{model.CartIssueInsufficientStock}, cart.Lines[0].Issues)
+	assert.Equal(test, []model.CartIssue{model.CartIssuePriceChanged}, cart.Lines[1].Issues)
+	assert.Equal(test, model.Money(28000), cart.Lines[1].PreviousPrice)
+	assert.Equal(test, model.Money(4*15000+32000), cart.Subtotal())
+	assert.False(test, cart.Ready())
+
+	// The new price has been seen now, and the customer fixed the lettuce
+	_, _ = fixture.service.UpdateLine(user, "lettuce", dto.UpdateCartLineRequest{Quantity: dto.QuantityDto{Value: "3", Unit: "piece"}})
+	cart, _ = fixture.service.Find(user)
+	assert.Empty(test, cart.Lines[1].Issues)
+	assert.True(test, cart.Ready())
+
+	fixture.reprice("lettuce", 15000, false)
+	fixture.stock(test, "cabbage", 0)
+	cart, _ = fixture.service.Find(user)
+	assert.Equal(test, []model.CartIssue{model.CartIssueUnavailable}, cart.Lines[0].Issues)
+	assert.Equal(test, []model.CartIssue{model.CartIssueOutOfStock}, cart.Lines[1].Issues)
+	assert.Equal(test, model.Money(32000), cart.Subtotal())
+}
+
+func testMerge_movesGuestCartIntoUserCart(test *testing.T) {
+	fixture := setupCartService(test)
+	guestCart, _ := fixture.service.AddLine(model.CartOwner{}, cartLine("cabbage", "0.5", "kg"))
+	guest := model.CartOwner{Token: guestCart.Cart.Token}
+	_, _ = fixture.service.AddLine(guest, cartLine("lettuce", "1", "piece"))
+	user := model.CartOwner{UserID: "user-1"}
+	_, _ = fixture.service.AddLine(user, cartLine("cabbage", "1", "kg"))
+
+	cart, err := fixture.service.Merge("user-1", guest.Token)
+	assert.NoError(test, err)
+	assert.Len(test, cart.Lines, 2)
+	assert.Equal(test, int64(1500), cart.Lines[0].Quantity.Base)
+	assert.Equal(test, "lettuce", cart.Lines[1].ProductID)
+
+	_, err = fixture.service.Find(guest)
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+	_, err = fixture.service.Merge("user-1", guest.Token)
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+
+	// A first-time user without a cart simply takes the guest cart over
+	another, _ := fixture.service.AddLine(model.CartOwner{}, cartLine("lettuce", "2", "piece"))
+	cart, err = fixture.service.Merge("user-2", another.Cart.Token)
+	assert.NoError(test, err)
+	assert.Equal(test, "user-2", cart.Cart.UserID)
+	assert.Empty(test, cart.Cart.Token)
+}
+
+func TestCartService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestGuestCart_addressedByToken", testGuestCart_addressedByToken)
+	test.Run("TestUpdateAndRemoveLine", testUpdateAndRemoveLine)
+	test.Run("TestFind_revalidatesPricesAndStock", testFind_revalidatesPricesAndStock)
+	test.Run("TestMerge_movesGuestCartIntoUserCart", testMerge_movesGuestCartIntoUserCart)
+}
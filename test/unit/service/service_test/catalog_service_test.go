@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/spreadsheet"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type catalogFixture struct {
+	*store
+	service      service.CatalogService
+	categoryRepo repository.CategoryRepository
+}
+
+func setupCatalogService() *catalogFixture {
+	fixture := &catalogFixture{store: newStore()}
+	fixture.categoryRepo = repository.NewCategoryRepository(fixture.datasource)
+	_ = fixture.categoryRepo.Create(context.Background(), model.Category{ID: "c1", Slug: "leafy-greens", Name: "Leafy greens"})
+	fixture.service = service.NewCatalogService(fixture.products, fixture.categoryRepo, spreadsheet.NewSpreadsheet(), fixture.transactor)
+	return fixture
+}
+
+// upload wraps content the way gin hands a multipart file to the service
+func upload(test *testing.T, fileName string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", fileName)
+	assert.NoError(test, err)
+	_, _ = part.Write(content)
+	assert.NoError(test, writer.Close())
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(test, err)
+	return form.File["file"][0]
+}
+
+const validSheet = "sku,name,category,price,price_unit,sale_unit,min_quantity,step,name_vi\n" +
+	"VEG-001,Water spinach,leafy-greens,20000,kg,kg,0.5,0.25,Rau muống\n" +
+	"VEG-002,Carrot,,30000,kg,g,500,100,Cà rốt\n"
+
+func testImport_dryRun_writesNothing(test *testing.T) {
+	fixture := setupCatalogService()
+
+	report, err := fixture.service.Import(upload(test, "catalog.csv", []byte(validSheet)), true, "en")
+	assert.NoError(test, err)
+	assert.Equal(test, 2, report.Created)
+	assert.Equal(test, 0, report.Failed)
+	assert.False(test, report.Applied)
+	assert.Empty(test, fixture.products.FindAll())
+}
+
+func testImport_rowErrors_localized_nothingApplied(test *testing.T) {
+	fixture := setupCatalogService()
+	sheet := validSheet +
+		"VEG-003,Tomato,fruit-veg,abc,kg,kg,0.5,0.25,\n" +
+		"VEG-001,Duplicate,,1000,kg,kg,1,1,\n"
+
+	report, err := fixture.service.Import(upload(test, "catalog.csv", []byte(sheet)), false, "vi")
+	assert.NoError(test, err)
+	assert.Equal(test, 2, report.Failed)
+	assert.False(test, report.Applied)
+	assert.Empty(test, fixture.products.FindAll())
+
+	tomato := report.Rows[2]
+	assert.Equal(test, 4, tomato.Row)
+	assert.Len(test, tomato.Errors, 2)
+	assert.Equal(test, "category", tomato.Errors[0].Column)
+	assert.Contains(test, tomato.Errors[0].Message, "danh mục không tồn tại")
+	assert.Equal(test, "invalid/price", tomato.Errors[1].Code)
+	assert.Equal(test, "invalid/duplicate-sku", report.Rows[3].Errors[0].Code)
+}
+
+func testImport_updateKeepsMissingColumns(test *testing.T) {
+	fixture := setupCatalogService()
+	_, err := fixture.service.Import(upload(test, "catalog.csv", []byte(validSheet)), false, "en")
+	assert.NoError(test, err)
+
+	report, err := fixture.service.Import(upload(test, "prices.csv", []byte("sku,price\nVEG-002,32000\n")), false, "en")
+	assert.NoError(test, err)
+	assert.True(test, report.Applied)
+	assert.Equal(test, 1, report.Updated)
+
+	carrot, err := fixture.products.FindBySKU("VEG-002")
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(32000), carrot.Price)
+	assert.Equal(test, "Carrot", carrot.Name)
+	assert.Equal(test, "Cà rốt", carrot.Translations["vi"].Name)
+	assert.Equal(test, int64(500), carrot.SaleRule.MinQuantity)
+}
+
+func testExport_xlsx_roundTrips(test *testing.T) {
+	fixture := setupCatalogService()
+	_, err := fixture.service.Import(upload(test, "catalog.csv", []byte(validSheet)), false, "en")
+	assert.NoError(test, err)
+
+	exported := &bytes.Buffer{}
+	assert.NoError(test, fixture.service.Export("xlsx", exported))
+
+	report, err := fixture.service.Import(upload(test, "catalog.xlsx", exported.Bytes()), true, "en")
+	assert.NoError(test, err)
+	assert.Equal(test, 0, report.Failed)
+	assert.Equal(test, 2, report.Updated)
+
+	spinach, _ := fixture.products.FindBySKU("VEG-001")
+	assert.Equal(test, "c1", spinach.CategoryID)
+}
+
+func testImport_unsupportedFormat_fail(test *testing.T) {
+	fixture := setupCatalogService()
+	_, err := fixture.service.Import(upload(test, "catalog.txt", []byte(validSheet)), true, "en")
+	assert.ErrorContains(test, err, "invalid/spreadsheet-format")
+}
+
+func TestCatalogService(test *testing.T) {
+	injection.Inject("test")
+	test.Run("TestImport_dryRun_writesNothing", testImport_dryRun_writesNothing)
+	test.Run("TestImport_rowErrors_localized_nothingApplied", testImport_rowErrors_localized_nothingApplied)
+	test.Run("TestImport_updateKeepsMissingColumns", testImport_updateKeepsMissingColumns)
+	test.Run("TestExport_xlsx_roundTrips", testExport_xlsx_roundTrips)
+	test.Run("TestImport_unsupportedFormat_fail", testImport_unsupportedFormat_fail)
+}
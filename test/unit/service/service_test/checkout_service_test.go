@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type checkoutFixture struct {
+	*cartFixture
+	checkout service.CheckoutService
+	slots    repository.DeliverySlotRepository
+	zones    repository.DeliveryZoneRepository
+	points   repository.LoyaltyRepository
+	users    repository.UserRepository
+	loyalty  service.LoyaltyService
+}
+
+func setupCheckoutService(test *testing.T) *checkoutFixture {
+	fixture := &checkoutFixture{cartFixture: setupCartService(test)}
+	fixture.slots = repository.NewDeliverySlotRepository(fixture.datasource)
+	fixture.zones = repository.NewDeliveryZoneRepository(fixture.datasource)
+	fixture.points = repository.NewLoyaltyRepository(fixture.datasource)
+	fixture.users = repository.NewUserRepository(fixture.datasource)
+	for _, id := range []string{"user-1", "user-2"} {
+		fixture.users.Save(context.Background(), model.User{ID: id, Name: id, Roles: []string{model.RoleCustomer}})
+	}
+	fixture.loyalty = service.NewLoyaltyService(fixture.points, fixture.orders, fixture.users, fixture.transactor, scheduler.NewScheduler())
+	fixture.checkout = service.NewCheckoutService(fixture.orders, fixture.carts, fixture.products, fixture.inventory, fixture.slots, fixture.zones, fixture.locations, fixture.promotions, fixture.loyalty, fixture.transactor)
+	_ = fixture.zones.Create(context.Background(), model.DeliveryZone{
+		ID: "inner-city", Name: "Inner city", Active: true,
+		Areas: []model.ZoneArea{{Province: "Hồ Chí Minh", District: "Quận 1"}},
+	})
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "tomorrow-morning", Start: start, End: start.Add(2 * time.Hour), Capacity: 2, Active: true})
+	return fixture
+}
+
+func (fixture *checkoutFixture) fill(test *testing.T, userID string, lines ...dto.CartLineRequest) {
+	for _, line := range lines {
+		_, err := fixture.service.AddLine(model.CartOwner{UserID: userID}, line)
+		assert.NoError(test, err)
+	}
+}
+
+func checkoutRequest(slotID string, paymentMethod string) dto.CheckoutRequest {
+	return dto.CheckoutRequest{
+		Address: dto.DeliveryAddressDto{
+			RecipientName: "Nguyễn Văn An",
+			Phone:         "0903123456",
+			Street:        "12 Nguyễn Huệ",
+			District:      "Quận 1",
+			Province:      "Hồ Chí Minh",
+		},
+		SlotID:        slotID,
+		PaymentMethod: paymentMethod,
+	}
+}
+
+func testCheckout_placesOrder(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	fixture.fill(test, "user-1", cartLine("cabbage", "1.5", "kg"), cartLine("lettuce", "2", "piece"))
+
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+	assert.Equal(test, "ORD-000001", order.Number)
+	assert.Equal(test, model.OrderConfirmed, order.Status)
+	assert.True(test, order.PaymentDueAt.IsZero())
+	assert.Len(test, order.Lines, 2)
+	assert.Equal(test, model.Money(42000), order.Lines[0].Total)
+	assert.Equal(test, model.Money(72000), order.Total)
+	assert.Equal(test, "Quận 1", order.Address.District)
+
+	// Stock is held without expiry, the slot booked and the cart gone
+	level := fixture.inventory.FindLevel("cabbage", model.DefaultLocationID)
+	assert.Equal(test, int64(1500), level.Reserved)
+	reservations := fixture.inventory.FindReservations(func(reservation model.Reservation) bool {
+		return reservation.Reference == order.ID
+	})
+	assert.Len(test, reservations, 2)
+	assert.True(test, reservations[0].ExpiresAt.IsZero())
+	slot, _ := fixture.slots.FindByID("tomorrow-morning")
+	assert.Equal(test, 1, slot.Booked)
+	assert.Equal(test, int64(2100), order.Weight)
+	assert.Equal(test, int64(2100), slot.BookedWeight)
+	_, err = fixture.carts.FindByUser("user-1")
+	assert.Equal(test, core.Error.NotFound.Cart, err)
+
+	_, err = fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.Equal(test, core.Error.Invalid.EmptyCart, err)
+}
+
+func testCheckout_paidOnlineAwaitsPayment(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	fixture.fill(test, "user-1", cartLine("lettuce", "1", "piece"))
+
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "card"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.OrderPendingPayment, order.Status)
+	assert.WithinDuration(test, time.Now().Add(30*time.Minute), order.PaymentDueAt, time.Minute)
+	reservation, _ := fixture.inventory.FindReservation(order.Lines[0].ReservationID)
+	assert.Equal(test, order.PaymentDueAt, reservation.ExpiresAt)
+}
+
+func testCheckout_rejectsChangedPrice(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	fixture.fill(test, "user-1", cartLine("cabbage", "1", "kg"))
+	fixture.reprice("cabbage", 30000, true)
+
+	_, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.Equal(test, core.Error.Conflict.PriceChanged, err)
+
+	// Once the customer has seen the new price, the order goes through at it
+	_, _ = fixture.service.Find(model.CartOwner{UserID: "user-1"})
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(30000), order.Total)
+}
+
+func testCheckout_rollsBackWhenOutOfStock(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	fixture.fill(test, "user-1", cartLine("cabbage", "1", "kg"), cartLine("lettuce", "4", "piece"))
+	fixture.stock(test, "lettuce", 3)
+
+	_, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.Equal(test, core.Error.Conflict.OutOfStock, err)
+
+	// Nothing of the attempt is left: no hold on the cabbage, no booking, the cart intact
+	assert.Equal(test, int64(0), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).Reserved)
+	assert.Empty(test, fixture.inventory.FindReservations(func(model.Reservation) bool { return true }))
+	slot, _ := fixture.slots.FindByID("tomorrow-morning")
+	assert.Equal(test, 0, slot.Booked)
+	cart, err := fixture.carts.FindByUser("user-1")
+	assert.NoError(test, err)
+	assert.Len(test, cart.Lines, 2)
+	assert.Empty(test, fixture.orders.FindAll(func(model.Order) bool { return true }))
+}
+
+func testCheckout_rejectsFullOrClosedSlot(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		fixture.fill(test, userID, cartLine("lettuce", "1", "piece"))
+	}
+	_, _ = fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	_, _ = fixture.checkout.Checkout("user-2", checkoutRequest("tomorrow-morning", "cod"))
+
+	_, err := fixture.checkout.Checkout("user-3", checkoutRequest("tomorrow-morning", "cod"))
+	assert.Equal(test, core.Error.Conflict.SlotFull, err)
+	_, err = fixture.checkout.Checkout("user-3", checkoutRequest("yesterday", "cod"))
+	assert.Equal(test, core.Error.NotFound.DeliverySlot, err)
+
+	start := time.Now().Add(-time.Hour)
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "started", Start: start, End: start.Add(2 * time.Hour), Capacity: 5, Active: true})
+	_, err = fixture.checkout.Checkout("user-3", checkoutRequest("started", "cod"))
+	assert.Equal(test, core.Error.Conflict.SlotClosed, err)
+}
+
+func testCheckout_booksSlotOfZoneByWeight(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "inner-city", ZoneID: "inner-city", Start: start, End: start.Add(time.Hour), WeightCapacity: 2000, Active: true})
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "suburbs", ZoneID: "suburbs", Start: start, End: start.Add(time.Hour), Capacity: 5, Active: true})
+	fixture.fill(test, "user-1", cartLine("cabbage", "1.5", "kg"), cartLine("lettuce", "2", "piece"))
+
+	_, err := fixture.checkout.Checkout("user-1", checkoutRequest("suburbs", "cod"))
+	assert.Equal(test, core.Error.Conflict.SlotZone, err)
+	_, err = fixture.checkout.Checkout("user-1", checkoutRequest("inner-city", "cod"))
+	assert.Equal(test, core.Error.Conflict.SlotFull, err)
+	assert.Equal(test, int64(0), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).Reserved)
+
+	_, _ = fixture.service.RemoveLine(model.CartOwner{UserID: "user-1"}, "lettuce")
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("inner-city", "cod"))
+	assert.NoError(test, err)
+	assert.Equal(test, "inner-city", order.ZoneID)
+	slot, _ := fixture.slots.FindByID("inner-city")
+	assert.Equal(test, int64(1500), slot.BookedWeight)
+}
+
+func testCheckout_chargesZoneDeliveryFee(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	// The warehouse sits by Ben Thanh market; the zone is a box around District 4 charging past 2 km and 1 kg
+	warehouse, _ := fixture.locations.FindByID(model.DefaultLocationID)
+	warehouse.Address.Point = &model.GeoPoint{Latitude: 10.7725, Longitude: 106.6980}
+	_ = fixture.locations.Update(context.Background(), *warehouse)
+	_ = fixture.zones.Create(context.Background(), model.DeliveryZone{
+		ID: "district-4", Name: "District 4", Active: true,
+		Polygon: []model.GeoPoint{{Latitude: 10.750, Longitude: 106.695}, {Latitude: 10.750, Longitude: 106.715}, {Latitude: 10.768, Longitude: 106.715}, {Latitude: 10.768, Longitude: 106.695}},
+		Fees:    model.DeliveryFeeRule{BaseFee: 15000, IncludedDistance: 2000, PerKm: 3000, IncludedWeight: 1000, PerKg: 2000, FreeFrom: 80000},
+	})
+	fixture.fill(test, "user-1", cartLine("cabbage", "1.5", "kg"), cartLine("lettuce", "2", "piece"))
+
+	request := checkoutRequest("tomorrow-morning", "cod")
+	request.Address.District = "Quận 4"
+	_, err := fixture.checkout.Checkout("user-1", request)
+	assert.Equal(test, core.Error.Invalid.OutOfDeliveryArea, err)
+
+	// About 2.4 km away and 2.1 kg: one started km and two started kg past what the base fee covers
+	latitude, longitude := 10.7550, 106.7100
+	request.Address.Latitude, request.Address.Longitude = &latitude, &longitude
+	order, err := fixture.checkout.Checkout("user-1", request)
+	assert.NoError(test, err)
+	assert.Equal(test, "district-4", order.ZoneID)
+	assert.Equal(test, model.Money(15000+3000+2*2000), order.DeliveryFee)
+	assert.Equal(test, model.Money(72000+22000), order.Total)
+
+	// Orders from the threshold ship free
+	fixture.fill(test, "user-2", cartLine("cabbage", "1.5", "kg"), cartLine("lettuce", "3", "piece"))
+	order, err = fixture.checkout.Checkout("user-2", request)
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(0), order.DeliveryFee)
+	assert.Equal(test, order.Subtotal, order.Total)
+}
+
+func TestCheckoutService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestCheckout_placesOrder", testCheckout_placesOrder)
+	test.Run("TestCheckout_paidOnlineAwaitsPayment", testCheckout_paidOnlineAwaitsPayment)
+	test.Run("TestCheckout_rejectsChangedPrice", testCheckout_rejectsChangedPrice)
+	test.Run("TestCheckout_rollsBackWhenOutOfStock", testCheckout_rollsBackWhenOutOfStock)
+	test.Run("TestCheckout_rejectsFullOrClosedSlot", testCheckout_rejectsFullOrClosedSlot)
+	test.Run("TestCheckout_booksSlotOfZoneByWeight", testCheckout_booksSlotOfZoneByWeight)
+	test.Run("TestCheckout_chargesZoneDeliveryFee", testCheckout_chargesZoneDeliveryFee)
+}
@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type claimFixture struct {
+	*paymentFixture
+	claims   service.ClaimService
+	credit   service.StoreCreditService
+	notifier *fakeNotifier
+}
+
+func setupClaimService(test *testing.T) *claimFixture {
+	fixture := &claimFixture{paymentFixture: setupPaymentService(test), notifier: &fakeNotifier{}}
+	creditRepo := repository.NewStoreCreditRepository(fixture.datasource)
+	fixture.claims = service.NewClaimService(
+		repository.NewClaimRepository(fixture.datasource),
+		fixture.orders,
+		fixture.paymentRepo,
+		fixture.inventory,
+		creditRepo,
+		fixture.providers,
+		nil,
+		nil,
+		fixture.notifier,
+		fixture.transactor,
+	)
+	fixture.credit = service.NewStoreCreditService(creditRepo)
+	return fixture
+}
+
+// deliver places an order of 1 kg of cabbage (28,000 VND), pays for it online unless it is cash on delivery, and
+// delivers it
+func (fixture *claimFixture) deliver(test *testing.T, userID string, paymentMethod string) *model.Order {
+	order := fixture.place(test, userID, paymentMethod)
+	if paymentMethod != "cod" {
+		attempt, err := fixture.payments.Pay(userID, order.ID, "203.0.113.7")
+		assert.NoError(test, err)
+		fixture.gateway.pay(test, attempt.RedirectURL, "success")
+	}
+	for _, status := range []model.OrderStatus{model.OrderPicking, model.OrderPacked, model.OrderOutForDelivery, model.OrderDelivered} {
+		_, err := fixture.move(order.ID, status, "")
+		assert.NoError(test, err)
+	}
+	return order
+}
+
+func claimLine(productID string, value string, unit string, reason string) dto.ClaimLineRequest {
+	return dto.ClaimLineRequest{ProductID: productID, Quantity: dto.QuantityDto{Value: value, Unit: unit}, Reason: reason}
+}
+
+func testClaim_neverExceedsDelivered(test *testing.T) {
+	fixture := setupClaimService(test)
+	undelivered := fixture.place(test, "user-1", "cod")
+	order := fixture.deliver(test, "user-1", "cod")
+
+	_, err := fixture.claims.Create("user-1", undelivered.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "1", "kg", "spoiled")}}, "en")
+	assert.Equal(test, core.Error.Conflict.OrderStatus, err)
+	_, err = fixture.claims.Create("user-2", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "1", "kg", "spoiled")}}, "en")
+	assert.Equal(test, core.Error.NotFound.Order, err)
+	_, err = fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("lettuce", "1", "piece", "missing")}}, "en")
+	assert.Equal(test, core.Error.Invalid.ClaimLines, err)
+
+	claim, err := fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{
+		Lines:       []dto.ClaimLineRequest{claimLine("cabbage", "600", "g", "bruised")},
+		Description: " Squashed at the bottom of the bag ",
+	}, "en")
+	assert.NoError(test, err)
+	assert.Equal(test, model.ClaimPending, claim.Status)
+	assert.Equal(test, model.Money(16800), claim.Claimed)
+	assert.Equal(test, "Squashed at the bottom of the bag", claim.Description)
+	assert.Equal(test, []string{model.RoleAdmin, model.RoleStaff}, fixture.notifier.sent[0].Roles)
+	assert.Equal(test, "16800 VND claimed on 1 line. Squashed at the bottom of the bag", fixture.notifier.sent[0].Body)
+
+	// Only 400 g is left to claim, until the first claim is rejected
+	_, err = fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "500", "g", "spoiled")}}, "en")
+	assert.Equal(test, core.Error.Invalid.ClaimLines, err)
+	_, err = fixture.claims.Reject("staff-1", claim.ID, dto.RejectClaimRequest{Note: " "})
+	assert.Equal(test, core.Error.Invalid.Reason, err)
+	rejected, err := fixture.claims.Reject("staff-1", claim.ID, dto.RejectClaimRequest{Note: "The photos show fresh cabbage"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.ClaimRejected, rejected.Status)
+	_, err = fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "1", "kg", "spoiled")}}, "en")
+	assert.NoError(test, err)
+
+	// Nor after the claim window
+	_, _ = fixture.orders.Update(context.Background(), order.ID, func(order *model.Order) error {
+		order.History[len(order.History)-1].At = time.Now().Add(-49 * time.Hour)
+		return nil
+	})
+	_, err = fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "1", "g", "spoiled")}}, "en")
+	assert.Equal(test, core.Error.Conflict.ClaimWindow, err)
+
+	mine := fixture.claims.FindMine("user-1", dto.ClaimQuery{Status: "pending"})
+	assert.Equal(test, 1, mine.Total)
+}
+
+func testApprove_refundsCardPayment(test *testing.T) {
+	fixture := setupClaimService(test)
+	order := fixture.deliver(test, "user-1", "card")
+	claim, _ := fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "500", "g", "spoiled")}}, "en")
+
+	// A refused refund leaves the claim to be decided again
+	fixture.gateway.refuseRefunds = true
+	_, err := fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "refund"})
+	assert.Equal(test, core.Error.Unavailable.PaymentGateway, err)
+	pending, _ := fixture.claims.FindByID(claim.ID)
+	assert.Equal(test, model.ClaimPending, pending.Status)
+
+	fixture.gateway.refuseRefunds = false
+	_, err = fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "refund", Amount: 20000})
+	assert.Equal(test, core.Error.Invalid.RefundAmount, err)
+	approved, err := fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "refund", Amount: 10000, Note: "Sorry!"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.ClaimApproved, approved.Status)
+	assert.Equal(test, model.Money(10000), approved.Amount)
+	assert.Equal(test, "RF-1", approved.TransactionID)
+	assert.Len(test, fixture.gateway.refunds, 1)
+	assert.Equal(test, "10000", fixture.gateway.refunds[0].Get("amount"))
+
+	paid, _ := fixture.paymentRepo.FindByID(approved.PaymentID)
+	assert.Equal(test, model.Money(10000), paid.Refunded)
+	_, err = fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "store_credit"})
+	assert.Equal(test, core.Error.Conflict.ClaimStatus, err)
+
+	// The spoiled cabbage came back and was written off
+	movements := fixture.inventory.FindMovements(func(movement model.Movement) bool { return movement.SourceID == claim.ID })
+	assert.Len(test, movements, 2)
+	assert.Equal(test, model.MovementReturn, movements[0].Type)
+	assert.Equal(test, int64(500), movements[0].Quantity)
+	assert.Equal(test, model.MovementSpoilage, movements[1].Type)
+	assert.Equal(test, int64(-500), movements[1].Quantity)
+	assert.Equal(test, int64(2000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+}
+
+func testApprove_storeCreditForCash(test *testing.T) {
+	fixture := setupClaimService(test)
+	order := fixture.deliver(test, "user-1", "cod")
+	claim, _ := fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "250", "g", "bruised")}}, "vi")
+
+	_, err := fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "refund"})
+	assert.Equal(test, core.Error.Conflict.RefundMethod, err)
+
+	approved, err := fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "store_credit", Disposition: "restock"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(7000), approved.Amount)
+	balance, entries := fixture.credit.FindMine("user-1")
+	assert.Equal(test, model.Money(7000), balance)
+	assert.Equal(test, claim.ID, entries[0].SourceID)
+
+	// Restocked goods stay on the shelf
+	assert.Equal(test, int64(2250), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+	// The customer hears of it in the language they claimed in
+	sent := fixture.notifier.sent[len(fixture.notifier.sent)-1]
+	assert.Equal(test, "user-1", sent.UserID)
+	assert.Equal(test, "Khiếu nại cho đơn hàng "+order.Number+" của bạn đã được chấp nhận", sent.Subject)
+}
+
+func testApprove_movesOnlyGoodsThatCameBack(test *testing.T) {
+	fixture := setupClaimService(test)
+	order := fixture.deliver(test, "user-1", "cod")
+	claim, _ := fixture.claims.Create("user-1", order.ID, dto.ClaimRequest{Lines: []dto.ClaimLineRequest{claimLine("cabbage", "250", "g", "missing")}}, "en")
+
+	// Missing goods never came back, so there is nothing to restock or write off
+	_, err := fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "store_credit", Disposition: "restock"})
+	assert.Equal(test, core.Error.Invalid.ClaimDisposition, err)
+	_, err = fixture.claims.Approve("staff-1", claim.ID, dto.ApproveClaimRequest{Resolution: "store_credit"})
+	assert.NoError(test, err)
+	assert.Empty(test, fixture.inventory.FindMovements(func(movement model.Movement) bool { return movement.SourceID == claim.ID }))
+	assert.Equal(test, int64(2000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+}
+
+func TestClaimService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestClaim_neverExceedsDelivered", testClaim_neverExceedsDelivered)
+	test.Run("TestApprove_refundsCardPayment", testApprove_refundsCardPayment)
+	test.Run("TestApprove_storeCreditForCash", testApprove_storeCreditForCash)
+	test.Run("TestApprove_movesOnlyGoodsThatCameBack", testApprove_movesOnlyGoodsThatCameBack)
+}
@@ -0,0 +1,239 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/imaging"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/storage"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deliveryFixture struct {
+	*paymentFixture
+	deliveries service.DeliveryService
+	notifier   *fakeNotifier
+}
+
+var (
+	binh = model.OrderActor{ID: "shipper-1", Roles: []string{model.RoleShipper}}
+	chau = model.OrderActor{ID: "shipper-2", Roles: []string{model.RoleShipper}}
+)
+
+// setupDeliveryService registers two shippers, and opens a slot tomorrow evening taking ten orders of the five
+// kilograms of cabbage in stock
+func setupDeliveryService(test *testing.T) *deliveryFixture {
+	fixture := &deliveryFixture{paymentFixture: setupPaymentService(test), notifier: &fakeNotifier{}}
+	fixture.deliveries = service.NewDeliveryService(
+		repository.NewDeliveryRepository(fixture.datasource),
+		fixture.orders,
+		fixture.paymentRepo,
+		fixture.inventory,
+		fixture.slots,
+		storage.NewLocalStorage(test.TempDir(), "http://localhost:8080/api/v1/media"),
+		imaging.NewImageProcessor(),
+		fixture.notifier,
+		fixture.transactor,
+	)
+	for _, shipper := range []dto.ShipperRequest{
+		{UserID: binh.ID, Name: "Trần Văn Bình", Phone: "0912345678"},
+		{UserID: chau.ID, Name: "Lê Minh Châu", Phone: "0987654321"},
+	} {
+		_, err := fixture.deliveries.CreateShipper(shipper)
+		assert.NoError(test, err)
+	}
+
+	fixture.stock(test, "cabbage", 5000)
+	start := time.Now().Add(30 * time.Hour).Truncate(time.Hour)
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "tomorrow-evening", Start: start, End: start.Add(2 * time.Hour), Capacity: 10, Active: true})
+	return fixture
+}
+
+// ready places an order of 1 kg of cabbage in the evening slot, paid online unless it is cash on delivery, and
+// packs it for a shipper
+func (fixture *deliveryFixture) ready(test *testing.T, userID string, paymentMethod string, shipper model.OrderActor) *model.Order {
+	fixture.fill(test, userID, cartLine("cabbage", "1", "kg"))
+	order, err := fixture.checkout.Checkout(userID, checkoutRequest("tomorrow-evening", paymentMethod))
+	assert.NoError(test, err)
+	if paymentMethod != "cod" {
+		attempt, err := fixture.payments.Pay(userID, order.ID, "203.0.113.7")
+		assert.NoError(test, err)
+		fixture.gateway.pay(test, attempt.RedirectURL, "success")
+	}
+	_, err = fixture.deliveries.Assign("dispatcher-1", order.ID, dto.AssignDeliveryRequest{ShipperID: shipper.ID})
+	assert.NoError(test, err)
+	_, _ = fixture.move(order.ID, model.OrderPicking, "")
+	order, err = fixture.move(order.ID, model.OrderPacked, "")
+	assert.NoError(test, err)
+	return order
+}
+
+// proof uploads a small PNG as a proof of delivery
+func (fixture *deliveryFixture) proof(test *testing.T, shipper model.OrderActor, orderID string, kind string) (*model.Delivery, error) {
+	var content bytes.Buffer
+	assert.NoError(test, png.Encode(&content, image.NewGray(image.Rect(0, 0, 8, 8))))
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "proof.png")
+	_, _ = part.Write(content.Bytes())
+	_ = writer.Close()
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(test, err)
+	return fixture.deliveries.AddProof(shipper, orderID, kind, form.File["file"][0])
+}
+
+func testDelivery_fromAssignmentToDoor(test *testing.T) {
+	fixture := setupDeliveryService(test)
+	fixture.fill(test, "user-1", cartLine("cabbage", "1", "kg"))
+	order, _ := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-evening", "cod"))
+
+	_, err := fixture.deliveries.Assign("dispatcher-1", order.ID, dto.AssignDeliveryRequest{ShipperID: "nobody"})
+	assert.Equal(test, core.Error.NotFound.Shipper, err)
+	_, err = fixture.deliveries.Assign("dispatcher-1", order.ID, dto.AssignDeliveryRequest{ShipperID: chau.ID})
+	assert.NoError(test, err)
+	delivery, err := fixture.deliveries.Assign("dispatcher-1", order.ID, dto.AssignDeliveryRequest{ShipperID: binh.ID})
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(28000), delivery.CODDue)
+
+	// The customer sees who brings the order
+	placed, _ := fixture.orders.FindByID(order.ID)
+	assert.Equal(test, &model.ShipperContact{ID: binh.ID, Name: "Trần Văn Bình", Phone: "0912345678"}, placed.Shipper)
+	assert.Equal(test, "Order ORD-000001 was added to your run sheet", fixture.notifier.sent[1].Subject)
+
+	// Only once it is packed, and only by its shipper
+	_, err = fixture.deliveries.PickUp(binh, order.ID)
+	assert.Equal(test, core.Error.Conflict.OrderStatus, err)
+	_, _ = fixture.move(order.ID, model.OrderPicking, "")
+	_, _ = fixture.move(order.ID, model.OrderPacked, "")
+	_, err = fixture.deliveries.PickUp(chau, order.ID)
+	assert.Equal(test, core.Error.NotFound.Delivery, err)
+	delivery, err = fixture.deliveries.PickUp(binh, order.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.DeliveryPickedUp, delivery.Status)
+	placed, _ = fixture.orders.FindByID(order.ID)
+	assert.Equal(test, model.OrderOutForDelivery, placed.Status)
+	assert.Equal(test, "user-1", fixture.notifier.sent[2].UserID)
+	assert.Contains(test, fixture.notifier.sent[2].Body, "0912345678")
+	_, err = fixture.deliveries.Assign("dispatcher-1", order.ID, dto.AssignDeliveryRequest{ShipperID: chau.ID})
+	assert.Equal(test, core.Error.Conflict.OrderStatus, err)
+
+	// Handed over with proof and exactly the cash due
+	_, err = fixture.deliveries.Deliver(binh, order.ID, dto.DeliverRequest{CODCollected: 28000})
+	assert.Equal(test, core.Error.Conflict.ProofRequired, err)
+	_, err = fixture.proof(test, binh, order.ID, "fingerprint")
+	assert.Equal(test, core.Error.Invalid.Request, err)
+	delivery, err = fixture.proof(test, binh, order.ID, "signature")
+	assert.NoError(test, err)
+	assert.Equal(test, model.ProofSignature, delivery.Proofs[0].Kind)
+
+	// The proof is served to the customer, the shipper and staff only
+	for _, actor := range []model.OrderActor{{ID: "user-1"}, binh, orderStaff} {
+		_, contentType, err := fixture.deliveries.Proof(actor, order.ID, delivery.Proofs[0].ID)
+		assert.NoError(test, err)
+		assert.Equal(test, delivery.Proofs[0].ContentType, contentType)
+	}
+	_, _, err = fixture.deliveries.Proof(model.OrderActor{ID: "user-2"}, order.ID, delivery.Proofs[0].ID)
+	assert.Equal(test, core.Error.NotFound.Delivery, err)
+	_, _, err = fixture.deliveries.Proof(chau, order.ID, delivery.Proofs[0].ID)
+	assert.Equal(test, core.Error.NotFound.Delivery, err)
+	_, err = fixture.deliveries.Deliver(binh, order.ID, dto.DeliverRequest{CODCollected: 20000})
+	assert.Equal(test, core.Error.Conflict.PaymentAmount, err)
+	delivery, err = fixture.deliveries.Deliver(binh, order.ID, dto.DeliverRequest{CODCollected: 28000})
+	assert.NoError(test, err)
+	assert.Equal(test, model.DeliveryDelivered, delivery.Status)
+
+	placed, _ = fixture.orders.FindByID(order.ID)
+	assert.Equal(test, model.OrderDelivered, placed.Status)
+	payment, _ := fixture.paymentRepo.FindByID(delivery.PaymentID)
+	assert.Equal(test, model.PaymentPaid, payment.Status)
+	assert.Equal(test, binh.ID, payment.SettledBy)
+
+	date := placed.SlotStart.In(util.StoreLocation).Format(util.DateLayout)
+	stops, err := fixture.deliveries.RunSheet(binh.ID, dto.RunSheetQuery{Date: date})
+	assert.NoError(test, err)
+	assert.Len(test, stops, 1)
+	assert.Equal(test, "Quận 1", stops[0].Order.Address.District)
+	stops, _ = fixture.deliveries.RunSheet(chau.ID, dto.RunSheetQuery{Date: date})
+	assert.Empty(test, stops)
+}
+
+func testDelivery_settlesCODTheCustomerStarted(test *testing.T) {
+	fixture := setupDeliveryService(test)
+	order := fixture.ready(test, "user-1", "cod", binh)
+	started, err := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	assert.NoError(test, err)
+
+	_, _ = fixture.deliveries.PickUp(binh, order.ID)
+	_, _ = fixture.proof(test, binh, order.ID, "")
+	delivery, err := fixture.deliveries.Deliver(binh, order.ID, dto.DeliverRequest{CODCollected: 28000})
+	assert.NoError(test, err)
+	assert.Equal(test, started.ID, delivery.PaymentID)
+	assert.Len(test, fixture.paymentRepo.FindAll(func(model.Payment) bool { return true }), 1)
+}
+
+func testCashReport_reconcilesPerShipper(test *testing.T) {
+	fixture := setupDeliveryService(test)
+	cash := []*model.Order{fixture.ready(test, "user-1", "cod", binh), fixture.ready(test, "user-2", "cod", binh)}
+	card := fixture.ready(test, "user-3", "card", binh)
+	refused := fixture.ready(test, "user-4", "cod", chau)
+
+	for _, order := range append(cash, card) {
+		_, _ = fixture.deliveries.PickUp(binh, order.ID)
+		_, _ = fixture.proof(test, binh, order.ID, "photo")
+		delivery, _ := fixture.deliveries.FindByOrder(order.ID)
+		_, err := fixture.deliveries.Deliver(binh, order.ID, dto.DeliverRequest{CODCollected: int64(delivery.CODDue)})
+		assert.NoError(test, err)
+	}
+	_, _ = fixture.deliveries.PickUp(chau, refused.ID)
+	_, err := fixture.deliveries.Fail(chau, refused.ID, dto.FailDeliveryRequest{Reason: " "})
+	assert.Equal(test, core.Error.Invalid.Reason, err)
+	_, err = fixture.deliveries.Fail(chau, refused.ID, dto.FailDeliveryRequest{Reason: "Refused at the door"})
+	assert.NoError(test, err)
+	returned, _ := fixture.orders.FindByID(refused.ID)
+	assert.Equal(test, model.OrderReturned, returned.Status)
+
+	reports, err := fixture.deliveries.CashReport(dto.CashReportQuery{})
+	assert.NoError(test, err)
+	assert.Len(test, reports, 2)
+	assert.Equal(test, "Lê Minh Châu", reports[0].Shipper.Name)
+	assert.Equal(test, 1, reports[0].Failed)
+	assert.Equal(test, model.Money(0), reports[0].Collected)
+	assert.Equal(test, 3, reports[1].Delivered)
+	assert.Equal(test, model.Money(56000), reports[1].Collected)
+	assert.Equal(test, model.Money(56000), reports[1].Outstanding)
+
+	// The cash is handed over in full, once
+	today := util.StoreToday().Format(util.DateLayout)
+	_, err = fixture.deliveries.Remit("cashier-1", binh.ID, dto.RemitCashRequest{Date: today, Amount: 50000})
+	assert.Equal(test, core.Error.Conflict.CashRemitted, err)
+	report, err := fixture.deliveries.Remit("cashier-1", binh.ID, dto.RemitCashRequest{Date: today, Amount: 56000})
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(56000), report.Remitted)
+	assert.Equal(test, model.Money(0), report.Outstanding)
+	_, err = fixture.deliveries.Remit("cashier-1", binh.ID, dto.RemitCashRequest{Date: today, Amount: 56000})
+	assert.Equal(test, core.Error.Conflict.CashRemitted, err)
+
+	yesterday := util.StoreToday().AddDate(0, 0, -1).Format(util.DateLayout)
+	reports, _ = fixture.deliveries.CashReport(dto.CashReportQuery{Date: yesterday})
+	assert.Empty(test, reports)
+}
+
+func TestDeliveryService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestDelivery_fromAssignmentToDoor", testDelivery_fromAssignmentToDoor)
+	test.Run("TestDelivery_settlesCODTheCustomerStarted", testDelivery_settlesCODTheCustomerStarted)
+	test.Run("TestCashReport_reconcilesPerShipper", testCashReport_reconcilesPerShipper)
+}
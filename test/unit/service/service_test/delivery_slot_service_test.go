@@ -0,0 +1,235 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/scheduler"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deliverySlotFixture struct {
+	*store
+	service service.DeliverySlotService
+	slots   repository.DeliverySlotRepository
+}
+
+func setupDeliverySlotService(test *testing.T) *deliverySlotFixture {
+	store := newStore()
+	zoneRepo := repository.NewDeliveryZoneRepository(store.datasource)
+	zones := service.NewDeliveryZoneService(zoneRepo, store.locations, store.transactor)
+	_, err := zones.Create(dto.DeliveryZoneRequest{
+		ID:    "inner-city",
+		Name:  "Inner city",
+		Areas: []dto.ZoneAreaDto{{Province: "Hồ Chí Minh", District: "Quận 1"}},
+	})
+	assert.NoError(test, err)
+	_, err = zones.Create(dto.DeliveryZoneRequest{
+		ID:    "thu-duc",
+		Name:  "Thủ Đức",
+		Areas: []dto.ZoneAreaDto{{Province: "Hồ Chí Minh", District: "Thủ Đức", Wards: []string{"Thảo Điền"}}},
+	})
+	assert.NoError(test, err)
+
+	fixture := &deliverySlotFixture{store: store, slots: repository.NewDeliverySlotRepository(store.datasource)}
+	fixture.service = service.NewDeliverySlotService(fixture.slots, zoneRepo, fixture.orders, store.transactor, scheduler.NewScheduler())
+	return fixture
+}
+
+// everyDay adds a template for each weekday
+func (fixture *deliverySlotFixture) everyDay(test *testing.T, request dto.SlotTemplateRequest) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		request.Weekday = int(weekday)
+		_, err := fixture.service.CreateTemplate(request)
+		assert.NoError(test, err)
+	}
+}
+
+func testGenerate_opensTemplateSlotsOnce(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	_, err := fixture.service.CreateTemplate(dto.SlotTemplateRequest{ZoneID: "nowhere", Starts: "08:00", Ends: "10:00", Capacity: 5})
+	assert.Equal(test, core.Error.Invalid.DeliveryZone, err)
+	_, err = fixture.service.CreateTemplate(dto.SlotTemplateRequest{Starts: "8am", Ends: "10:00", Capacity: 5})
+	assert.Equal(test, core.Error.Invalid.SlotTemplate, err)
+
+	// An evening slot closing at noon: today's has closed or not depending on the time, the next 13 days' are open
+	fixture.everyDay(test, dto.SlotTemplateRequest{ZoneID: "inner-city", Starts: "18:00", Ends: "20:00", Cutoff: 360, Capacity: 10})
+	slots := fixture.slots.FindAll(func(model.DeliverySlot) bool { return true })
+	assert.GreaterOrEqual(test, len(slots), 13)
+	assert.LessOrEqual(test, len(slots), 14)
+	assert.Equal(test, 0, fixture.service.Generate())
+
+	last := slots[len(slots)-1].Start.In(util.StoreLocation)
+	assert.Equal(test, 18, last.Hour())
+	assert.Equal(test, util.StoreToday().AddDate(0, 0, 13), time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, util.StoreLocation))
+	assert.Equal(test, slots[0].Start.Add(-6*time.Hour), slots[0].Cutoff())
+}
+
+func testFindAvailable_forAddress(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	fixture.everyDay(test, dto.SlotTemplateRequest{ZoneID: "inner-city", Starts: "23:00", Ends: "23:59", Capacity: 10})
+	fixture.everyDay(test, dto.SlotTemplateRequest{ZoneID: "thu-duc", Starts: "23:00", Ends: "23:59", WeightCapacity: 50000})
+	tomorrow := util.StoreToday().AddDate(0, 0, 1).Format(util.DateLayout)
+	query := dto.DeliverySlotQuery{From: tomorrow, To: tomorrow}
+
+	all, _ := fixture.service.FindAvailable(query)
+	assert.Len(test, all, 2)
+
+	query.Province, query.District = "Hồ Chí Minh", "quận 1"
+	inner, err := fixture.service.FindAvailable(query)
+	assert.NoError(test, err)
+	assert.Len(test, inner, 1)
+	assert.Equal(test, "inner-city", inner[0].ZoneID)
+
+	query.District, query.Ward = "Thủ Đức", "Thao Dien"
+	thuDuc, _ := fixture.service.FindAvailable(query)
+	assert.Len(test, thuDuc, 1)
+	assert.Equal(test, "thu-duc", thuDuc[0].ZoneID)
+
+	// A slot serving every zone is offered in each of them, but nothing is outside the zones
+	start := util.StoreToday().AddDate(0, 0, 1).Add(9 * time.Hour)
+	_, err = fixture.service.Create(dto.DeliverySlotRequest{Start: start, End: start.Add(time.Hour), Capacity: 3})
+	assert.NoError(test, err)
+	thuDuc, _ = fixture.service.FindAvailable(query)
+	assert.Len(test, thuDuc, 2)
+	assert.Empty(test, thuDuc[0].ZoneID)
+	query.District, query.Ward = "Bình Chánh", ""
+	_, err = fixture.service.FindAvailable(query)
+	assert.Equal(test, core.Error.Invalid.OutOfDeliveryArea, err)
+}
+
+func testFindAvailable_hasRoomForOrder(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	start := util.StoreToday().AddDate(0, 0, 1).Add(9 * time.Hour)
+	tomorrow := start.Format(util.DateLayout)
+	full, err := fixture.service.Create(dto.DeliverySlotRequest{Start: start, End: start.Add(time.Hour), WeightCapacity: 5000})
+	assert.NoError(test, err)
+	light, err := fixture.service.Create(dto.DeliverySlotRequest{Start: start, End: start.Add(time.Hour), Capacity: 5, WeightCapacity: 5000})
+	assert.NoError(test, err)
+	for id, weight := range map[string]int64{full.ID: 5000, light.ID: 2000} {
+		_, err = fixture.slots.Update(context.Background(), id, func(slot *model.DeliverySlot) error {
+			return slot.Book(weight, time.Now())
+		})
+		assert.NoError(test, err)
+	}
+
+	// A slot with no weight left is not offered, and one with some left only to orders that fit in it
+	available, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: tomorrow, To: tomorrow})
+	assert.Len(test, available, 1)
+	assert.Equal(test, light.ID, available[0].ID)
+	available, _ = fixture.service.FindAvailable(dto.DeliverySlotQuery{From: tomorrow, To: tomorrow, Weight: 3000})
+	assert.Len(test, available, 1)
+	available, _ = fixture.service.FindAvailable(dto.DeliverySlotQuery{From: tomorrow, To: tomorrow, Weight: 3001})
+	assert.Empty(test, available)
+}
+
+func testHoliday_closesAndReopensSlots(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	day := util.StoreToday().AddDate(0, 0, 3)
+	date := day.Format(util.DateLayout)
+
+	holiday, err := fixture.service.CreateHoliday(dto.HolidayRequest{Date: date, ZoneID: "inner-city", Name: "Street festival"})
+	assert.NoError(test, err)
+	_, err = fixture.service.CreateHoliday(dto.HolidayRequest{Date: date, ZoneID: "inner-city", Name: "Twice"})
+	assert.Equal(test, core.Error.Conflict.Holiday, err)
+
+	// Slots generated on the holiday are born closed, in its zone only
+	fixture.everyDay(test, dto.SlotTemplateRequest{ZoneID: "inner-city", Starts: "08:00", Ends: "10:00", Capacity: 10})
+	fixture.everyDay(test, dto.SlotTemplateRequest{ZoneID: "thu-duc", Starts: "08:00", Ends: "10:00", Capacity: 10})
+	onHoliday, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: date, To: date})
+	assert.Len(test, onHoliday, 1)
+	assert.Equal(test, "thu-duc", onHoliday[0].ZoneID)
+
+	everywhere, err := fixture.service.CreateHoliday(dto.HolidayRequest{Date: date, Name: "Storm"})
+	assert.NoError(test, err)
+	closed, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: date, To: date})
+	assert.Empty(test, closed)
+	assert.Len(test, fixture.service.FindHolidays(), 2)
+
+	// Removing one holiday leaves the day closed by the other
+	_, err = fixture.service.DeleteHoliday(everywhere.ID)
+	assert.NoError(test, err)
+	reopened, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: date, To: date})
+	assert.Len(test, reopened, 1)
+	_, _ = fixture.service.DeleteHoliday(holiday.ID)
+	reopened, _ = fixture.service.FindAvailable(dto.DeliverySlotQuery{From: date, To: date})
+	assert.Len(test, reopened, 2)
+	_, err = fixture.service.DeleteHoliday(holiday.ID)
+	assert.Equal(test, core.Error.NotFound.Holiday, err)
+}
+
+func testUpdate_movesSlotOnAndOffHoliday(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	holiday := util.StoreToday().AddDate(0, 0, 3)
+	date := holiday.Format(util.DateLayout)
+	_, err := fixture.service.CreateHoliday(dto.HolidayRequest{Date: date, ZoneID: "inner-city", Name: "Street festival"})
+	assert.NoError(test, err)
+
+	dayBefore := holiday.AddDate(0, 0, -1).Add(8 * time.Hour)
+	request := dto.DeliverySlotRequest{ZoneID: "inner-city", Start: dayBefore, End: dayBefore.Add(2 * time.Hour), Capacity: 5}
+	slot, err := fixture.service.Create(request)
+	assert.NoError(test, err)
+	assert.Empty(test, slot.HolidayID)
+
+	request.Start, request.End = request.Start.AddDate(0, 0, 1), request.End.AddDate(0, 0, 1)
+	slot, err = fixture.service.Update(slot.ID, request)
+	assert.NoError(test, err)
+	assert.NotEmpty(test, slot.HolidayID)
+	closed, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: date, To: date})
+	assert.Empty(test, closed)
+
+	request.Start, request.End = request.Start.AddDate(0, 0, 1), request.End.AddDate(0, 0, 1)
+	slot, err = fixture.service.Update(slot.ID, request)
+	assert.NoError(test, err)
+	assert.Empty(test, slot.HolidayID)
+	dayAfter := holiday.AddDate(0, 0, 1).Format(util.DateLayout)
+	reopened, _ := fixture.service.FindAvailable(dto.DeliverySlotQuery{From: dayAfter, To: dayAfter})
+	assert.Len(test, reopened, 1)
+}
+
+func testUpdate_movesBookedOrders(test *testing.T) {
+	fixture := setupDeliverySlotService(test)
+	start := util.StoreToday().AddDate(0, 0, 1).Add(9 * time.Hour)
+	request := dto.DeliverySlotRequest{ZoneID: "inner-city", Start: start, End: start.Add(time.Hour), Capacity: 5}
+	slot, err := fixture.service.Create(request)
+	assert.NoError(test, err)
+	_, _ = fixture.slots.Update(context.Background(), slot.ID, func(slot *model.DeliverySlot) error {
+		return slot.Book(1000, time.Now())
+	})
+	booked := fixture.orders.Create(context.Background(), model.Order{
+		ID: "booked", Status: model.OrderConfirmed, ZoneID: "inner-city", SlotID: slot.ID, SlotStart: slot.Start, SlotEnd: slot.End,
+	})
+
+	// The booked order cannot be left in a zone the slot no longer serves
+	request.ZoneID = "thu-duc"
+	_, err = fixture.service.Update(slot.ID, request)
+	assert.Equal(test, core.Error.Conflict.SlotBooked, err)
+
+	// Retimed, the slot takes its orders along
+	request.ZoneID = ""
+	request.Start, request.End = start.Add(2*time.Hour), start.Add(3*time.Hour)
+	_, err = fixture.service.Update(slot.ID, request)
+	assert.NoError(test, err)
+	order, _ := fixture.orders.FindByID(booked.ID)
+	assert.Equal(test, request.Start, order.SlotStart)
+	assert.Equal(test, request.End, order.SlotEnd)
+}
+
+func TestDeliverySlotService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestGenerate_opensTemplateSlotsOnce", testGenerate_opensTemplateSlotsOnce)
+	test.Run("TestFindAvailable_forAddress", testFindAvailable_forAddress)
+	test.Run("TestFindAvailable_hasRoomForOrder", testFindAvailable_hasRoomForOrder)
+	test.Run("TestHoliday_closesAndReopensSlots", testHoliday_closesAndReopensSlots)
+	test.Run("TestUpdate_movesSlotOnAndOffHoliday", testUpdate_movesSlotOnAndOffHoliday)
+	test.Run("TestUpdate_movesBookedOrders", testUpdate_movesBookedOrders)
+}
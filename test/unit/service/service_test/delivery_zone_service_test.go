@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testQuote_pricesLikeCheckout(test *testing.T) {
+	store := newStore()
+	warehouse, _ := store.locations.FindByID(model.DefaultLocationID)
+	warehouse.Address.Point = &model.GeoPoint{Latitude: 10.7725, Longitude: 106.6980}
+	_ = store.locations.Update(context.Background(), *warehouse)
+	zones := service.NewDeliveryZoneService(repository.NewDeliveryZoneRepository(store.datasource), store.locations, store.transactor)
+
+	_, err := zones.Create(dto.DeliveryZoneRequest{ID: "nowhere", Name: "Nowhere"})
+	assert.Equal(test, core.Error.Invalid.DeliveryZone, err)
+	_, err = zones.Create(dto.DeliveryZoneRequest{
+		ID: "district-4", Name: "District 4",
+		Areas: []dto.ZoneAreaDto{{Province: "Hồ Chí Minh", District: "Quận 4"}},
+		Fees:  dto.DeliveryFeeRuleDto{BaseFee: 15000, PerKm: 3000, PerKg: 2000, IncludedWeight: 2000, FreeFrom: 300000},
+	})
+	assert.NoError(test, err)
+
+	// Named but not geocoded: no distance to charge for
+	address := dto.AddressDto{Street: "1 Khánh Hội", District: "Quận 4", Province: "Hồ Chí Minh"}
+	zone, quote, err := zones.Quote(dto.DeliveryQuoteRequest{Address: address, Subtotal: 120000, Weight: 2500})
+	assert.NoError(test, err)
+	assert.Equal(test, "district-4", zone.ID)
+	assert.Equal(test, int64(0), quote.Distance)
+	assert.Equal(test, model.Money(17000), quote.Fee)
+
+	latitude, longitude := 10.7590, 106.7050
+	address.Latitude, address.Longitude = &latitude, &longitude
+	_, quote, _ = zones.Quote(dto.DeliveryQuoteRequest{Address: address, Subtotal: 120000, Weight: 2500})
+	assert.InDelta(test, 1680, quote.Distance, 30)
+	assert.Equal(test, model.Money(15000+2*3000+2000), quote.Fee)
+
+	address.District = "Quận 7"
+	_, _, err = zones.Quote(dto.DeliveryQuoteRequest{Address: address})
+	assert.Equal(test, core.Error.Invalid.OutOfDeliveryArea, err)
+}
+
+func TestDeliveryZoneService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestQuote_pricesLikeCheckout", testQuote_pricesLikeCheckout)
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/data"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// store is the in-memory backing every service fixture is built on. Tables live in the repository that made them,
+// so the repositories services share are made here once and handed to each of them.
+type store struct {
+	datasource *data.Datasource
+	transactor infra_interface.Transactor
+	products   repository.ProductRepository
+	inventory  repository.InventoryRepository
+	locations  repository.LocationRepository
+	orders     repository.OrderRepository
+}
+
+func newStore() *store {
+	datasource := data.NewDatasource()
+	return &store{
+		datasource: datasource,
+		transactor: data.NewTransactor(datasource),
+		products:   repository.NewProductRepository(datasource),
+		inventory:  repository.NewInventoryRepository(datasource),
+		locations:  repository.NewLocationRepository(datasource),
+		orders:     repository.NewOrderRepository(datasource),
+	}
+}
+
+// newGreengrocer is a store selling lettuce by the piece and cabbage by weight
+func newGreengrocer() *store {
+	store := newStore()
+	store.add(
+		model.Product{
+			ID: "lettuce", SKU: "VEG-010", Name: "Lettuce", Price: 15000, PriceUnit: model.UnitPiece, UnitWeight: 300, Active: true,
+			SaleRule: model.SaleRule{Unit: model.UnitPiece, MinQuantity: 1, Step: 1, MaxQuantity: 10},
+		},
+		model.Product{
+			ID: "cabbage", SKU: "VEG-011", Name: "Cabbage", Price: 28000, PriceUnit: model.UnitKilogram, Active: true,
+			SaleRule: model.SaleRule{Unit: model.UnitKilogram, MinQuantity: 500, Step: 250},
+		},
+	)
+	return store
+}
+
+func (store *store) add(products ...model.Product) {
+	for _, product := range products {
+		_ = store.products.Create(context.Background(), product)
+	}
+}
+
+// stock sets what is on hand at the default location, bypassing the ledger
+func (store *store) stock(test *testing.T, productID string, onHand int64) {
+	_, err := store.inventory.UpdateLevel(context.Background(), productID, model.DefaultLocationID, func(level *model.StockLevel) error {
+		level.OnHand = onHand
+		return nil
+	})
+	assert.NoError(test, err)
+}
+
+// fakeNotifier keeps notifications in memory instead of delivering them
+type fakeNotifier struct {
+	mutex sync.Mutex
+	sent  []infra_interface.Notification
+}
+
+func (notifier *fakeNotifier) Notify(notification infra_interface.Notification) error {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	notifier.sent = append(notifier.sent, notification)
+	return nil
+}
+
+func (notifier *fakeNotifier) Name() string { return "FakeNotifier" }
+func (notifier *fakeNotifier) Start() error { return nil }
+func (notifier *fakeNotifier) Stop() error  { return nil }
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type inventoryFixture struct {
+	*store
+	service service.InventoryService
+}
+
+func setupInventoryService(test *testing.T, lettuce string, cabbage string) *inventoryFixture {
+	fixture := &inventoryFixture{store: newGreengrocer()}
+	fixture.service = service.NewInventoryService(fixture.inventory, fixture.products, fixture.locations, fixture.transactor, scheduler.NewScheduler())
+	_, err := fixture.service.Adjust("staff-1", dto.AdjustStockRequest{ProductID: "lettuce", Quantity: dto.QuantityDto{Value: lettuce, Unit: "piece"}, Direction: "in", Reason: "recount"})
+	assert.NoError(test, err)
+	_, err = fixture.service.Adjust("staff-1", dto.AdjustStockRequest{ProductID: "cabbage", Quantity: dto.QuantityDto{Value: cabbage, Unit: "kg"}, Direction: "in", Reason: "recount"})
+	assert.NoError(test, err)
+	return fixture
+}
+
+func reserve(reference string, items ...dto.ReservationItemDto) dto.ReserveRequest {
+	return dto.ReserveRequest{Reference: reference, Items: items}
+}
+
+func item(productID string, value string, unit string) dto.ReservationItemDto {
+	return dto.ReservationItemDto{ProductID: productID, Quantity: dto.QuantityDto{Value: value, Unit: unit}}
+}
+
+func testReserve_allOrNothing(test *testing.T) {
+	fixture := setupInventoryService(test, "1", "2")
+
+	_, err := fixture.service.Reserve(reserve("checkout-1", item("cabbage", "500", "g"), item("lettuce", "2", "piece")))
+	assert.Equal(test, core.Error.Conflict.InsufficientStock, err)
+	assert.Equal(test, int64(0), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).Reserved)
+	assert.Empty(test, fixture.service.FindReservations(dto.ReservationQuery{}))
+
+	reservations, err := fixture.service.Reserve(reserve("checkout-2", item("cabbage", "0.5", "kg"), item("lettuce", "1", "piece")))
+	assert.NoError(test, err)
+	assert.Len(test, reservations, 2)
+	cabbage := fixture.inventory.FindLevel("cabbage", model.DefaultLocationID)
+	assert.Equal(test, int64(1500), cabbage.Available())
+}
+
+func testCommitAndRelease(test *testing.T) {
+	fixture := setupInventoryService(test, "3", "1")
+	reservations, _ := fixture.service.Reserve(reserve("checkout-1", item("lettuce", "2", "piece")))
+
+	committed, err := fixture.service.Commit("staff-1", reservations[0].ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.ReservationCommitted, committed.Status)
+	level := fixture.inventory.FindLevel("lettuce", model.DefaultLocationID)
+	assert.Equal(test, int64(1), level.OnHand)
+	assert.Equal(test, int64(0), level.Reserved)
+
+	_, err = fixture.service.Release(reservations[0].ID)
+	assert.Equal(test, core.Error.Conflict.ReservationClosed, err)
+	_, err = fixture.service.Release("missing")
+	assert.Equal(test, core.Error.NotFound.Reservation, err)
+}
+
+func testReservation_expiresAfterTTL(test *testing.T) {
+	ttl := core.Configs.Inventory.ReservationTTL
+	core.Configs.Inventory.ReservationTTL = "20ms"
+	defer func() { core.Configs.Inventory.ReservationTTL = ttl }()
+	fixture := setupInventoryService(test, "1", "1")
+
+	first, err := fixture.service.Reserve(reserve("checkout-1", item("lettuce", "1", "piece")))
+	assert.NoError(test, err)
+	_, err = fixture.service.Reserve(reserve("checkout-2", item("lettuce", "1", "piece")))
+	assert.Equal(test, core.Error.Conflict.InsufficientStock, err)
+
+	time.Sleep(30 * time.Millisecond)
+
+	// The overdue hold is released as soon as someone else needs the stock
+	_, err = fixture.service.Reserve(reserve("checkout-3", item("lettuce", "1", "piece")))
+	assert.NoError(test, err)
+	_, err = fixture.service.Commit("staff-1", first[0].ID)
+	assert.Equal(test, core.Error.Conflict.ReservationClosed, err)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(test, 1, fixture.service.ExpireReservations())
+	level := fixture.inventory.FindLevel("lettuce", model.DefaultLocationID)
+	assert.Equal(test, int64(1), level.Available())
+}
+
+// testStress_lastLettuce - many customers check out the last head of lettuce at the same moment
+func testStress_lastLettuce(test *testing.T) {
+	fixture := setupInventoryService(test, "1", "0.1")
+	const customers = 500
+
+	var wait sync.WaitGroup
+	var mutex sync.Mutex
+	start := make(chan struct{})
+	succeeded, rejected := 0, 0
+	for customer := range customers {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			<-start
+			_, err := fixture.service.Reserve(reserve("checkout-"+strconv.Itoa(customer), item("lettuce", "1", "piece")))
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err == nil {
+				succeeded++
+			} else if err == core.Error.Conflict.InsufficientStock {
+				rejected++
+			}
+		}()
+	}
+	close(start)
+	wait.Wait()
+
+	assert.Equal(test, 1, succeeded)
+	assert.Equal(test, customers-1, rejected)
+	level := fixture.inventory.FindLevel("lettuce", model.DefaultLocationID)
+	assert.Equal(test, int64(1), level.Reserved)
+	assert.Equal(test, int64(0), level.Available())
+}
+
+// testStress_mixedCheckouts - concurrent reserve, commit and release never sell more than was on hand
+func testStress_mixedCheckouts(test *testing.T) {
+	fixture := setupInventoryService(test, "100", "20")
+	const workers, rounds = 50, 40
+
+	var wait sync.WaitGroup
+	for worker := range workers {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for round := range rounds {
+				reference := strconv.Itoa(worker) + "-" + strconv.Itoa(round)
+				reservations, err := fixture.service.Reserve(reserve(reference,
+					item("lettuce", strconv.Itoa(1+rand.IntN(3)), "piece"),
+					item("cabbage", strconv.Itoa(100*(1+rand.IntN(5))), "g"),
+				))
+				if err != nil {
+					assert.Equal(test, core.Error.Conflict.InsufficientStock, err)
+					continue
+				}
+				for _, reservation := range reservations {
+					if rand.IntN(2) == 0 {
+						_, err = fixture.service.Commit("staff-1", reservation.ID)
+					} else {
+						_, err = fixture.service.Release(reservation.ID)
+					}
+					assert.NoError(test, err)
+				}
+			}
+		}()
+	}
+	wait.Wait()
+
+	for _, product := range []struct {
+		id      string
+		initial int64
+	}{{"lettuce", 100}, {"cabbage", 20000}} {
+		sold := int64(0)
+		for _, reservation := range fixture.service.FindReservations(dto.ReservationQuery{Status: "committed"}) {
+			if reservation.ProductID == product.id {
+				sold += reservation.Quantity
+			}
+		}
+		level := fixture.inventory.FindLevel(product.id, model.DefaultLocationID)
+		assert.LessOrEqual(test, sold, product.initial)
+		assert.Equal(test, product.initial-sold, level.OnHand)
+		assert.Equal(test, int64(0), level.Reserved)
+	}
+	// Every sale went through the ledger, in order
+	movements := fixture.inventory.FindMovements(func(model.Movement) bool { return true })
+	sums := model.SumMovements(movements)
+	assert.Equal(test, fixture.inventory.FindLevel("lettuce", model.DefaultLocationID).OnHand, sums[model.StockKey{ProductID: "lettuce", LocationID: model.DefaultLocationID}])
+	for i, movement := range movements {
+		assert.Equal(test, int64(i+1), movement.Sequence)
+	}
+	assert.Empty(test, fixture.service.FindReservations(dto.ReservationQuery{Status: "active"}))
+}
+
+func testTransfer_inTransitUntilReceived(test *testing.T) {
+	fixture := setupInventoryService(test, "10", "1")
+	_ = fixture.locations.Create(context.Background(), model.Location{ID: "store-district-1", Type: model.LocationStore, Active: true})
+	_, _ = fixture.service.Reserve(reserve("checkout-1", item("lettuce", "4", "piece")))
+	transfer := func(value string) dto.TransferRequest {
+		return dto.TransferRequest{
+			ProductID:      "lettuce",
+			FromLocationID: model.DefaultLocationID,
+			ToLocationID:   "store-district-1",
+			Quantity:       dto.QuantityDto{Value: value, Unit: "piece"},
+		}
+	}
+
+	// Reserved lettuces stay at the warehouse
+	_, err := fixture.service.Transfer("staff-1", transfer("7"))
+	assert.Equal(test, core.Error.Conflict.InsufficientStock, err)
+
+	sent, err := fixture.service.Transfer("staff-1", transfer("6"))
+	assert.NoError(test, err)
+	assert.Equal(test, int64(4), fixture.inventory.FindLevel("lettuce", model.DefaultLocationID).OnHand)
+	store := fixture.inventory.FindLevel("lettuce", "store-district-1")
+	assert.Equal(test, int64(0), store.OnHand)
+	assert.Equal(test, int64(6), store.InTransit)
+
+	availability, err := fixture.service.Availability("lettuce")
+	assert.NoError(test, err)
+	// The rest of the warehouse stock is reserved and nothing has arrived at the store yet
+	assert.Equal(test, "0", availability.Available.Value)
+	assert.Len(test, availability.Locations, 2)
+
+	received, err := fixture.service.ReceiveTransfer("staff-1", sent.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.TransferReceived, received.Status)
+	store = fixture.inventory.FindLevel("lettuce", "store-district-1")
+	assert.Equal(test, int64(6), store.OnHand)
+	assert.Equal(test, int64(0), store.InTransit)
+
+	_, err = fixture.service.CancelTransfer("staff-1", sent.ID)
+	assert.Equal(test, core.Error.Conflict.TransferClosed, err)
+}
+
+func testCancelTransfer_returnsStock(test *testing.T) {
+	fixture := setupInventoryService(test, "5", "1")
+	_ = fixture.locations.Create(context.Background(), model.Location{ID: "dark-store-7", Type: model.LocationDarkStore, Active: true})
+
+	sent, err := fixture.service.Transfer("staff-1", dto.TransferRequest{
+		ProductID:      "cabbage",
+		FromLocationID: model.DefaultLocationID,
+		ToLocationID:   "dark-store-7",
+		Quantity:       dto.QuantityDto{Value: "400", Unit: "g"},
+	})
+	assert.NoError(test, err)
+
+	_, err = fixture.service.CancelTransfer("staff-1", sent.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, int64(1000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+	assert.Equal(test, int64(0), fixture.inventory.FindLevel("cabbage", "dark-store-7").InTransit)
+
+	_, err = fixture.service.Transfer("staff-1", dto.TransferRequest{
+		ProductID:      "cabbage",
+		FromLocationID: model.DefaultLocationID,
+		ToLocationID:   model.DefaultLocationID,
+		Quantity:       dto.QuantityDto{Value: "1", Unit: "kg"},
+	})
+	assert.Equal(test, core.Error.Invalid.Transfer, err)
+}
+
+func TestInventoryService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestReserve_allOrNothing", testReserve_allOrNothing)
+	test.Run("TestCommitAndRelease", testCommitAndRelease)
+	test.Run("TestReservation_expiresAfterTTL", testReservation_expiresAfterTTL)
+	test.Run("TestTransfer_inTransitUntilReceived", testTransfer_inTransitUntilReceived)
+	test.Run("TestCancelTransfer_returnsStock", testCancelTransfer_returnsStock)
+	test.Run("TestStress_lastLettuce", testStress_lastLettuce)
+	test.Run("TestStress_mixedCheckouts", testStress_mixedCheckouts)
+}
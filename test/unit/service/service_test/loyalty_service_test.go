@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (fixture *checkoutFixture) setStatus(id string, status model.OrderStatus) {
+	_, _ = fixture.orders.Update(context.Background(), id, func(order *model.Order) error {
+		order.Status = status
+		return nil
+	})
+}
+
+func testLoyalty_earnsOnDeliveredOrdersOnce(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	fixture.fill(test, "user-1", cartLine("lettuce", "2", "piece"), cartLine("cabbage", "1", "kg"))
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+
+	// Nothing is earned before delivery
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+
+	// 58000 paid earns 5 points at the member rate, once
+	fixture.setStatus(order.ID, model.OrderDelivered)
+	assert.Equal(test, model.LoyaltyRun{Earned: 1}, fixture.loyalty.Run())
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+
+	account := fixture.loyalty.FindAccount("user-1")
+	assert.Equal(test, int64(5), account.Balance)
+	assert.Equal(test, int64(5), account.TierPoints)
+	assert.Equal(test, model.TierMember, account.Tier.Tier)
+	assert.Equal(test, model.TierSilver, account.Next.Tier)
+
+	history := fixture.loyalty.FindHistory("user-1", dto.LoyaltyHistoryQuery{})
+	assert.Equal(test, 1, history.Total)
+	assert.Equal(test, model.LoyaltyEarn, history.Items[0].Kind)
+	assert.Equal(test, order.ID, history.Items[0].OrderID)
+	assert.Equal(test, model.Message{ID: "Loyalty.OrderDelivered", Data: map[string]any{"Number": order.Number}}, history.Items[0].Note)
+	assert.Equal(test, "Đơn hàng "+order.Number+" đã giao", dto.ToLoyaltyEntryResponse(&history.Items[0], "vi").Note)
+	assert.True(test, history.Items[0].ExpiresAt.After(time.Now().AddDate(0, 11, 0)))
+
+	// An order paid for under 10000 earns nothing, and is settled all the same
+	_, err = fixture.loyalty.Adjust("admin-1", "user-2", dto.LoyaltyAdjustRequest{Points: 75, Note: "Welcome gift"})
+	assert.NoError(test, err)
+	fixture.fill(test, "user-2", cartLine("lettuce", "1", "piece"))
+	request := checkoutRequest("tomorrow-morning", "cod")
+	request.RedeemPoints = 75
+	cheap, err := fixture.checkout.Checkout("user-2", request)
+	assert.NoError(test, err)
+	fixture.setStatus(cheap.ID, model.OrderDelivered)
+	assert.Equal(test, model.LoyaltyRun{Earned: 1}, fixture.loyalty.Run())
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+	assert.Equal(test, int64(0), fixture.loyalty.FindAccount("user-2").Balance)
+}
+
+func testLoyalty_settlesReturnedOrders(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	_, err := fixture.loyalty.Adjust("admin-1", "user-1", dto.LoyaltyAdjustRequest{Points: 300, Note: "Welcome gift"})
+	assert.NoError(test, err)
+	fixture.fill(test, "user-1", cartLine("lettuce", "2", "piece"), cartLine("cabbage", "1", "kg"))
+	request := checkoutRequest("tomorrow-morning", "cod")
+	request.RedeemPoints = 200
+	redeemed, err := fixture.checkout.Checkout("user-1", request)
+	assert.NoError(test, err)
+	fixture.fill(test, "user-2", cartLine("lettuce", "1", "piece"))
+	paid, err := fixture.checkout.Checkout("user-2", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+
+	// 38000 paid earns 3 points, 15000 earns 1
+	fixture.setStatus(redeemed.ID, model.OrderDelivered)
+	fixture.setStatus(paid.ID, model.OrderDelivered)
+	assert.Equal(test, model.LoyaltyRun{Earned: 2}, fixture.loyalty.Run())
+	assert.Equal(test, int64(103), fixture.loyalty.FindAccount("user-1").Balance)
+
+	// Returned, the orders give back the points spent on them and take back those they earned, once
+	fixture.setStatus(redeemed.ID, model.OrderReturned)
+	fixture.setStatus(paid.ID, model.OrderReturned)
+	assert.Equal(test, model.LoyaltyRun{GivenBack: 1, TakenBack: 1}, fixture.loyalty.Run())
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+	assert.Equal(test, int64(300), fixture.loyalty.FindAccount("user-1").Balance)
+	assert.Equal(test, int64(0), fixture.loyalty.FindAccount("user-2").Balance)
+	history := fixture.loyalty.FindHistory("user-2", dto.LoyaltyHistoryQuery{Kind: "adjust"})
+	assert.Equal(test, int64(-1), history.Items[0].Points)
+	assert.Equal(test, "Order "+paid.Number+" returned", dto.ToLoyaltyEntryResponse(&history.Items[0], "en").Note)
+}
+
+func testCheckout_redeemsLoyaltyPoints(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	_, err := fixture.loyalty.Adjust("admin-1", "user-1", dto.LoyaltyAdjustRequest{Points: 300, Note: "Welcome gift"})
+	assert.NoError(test, err)
+	fixture.fill(test, "user-1", cartLine("lettuce", "2", "piece"), cartLine("cabbage", "1", "kg"))
+
+	// Points pay for at most half of the 58000, and only the customer's own
+	request := checkoutRequest("tomorrow-morning", "cod")
+	request.RedeemPoints = 291
+	_, err = fixture.checkout.Checkout("user-1", request)
+	assert.Equal(test, core.Error.Invalid.RedeemPoints, err)
+	_, err = fixture.checkout.Checkout("user-2", request)
+	assert.Equal(test, core.Error.Invalid.EmptyCart, err)
+	fixture.fill(test, "user-2", cartLine("lettuce", "1", "piece"))
+	request.RedeemPoints = 1
+	_, err = fixture.checkout.Checkout("user-2", request)
+	assert.Equal(test, core.Error.Conflict.InsufficientPoints, err)
+
+	request.RedeemPoints = 200
+	order, err := fixture.checkout.Checkout("user-1", request)
+	assert.NoError(test, err)
+	assert.Equal(test, int64(200), order.PointsRedeemed)
+	assert.Equal(test, model.Money(20000), order.PointsDiscount)
+	assert.Equal(test, model.Money(38000), order.Total)
+	assert.Equal(test, int64(100), fixture.loyalty.FindAccount("user-1").Balance)
+
+	// Points spent on an order that was cancelled are given back once; the order earns nothing
+	fixture.setStatus(order.ID, model.OrderCancelled)
+	assert.Equal(test, model.LoyaltyRun{GivenBack: 1}, fixture.loyalty.Run())
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+	assert.Equal(test, int64(300), fixture.loyalty.FindAccount("user-1").Balance)
+
+	history := fixture.loyalty.FindHistory("user-1", dto.LoyaltyHistoryQuery{})
+	assert.Equal(test, 3, history.Total)
+	assert.Equal(test, model.LoyaltyAdjust, history.Items[0].Kind)
+	assert.Equal(test, model.SystemActor, history.Items[0].ActorID)
+	assert.Equal(test, int64(200), history.Items[0].Points)
+	assert.Equal(test, model.LoyaltyRedeem, history.Items[1].Kind)
+	assert.Equal(test, int64(-200), history.Items[1].Points)
+}
+
+func testLoyalty_adjustsAndExpires(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	now := time.Now()
+	fixture.points.Append(context.Background(), model.LoyaltyEntry{
+		ID: "old", UserID: "user-1", Kind: model.LoyaltyEarn, Points: 120,
+		CreatedAt: now.AddDate(-1, 0, -1), ExpiresAt: now.AddDate(0, 0, -1),
+	})
+	_, err := fixture.loyalty.Adjust("admin-1", "user-1", dto.LoyaltyAdjustRequest{Points: 80, Note: "Late delivery"})
+	assert.NoError(test, err)
+
+	// Expired points are left out of the balance before the run records them
+	assert.Equal(test, int64(80), fixture.loyalty.FindAccount("user-1").Balance)
+	_, err = fixture.loyalty.Adjust("admin-1", "user-1", dto.LoyaltyAdjustRequest{Points: -81, Note: "Fraud"})
+	assert.Equal(test, core.Error.Conflict.InsufficientPoints, err)
+	_, err = fixture.loyalty.Adjust("admin-1", "nobody", dto.LoyaltyAdjustRequest{Points: 80, Note: "Typo"})
+	assert.Equal(test, core.Error.NotFound.User, err)
+
+	assert.Equal(test, model.LoyaltyRun{Expired: 1}, fixture.loyalty.Run())
+	assert.Equal(test, model.LoyaltyRun{}, fixture.loyalty.Run())
+	expired := fixture.loyalty.FindHistory("user-1", dto.LoyaltyHistoryQuery{Kind: "expire"})
+	assert.Equal(test, 1, expired.Total)
+	assert.Equal(test, int64(-120), expired.Items[0].Points)
+	assert.Equal(test, "Điểm hết hạn", dto.ToLoyaltyEntryResponse(&expired.Items[0], "vi").Note)
+
+	entry, err := fixture.loyalty.Adjust("admin-1", "user-1", dto.LoyaltyAdjustRequest{Points: -30, Note: "Duplicate gift"})
+	assert.NoError(test, err)
+	assert.Equal(test, "admin-1", entry.ActorID)
+	assert.True(test, entry.ExpiresAt.IsZero())
+	assert.Equal(test, "Duplicate gift", dto.ToLoyaltyEntryResponse(entry, "vi").Note) // Staff's words are kept as typed
+	assert.Equal(test, int64(50), fixture.loyalty.FindAccount("user-1").Balance)
+	assert.Equal(test, 4, fixture.loyalty.FindHistory("user-1", dto.LoyaltyHistoryQuery{}).Total)
+}
+
+func TestLoyaltyService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestLoyalty_earnsOnDeliveredOrdersOnce", testLoyalty_earnsOnDeliveredOrdersOnce)
+	test.Run("TestCheckout_redeemsLoyaltyPoints", testCheckout_redeemsLoyaltyPoints)
+	test.Run("TestLoyalty_settlesReturnedOrders", testLoyalty_settlesReturnedOrders)
+	test.Run("TestLoyalty_adjustsAndExpires", testLoyalty_adjustsAndExpires)
+}
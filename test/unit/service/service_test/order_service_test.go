@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var orderStaff = model.OrderActor{ID: "staff-1", Roles: []string{model.RoleStaff}}
+
+type orderFixture struct {
+	*checkoutFixture
+	orderService service.OrderService
+}
+
+func setupOrderService(test *testing.T) *orderFixture {
+	fixture := &orderFixture{checkoutFixture: setupCheckoutService(test)}
+	fixture.orderService = service.NewOrderService(fixture.orders, fixture.inventory, fixture.slots, fixture.transactor, scheduler.NewScheduler())
+	return fixture
+}
+
+func (fixture *orderFixture) place(test *testing.T, userID string, paymentMethod string) *model.Order {
+	fixture.fill(test, userID, cartLine("cabbage", "1", "kg"))
+	order, err := fixture.checkout.Checkout(userID, checkoutRequest("tomorrow-morning", paymentMethod))
+	assert.NoError(test, err)
+	return order
+}
+
+func (fixture *orderFixture) move(id string, status model.OrderStatus, reason string) (*model.Order, error) {
+	return fixture.orderService.Transition(orderStaff, id, dto.OrderTransitionRequest{Status: string(status), Reason: reason})
+}
+
+func testOrderWorkflow_shipsHeldStock(test *testing.T) {
+	fixture := setupOrderService(test)
+	order := fixture.place(test, "user-1", "cod")
+
+	_, err := fixture.move(order.ID, model.OrderPicking, "")
+	assert.NoError(test, err)
+	_, err = fixture.move(order.ID, model.OrderOutForDelivery, "")
+	assert.Equal(test, core.Error.Conflict.OrderStatus, err)
+	_, _ = fixture.move(order.ID, model.OrderPacked, "")
+	_, err = fixture.move(order.ID, model.OrderOutForDelivery, "")
+	assert.NoError(test, err)
+
+	// Leaving the store turns the hold into a sale
+	level := fixture.inventory.FindLevel("cabbage", model.DefaultLocationID)
+	assert.Equal(test, int64(0), level.Reserved)
+	assert.Equal(test, int64(2000), level.OnHand)
+	reservation, _ := fixture.inventory.FindReservation(order.Lines[0].ReservationID)
+	assert.Equal(test, model.ReservationCommitted, reservation.Status)
+
+	delivered, err := fixture.move(order.ID, model.OrderDelivered, "")
+	assert.NoError(test, err)
+	assert.Len(test, delivered.History, 5)
+
+	verifier := service.NewPurchaseVerifier(fixture.orders)
+	assert.True(test, verifier.HasReceived("user-1", "cabbage"))
+	assert.False(test, verifier.HasReceived("user-1", "lettuce"))
+	assert.False(test, verifier.HasReceived("user-2", "cabbage"))
+}
+
+func testOrderCancel_givesBackStockAndSlot(test *testing.T) {
+	fixture := setupOrderService(test)
+	order := fixture.place(test, "user-1", "cod")
+
+	_, err := fixture.orderService.Cancel("user-2", order.ID, dto.CancelOrderRequest{Reason: "Not mine"})
+	assert.Equal(test, core.Error.NotFound.Order, err)
+
+	cancelled, err := fixture.orderService.Cancel("user-1", order.ID, dto.CancelOrderRequest{Reason: "Ordered twice"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.OrderCancelled, cancelled.Status)
+	assert.Equal(test, "user-1", cancelled.History[1].ActorID)
+	assert.Equal(test, int64(0), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).Reserved)
+	slot, _ := fixture.slots.FindByID("tomorrow-morning")
+	assert.Equal(test, 0, slot.Booked)
+
+	mine := fixture.orderService.FindMine("user-1", dto.OrderQuery{Status: "cancelled"})
+	assert.Equal(test, 1, mine.Total)
+}
+
+func testOrderPayment_confirmsOrCancels(test *testing.T) {
+	fixture := setupOrderService(test)
+	paid := fixture.place(test, "user-1", "bank_transfer")
+	unpaid := fixture.place(test, "user-2", "card")
+
+	// Staff confirm the transfer: the stock is held for good
+	_, err := fixture.move(paid.ID, model.OrderConfirmed, "")
+	assert.NoError(test, err)
+	reservation, _ := fixture.inventory.FindReservation(paid.Lines[0].ReservationID)
+	assert.True(test, reservation.ExpiresAt.IsZero())
+
+	assert.Equal(test, 0, fixture.orderService.CancelUnpaid())
+	_, _ = fixture.orders.Update(context.Background(), unpaid.ID, func(order *model.Order) error {
+		order.PaymentDueAt = time.Now().Add(-time.Second)
+		return nil
+	})
+	assert.Equal(test, 1, fixture.orderService.CancelUnpaid())
+
+	cancelled, _ := fixture.orderService.FindByID(unpaid.ID)
+	assert.Equal(test, model.OrderCancelled, cancelled.Status)
+	assert.Equal(test, model.SystemActor, cancelled.History[1].ActorID)
+	assert.Equal(test, int64(1000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).Reserved)
+	slot, _ := fixture.slots.FindByID("tomorrow-morning")
+	assert.Equal(test, 1, slot.Booked)
+}
+
+func TestOrderService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestOrderWorkflow_shipsHeldStock", testOrderWorkflow_shipsHeldStock)
+	test.Run("TestOrderCancel_givesBackStockAndSlot", testOrderCancel_givesBackStockAndSlot)
+	test.Run("TestOrderPayment_confirmsOrCancels", testOrderPayment_confirmsOrCancels)
+}
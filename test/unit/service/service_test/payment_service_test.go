@@ -0,0 +1,293 @@
+package service_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/infra_interface"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/payment"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const gatewaySecret = "test-secret"
+
+// fakeGateway plays the payment gateway: it takes signed payment forms, and "paying" one of its pages notifies the
+// IPN URL and sends the browser back to the return URL, the way a real gateway does. Refunds are accepted unless
+// refuseRefunds is set.
+type fakeGateway struct {
+	server        *httptest.Server
+	mutex         sync.Mutex
+	payments      map[string]url.Values // Create forms by reference
+	refunds       []url.Values
+	refuseRefunds bool
+}
+
+func newFakeGateway(test *testing.T) *fakeGateway {
+	gateway := &fakeGateway{payments: make(map[string]url.Values)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /payments", func(writer http.ResponseWriter, request *http.Request) {
+		_ = request.ParseForm()
+		if request.PostForm.Get("signature") != payment.Sign(gatewaySecret, request.PostForm) {
+			_ = json.NewEncoder(writer).Encode(map[string]string{"code": "97", "message": "bad signature"})
+			return
+		}
+		reference := request.PostForm.Get("reference")
+		gateway.mutex.Lock()
+		gateway.payments[reference] = request.PostForm
+		gateway.mutex.Unlock()
+		_ = json.NewEncoder(writer).Encode(map[string]string{
+			"code":    "00",
+			"pay_url": gateway.server.URL + "/pay/" + reference,
+		})
+	})
+	mux.HandleFunc("POST /refunds", func(writer http.ResponseWriter, request *http.Request) {
+		_ = request.ParseForm()
+		gateway.mutex.Lock()
+		defer gateway.mutex.Unlock()
+		if gateway.refuseRefunds || request.PostForm.Get("signature") != payment.Sign(gatewaySecret, request.PostForm) {
+			_ = json.NewEncoder(writer).Encode(map[string]string{"code": "94", "message": "refund refused"})
+			return
+		}
+		gateway.refunds = append(gateway.refunds, request.PostForm)
+		_ = json.NewEncoder(writer).Encode(map[string]string{
+			"code":           "00",
+			"transaction_id": fmt.Sprintf("RF-%d", len(gateway.refunds)),
+		})
+	})
+	mux.HandleFunc("GET /pay/{reference}", func(writer http.ResponseWriter, request *http.Request) {
+		gateway.mutex.Lock()
+		form, ok := gateway.payments[request.PathValue("reference")]
+		gateway.mutex.Unlock()
+		if !ok {
+			http.NotFound(writer, request)
+			return
+		}
+		code := "00"
+		if request.URL.Query().Get("outcome") == "cancel" {
+			code = "24"
+		}
+		callback := gateway.callback(form.Get("reference"), form.Get("amount"), code)
+
+		response, err := http.Get(form.Get("ipn_url") + "?" + callback.Encode())
+		assert.NoError(test, err)
+		_ = response.Body.Close()
+		http.Redirect(writer, request, form.Get("return_url")+"?"+callback.Encode(), http.StatusFound)
+	})
+	gateway.server = httptest.NewServer(mux)
+	test.Cleanup(gateway.server.Close)
+	return gateway
+}
+
+func (gateway *fakeGateway) callback(reference string, amount string, code string) url.Values {
+	params := url.Values{
+		"merchant_code":  {"VEGSTORE"},
+		"reference":      {reference},
+		"transaction_id": {"GW-" + reference[:8]},
+		"amount":         {amount},
+		"code":           {code},
+	}
+	params.Set("signature", payment.Sign(gatewaySecret, params))
+	return params
+}
+
+// pay opens the gateway page as the customer's browser would and returns where the gateway sent it back to
+func (gateway *fakeGateway) pay(test *testing.T, redirectURL string, outcome string) url.Values {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	response, err := client.Get(redirectURL + "?outcome=" + outcome)
+	assert.NoError(test, err)
+	defer response.Body.Close()
+	assert.Equal(test, http.StatusFound, response.StatusCode)
+	location, err := url.Parse(response.Header.Get("Location"))
+	assert.NoError(test, err)
+	return location.Query()
+}
+
+type paymentFixture struct {
+	*orderFixture
+	payments       service.PaymentService
+	paymentRepo    repository.PaymentRepository
+	providers      infra_interface.PaymentProviders
+	gateway        *fakeGateway
+	mutex          sync.Mutex
+	notifications  int
+	notifyFailures []error
+}
+
+func setupPaymentService(test *testing.T) *paymentFixture {
+	fixture := &paymentFixture{orderFixture: setupOrderService(test), gateway: newFakeGateway(test)}
+
+	// The store's IPN endpoint, as the gateway reaches it
+	ipn := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, err := fixture.payments.HandleCallback(request.URL.Query())
+		fixture.mutex.Lock()
+		fixture.notifications++
+		if err != nil {
+			fixture.notifyFailures = append(fixture.notifyFailures, err)
+		}
+		fixture.mutex.Unlock()
+	}))
+	test.Cleanup(ipn.Close)
+
+	gateway := payment.NewGatewayProvider(payment.GatewayConfig{
+		URL:          fixture.gateway.server.URL,
+		MerchantCode: "VEGSTORE",
+		Secret:       gatewaySecret,
+		ReturnURL:    "https://shop.example/payment/return",
+		IPNURL:       ipn.URL,
+	}, nil)
+	fixture.paymentRepo = repository.NewPaymentRepository(fixture.datasource)
+	fixture.providers = infra_interface.PaymentProviders{
+		"cod":           payment.NewCashOnDelivery(),
+		"bank_transfer": payment.NewBankTransfer("Vietcombank", "0123456789", "CONG TY VEG STORE"),
+		"card":          gateway,
+		"e_wallet":      gateway,
+	}
+	fixture.payments = service.NewPaymentService(
+		fixture.paymentRepo,
+		fixture.orders,
+		fixture.inventory,
+		fixture.slots,
+		fixture.providers,
+		fixture.transactor,
+	)
+	return fixture
+}
+
+func testPay_redirectsAndConfirmsOnNotification(test *testing.T) {
+	fixture := setupPaymentService(test)
+	order := fixture.place(test, "user-1", "card")
+
+	_, err := fixture.payments.Pay("user-2", order.ID, "203.0.113.7")
+	assert.Equal(test, core.Error.NotFound.Order, err)
+
+	attempt, err := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	assert.NoError(test, err)
+	assert.Equal(test, model.PaymentPending, attempt.Status)
+	assert.True(test, strings.HasPrefix(attempt.RedirectURL, fixture.gateway.server.URL+"/pay/"))
+
+	returned := fixture.gateway.pay(test, attempt.RedirectURL, "success")
+	assert.Equal(test, 1, fixture.notifications)
+	assert.Empty(test, fixture.notifyFailures)
+
+	confirmed, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderConfirmed, confirmed.Status)
+	assert.Equal(test, model.SystemActor, confirmed.History[1].ActorID)
+	reservation, _ := fixture.inventory.FindReservation(order.Lines[0].ReservationID)
+	assert.True(test, reservation.ExpiresAt.IsZero())
+
+	// The browser coming back and the gateway retrying its notification change nothing
+	paid, err := fixture.payments.HandleCallback(returned)
+	assert.NoError(test, err)
+	assert.Equal(test, model.PaymentPaid, paid.Status)
+	assert.Equal(test, model.SystemActor, paid.SettledBy)
+	_, err = fixture.payments.HandleCallback(returned)
+	assert.NoError(test, err)
+	confirmed, _ = fixture.orderService.FindByID(order.ID)
+	assert.Len(test, confirmed.History, 2)
+
+	_, err = fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	assert.Equal(test, core.Error.Conflict.OrderStatus, err)
+}
+
+func testPay_customerCanRetryAfterCancelling(test *testing.T) {
+	fixture := setupPaymentService(test)
+	order := fixture.place(test, "user-1", "e_wallet")
+
+	first, _ := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	fixture.gateway.pay(test, first.RedirectURL, "cancel")
+	pending, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderPendingPayment, pending.Status)
+
+	second, _ := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	fixture.gateway.pay(test, second.RedirectURL, "success")
+
+	payments, err := fixture.payments.FindByOrder("user-1", order.ID)
+	assert.NoError(test, err)
+	assert.Len(test, payments, 2)
+	statuses := []model.PaymentStatus{payments[0].Status, payments[1].Status}
+	assert.ElementsMatch(test, []model.PaymentStatus{model.PaymentFailed, model.PaymentPaid}, statuses)
+	confirmed, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderConfirmed, confirmed.Status)
+}
+
+func testHandleCallback_rejectsForgedOrWrongAmount(test *testing.T) {
+	fixture := setupPaymentService(test)
+	order := fixture.place(test, "user-1", "card")
+	attempt, _ := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+
+	forged := fixture.gateway.callback(attempt.ID, fmt.Sprint(int64(order.Total)), "00")
+	forged.Set("signature", payment.Sign("guessed-secret", forged))
+	_, err := fixture.payments.HandleCallback(forged)
+	assert.Equal(test, core.Error.Invalid.PaymentSignature, err)
+
+	_, err = fixture.payments.HandleCallback(fixture.gateway.callback(attempt.ID, "1000", "00"))
+	assert.Equal(test, core.Error.Conflict.PaymentAmount, err)
+	_, err = fixture.payments.HandleCallback(fixture.gateway.callback("00000000-unknown", "1000", "00"))
+	assert.Equal(test, core.Error.NotFound.Payment, err)
+
+	pending, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderPendingPayment, pending.Status)
+}
+
+func testConfirmTransfer_byStaff(test *testing.T) {
+	fixture := setupPaymentService(test)
+	order := fixture.place(test, "user-1", "bank_transfer")
+
+	transfer, err := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	assert.NoError(test, err)
+	assert.Empty(test, transfer.RedirectURL)
+	assert.Contains(test, transfer.Instructions, "0123456789")
+	assert.Contains(test, transfer.Instructions, order.Number)
+
+	paid, err := fixture.payments.ConfirmTransfer(orderStaff, transfer.ID, dto.ConfirmTransferRequest{TransactionID: "FT26293012345"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.PaymentPaid, paid.Status)
+	assert.Equal(test, "staff-1", paid.SettledBy)
+	confirmed, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderConfirmed, confirmed.Status)
+	assert.Equal(test, "staff-1", confirmed.History[1].ActorID)
+
+	cod := fixture.place(test, "user-2", "cod")
+	cash, err := fixture.payments.Pay("user-2", cod.ID, "203.0.113.7")
+	assert.NoError(test, err)
+	_, err = fixture.payments.ConfirmTransfer(orderStaff, cash.ID, dto.ConfirmTransferRequest{TransactionID: "FT1"})
+	assert.Equal(test, core.Error.Conflict.PaymentMethod, err)
+}
+
+func testConfirmTransfer_cancelledOrderIsDueRefund(test *testing.T) {
+	fixture := setupPaymentService(test)
+	order := fixture.place(test, "user-1", "bank_transfer")
+	transfer, err := fixture.payments.Pay("user-1", order.ID, "203.0.113.7")
+	assert.NoError(test, err)
+	_, err = fixture.orderService.Cancel("user-1", order.ID, dto.CancelOrderRequest{Reason: "Ordered twice"})
+	assert.NoError(test, err)
+
+	paid, err := fixture.payments.ConfirmTransfer(orderStaff, transfer.ID, dto.ConfirmTransferRequest{TransactionID: "FT26293012345"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.PaymentPaid, paid.Status)
+	assert.True(test, paid.RefundDue)
+	cancelled, _ := fixture.orderService.FindByID(order.ID)
+	assert.Equal(test, model.OrderCancelled, cancelled.Status)
+}
+
+func TestPaymentService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestPay_redirectsAndConfirmsOnNotification", testPay_redirectsAndConfirmsOnNotification)
+	test.Run("TestPay_customerCanRetryAfterCancelling", testPay_customerCanRetryAfterCancelling)
+	test.Run("TestHandleCallback_rejectsForgedOrWrongAmount", testHandleCallback_rejectsForgedOrWrongAmount)
+	test.Run("TestConfirmTransfer_byStaff", testConfirmTransfer_byStaff)
+	test.Run("TestConfirmTransfer_cancelledOrderIsDueRefund", testConfirmTransfer_cancelledOrderIsDueRefund)
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createWelcomeOffers offers 10000 off a first order with the WELCOME code, then 10% off lettuce for everyone
+func createWelcomeOffers(test *testing.T, fixture *cartFixture) {
+	_, err := fixture.promotions.Create(dto.PromotionRequest{
+		Name: "Welcome", Code: "welcome", Kind: "fixed", Amount: 10000, FirstOrderOnly: true, Priority: 10, Stackable: true,
+	})
+	assert.NoError(test, err)
+	_, err = fixture.promotions.Create(dto.PromotionRequest{
+		Name: "Lettuce week", Kind: "percentage", Percent: 10, ProductIDs: []string{"lettuce"}, Priority: 5, Stackable: true,
+	})
+	assert.NoError(test, err)
+}
+
+func testCheckout_appliesPromotions(test *testing.T) {
+	fixture := setupCheckoutService(test)
+	createWelcomeOffers(test, fixture.cartFixture)
+	owner := model.CartOwner{UserID: "user-1"}
+	fixture.fill(test, "user-1", cartLine("lettuce", "2", "piece"), cartLine("cabbage", "1", "kg"))
+
+	// 10000 shared over 30000 and 28000, then 10% of the 24828 left of the lettuce
+	cart, err := fixture.service.ApplyCoupon(owner, dto.CouponRequest{Code: " Welcome"})
+	assert.NoError(test, err)
+	assert.Equal(test, "WELCOME", cart.Cart.Coupon)
+	assert.Equal(test, []model.Money{5172 + 2483, 4828}, cart.Promotions.LineDiscounts)
+	assert.Equal(test, "10% off 1 product", dto.Localize(cart.Promotions.Applied[1].Explanation, "en"))
+	assert.Equal(test, "Giảm 10% cho 1 sản phẩm", dto.Localize(cart.Promotions.Applied[1].Explanation, "vi"))
+
+	order, err := fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(12483), order.Discount)
+	assert.Equal(test, model.Money(58000-12483), order.Total)
+	assert.Equal(test, model.Money(7655), order.Lines[0].Discount)
+	assert.Equal(test, model.Money(30000-7655), order.Lines[0].Paid())
+	assert.Len(test, order.Promotions, 2)
+
+	// The welcome code is spent on the first order, until that order is cancelled
+	fixture.fill(test, "user-1", cartLine("lettuce", "1", "piece"))
+	cart, err = fixture.service.ApplyCoupon(owner, dto.CouponRequest{Code: "WELCOME"})
+	assert.NoError(test, err)
+	assert.Equal(test, model.RejectedFirstOrderOnly, cart.Promotions.Rejected[0].Reason)
+	_, err = fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.Equal(test, core.Error.Conflict.Coupon, err)
+
+	_, _ = fixture.orders.Update(context.Background(), order.ID, func(order *model.Order) error {
+		order.Status = model.OrderCancelled
+		return nil
+	})
+	order, err = fixture.checkout.Checkout("user-1", checkoutRequest("tomorrow-morning", "cod"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.Money(10000+500), order.Discount)
+
+	// Without the code, only the lettuce week applies
+	fixture.fill(test, "user-2", cartLine("lettuce", "1", "piece"))
+	cart, _ = fixture.service.Find(model.CartOwner{UserID: "user-2"})
+	assert.Equal(test, model.Money(1500), cart.Promotions.Discount)
+	assert.Empty(test, cart.Promotions.Rejected)
+}
+
+func testPromotion_managesCodes(test *testing.T) {
+	fixture := setupCartService(test)
+	createWelcomeOffers(test, fixture)
+
+	_, err := fixture.promotions.Create(dto.PromotionRequest{Name: "Again", Code: "WELCOME", Kind: "free_shipping"})
+	assert.Equal(test, core.Error.Conflict.PromotionCode, err)
+	_, err = fixture.promotions.Create(dto.PromotionRequest{Name: "Nothing off", Kind: "percentage"})
+	assert.Equal(test, core.Error.Invalid.Promotion, err)
+
+	guest, err := fixture.service.AddLine(model.CartOwner{}, cartLine("lettuce", "1", "piece"))
+	assert.NoError(test, err)
+	_, err = fixture.service.ApplyCoupon(model.CartOwner{Token: guest.Cart.Token}, dto.CouponRequest{Code: "SPRING"})
+	assert.Equal(test, core.Error.NotFound.Promotion, err)
+	_, err = fixture.service.ApplyCoupon(model.CartOwner{Token: guest.Cart.Token}, dto.CouponRequest{Code: "welcome"})
+	assert.NoError(test, err)
+	cart, err := fixture.service.Merge("user-1", guest.Cart.Token)
+	assert.NoError(test, err)
+	assert.Equal(test, "WELCOME", cart.Cart.Coupon)
+	cart, err = fixture.service.RemoveCoupon(model.CartOwner{UserID: "user-1"})
+	assert.NoError(test, err)
+	assert.Empty(test, cart.Cart.Coupon)
+
+	// Only running promotions without a code are advertised; a deactivated code can no longer be entered
+	current := fixture.promotions.FindCurrent()
+	assert.Len(test, current, 1)
+	assert.Equal(test, "Lettuce week", current[0].Name)
+	welcome := fixture.promotions.FindAll(dto.PromotionQuery{Code: "welcome"}).Items[0]
+	inactive := false
+	_, err = fixture.promotions.Update(welcome.ID, dto.PromotionRequest{Name: "Welcome", Code: "WELCOME", Kind: "fixed", Amount: 10000, Active: &inactive})
+	assert.NoError(test, err)
+	_, err = fixture.service.ApplyCoupon(model.CartOwner{UserID: "user-1"}, dto.CouponRequest{Code: "welcome"})
+	assert.Equal(test, core.Error.NotFound.Promotion, err)
+	assert.Equal(test, 1, fixture.promotions.FindAll(dto.PromotionQuery{Active: "false"}).Total)
+}
+
+func TestPromotionService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestCheckout_appliesPromotions", testCheckout_appliesPromotions)
+	test.Run("TestPromotion_managesCodes", testPromotion_managesCodes)
+}
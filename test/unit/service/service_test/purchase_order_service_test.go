@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type purchaseOrderFixture struct {
+	*store
+	service service.PurchaseOrderService
+	lots    repository.StockLotRepository
+}
+
+func setupPurchaseOrderService(test *testing.T) *purchaseOrderFixture {
+	store := newGreengrocer()
+	supplierRepo := repository.NewSupplierRepository(store.datasource)
+	suppliers := service.NewSupplierService(supplierRepo, store.products, store.transactor)
+	_, err := suppliers.Create(dto.SupplierRequest{
+		ID:           "da-lat-green-farm",
+		Name:         "Da Lat Green Farm",
+		Type:         "farm",
+		LeadTimeDays: 2,
+		Products:     []dto.SuppliedProductDto{{ProductID: "cabbage", CostPrice: 12000}},
+	})
+	assert.NoError(test, err)
+
+	fixture := &purchaseOrderFixture{store: store, lots: repository.NewStockLotRepository(store.datasource)}
+	fixture.service = service.NewPurchaseOrderService(
+		repository.NewPurchaseOrderRepository(store.datasource),
+		supplierRepo,
+		store.products,
+		store.locations,
+		fixture.lots,
+		store.inventory,
+		store.transactor,
+	)
+	return fixture
+}
+
+func orderOf(lines ...dto.PurchaseOrderLineDto) dto.PurchaseOrderRequest {
+	return dto.PurchaseOrderRequest{SupplierID: "da-lat-green-farm", Lines: lines}
+}
+
+func orderLine(productID string, value string, unit string) dto.PurchaseOrderLineDto {
+	return dto.PurchaseOrderLineDto{ProductID: productID, Quantity: dto.QuantityDto{Value: value, Unit: unit}}
+}
+
+func receiptOf(code string, value string) dto.GoodsReceiptRequest {
+	return dto.GoodsReceiptRequest{Lines: []dto.GoodsReceiptLineDto{{
+		ProductID:   "cabbage",
+		Code:        code,
+		Quantity:    dto.QuantityDto{Value: value, Unit: "kg"},
+		HarvestedOn: "2026-10-15",
+		BestBefore:  "2026-10-25",
+	}}}
+}
+
+func testCreate_checksSupplierProducts(test *testing.T) {
+	fixture := setupPurchaseOrderService(test)
+
+	_, err := fixture.service.Create("buyer-1", orderOf(orderLine("lettuce", "20", "piece")))
+	assert.Equal(test, core.Error.Invalid.SupplierProduct, err)
+	_, err = fixture.service.Create("buyer-1", orderOf(orderLine("cabbage", "5", "kg"), orderLine("cabbage", "1", "kg")))
+	assert.Equal(test, core.Error.Invalid.PurchaseOrder, err)
+
+	order, err := fixture.service.Create("buyer-1", orderOf(orderLine("cabbage", "50", "kg")))
+	assert.NoError(test, err)
+	assert.Equal(test, "PO-000001", order.Number)
+	assert.Equal(test, model.PurchaseOrderDraft, order.Status)
+	assert.Equal(test, model.DefaultLocationID, order.LocationID)
+	assert.Equal(test, model.Money(600000), order.Total())
+}
+
+func testLifecycle_receiptsCreateLotsAndLedgerEntries(test *testing.T) {
+	fixture := setupPurchaseOrderService(test)
+	order, _ := fixture.service.Create("buyer-1", orderOf(orderLine("cabbage", "50", "kg")))
+
+	_, err := fixture.service.Receive("clerk-1", order.ID, receiptOf("DL-1", "20"))
+	assert.Equal(test, core.Error.Conflict.PurchaseOrderStatus, err)
+
+	sent, err := fixture.service.Send(order.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, util.StoreToday().AddDate(0, 0, 2), sent.ExpectedOn)
+	_, err = fixture.service.Update(order.ID, orderOf(orderLine("cabbage", "60", "kg")))
+	assert.Equal(test, core.Error.Conflict.PurchaseOrderStatus, err)
+
+	partial, err := fixture.service.Receive("clerk-1", order.ID, receiptOf("DL-1", "20"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.PurchaseOrderPartiallyReceived, partial.Status)
+	assert.Equal(test, int64(30000), partial.Lines[0].Outstanding())
+
+	lots := fixture.lots.FindByProduct("cabbage")
+	assert.Len(test, lots, 1)
+	assert.Equal(test, "da-lat-green-farm", lots[0].SupplierID)
+	assert.Equal(test, model.DefaultLocationID, lots[0].LocationID)
+	assert.Equal(test, int64(20000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+	movements := fixture.inventory.FindMovements(func(movement model.Movement) bool {
+		return movement.SourceType == model.SourcePurchaseOrder && movement.SourceID == order.ID
+	})
+	assert.Len(test, movements, 1)
+	assert.Equal(test, model.MovementReceipt, movements[0].Type)
+	assert.Equal(test, "clerk-1", movements[0].ActorID)
+
+	// More than is outstanding is refused and leaves no trace
+	_, err = fixture.service.Receive("clerk-1", order.ID, receiptOf("DL-2", "31"))
+	assert.Equal(test, core.Error.Invalid.GoodsReceipt, err)
+	assert.Len(test, fixture.lots.FindByProduct("cabbage"), 1)
+	assert.Equal(test, int64(20000), fixture.inventory.FindLevel("cabbage", model.DefaultLocationID).OnHand)
+
+	received, err := fixture.service.Receive("clerk-1", order.ID, receiptOf("DL-2", "30"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.PurchaseOrderReceived, received.Status)
+	assert.Len(test, received.Receipts, 2)
+
+	closed, err := fixture.service.Close(order.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.PurchaseOrderClosed, closed.Status)
+	_, err = fixture.service.Close(order.ID)
+	assert.Equal(test, core.Error.Conflict.PurchaseOrderStatus, err)
+}
+
+func TestPurchaseOrderService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestCreate_checksSupplierProducts", testCreate_checksSupplierProducts)
+	test.Run("TestLifecycle_receiptsCreateLotsAndLedgerEntries", testLifecycle_receiptsCreateLotsAndLedgerEntries)
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type reorderFixture struct {
+	*store
+	service        service.ReorderService
+	purchaseOrders repository.PurchaseOrderRepository
+	notifier       *fakeNotifier
+}
+
+// setupReorderService registers cabbage (per kg) from a preferred farm and a cheaper wholesaler, lettuce (per piece)
+// from the wholesaler only, and tomato (per kg) from nobody
+func setupReorderService(test *testing.T) *reorderFixture {
+	store := newGreengrocer()
+	store.add(model.Product{ID: "tomato", SKU: "VEG-012", PriceUnit: model.UnitKilogram})
+	supplierRepo := repository.NewSupplierRepository(store.datasource)
+	suppliers := service.NewSupplierService(supplierRepo, store.products, store.transactor)
+	_, err := suppliers.Create(dto.SupplierRequest{
+		ID:       "da-lat-green-farm",
+		Name:     "Da Lat Green Farm",
+		Type:     "farm",
+		Products: []dto.SuppliedProductDto{{ProductID: "cabbage", CostPrice: 12000, Preferred: true}},
+	})
+	assert.NoError(test, err)
+	_, err = suppliers.Create(dto.SupplierRequest{
+		ID:   "thu-duc-wholesale",
+		Name: "Thu Duc Wholesale Market",
+		Type: "wholesaler",
+		Products: []dto.SuppliedProductDto{
+			{ProductID: "cabbage", CostPrice: 10000},
+			{ProductID: "lettuce", CostPrice: 4000},
+		},
+	})
+	assert.NoError(test, err)
+
+	fixture := &reorderFixture{
+		store:          store,
+		purchaseOrders: repository.NewPurchaseOrderRepository(store.datasource),
+		notifier:       &fakeNotifier{},
+	}
+	fixture.service = service.NewReorderService(
+		repository.NewReorderRepository(store.datasource),
+		store.products,
+		store.locations,
+		store.inventory,
+		supplierRepo,
+		fixture.purchaseOrders,
+		store.transactor,
+		fixture.notifier,
+		scheduler.NewScheduler(),
+	)
+	return fixture
+}
+
+func reorderRule(productID string, point string, target string, unit string) dto.ReorderRuleRequest {
+	return dto.ReorderRuleRequest{
+		ProductID:    productID,
+		ReorderPoint: dto.QuantityDto{Value: point, Unit: unit},
+		TargetLevel:  dto.QuantityDto{Value: target, Unit: unit},
+	}
+}
+
+func testSetRule_validatesLevels(test *testing.T) {
+	fixture := setupReorderService(test)
+
+	_, err := fixture.service.SetRule("staff-1", reorderRule("cabbage", "5", "5", "kg"))
+	assert.Equal(test, core.Error.Invalid.ReorderRule, err)
+	_, err = fixture.service.SetRule("staff-1", reorderRule("cabbage", "5", "20", "piece"))
+	assert.Equal(test, core.Error.Invalid.Unit, err)
+	_, err = fixture.service.SetRule("staff-1", reorderRule("durian", "5", "20", "kg"))
+	assert.Equal(test, core.Error.NotFound.Product, err)
+
+	rule, err := fixture.service.SetRule("staff-1", reorderRule("cabbage", "0", "500", "g"))
+	assert.NoError(test, err)
+	assert.Equal(test, model.DefaultLocationID, rule.LocationID)
+	assert.Equal(test, int64(500), rule.TargetLevel)
+
+	// Setting the rule again replaces it
+	_, _ = fixture.service.SetRule("staff-2", reorderRule("cabbage", "5", "20", "kg"))
+	rules := fixture.service.FindRules(dto.ReorderRuleQuery{ProductID: "cabbage"})
+	assert.Len(test, rules, 1)
+	assert.Equal(test, int64(5000), rules[0].ReorderPoint)
+	assert.Equal(test, "staff-2", rules[0].UpdatedBy)
+
+	assert.NoError(test, fixture.service.DeleteRule("cabbage", ""))
+	assert.Equal(test, core.Error.NotFound.ReorderRule, fixture.service.DeleteRule("cabbage", ""))
+}
+
+func testCheckStock_raisesAlertsOncePerShortage(test *testing.T) {
+	fixture := setupReorderService(test)
+	_, _ = fixture.service.SetRule("staff-1", reorderRule("cabbage", "5", "20", "kg"))
+	_, _ = fixture.service.SetRule("staff-1", reorderRule("lettuce", "10", "30", "piece"))
+	_, _ = fixture.service.SetRule("staff-1", reorderRule("tomato", "2", "8", "kg"))
+	fixture.stock(test, "cabbage", 3000)
+	fixture.stock(test, "lettuce", 12)
+
+	check, err := fixture.service.CheckStock()
+	assert.NoError(test, err)
+	assert.Len(test, check.Raised, 2)
+	assert.Equal(test, "cabbage", check.Raised[0].ProductID)
+	assert.Equal(test, "da-lat-green-farm", check.Raised[0].SupplierID) // preferred over the cheaper wholesaler
+	assert.Equal(test, int64(17000), check.Raised[0].SuggestedQuantity)
+	assert.Equal(test, "tomato", check.Raised[1].ProductID)
+	assert.Empty(test, check.Raised[1].SupplierID)
+	assert.Empty(test, check.Raised[1].PurchaseOrderID)
+
+	assert.Len(test, check.PurchaseOrders, 1)
+	order := check.PurchaseOrders[0]
+	assert.Equal(test, check.Raised[0].PurchaseOrderID, order.ID)
+	assert.Equal(test, model.PurchaseOrderDraft, order.Status)
+	assert.Equal(test, model.SystemActor, order.CreatedBy)
+	assert.Equal(test, model.Money(12000*17), order.Total())
+
+	assert.Len(test, fixture.notifier.sent, 1)
+	assert.ElementsMatch(test, []string{model.RoleAdmin, model.RoleStaff}, fixture.notifier.sent[0].Roles)
+	assert.Contains(test, fixture.notifier.sent[0].Body, "suggested 17 kg on "+order.Number)
+	assert.Contains(test, fixture.notifier.sent[0].Body, "tomato at central-warehouse: 0 kg available")
+
+	// Still low: the open alerts stand and nobody is told twice
+	check, _ = fixture.service.CheckStock()
+	assert.Empty(test, check.Raised)
+	assert.Empty(test, check.PurchaseOrders)
+	assert.Len(test, fixture.notifier.sent, 1)
+
+	fixture.stock(test, "cabbage", 25000)
+	fixture.stock(test, "lettuce", 8)
+	check, _ = fixture.service.CheckStock()
+	assert.Equal(test, 1, check.Resolved)
+	assert.Len(test, check.Raised, 1)
+	assert.Equal(test, "thu-duc-wholesale", check.Raised[0].SupplierID)
+	assert.Equal(test, int64(22), check.Raised[0].SuggestedQuantity)
+
+	open := fixture.service.FindAlerts(dto.LowStockAlertQuery{Status: "open"})
+	assert.Equal(test, 2, open.Total)
+	resolved := fixture.service.FindAlerts(dto.LowStockAlertQuery{ProductID: "cabbage", Status: "resolved"})
+	assert.Equal(test, 1, resolved.Total)
+	assert.False(test, resolved.Items[0].ResolvedAt.IsZero())
+}
+
+func testCheckStock_countsStockAlreadyOrdered(test *testing.T) {
+	fixture := setupReorderService(test)
+	_, _ = fixture.service.SetRule("staff-1", reorderRule("cabbage", "5", "20", "kg"))
+	fixture.stock(test, "cabbage", 3000)
+	first, _ := fixture.service.CheckStock()
+
+	// Back above the point, then low again while the first suggestion is still open
+	fixture.stock(test, "cabbage", 6000)
+	_, _ = fixture.service.CheckStock()
+	fixture.stock(test, "cabbage", 4000)
+	check, err := fixture.service.CheckStock()
+	assert.NoError(test, err)
+	assert.Len(test, check.Raised, 1)
+	assert.Equal(test, int64(17000), check.Raised[0].Incoming)
+	assert.Equal(test, int64(0), check.Raised[0].SuggestedQuantity)
+	assert.Empty(test, check.PurchaseOrders)
+	assert.Contains(test, fixture.notifier.sent[1].Body, "enough is already on its way")
+
+	// Once the first suggestion is closed unsent, the shortfall is suggested again
+	_, _ = fixture.purchaseOrders.Update(context.Background(), first.PurchaseOrders[0].ID, func(order *model.PurchaseOrder) error {
+		order.Status = model.PurchaseOrderClosed
+		return nil
+	})
+	fixture.stock(test, "cabbage", 6000)
+	_, _ = fixture.service.CheckStock()
+	fixture.stock(test, "cabbage", 4000)
+	check, _ = fixture.service.CheckStock()
+	assert.Equal(test, int64(16000), check.Raised[0].SuggestedQuantity)
+	assert.Len(test, check.PurchaseOrders, 1)
+}
+
+func TestReorderService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestSetRule_validatesLevels", testSetRule_validatesLevels)
+	test.Run("TestCheckStock_raisesAlertsOncePerShortage", testCheckStock_raisesAlertsOncePerShortage)
+	test.Run("TestCheckStock_countsStockAlreadyOrdered", testCheckStock_countsStockAlreadyOrdered)
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type reviewFixture struct {
+	*store
+	service service.ReviewService
+}
+
+func setupReviewService() *reviewFixture {
+	fixture := &reviewFixture{store: newStore()}
+	fixture.add(model.Product{ID: "p1", SKU: "VEG-001", Name: "Water spinach", Price: 20000, PriceUnit: model.UnitKilogram})
+
+	// Alice and Bob have received the product; Mallory's order never arrived
+	for userID, status := range map[string]model.OrderStatus{"alice": model.OrderDelivered, "bob": model.OrderDelivered, "mallory": model.OrderOutForDelivery} {
+		fixture.orders.Create(context.Background(), model.Order{ID: "order-" + userID, UserID: userID, Status: status, Lines: []model.OrderLine{{ProductID: "p1"}}})
+	}
+	fixture.service = service.NewReviewService(
+		repository.NewReviewRepository(fixture.datasource),
+		fixture.products,
+		service.NewPurchaseVerifier(fixture.orders),
+		nil,
+		nil,
+		fixture.transactor,
+	)
+	return fixture
+}
+
+func testCreate_requiresDeliveredOrder(test *testing.T) {
+	fixture := setupReviewService()
+
+	_, err := fixture.service.Create("mallory", dto.ReviewRequest{ProductID: "p1", Rating: 5})
+	assert.Equal(test, core.Error.Auth.PurchaseRequired, err)
+
+	_, err = fixture.service.Create("alice", dto.ReviewRequest{ProductID: "p1", Rating: 0})
+	assert.Equal(test, core.Error.Invalid.Rating, err)
+}
+
+func testCreate_onePerUserAndProduct(test *testing.T) {
+	fixture := setupReviewService()
+
+	review, err := fixture.service.Create("alice", dto.ReviewRequest{ProductID: "p1", Rating: 5, Text: " Fresh "})
+	assert.NoError(test, err)
+	assert.Equal(test, model.ReviewPending, review.Status)
+	assert.Equal(test, "Fresh", review.Text)
+
+	_, err = fixture.service.Create("alice", dto.ReviewRequest{ProductID: "p1", Rating: 1})
+	assert.Equal(test, core.Error.Conflict.Review, err)
+}
+
+func testModerate_updatesProductRating(test *testing.T) {
+	fixture := setupReviewService()
+	alice, _ := fixture.service.Create("alice", dto.ReviewRequest{ProductID: "p1", Rating: 5})
+	bob, _ := fixture.service.Create("bob", dto.ReviewRequest{ProductID: "p1", Rating: 2})
+
+	_, err := fixture.service.Moderate("staff", alice.ID, dto.ModerationRequest{Decision: "approve"})
+	assert.NoError(test, err)
+	_, err = fixture.service.Moderate("staff", bob.ID, dto.ModerationRequest{Decision: "approve"})
+	assert.NoError(test, err)
+
+	product, _ := fixture.products.FindByID("p1")
+	assert.Equal(test, 3.5, product.Rating.Average)
+	assert.Equal(test, [5]int{0, 1, 0, 0, 1}, product.Rating.Histogram)
+
+	rejected, err := fixture.service.Moderate("staff", bob.ID, dto.ModerationRequest{Decision: "reject", Note: "Off topic"})
+	assert.NoError(test, err)
+	assert.Equal(test, "Off topic", rejected.ModerationNote)
+
+	page, summary, err := fixture.service.FindByProduct("p1", dto.ReviewQuery{Sort: "recent"})
+	assert.NoError(test, err)
+	assert.Equal(test, 1, summary.Count)
+	assert.Equal(test, 5.0, summary.Average)
+	assert.Len(test, page.Items, 1)
+
+	queue := fixture.service.FindForModeration(dto.ModerationQuery{Status: "rejected"})
+	assert.Len(test, queue.Items, 1)
+}
+
+func testVote_onlyOnPublishedReviews(test *testing.T) {
+	fixture := setupReviewService()
+	review, _ := fixture.service.Create("alice", dto.ReviewRequest{ProductID: "p1", Rating: 4})
+	helpful := true
+
+	_, err := fixture.service.Vote("bob", review.ID, dto.VoteRequest{Helpful: &helpful})
+	assert.Equal(test, core.Error.NotFound.Review, err)
+
+	_, _ = fixture.service.Moderate("staff", review.ID, dto.ModerationRequest{Decision: "approve"})
+	voted, err := fixture.service.Vote("bob", review.ID, dto.VoteRequest{Helpful: &helpful})
+	assert.NoError(test, err)
+	count, _ := voted.HelpfulVotes()
+	assert.Equal(test, 1, count)
+
+	_, err = fixture.service.Vote("alice", review.ID, dto.VoteRequest{Helpful: &helpful})
+	assert.Equal(test, core.Error.Invalid.OwnReviewVote, err)
+}
+
+func TestReviewService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestCreate_requiresDeliveredOrder", testCreate_requiresDeliveredOrder)
+	test.Run("TestCreate_onePerUserAndProduct", testCreate_onePerUserAndProduct)
+	test.Run("TestModerate_updatesProductRating", testModerate_updatesProductRating)
+	test.Run("TestVote_onlyOnPublishedReviews", testVote_onlyOnPublishedReviews)
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ledgerFixture struct {
+	*store
+	inventoryService service.InventoryService
+	ledger           service.StockLedgerService
+}
+
+func setupStockLedgerService(test *testing.T) *ledgerFixture {
+	fixture := &ledgerFixture{store: newGreengrocer()}
+	fixture.inventoryService = service.NewInventoryService(fixture.inventory, fixture.products, fixture.locations, fixture.transactor, scheduler.NewScheduler())
+	fixture.ledger = service.NewStockLedgerService(fixture.inventory, fixture.products, fixture.locations, fixture.transactor)
+	_, err := fixture.inventoryService.Adjust("staff-1", dto.AdjustStockRequest{
+		ProductID: "lettuce",
+		Quantity:  dto.QuantityDto{Value: "10", Unit: "piece"},
+		Direction: "in",
+		Reason:    model.ReasonFound,
+	})
+	assert.NoError(test, err)
+	return fixture
+}
+
+func testLedger_recordsEveryMovement(test *testing.T) {
+	fixture := setupStockLedgerService(test)
+	reservations, _ := fixture.inventoryService.Reserve(reserve("checkout-1", item("lettuce", "3", "piece")))
+	_, err := fixture.inventoryService.Commit("cashier-1", reservations[0].ID)
+	assert.NoError(test, err)
+	_, err = fixture.inventoryService.Adjust("staff-2", dto.AdjustStockRequest{
+		ProductID: "lettuce",
+		Quantity:  dto.QuantityDto{Value: "1", Unit: "piece"},
+		Direction: "out",
+		Reason:    model.ReasonDamaged,
+	})
+	assert.NoError(test, err)
+
+	page, err := fixture.ledger.FindMovements(dto.MovementQuery{ProductID: "lettuce"})
+	assert.NoError(test, err)
+	assert.Equal(test, 3, page.Total)
+	sale := page.Items[1]
+	assert.Equal(test, model.MovementSale, sale.Type)
+	assert.Equal(test, int64(-3), sale.Quantity)
+	assert.Equal(test, int64(7), sale.Balance)
+	assert.Equal(test, "cashier-1", sale.ActorID)
+	assert.Equal(test, model.SourceReservation, sale.SourceType)
+	assert.Equal(test, "checkout-1", sale.SourceID)
+
+	// Releasing a reservation does not touch stock on hand, so it leaves no trace in the ledger
+	reservations, _ = fixture.inventoryService.Reserve(reserve("checkout-2", item("lettuce", "1", "piece")))
+	_, _ = fixture.inventoryService.Release(reservations[0].ID)
+	page, _ = fixture.ledger.FindMovements(dto.MovementQuery{Type: "sale"})
+	assert.Equal(test, 1, page.Total)
+
+	_, err = fixture.ledger.FindMovements(dto.MovementQuery{From: "19/10/2026"})
+	assert.Equal(test, core.Error.Invalid.Date, err)
+}
+
+func testRebuild_correctsDrift(test *testing.T) {
+	fixture := setupStockLedgerService(test)
+	_, _ = fixture.inventory.UpdateLevel(context.Background(), "lettuce", model.DefaultLocationID, func(level *model.StockLevel) error {
+		level.OnHand = 4
+		return nil
+	})
+
+	response, err := fixture.ledger.Rebuild("admin-1")
+	assert.NoError(test, err)
+	assert.Equal(test, 1, response.Movements)
+	assert.Len(test, response.Corrected, 1)
+	assert.Equal(test, "4", response.Corrected[0].Before.Value)
+	assert.Equal(test, "10", response.Corrected[0].After.Value)
+	assert.Equal(test, int64(10), fixture.inventory.FindLevel("lettuce", model.DefaultLocationID).OnHand)
+
+	response, _ = fixture.ledger.Rebuild("admin-1")
+	assert.Empty(test, response.Corrected)
+}
+
+func testStocktake_postsVariances(test *testing.T) {
+	fixture := setupStockLedgerService(test)
+	count := func(productID string, value string, unit string) dto.StocktakeCountDto {
+		return dto.StocktakeCountDto{ProductID: productID, Quantity: dto.QuantityDto{Value: value, Unit: unit}}
+	}
+
+	_, err := fixture.ledger.CreateStocktake("staff-1", dto.StocktakeRequest{
+		Counts: []dto.StocktakeCountDto{count("lettuce", "8", "piece"), count("lettuce", "1", "piece")},
+	})
+	assert.Equal(test, core.Error.Invalid.Stocktake, err)
+
+	stocktake, err := fixture.ledger.CreateStocktake("staff-1", dto.StocktakeRequest{
+		Counts: []dto.StocktakeCountDto{count("lettuce", "8", "piece"), count("cabbage", "0", "kg")},
+	})
+	assert.NoError(test, err)
+	assert.Equal(test, int64(10), stocktake.Lines[0].Expected)
+	assert.Equal(test, int64(-2), stocktake.Lines[0].Variance())
+	assert.Equal(test, int64(0), stocktake.Lines[1].Variance())
+	assert.Equal(test, int64(10), fixture.inventory.FindLevel("lettuce", model.DefaultLocationID).OnHand)
+
+	applied, err := fixture.ledger.ApplyStocktake("manager-1", stocktake.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.StocktakeApplied, applied.Status)
+	assert.Equal(test, int64(8), fixture.inventory.FindLevel("lettuce", model.DefaultLocationID).OnHand)
+	page, _ := fixture.ledger.FindMovements(dto.MovementQuery{SourceID: stocktake.ID})
+	assert.Equal(test, 1, page.Total)
+	assert.Equal(test, model.ReasonStocktake, page.Items[0].Reason)
+
+	_, err = fixture.ledger.ApplyStocktake("manager-1", stocktake.ID)
+	assert.Equal(test, core.Error.Conflict.StocktakeClosed, err)
+}
+
+func TestStockLedgerService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestLedger_recordsEveryMovement", testLedger_recordsEveryMovement)
+	test.Run("TestRebuild_correctsDrift", testRebuild_correctsDrift)
+	test.Run("TestStocktake_postsVariances", testStocktake_postsVariances)
+}
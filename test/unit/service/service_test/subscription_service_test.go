@@ -0,0 +1,256 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veg-store-backend/injection"
+	"veg-store-backend/injection/core"
+	"veg-store-backend/internal/application/dto"
+	"veg-store-backend/internal/application/service"
+	"veg-store-backend/internal/domain/model"
+	"veg-store-backend/internal/infrastructure/repository"
+	"veg-store-backend/internal/infrastructure/scheduler"
+	"veg-store-backend/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type subscriptionFixture struct {
+	*paymentFixture
+	subscriptions service.SubscriptionService
+	notifier      *fakeNotifier
+	plan          *model.SubscriptionPlan
+	tomorrow      time.Time
+}
+
+// setupSubscriptionService offers a weekly family box of 1 kg of cabbage, or bok choy when it runs out, and two
+// lettuces, delivered tomorrow's weekday in a morning or an afternoon slot
+func setupSubscriptionService(test *testing.T) *subscriptionFixture {
+	fixture := &subscriptionFixture{paymentFixture: setupPaymentService(test), notifier: &fakeNotifier{}}
+	fixture.subscriptions = service.NewSubscriptionService(
+		repository.NewSubscriptionRepository(fixture.datasource),
+		fixture.orders,
+		fixture.products,
+		fixture.inventory,
+		fixture.slots,
+		fixture.zones,
+		fixture.locations,
+		fixture.payments,
+		fixture.notifier,
+		fixture.transactor,
+		scheduler.NewScheduler(),
+	)
+	_ = fixture.products.Create(context.Background(), model.Product{
+		ID: "bok-choy", SKU: "VEG-012", Name: "Bok choy", Price: 32000, PriceUnit: model.UnitKilogram, Active: true,
+		SaleRule: model.SaleRule{Unit: model.UnitKilogram, MinQuantity: 500, Step: 250},
+	})
+	fixture.stock(test, "bok-choy", 2000)
+
+	// Checkout's slot lands on the box day just after the store's midnight; boxes are booked into their own
+	_, _ = fixture.slots.Update(context.Background(), "tomorrow-morning", func(slot *model.DeliverySlot) error {
+		slot.Active = false
+		return nil
+	})
+	fixture.tomorrow = util.StoreToday().AddDate(0, 0, 1)
+	morning := fixture.tomorrow.Add(8 * time.Hour)
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "box-afternoon", Start: morning.Add(6 * time.Hour), End: morning.Add(8 * time.Hour), Capacity: 5, Active: true})
+	fixture.slots.Create(context.Background(), model.DeliverySlot{ID: "box-morning", Start: morning, End: morning.Add(2 * time.Hour), Capacity: 5, Active: true})
+
+	plan, err := fixture.subscriptions.CreatePlan(dto.SubscriptionPlanRequest{
+		Name: "Family box",
+		Items: []dto.BoxItemDto{
+			{ProductID: "cabbage", Quantity: dto.QuantityDto{Value: "1", Unit: "kg"}, Substitutes: []string{"bok-choy"}},
+			{ProductID: "lettuce", Quantity: dto.QuantityDto{Value: "2", Unit: "piece"}},
+		},
+		Frequency:   "weekly",
+		DeliveryDay: int(fixture.tomorrow.Weekday()),
+	})
+	assert.NoError(test, err)
+	fixture.plan = plan
+	return fixture
+}
+
+func (fixture *subscriptionFixture) subscribe(test *testing.T, userID string, paymentMethod string) *model.Subscription {
+	request := checkoutRequest("", paymentMethod)
+	subscription, err := fixture.subscriptions.Subscribe(userID, dto.SubscribeRequest{
+		PlanID:        fixture.plan.ID,
+		Address:       request.Address,
+		PaymentMethod: paymentMethod,
+	}, "en")
+	assert.NoError(test, err)
+	return subscription
+}
+
+func testSubscription_ordersBoxesDue(test *testing.T) {
+	fixture := setupSubscriptionService(test)
+	cash := fixture.subscribe(test, "user-1", "cod")
+	card := fixture.subscribe(test, "user-2", "card")
+	assert.Equal(test, fixture.tomorrow, cash.NextDelivery)
+	assert.Equal(test, "Cabbage", fixture.plan.Items[0].Name)
+
+	runs := fixture.subscriptions.Generate()
+	assert.Len(test, runs, 2)
+	assert.Equal(test, model.BoxOrdered, runs[0].Outcome)
+	assert.Empty(test, runs[0].Notes)
+
+	order, _ := fixture.orders.FindByID(runs[0].OrderID)
+	assert.Equal(test, model.OrderConfirmed, order.Status)
+	assert.Equal(test, cash.ID, order.SubscriptionID)
+	assert.Equal(test, "box-morning", order.SlotID)
+	assert.Equal(test, model.Money(58000), order.Total)
+	assert.Equal(test, "Your Family box for "+fixture.tomorrow.Format("02/01")+" is ordered as ORD-000001", fixture.notifier.sent[0].Subject)
+
+	// Boxes paid online wait for the payment the store started, until the delivery day begins
+	order, _ = fixture.orders.FindByID(runs[1].OrderID)
+	assert.Equal(test, model.OrderPendingPayment, order.Status)
+	assert.Equal(test, fixture.tomorrow, order.PaymentDueAt)
+	payments, _ := fixture.payments.FindByOrder("user-2", order.ID)
+	assert.Len(test, payments, 1)
+	assert.Contains(test, fixture.notifier.sent[1].Body, "Pay here: "+payments[0].RedirectURL)
+
+	// Each delivery day is ordered once; the next one is a week later
+	assert.Empty(test, fixture.subscriptions.Generate())
+	updated, _ := fixture.subscriptions.FindMineByID("user-2", card.ID)
+	assert.Equal(test, fixture.tomorrow.AddDate(0, 0, 7), updated.NextDelivery)
+	assert.Len(test, updated.History, 1)
+	cabbage := fixture.inventory.FindLevel("cabbage", model.DefaultLocationID)
+	assert.Equal(test, int64(2000), cabbage.Reserved)
+}
+
+func testSubscription_substitutesOutOfStock(test *testing.T) {
+	fixture := setupSubscriptionService(test)
+	fixture.stock(test, "cabbage", 0)
+	fixture.stock(test, "lettuce", 1)
+	fixture.subscribe(test, "user-1", "cod")
+
+	runs := fixture.subscriptions.Generate()
+	assert.Equal(test, []model.Message{
+		{ID: "Subscription.Substituted", Data: map[string]any{"Substitute": "Bok choy", "Item": "Cabbage"}},
+		{ID: "Subscription.LeftOut", Data: map[string]any{"Item": "Lettuce"}},
+	}, runs[0].Notes)
+	assert.Equal(test, "Bok choy instead of Cabbage; Lettuce left out, out of stock", dto.ToBoxRunResponses(runs, "en")[0].Note)
+	order, _ := fixture.orders.FindByID(runs[0].OrderID)
+	assert.Len(test, order.Lines, 1)
+	assert.Equal(test, "bok-choy", order.Lines[0].ProductID)
+	assert.Equal(test, "cabbage", order.Lines[0].SubstituteFor)
+	assert.Contains(test, fixture.notifier.sent[0].Body, "Bok choy instead of Cabbage")
+
+	// With nothing left, the day fails and holds nothing. The customer hears of it in the language they subscribed in.
+	fixture.stock(test, "bok-choy", 0)
+	subscription, err := fixture.subscriptions.Subscribe("user-2", dto.SubscribeRequest{
+		PlanID: fixture.plan.ID, Address: checkoutRequest("", "cod").Address, PaymentMethod: "cod",
+	}, "vi")
+	assert.NoError(test, err)
+	runs = fixture.subscriptions.Generate()
+	assert.Equal(test, model.BoxFailed, runs[0].Outcome)
+	assert.Equal(test, []model.Message{{ID: "Subscription.NothingInStock"}}, runs[0].Notes)
+	assert.Equal(test, "Chúng tôi không thể đặt Family box ngày "+fixture.tomorrow.Format("02/01")+" của bạn", fixture.notifier.sent[1].Subject)
+	assert.Equal(test, "Không có món nào trong hộp còn hàng", fixture.notifier.sent[1].Body)
+	slot, _ := fixture.slots.FindByID("box-morning")
+	assert.Equal(test, 1, slot.Booked)
+	subscription, _ = fixture.subscriptions.FindByID(subscription.ID)
+	assert.Equal(test, fixture.tomorrow.AddDate(0, 0, 7), subscription.NextDelivery)
+}
+
+func testSubscription_failsWithoutSlot(test *testing.T) {
+	fixture := setupSubscriptionService(test)
+	for _, id := range []string{"tomorrow-morning", "box-morning", "box-afternoon"} {
+		_, _ = fixture.slots.Update(context.Background(), id, func(slot *model.DeliverySlot) error {
+			slot.Active = false
+			return nil
+		})
+	}
+	fixture.subscribe(test, "user-1", "cod")
+
+	runs := fixture.subscriptions.Generate()
+	assert.Equal(test, []model.Message{{ID: "Subscription.NoSlot"}}, runs[0].Notes)
+	cabbage := fixture.inventory.FindLevel("cabbage", model.DefaultLocationID)
+	assert.Equal(test, int64(0), cabbage.Reserved)
+	assert.Empty(test, fixture.orders.FindAll(func(model.Order) bool { return true }))
+}
+
+func testSubscription_customerManagesDeliveries(test *testing.T) {
+	fixture := setupSubscriptionService(test)
+	subscription := fixture.subscribe(test, "user-1", "cod")
+	tomorrow := fixture.tomorrow.Format(util.DateLayout)
+
+	_, err := fixture.subscriptions.Skip("user-2", subscription.ID, dto.SkipDeliveryRequest{Date: tomorrow})
+	assert.Equal(test, core.Error.NotFound.Subscription, err)
+	_, err = fixture.subscriptions.Skip("user-1", subscription.ID, dto.SkipDeliveryRequest{Date: fixture.tomorrow.AddDate(0, 0, 3).Format(util.DateLayout)})
+	assert.Equal(test, core.Error.Invalid.Date, err)
+	_, err = fixture.subscriptions.Skip("user-1", subscription.ID, dto.SkipDeliveryRequest{Date: tomorrow})
+	assert.NoError(test, err)
+
+	runs := fixture.subscriptions.Generate()
+	assert.Equal(test, model.BoxSkipped, runs[0].Outcome)
+	assert.Empty(test, runs[0].OrderID)
+	assert.Empty(test, fixture.notifier.sent)
+
+	// A pause with no end holds boxes until the customer resumes
+	paused, err := fixture.subscriptions.Pause("user-1", subscription.ID, dto.PauseSubscriptionRequest{})
+	assert.NoError(test, err)
+	assert.Equal(test, model.SubscriptionPaused, paused.Status)
+	_, err = fixture.subscriptions.Pause("user-1", subscription.ID, dto.PauseSubscriptionRequest{})
+	assert.Equal(test, core.Error.Conflict.SubscriptionStatus, err)
+	resumed, err := fixture.subscriptions.Resume("user-1", subscription.ID)
+	assert.NoError(test, err)
+	assert.Equal(test, model.SubscriptionActive, resumed.Status)
+
+	cancelled, err := fixture.subscriptions.Cancel("user-1", subscription.ID, dto.CancelSubscriptionRequest{Reason: "Moving abroad"})
+	assert.NoError(test, err)
+	assert.Equal(test, "Moving abroad", cancelled.CancelReason)
+	_, err = fixture.subscriptions.Resume("user-1", subscription.ID)
+	assert.Equal(test, core.Error.Conflict.SubscriptionStatus, err)
+
+	page := fixture.subscriptions.FindAll(dto.SubscriptionQuery{Status: "cancelled"})
+	assert.Equal(test, 1, page.Total)
+}
+
+func testSubscribe_checksPlanAndStart(test *testing.T) {
+	fixture := setupSubscriptionService(test)
+	request := dto.SubscribeRequest{PlanID: fixture.plan.ID, Address: checkoutRequest("", "cod").Address, PaymentMethod: "cod"}
+
+	request.StartDate = util.StoreToday().Format(util.DateLayout)
+	_, err := fixture.subscriptions.Subscribe("user-1", request, "en")
+	assert.Equal(test, core.Error.Invalid.Date, err)
+
+	request.StartDate = fixture.tomorrow.AddDate(0, 0, 1).Format(util.DateLayout)
+	subscription, err := fixture.subscriptions.Subscribe("user-1", request, "en")
+	assert.NoError(test, err)
+	assert.Equal(test, fixture.tomorrow.AddDate(0, 0, 7), subscription.NextDelivery)
+
+	request.Address.District = "Quận 9"
+	_, err = fixture.subscriptions.Subscribe("user-1", request, "en")
+	assert.Equal(test, core.Error.Invalid.OutOfDeliveryArea, err)
+
+	inactive := false
+	_, err = fixture.subscriptions.UpdatePlan(fixture.plan.ID, dto.SubscriptionPlanRequest{
+		Name:      "Family box",
+		Items:     []dto.BoxItemDto{{ProductID: "lettuce", Quantity: dto.QuantityDto{Value: "1", Unit: "kg"}}},
+		Frequency: "weekly",
+		Active:    &inactive,
+	})
+	assert.Equal(test, core.Error.Invalid.Unit, err)
+	_, err = fixture.subscriptions.UpdatePlan(fixture.plan.ID, dto.SubscriptionPlanRequest{
+		Name:      "Family box",
+		Items:     []dto.BoxItemDto{{ProductID: "lettuce", Quantity: dto.QuantityDto{Value: "1", Unit: "piece"}}},
+		Frequency: "weekly",
+		Active:    &inactive,
+	})
+	assert.NoError(test, err)
+	assert.Empty(test, fixture.subscriptions.FindPlans(true))
+	request.Address.District = "Quận 1"
+	_, err = fixture.subscriptions.Subscribe("user-1", request, "en")
+	assert.Equal(test, core.Error.NotFound.SubscriptionPlan, err)
+}
+
+func TestSubscriptionService(test *testing.T) {
+	injection.Inject("test")
+
+	test.Run("TestSubscription_ordersBoxesDue", testSubscription_ordersBoxesDue)
+	test.Run("TestSubscription_substitutesOutOfStock", testSubscription_substitutesOutOfStock)
+	test.Run("TestSubscription_failsWithoutSlot", testSubscription_failsWithoutSlot)
+	test.Run("TestSubscription_customerManagesDeliveries", testSubscription_customerManagesDeliveries)
+	test.Run("TestSubscribe_checksPlanAndStart", testSubscribe_checksPlanAndStart)
+}
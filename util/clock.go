@@ -0,0 +1,38 @@
+package util
+
+import (
+	"time"
+	_ "time/tzdata" // Embed the zone database so the store zone resolves on minimal images
+)
+
+// StoreTimeZone - the store operates in Vietnam, so business dates (seasons, delivery days, cutoffs) use this zone
+const StoreTimeZone = "Asia/Ho_Chi_Minh"
+
+// DateLayout - calendar dates (harvest, best-before, delivery days) travel as "2006-01-02" in the store zone
+const DateLayout = "2006-01-02"
+
+var StoreLocation = mustLoadLocation(StoreTimeZone)
+
+// StoreNow - Usage: util.StoreNow() instead of time.Now() whenever the calendar date or month matters.
+func StoreNow() time.Time {
+	return time.Now().In(StoreLocation)
+}
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// StoreToday - midnight of the current calendar day in the store zone
+func StoreToday() time.Time {
+	now := StoreNow()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, StoreLocation)
+}
+
+// ParseStoreDate parses a "2006-01-02" calendar date as midnight in the store zone
+func ParseStoreDate(value string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, value, StoreLocation)
+}
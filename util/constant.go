@@ -4,3 +4,5 @@ const DefaultLocale = "en"
 const AppContextKey = "app_context"
 const LocaleContextKey = "locale"
 const TraceIDContextKey = "trace_id"
+const ClaimsContextKey = "claims"
+const CartTokenHeader = "X-Cart-Token"
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"os"
 	"path/filepath"
 	"time"
@@ -63,3 +65,10 @@ func findGoModuleRoot() string {
 
 	panic("failed to find go.mod")
 }
+
+// RandomToken - an unguessable URL-safe token of 32 random bytes, e.g. for anonymous carts
+func RandomToken() string {
+	bytes := make([]byte, 32)
+	_, _ = rand.Read(bytes)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,22 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldText - Usage: util.FoldText("Rau Muống") == "rau muong", to compare Vietnamese text ignoring case and accents.
+func FoldText(text string) string {
+	// Transformers keep internal state, so each call builds its own chain
+	accentRemover := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(accentRemover, strings.ToLower(text))
+	if err != nil {
+		folded = strings.ToLower(text)
+	}
+	// "đ" is a separate letter rather than an accented "d", so NFD leaves it alone
+	return strings.ReplaceAll(folded, "đ", "d")
+}